@@ -101,6 +101,21 @@ func CheckRepoState(
 			enum.PermissionServiceAccountView,
 		},
 
+		// pull mirrors are read-only, their content is updated only by the synchronization from the remote.
+		enum.RepoStatePullMirror: {
+			enum.PermissionRepoView,
+			enum.PermissionRepoEdit,
+			enum.PermissionRepoDelete,
+			enum.PermissionRepoReportCommitCheck,
+
+			enum.PermissionPipelineView,
+			enum.PermissionPipelineExecute,
+			enum.PermissionPipelineEdit,
+			enum.PermissionPipelineDelete,
+
+			enum.PermissionServiceAccountView,
+		},
+
 		// allowed permissions for repos on transition states during import/migration are handled by their controller.
 		enum.RepoStateGitImport:         {},
 		enum.RepoStateMigrateDataImport: {},
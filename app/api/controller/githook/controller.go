@@ -24,9 +24,12 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -57,6 +60,9 @@ type Controller struct {
 	postReceiveExtender PostReceiveExtender
 	sseStreamer         sse.Streamer
 	lfsStore            store.LFSObjectStore
+	publicKeySvc        publickey.Service
+	mirrorSvc           *mirror.Service
+	userGroupService    usergroup.SearchService
 }
 
 func NewController(
@@ -77,6 +83,9 @@ func NewController(
 	postReceiveExtender PostReceiveExtender,
 	sseStreamer sse.Streamer,
 	lfsStore store.LFSObjectStore,
+	publicKeySvc publickey.Service,
+	mirrorSvc *mirror.Service,
+	userGroupService usergroup.SearchService,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -96,6 +105,9 @@ func NewController(
 		postReceiveExtender: postReceiveExtender,
 		sseStreamer:         sseStreamer,
 		lfsStore:            lfsStore,
+		publicKeySvc:        publicKeySvc,
+		mirrorSvc:           mirrorSvc,
+		userGroupService:    userGroupService,
 	}
 }
 
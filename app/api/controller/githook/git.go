@@ -30,8 +30,14 @@ type RestrictedGIT interface {
 	IsAncestor(ctx context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error)
 	ScanSecrets(ctx context.Context, param *git.ScanSecretsParams) (*git.ScanSecretsOutput, error)
 	GetBranch(ctx context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error)
+	ListBranchesContaining(
+		ctx context.Context,
+		params *git.ListBranchesContainingParams,
+	) (*git.ListBranchesContainingOutput, error)
 	Diff(ctx context.Context, in *git.DiffParams, files ...api.FileDiffRequest) (<-chan *git.FileDiff, <-chan error)
 	GetBlob(ctx context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error)
+	ListNewCommits(ctx context.Context, params *git.ListNewCommitsParams) (*git.ListNewCommitsOutput, error)
+	ListNewCommitFiles(ctx context.Context, params *git.ListNewCommitFilesParams) (*git.ListNewCommitFilesOutput, error)
 	// TODO: remove. Kept for backwards compatibility.
 	FindOversizeFiles(
 		ctx context.Context,
@@ -43,6 +43,9 @@ const (
 
 	// gitReferenceNamePrefixTag is the prefix of pull req references.
 	gitReferenceNamePullReq = "refs/pullreq/"
+
+	// gitReferenceNameMergeQueue is the prefix of merge queue references.
+	gitReferenceNameMergeQueue = "refs/queue/"
 )
 
 // PostReceive executes the post-receive hook for a git repository.
@@ -77,6 +80,11 @@ func (c *Controller) PostReceive(
 		c.reportReferenceEvents(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput)
 	}
 
+	// push the updated references to the push mirrors - best effort
+	if repo.State == enum.RepoStateActive {
+		c.triggerPushMirrors(ctx, repo, in.PostReceiveInput)
+	}
+
 	// handle branch updates related to PRs - best effort
 	c.handlePRMessaging(ctx, repo, in.PostReceiveInput, &out)
 
@@ -88,6 +96,24 @@ func (c *Controller) PostReceive(
 	return out, nil
 }
 
+// triggerPushMirrors starts a push to the push mirrors of the repository that mirror any of the updated references.
+func (c *Controller) triggerPushMirrors(
+	ctx context.Context,
+	repo *types.Repository,
+	in hook.PostReceiveInput,
+) {
+	refs := make([]string, len(in.RefUpdates))
+	for i, refUpdate := range in.RefUpdates {
+		refs[i] = refUpdate.Ref
+	}
+
+	if err := c.mirrorSvc.TriggerPush(ctx, repo.ID, refs); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repo.ID).
+			Msg("failed to trigger push mirrors")
+	}
+}
+
 // reportReferenceEvents is reporting reference events to the event system.
 // NOTE: keep best effort for now as it doesn't change the outcome of the git operation.
 // TODO: in the future we might want to think about propagating errors so user is aware of events not being triggered.
@@ -360,7 +386,8 @@ func (c *Controller) updateLastGITPushTime(
 	in types.GithookPostReceiveInput,
 ) {
 	isNonePRRefFn := func(refUpdate hook.ReferenceUpdate) bool {
-		return !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePullReq)
+		return !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePullReq) &&
+			!strings.HasPrefix(refUpdate.Ref, gitReferenceNameMergeQueue)
 	}
 	// ignore push that only contains pr refs for last git push time updates
 	if !slices.ContainsFunc(in.RefUpdates, isNonePRRefFn) {
@@ -20,11 +20,14 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -33,6 +36,10 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+// maxPushVerifyCommits is the maximum number of new commits per branch that are verified against push rules.
+// The push rules report a violation for a branch with more new commits, rather than skipping the rest of them.
+const maxPushVerifyCommits = 1000
+
 // allowedRepoStatesForPush lists repository states that git push is allowed for internal and external calls.
 var allowedRepoStatesForPush = []enum.RepoState{enum.RepoStateActive, enum.RepoStateMigrateGitPush}
 
@@ -50,6 +57,13 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
+	// archived repositories are read-only, for both git pushes and API operations.
+	if repo.State == enum.RepoStateArchived {
+		output.Error = ptr.String("This repository is archived and is read-only. " +
+			"Unarchive the repository to push changes.")
+		return output, nil
+	}
+
 	if !in.Internal && !slices.Contains(allowedRepoStatesForPush, repo.State) {
 		output.Error = ptr.String(fmt.Sprintf("Push not allowed when repository is in '%s' state", repo.State))
 		return output, nil
@@ -96,7 +110,7 @@ func (c *Controller) PreReceive(
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		err = c.checkProtectionRules(ctx, rgit, dummySession, repo, refUpdates, in, &output)
 		if output.Error != nil {
 			return output, nil
 		}
@@ -142,7 +156,8 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 	}
 
 	fn := func(ref string) bool {
-		return strings.HasPrefix(ref, gitReferenceNamePullReq)
+		return strings.HasPrefix(ref, gitReferenceNamePullReq) ||
+			strings.HasPrefix(ref, gitReferenceNameMergeQueue)
 	}
 
 	return slices.ContainsFunc(refUpdates.other.created, fn) ||
@@ -153,9 +168,11 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.RepositoryCore,
 	refUpdates changedRefs,
+	in types.GithookPreReceiveInput,
 	output *hook.Output,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
@@ -171,20 +188,33 @@ func (c *Controller) checkProtectionRules(
 	var ruleViolations []types.RuleViolations
 	var errCheckAction error
 
-	//nolint:unparam
+	tagTargets := make(map[string]sha.SHA)
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag) && !refUpdate.New.IsNil() {
+			tagTargets[refUpdate.Ref[len(gitReferenceNamePrefixTag):]] = refUpdate.New
+		}
+	}
+
+	listBranchesContaining := func(ctx context.Context, tagName string) ([]string, error) {
+		return listBranchesContainingAfterPush(ctx, rgit, repo, in, tagTargets[tagName])
+	}
+
 	checkAction := func(refAction protection.RefAction, refType protection.RefType, names []string) {
 		if errCheckAction != nil || len(names) == 0 {
 			return
 		}
 
 		violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-			Actor:       &session.Principal,
-			AllowBypass: true,
-			IsRepoOwner: isRepoOwner,
-			Repo:        repo,
-			RefAction:   refAction,
-			RefType:     refType,
-			RefNames:    names,
+			ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+			Actor:              &session.Principal,
+			AllowBypass:        true,
+			IsRepoOwner:        isRepoOwner,
+			Repo:               repo,
+			RefAction:          refAction,
+			RefType:            refType,
+			RefNames:           names,
+
+			ListBranchesContaining: listBranchesContaining,
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -198,11 +228,21 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
 	checkAction(protection.RefActionUpdateForce, protection.RefTypeBranch, refUpdates.branches.forced)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
 
 	if errCheckAction != nil {
 		return errCheckAction
 	}
 
+	pushViolations, err := c.checkPushRules(ctx, rgit, session, isRepoOwner, protectionRules, repo, in)
+	if err != nil {
+		return err
+	}
+
+	ruleViolations = append(ruleViolations, pushViolations...)
+
 	var criticalViolation bool
 
 	for _, ruleViolation := range ruleViolations {
@@ -225,6 +265,201 @@ func (c *Controller) checkProtectionRules(
 	return nil
 }
 
+// listBranchesContainingAfterPush returns names of branches that contain the target commit once the push is applied.
+// The existing branches are listed by git, but the branches created, updated or deleted by the push are checked
+// against their new commits, because the push isn't applied to the branches yet.
+func listBranchesContainingAfterPush(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+	target sha.SHA,
+) ([]string, error) {
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	out, err := rgit.ListBranchesContaining(ctx, &git.ListBranchesContainingParams{
+		ReadParams: readParams,
+		Rev:        target.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pushedBranches := make(map[string]sha.SHA)
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			pushedBranches[refUpdate.Ref[len(gitReferenceNamePrefixBranch):]] = refUpdate.New
+		}
+	}
+
+	branchNames := make([]string, 0, len(out.BranchNames))
+	for _, branchName := range out.BranchNames {
+		if _, ok := pushedBranches[branchName]; !ok {
+			branchNames = append(branchNames, branchName)
+		}
+	}
+
+	for branchName, newSHA := range pushedBranches {
+		if newSHA.IsNil() {
+			continue
+		}
+
+		result, err := rgit.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          readParams,
+			AncestorCommitSHA:   target,
+			DescendantCommitSHA: newSHA,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if branch %q contains the commit: %w", branchName, err)
+		}
+
+		if result.Ancestor {
+			branchNames = append(branchNames, branchName)
+		}
+	}
+
+	return branchNames, nil
+}
+
+// checkPushRules verifies the pushed commits of every created or updated branch against the push protection rules.
+// The commits of an updated branch are listed from its old commit, and the commits of a created branch
+// from the default branch, so the commits that are already reachable from other branches are verified as well.
+func (c *Controller) checkPushRules(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	session *auth.Session,
+	isRepoOwner bool,
+	protectionRules protection.Protection,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+) ([]types.RuleViolations, error) {
+	var ruleViolations []types.RuleViolations
+	var verifiedEmails []string
+
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		// without a base commit (the default branch doesn't exist yet)
+		// the commits that aren't reachable from any existing reference are verified.
+		var after string
+		baseSHA, ok, err := GetBaseSHAForScanningChanges(ctx, rgit, repo, in.Environment, in.RefUpdates, refUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get base commit of branch %q: %w", branchName, err)
+		}
+		if ok {
+			after = baseSHA.String()
+		}
+
+		newCommits, err := rgit.ListNewCommits(ctx, &git.ListNewCommitsParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			GitREF: refUpdate.New.String(),
+			After:  after,
+			Limit:  maxPushVerifyCommits + 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list new commits of branch %q: %w", branchName, err)
+		}
+
+		if len(newCommits.Commits) == 0 {
+			continue
+		}
+
+		// the commits over the limit aren't verified, the protection rules report that instead.
+		commitsTruncated := len(newCommits.Commits) > maxPushVerifyCommits
+		if commitsTruncated {
+			newCommits.Commits = newCommits.Commits[:maxPushVerifyCommits]
+		}
+
+		if verifiedEmails == nil {
+			verifiedEmails, err = c.publicKeySvc.ListVerifiedEmails(ctx, session.Principal.ToPrincipalInfo())
+			if err != nil {
+				return nil, fmt.Errorf("failed to list verified emails of the principal: %w", err)
+			}
+		}
+
+		commits := make([]types.Commit, len(newCommits.Commits))
+		for i := range newCommits.Commits {
+			commit, err := controller.MapCommit(&newCommits.Commits[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to map commit: %w", err)
+			}
+			commits[i] = *commit
+		}
+
+		if err := c.publicKeySvc.VerifyCommits(ctx, newCommits.Commits, commits); err != nil {
+			return nil, fmt.Errorf("failed to verify commit signatures of branch %q: %w", branchName, err)
+		}
+
+		violations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+			ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+			Actor:              &session.Principal,
+			AllowBypass:        true,
+			IsRepoOwner:        isRepoOwner,
+			Repo:               repo,
+			BranchName:         branchName,
+			Commits:            commits,
+			CommitsTruncated:   commitsTruncated,
+			VerifiedEmails:     verifiedEmails,
+			ListFiles: controller.OnceFunc(func(ctx context.Context) ([]protection.PushedFile, error) {
+				return listPushedFiles(ctx, rgit, repo, in.Environment.AlternateObjectDirs,
+					refUpdate.New.String(), after)
+			}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify push protection rules for branch %q: %w", branchName, err)
+		}
+
+		ruleViolations = append(ruleViolations, violations...)
+	}
+
+	return ruleViolations, nil
+}
+
+// listPushedFiles returns the files changed by the commits reachable from the provided git reference
+// that aren't reachable from the after reference, or from any existing reference if it's empty.
+func listPushedFiles(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	alternateObjectDirs []string,
+	gitRef string,
+	after string,
+) ([]protection.PushedFile, error) {
+	out, err := rgit.ListNewCommitFiles(ctx, &git.ListNewCommitFilesParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: alternateObjectDirs,
+		},
+		GitREF: gitRef,
+		After:  after,
+		Limit:  maxPushVerifyCommits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of new commits: %w", err)
+	}
+
+	files := make([]protection.PushedFile, len(out.Files))
+	for i, file := range out.Files {
+		files[i] = protection.PushedFile{
+			Path:    file.Path,
+			Deleted: file.Deleted,
+			Size:    file.Size,
+		}
+	}
+
+	return files, nil
+}
+
 type changes struct {
 	created []string
 	deleted []string
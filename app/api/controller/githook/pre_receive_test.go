@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/storage"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckPushRulesAlreadyReachableCommits verifies that the commits pushed to a protected branch are verified
+// even if they have been pushed to an unprotected branch before.
+func TestCheckPushRulesAlreadyReachableCommits(t *testing.T) {
+	ctx := context.Background()
+	gitService := newTestGitService(t)
+
+	actor := git.Identity{Name: "test", Email: "test@example.com"}
+
+	repoOut, err := gitService.CreateRepository(ctx, &git.CreateRepositoryParams{
+		Actor:         actor,
+		DefaultBranch: "main",
+		Files:         []git.File{{Path: "README.md", Content: []byte("# test\n")}},
+	})
+	require.NoError(t, err)
+
+	mainBranch, err := gitService.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.ReadParams{RepoUID: repoOut.UID},
+		BranchName: "main",
+	})
+	require.NoError(t, err)
+
+	// the first step of the push: the commit is pushed to an unprotected branch.
+	featureCommit, err := gitService.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams: git.WriteParams{Actor: actor, RepoUID: repoOut.UID},
+		Message:     "add secret",
+		Branch:      "main",
+		NewBranch:   "feature",
+		Actions: []git.CommitFileAction{
+			{Action: git.CreateAction, Path: "secret/key.txt", Payload: []byte("secret")},
+		},
+	})
+	require.NoError(t, err)
+
+	c := &Controller{
+		publicKeySvc:     testPublicKeyService{},
+		userGroupService: testUserGroupService{},
+	}
+
+	session := &auth.Session{Principal: types.Principal{ID: 1, Email: actor.Email}}
+	repo := &types.RepositoryCore{ID: 1, GitUID: repoOut.UID, DefaultBranch: "main"}
+	rules := testPushProtection{push: &protection.DefPush{CommitMessagePattern: "^feat: "}}
+
+	tests := []struct {
+		name      string
+		refUpdate hook.ReferenceUpdate
+	}{
+		{
+			name: "protected branch is updated",
+			refUpdate: hook.ReferenceUpdate{
+				Ref: "refs/heads/main",
+				Old: mainBranch.Branch.SHA,
+				New: featureCommit.CommitID,
+			},
+		},
+		{
+			name: "protected branch is created",
+			refUpdate: hook.ReferenceUpdate{
+				Ref: "refs/heads/release",
+				Old: sha.Nil,
+				New: featureCommit.CommitID,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the second step of the push: the protected branch is pointed to the already reachable commit.
+			violations, err := c.checkPushRules(ctx, gitService, session, false, rules, repo,
+				types.GithookPreReceiveInput{
+					PreReceiveInput: hook.PreReceiveInput{RefUpdates: []hook.ReferenceUpdate{test.refUpdate}},
+				})
+			require.NoError(t, err)
+			require.Len(t, violations, 1)
+			require.Len(t, violations[0].Violations, 1)
+			require.Equal(t, "push.commit.message", violations[0].Violations[0].Code)
+		})
+	}
+}
+
+// testPushProtection is a protection that verifies the pushes with the push rule.
+type testPushProtection struct {
+	protection.Protection
+	push *protection.DefPush
+}
+
+func (p testPushProtection) PushVerify(
+	ctx context.Context,
+	in protection.PushVerifyInput,
+) ([]types.RuleViolations, error) {
+	return p.push.PushVerify(ctx, in)
+}
+
+type testPublicKeyService struct {
+	publickey.Service
+}
+
+func (testPublicKeyService) ListVerifiedEmails(context.Context, *types.PrincipalInfo) ([]string, error) {
+	return nil, nil
+}
+
+func (testPublicKeyService) VerifyCommits(context.Context, []git.Commit, []types.Commit) error {
+	return nil
+}
+
+type testUserGroupService struct {
+	usergroup.SearchService
+}
+
+func (testUserGroupService) ListUserIDsByGroupIDs(context.Context, []int64) ([]int64, error) {
+	return nil, nil
+}
+
+type testHookClientFactory struct{}
+
+func (testHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+func newTestGitService(t *testing.T) *git.Service {
+	// the hooks aren't executed by the git operations, so the hook binary doesn't need to exist.
+	root := t.TempDir()
+	config := gittypes.Config{Root: root, HookPath: filepath.Join(root, "hook")}
+
+	adapter, err := api.New(config, nil, testHookClientFactory{})
+	require.NoError(t, err)
+
+	s, err := git.New(config, adapter, testHookClientFactory{}, storage.NewLocalStore(), nil)
+	require.NoError(t, err)
+
+	return s
+}
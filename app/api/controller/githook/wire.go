@@ -19,9 +19,12 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -61,6 +64,9 @@ func ProvideController(
 	postReceiveExtender PostReceiveExtender,
 	sseStreamer sse.Streamer,
 	lfsStore store.LFSObjectStore,
+	publicKeySvc publickey.Service,
+	mirrorSvc *mirror.Service,
+	userGroupService usergroup.SearchService,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -80,6 +86,9 @@ func ProvideController(
 		postReceiveExtender,
 		sseStreamer,
 		lfsStore,
+		publicKeySvc,
+		mirrorSvc,
+		userGroupService,
 	)
 
 	// TODO: improve wiring if possible
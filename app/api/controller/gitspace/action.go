@@ -72,6 +72,9 @@ func (c *Controller) Action(
 			enum.PermissionRepoView); err != nil {
 			return nil, err
 		}
+		if repo.State == enum.RepoStateArchived && in.Action == enum.GitspaceActionTypeStart {
+			return nil, ErrGitspaceRepoArchived
+		}
 	}
 
 	gitspaceConfig.BranchURL = c.gitspaceSvc.GetBranchURL(ctx, gitspaceConfig)
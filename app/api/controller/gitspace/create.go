@@ -41,6 +41,10 @@ var (
 	// ErrGitspaceRequiresParent if the user tries to create a secret without a parent space.
 	ErrGitspaceRequiresParent = usererror.BadRequest(
 		"Parent space required - standalone gitspace are not supported.")
+
+	// ErrGitspaceRepoArchived if the user tries to create or start a gitspace for an archived repository.
+	ErrGitspaceRepoArchived = usererror.BadRequest(
+		"Gitspaces are not allowed for archived repositories.")
 )
 
 // CreateInput is the input used for create operations.
@@ -103,6 +107,9 @@ func (c *Controller) Create(
 			enum.PermissionRepoView); err != nil {
 			return nil, err
 		}
+		if repo.State == enum.RepoStateArchived {
+			return nil, ErrGitspaceRepoArchived
+		}
 	}
 	suffixUID, err := gonanoid.Generate(gitspace.AllowedUIDAlphabet, 6)
 	if err != nil {
@@ -163,6 +163,7 @@ func (c *Controller) CreateRepo(
 			Repository: *repo,
 			IsPublic:   isRepoPublic,
 		}),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Warn().Msgf("failed to insert audit log for import repository operation: %s", err)
@@ -93,7 +93,8 @@ func (c *Controller) Create(
 		CreatedBy:   session.Principal.ID,
 		Identifier:  "default",
 		Actions: []enum.TriggerAction{enum.TriggerActionPullReqCreated,
-			enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated},
+			enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated,
+			enum.TriggerActionPullReqMergeQueued},
 		Disabled: false,
 		Version:  0,
 	}
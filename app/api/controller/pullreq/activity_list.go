@@ -42,6 +42,9 @@ func (c *Controller) ActivityList(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	// the pending comments of the current user are included.
+	filter.PendingAuthorID = session.Principal.ID
+
 	list, err := c.activityStore.List(ctx, pr.ID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests activities: %w", err)
@@ -57,6 +60,20 @@ func (c *Controller) ActivityList(
 		}
 	}
 
+	reactions, err := c.reactionStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reactions: %w", err)
+	}
+
+	reactionSummaries, err := c.summarizeReactions(ctx, reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, act := range list {
+		act.Reactions = reactionSummaries[act.ID]
+	}
+
 	list = removeDeletedComments(list)
 
 	return list, nil
@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeEnableInput struct {
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+}
+
+func (in *AutoMergeEnableInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if (in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodFastForward) &&
+		(in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables auto-merge of a pull request. The pull request is merged with the provided
+// merge method on behalf of the current user as soon as all protection rule requirements are satisfied.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeEnableInput,
+) (*types.PullReqAutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	autoMerge := &types.PullReqAutoMerge{
+		PullReqID: pr.ID,
+		RepoID:    repo.ID,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+		Method:    in.Method,
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+
+	if err = c.autoMergeStore.Upsert(ctx, autoMerge); err != nil {
+		return nil, fmt.Errorf("failed to store pull request auto-merge: %w", err)
+	}
+
+	autoMerge.EnabledBy = session.Principal.ToPrincipalInfo()
+
+	pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadAutoMerge{Enabled: true, MergeMethod: in.Method}, nil)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for enabled auto-merge")
+	}
+
+	pr.AutoMerge = autoMerge
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqAutoMergeEnabled, pr)
+
+	// the pull request might be mergeable already, the event triggers the first merge attempt.
+	c.eventReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+		Base:        eventBase(pr, &session.Principal),
+		MergeMethod: in.Method,
+	})
+
+	return autoMerge, nil
+}
+
+// AutoMergeDisable disables auto-merge of a pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if _, err = c.autoMergeStore.Find(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	return c.AutoMergeDisableNoAuth(ctx, pr, session.Principal.ID, "")
+}
+
+// AutoMergeDisableNoAuth disables auto-merge of a pull request without checking access.
+// The reason should be provided if auto-merge is disabled by the system rather than by a user.
+func (c *Controller) AutoMergeDisableNoAuth(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	reason string,
+) error {
+	if err := c.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	repo, err := c.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	_, err = c.activityStore.CreateWithPayload(ctx, pr, principalID,
+		&types.PullRequestActivityPayloadAutoMerge{Enabled: false, Reason: reason}, nil)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for disabled auto-merge")
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqAutoMergeDisabled, pr)
+
+	return nil
+}
+
+// backfillAutoMerge sets the auto-merge settings of the pull request, if auto-merge is enabled.
+func (c *Controller) backfillAutoMerge(ctx context.Context, pr *types.PullReq) error {
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	autoMerge.EnabledBy, err = c.principalInfoCache.Get(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info of the auto-merge user: %w", err)
+	}
+
+	pr.AutoMerge = autoMerge
+
+	return nil
+}
@@ -47,6 +47,8 @@ type CommentCreateInput struct {
 	LineStartNew    bool   `json:"line_start_new"`
 	LineEnd         int    `json:"line_end"`
 	LineEndNew      bool   `json:"line_end_new"`
+	// Pending comments are visible only to their author until the author submits a review.
+	Pending bool `json:"pending"`
 }
 
 func (in *CommentCreateInput) IsReply() bool {
@@ -119,10 +121,21 @@ func (c *Controller) CommentCreate(
 
 	var parentAct *types.PullReqActivity
 	if in.IsReply() {
-		parentAct, err = c.checkIsReplyable(ctx, pr, in.ParentID)
+		parentAct, err = c.checkIsReplyable(ctx, session, pr, in.ParentID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to verify reply: %w", err)
 		}
+
+		// replies to pending comments are pending too.
+		in.Pending = in.Pending || parentAct.Pending
+	}
+
+	if in.Pending && pr.CreatedBy == session.Principal.ID {
+		return nil, usererror.BadRequest("Pending review comments can't be added to own pull requests.")
+	}
+
+	if in.Pending && pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pending review comments can be added only to open pull requests.")
 	}
 
 	// fetch code snippet from git for code comments
@@ -190,6 +203,11 @@ func (c *Controller) CommentCreate(
 			return fmt.Errorf("failed to write pull request comment: %w", err)
 		}
 
+		if act.Pending {
+			// pending comments are counted when the review is submitted.
+			return nil
+		}
+
 		pr.CommentCount++
 		if act.IsBlocking() {
 			pr.UnresolvedCount++
@@ -216,6 +234,10 @@ func (c *Controller) CommentCreate(
 		c.migrateCodeComment(ctx, repo, pr, in, act.AsCodeComment(), cut)
 	}
 
+	if act.Pending {
+		return act, nil
+	}
+
 	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
 
 	// publish event for all comments
@@ -249,6 +271,7 @@ func (c *Controller) CommentCreate(
 
 func (c *Controller) checkIsReplyable(
 	ctx context.Context,
+	session *auth.Session,
 	pr *types.PullReq,
 	parentID int64,
 ) (*types.PullReqActivity, error) {
@@ -261,6 +284,10 @@ func (c *Controller) checkIsReplyable(
 		return nil, fmt.Errorf("failed to find parent pull request activity: %w", err)
 	}
 
+	if parentAct.Pending && parentAct.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Parent pull request activity not found.")
+	}
+
 	if parentAct.PullReqID != pr.ID || parentAct.RepoID != pr.TargetRepoID {
 		return nil, usererror.BadRequest("Parent pull request activity doesn't belong to the same pull request.")
 	}
@@ -345,6 +372,7 @@ func getCommentActivity(
 		Metadata:   nil,
 		ResolvedBy: nil,
 		Resolved:   nil,
+		Pending:    in.Pending,
 		Author:     *session.Principal.ToPrincipalInfo(),
 	}
 
@@ -39,6 +39,7 @@ func (c *Controller) CommentDelete(
 	}
 
 	var pr *types.PullReq
+	var pending bool
 
 	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
 		var err error
@@ -57,6 +58,8 @@ func (c *Controller) CommentDelete(
 			return nil
 		}
 
+		pending = act.Pending
+
 		now := time.Now().UnixMilli()
 
 		isBlocking := act.IsBlocking()
@@ -67,6 +70,11 @@ func (c *Controller) CommentDelete(
 			return fmt.Errorf("failed to mark comment as deleted: %w", err)
 		}
 
+		if pending {
+			// pending comments aren't included in the pull request comment counters.
+			return nil
+		}
+
 		pr.CommentCount--
 		if isBlocking {
 			pr.UnresolvedCount--
@@ -83,6 +91,10 @@ func (c *Controller) CommentDelete(
 		return err
 	}
 
+	if pending {
+		return nil
+	}
+
 	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
 
 	return nil
@@ -0,0 +1,301 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+// CommitApplyInput defines where the commit created by the revert or the cherry-pick operation is stored.
+type CommitApplyInput struct {
+	// TargetBranch is the branch to which the changes are applied. The default is the repository's default branch.
+	TargetBranch string `json:"target_branch"`
+
+	// NewBranch, if provided, is created for the new commit and a pull request
+	// from it to the TargetBranch is opened. Otherwise, the TargetBranch is updated directly.
+	NewBranch string `json:"new_branch"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CommitApplyInput) sanitize(repo *types.RepositoryCore) error {
+	in.TargetBranch = strings.TrimSpace(in.TargetBranch)
+	in.NewBranch = strings.TrimSpace(in.NewBranch)
+
+	if in.TargetBranch == "" {
+		in.TargetBranch = repo.DefaultBranch
+	}
+
+	if in.NewBranch == in.TargetBranch {
+		return usererror.BadRequest("The new branch must be different from the target branch.")
+	}
+
+	return nil
+}
+
+// CommitApplyOutput is the result of the revert and the cherry-pick operations.
+type CommitApplyOutput struct {
+	CommitSHA sha.SHA        `json:"commit_sha,omitempty"`
+	Branch    string         `json:"branch,omitempty"`
+	PullReq   *types.PullReq `json:"pull_req,omitempty"`
+	types.DryRunRulesOutput
+}
+
+// commitApplyFunc creates a new commit on top of the base commit and updates the provided refs to it.
+type commitApplyFunc func(
+	writeParams git.WriteParams,
+	baseSHA sha.SHA,
+	refs []git.RefUpdate,
+) (sha.SHA, []string, error)
+
+// pullReqDetails is used to open a pull request for the new branch created by the revert or the cherry-pick.
+type pullReqDetails struct {
+	Title       string
+	Description string
+}
+
+// applyCommit creates a new commit on top of the target branch using the provided function.
+// The revert and the cherry-pick of commits are repository endpoints, but they're implemented here,
+// because they share this with the revert of a pull request and they open a pull request for the new branch.
+// Depending on the input, the target branch is either updated directly or a new branch and a pull request are created.
+//
+//nolint:gocognit
+func (c *Controller) applyCommit(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	in *CommitApplyInput,
+	operation string,
+	fn commitApplyFunc,
+	prDetails func() pullReqDetails,
+) (CommitApplyOutput, *types.MergeViolations, error) {
+	targetSHA, err := c.verifyBranchExistence(ctx, repo, in.TargetBranch)
+	if err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	branch := in.TargetBranch
+	refAction := protection.RefActionUpdate
+	oldSHA := targetSHA
+
+	if in.NewBranch != "" {
+		_, err = c.git.GetRef(ctx, git.GetRefParams{
+			ReadParams: git.CreateReadParams(repo),
+			Name:       in.NewBranch,
+			Type:       gitenum.RefTypeBranch,
+		})
+		if err == nil {
+			return CommitApplyOutput{}, nil, usererror.BadRequestf("The branch %q already exists.", in.NewBranch)
+		}
+		if !errors.IsNotFound(err) {
+			return CommitApplyOutput{}, nil, fmt.Errorf("failed to check existence of the new branch: %w", err)
+		}
+
+		branch = in.NewBranch
+		refAction = protection.RefActionCreate
+		oldSHA = sha.Nil
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          refAction,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{branch},
+	})
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// The commits are created without updating any reference first,
+	// to verify them against the push rules before the branch is updated.
+	trialSHA, conflicts, err := fn(writeParams, targetSHA, nil)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	if len(conflicts) > 0 {
+		return CommitApplyOutput{}, &types.MergeViolations{
+			ConflictFiles:  conflicts,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("The %s is blocked by conflicting files: %v", operation, conflicts),
+		}, nil
+	}
+
+	pushViolations, err := c.verifyAppliedCommits(ctx, session, repo, protectionRules, isRepoOwner,
+		in.BypassRules, branch, targetSHA, trialSHA)
+	if err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	violations = append(violations, pushViolations...)
+
+	if in.DryRunRules {
+		return CommitApplyOutput{
+			DryRunRulesOutput: types.DryRunRulesOutput{
+				DryRunRules:    true,
+				RuleViolations: violations,
+			},
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return CommitApplyOutput{}, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	branchRef, err := git.GetRefPath(branch, gitenum.RefTypeBranch)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to generate ref name: %w", err)
+	}
+
+	commitSHA, conflicts, err := fn(writeParams, targetSHA, []git.RefUpdate{{
+		Name: branchRef,
+		Old:  oldSHA,
+		New:  sha.SHA{}, // update to the new commit
+	}})
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	if len(conflicts) > 0 {
+		return CommitApplyOutput{}, &types.MergeViolations{
+			ConflictFiles:  conflicts,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("The %s is blocked by conflicting files: %v", operation, conflicts),
+		}, nil
+	}
+
+	out := CommitApplyOutput{
+		CommitSHA: commitSHA,
+		Branch:    branch,
+		DryRunRulesOutput: types.DryRunRulesOutput{
+			RuleViolations: violations,
+		},
+	}
+
+	if in.NewBranch == "" {
+		return out, nil, nil
+	}
+
+	details := prDetails()
+
+	out.PullReq, err = c.Create(ctx, session, repo.Path, &CreateInput{
+		Title:        details.Title,
+		Description:  details.Description,
+		SourceBranch: in.NewBranch,
+		TargetBranch: in.TargetBranch,
+		BypassRules:  in.BypassRules,
+	})
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return out, nil, nil
+}
+
+// verifyAppliedCommits verifies the commits that are about to be added on top of the base commit
+// against the push protection rules of the branch.
+func (c *Controller) verifyAppliedCommits(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	protectionRules protection.Protection,
+	isRepoOwner bool,
+	allowBypass bool,
+	branch string,
+	baseSHA sha.SHA,
+	commitSHA sha.SHA,
+) ([]types.RuleViolations, error) {
+	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     commitSHA.String(),
+		After:      baseSHA.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applied commits: %w", err)
+	}
+
+	commits := make([]types.Commit, len(output.Commits))
+	for i := range output.Commits {
+		commit, err := controller.MapCommit(&output.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	files, err := c.listCommitFiles(ctx, repo, commitSHA.String(), baseSHA.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return controller.VerifyServerCommits(ctx, c.publicKeySvc, protectionRules, protection.PushVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        allowBypass,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		BranchName:         branch,
+	}, commits, files)
+}
+
+// getCommit returns the commit with the provided SHA or a user error if the commit doesn't exist.
+func (c *Controller) getCommit(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	commitSHA sha.SHA,
+) (*git.Commit, error) {
+	output, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   commitSHA.String(),
+	})
+	if errors.IsNotFound(err) {
+		return nil, usererror.NotFoundf("Commit %s not found.", commitSHA)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", commitSHA, err)
+	}
+
+	return &output.Commit, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CherryPickInput struct {
+	CommitApplyInput
+
+	// FromCommitSHA and ToCommitSHA define the range of commits that are cherry-picked.
+	// The FromCommitSHA is excluded from the range. If it's not provided, only the ToCommitSHA is cherry-picked.
+	FromCommitSHA sha.SHA `json:"from_commit_sha"`
+	ToCommitSHA   sha.SHA `json:"to_commit_sha"`
+
+	// Title and Description are used for the pull request if the NewBranch is provided.
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (in *CherryPickInput) sanitize(repo *types.RepositoryCore) error {
+	if in.ToCommitSHA.IsEmpty() {
+		return usererror.BadRequest("Commit SHA must be provided.")
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	return in.CommitApplyInput.sanitize(repo)
+}
+
+// CherryPick applies a commit or a range of commits on top of the target branch.
+// The commits are either added directly to the target branch or to a new branch with a pull request.
+// The commits keep their authors, so a target branch that requires verified emails
+// accepts only the commits authored by the actor.
+func (c *Controller) CherryPick(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CherryPickInput,
+) (CommitApplyOutput, *types.MergeViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err := in.sanitize(repo); err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	commit, err := c.getCommit(ctx, repo, in.ToCommitSHA)
+	if err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	fromCommitSHA := in.FromCommitSHA
+	if fromCommitSHA.IsEmpty() {
+		if len(commit.ParentSHAs) == 0 {
+			return CommitApplyOutput{}, nil, usererror.BadRequestf(
+				"The commit %s has no parent and can't be cherry-picked.", commit.SHA)
+		}
+
+		fromCommitSHA = commit.ParentSHAs[0]
+	} else if _, err = c.getCommit(ctx, repo, fromCommitSHA); err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	if in.FromCommitSHA.IsEmpty() {
+		if in.Title == "" {
+			in.Title = fmt.Sprintf("Cherry-pick %q into %s", commit.Title, in.TargetBranch)
+		}
+		if in.Description == "" {
+			in.Description = fmt.Sprintf("Cherry-picks commit %s.", commit.SHA)
+		}
+	} else {
+		if in.Title == "" {
+			in.Title = fmt.Sprintf("Cherry-pick commits into %s", in.TargetBranch)
+		}
+		if in.Description == "" {
+			in.Description = fmt.Sprintf("Cherry-picks commits %s..%s.", in.FromCommitSHA, commit.SHA)
+		}
+	}
+
+	return c.applyCommit(ctx, session, repo, &in.CommitApplyInput, "cherry-pick",
+		func(writeParams git.WriteParams, baseSHA sha.SHA, refs []git.RefUpdate) (sha.SHA, []string, error) {
+			now := time.Now()
+
+			output, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+				WriteParams:   writeParams,
+				BaseSHA:       baseSHA,
+				FromCommitSHA: fromCommitSHA,
+				ToCommitSHA:   commit.SHA,
+				Committer:     controller.SystemServicePrincipalInfo(),
+				CommitterDate: &now,
+				Refs:          refs,
+			})
+			if err != nil {
+				return sha.None, nil, err
+			}
+
+			return output.CommitSHA, output.ConflictFiles, nil
+		},
+		func() pullReqDetails {
+			return pullReqDetails{
+				Title:       in.Title,
+				Description: in.Description,
+			}
+		})
+}
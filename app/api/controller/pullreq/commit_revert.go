@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RevertCommitInput struct {
+	CommitApplyInput
+
+	CommitSHA sha.SHA `json:"commit_sha"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+func (in *RevertCommitInput) sanitize(repo *types.RepositoryCore) error {
+	if in.CommitSHA.IsEmpty() {
+		return usererror.BadRequest("Commit SHA must be provided.")
+	}
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	return in.CommitApplyInput.sanitize(repo)
+}
+
+// RevertCommit creates a new commit on top of the target branch that reverts the changes of a commit.
+// The commit is either added directly to the target branch or to a new branch with a pull request.
+func (c *Controller) RevertCommit(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RevertCommitInput,
+) (CommitApplyOutput, *types.MergeViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err := in.sanitize(repo); err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	commit, err := c.getCommit(ctx, repo, in.CommitSHA)
+	if err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	if len(commit.ParentSHAs) == 0 {
+		return CommitApplyOutput{}, nil, usererror.BadRequestf("The commit %s has no parent and can't be reverted.",
+			commit.SHA)
+	}
+
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("Revert %q", commit.Title)
+	}
+	if in.Message == "" {
+		in.Message = fmt.Sprintf("This reverts commit %s.", commit.SHA)
+	}
+
+	return c.applyCommit(ctx, session, repo, &in.CommitApplyInput, "revert",
+		func(writeParams git.WriteParams, baseSHA sha.SHA, refs []git.RefUpdate) (sha.SHA, []string, error) {
+			return c.revert(ctx, session, writeParams, baseSHA, commit.ParentSHAs[0], commit.SHA,
+				git.CommitMessage(in.Title, in.Message), refs)
+		},
+		func() pullReqDetails {
+			return pullReqDetails{
+				Title:       in.Title,
+				Description: in.Message,
+			}
+		})
+}
+
+// revert reverts the changes between the parent commit and the commit on top of the base commit.
+func (c *Controller) revert(
+	ctx context.Context,
+	session *auth.Session,
+	writeParams git.WriteParams,
+	baseSHA sha.SHA,
+	parentCommitSHA sha.SHA,
+	commitSHA sha.SHA,
+	message string,
+	refs []git.RefUpdate,
+) (sha.SHA, []string, error) {
+	author := controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	committer := controller.SystemServicePrincipalInfo()
+	now := time.Now()
+
+	output, err := c.git.Revert(ctx, &git.RevertParams{
+		WriteParams:     writeParams,
+		BaseSHA:         baseSHA,
+		CommitSHA:       commitSHA,
+		ParentCommitSHA: parentCommitSHA,
+		Message:         message,
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		Refs:            refs,
+	})
+	if err != nil {
+		return sha.None, nil, err
+	}
+
+	return output.CommitSHA, output.ConflictFiles, nil
+}
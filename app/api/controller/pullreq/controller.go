@@ -31,6 +31,7 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -79,6 +80,10 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	publicKeySvc           publickey.Service
+	autoMergeStore         store.PullReqAutoMergeStore
+	mergeQueueStore        store.MergeQueueStore
+	reactionStore          store.PullReqReactionStore
 }
 
 func NewController(
@@ -113,6 +118,10 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	publicKeySvc publickey.Service,
+	autoMergeStore store.PullReqAutoMergeStore,
+	mergeQueueStore store.MergeQueueStore,
+	reactionStore store.PullReqReactionStore,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -146,6 +155,10 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		publicKeySvc:           publicKeySvc,
+		autoMergeStore:         autoMergeStore,
+		mergeQueueStore:        mergeQueueStore,
+		reactionStore:          reactionStore,
 	}
 }
 
@@ -230,10 +243,28 @@ func (c *Controller) fetchRules(
 	return protectionRules, isRepoOwner, nil
 }
 
+// getCommentForPR returns a published comment of the pull request.
 func (c *Controller) getCommentForPR(
 	ctx context.Context,
 	pr *types.PullReq,
 	commentID int64,
+) (*types.PullReqActivity, error) {
+	comment, err := c.findComment(ctx, pr, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Pending {
+		return nil, usererror.ErrNotFound
+	}
+
+	return comment, nil
+}
+
+func (c *Controller) findComment(
+	ctx context.Context,
+	pr *types.PullReq,
+	commentID int64,
 ) (*types.PullReqActivity, error) {
 	if commentID <= 0 {
 		return nil, usererror.BadRequest("A valid comment ID must be provided.")
@@ -266,11 +297,16 @@ func (c *Controller) getCommentForPR(
 func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
-	comment, err := c.getCommentForPR(ctx, pr, commentID)
+	comment, err := c.findComment(ctx, pr, commentID)
 	if err != nil {
 		return nil, err
 	}
 
+	// pending comments are visible only to their authors.
+	if comment.Pending && comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
 	if comment.CreatedBy != session.Principal.ID {
 		return nil, usererror.BadRequest("Only own comments may be updated.")
 	}
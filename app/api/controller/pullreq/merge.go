@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
@@ -167,11 +168,6 @@ func (c *Controller) Merge(
 		)
 	}
 
-	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
-	}
-
 	targetWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
@@ -180,48 +176,28 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
-		}
-
 		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
-	}
 
-	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
-	}
-
-	checkResults, err := c.checkStore.ListResults(ctx, targetRepo.ID, in.SourceSHA)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list status checks: %w", err)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+		}
 	}
 
-	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
-	// check for error and ignore if it is codeowners file not found else throw error
-	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
-		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	// backfill commit title if none provided
+	if in.Title == "" {
+		in.Title = mergeCommitTitle(in.Method, pr, sourceRepo)
 	}
 
-	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
-		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
-		Actor:              &session.Principal,
-		AllowBypass:        in.BypassRules,
-		IsRepoOwner:        isRepoOwner,
-		TargetRepo:         targetRepo,
-		SourceRepo:         sourceRepo,
-		PullReq:            pr,
-		Reviewers:          reviewers,
-		Method:             in.Method, // the method can be empty for dry run or dry run rules
-		CheckResults:       checkResults,
-		CodeOwners:         codeOwnerWithApproval,
-	})
+	ruleOut, violations, err := c.mergeVerify(ctx, session, targetRepo, sourceRepo, pr,
+		in.Method, // the method can be empty for dry run or dry run rules
+		git.CommitMessage(in.Title, in.Message),
+		in.SourceSHA, in.BypassRules, false)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+		return nil, nil, err
 	}
 
 	if in.DryRunRules {
@@ -243,6 +219,7 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
@@ -368,6 +345,7 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
@@ -403,39 +381,7 @@ func (c *Controller) Merge(
 
 	// commit details: author, committer and message
 
-	var author *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		author = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodSquash:
-		author = controller.IdentityFromPrincipalInfo(pr.Author)
-	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
-	}
-
-	var committer *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = controller.SystemServicePrincipalInfo()
-	case enum.MergeMethodRebase:
-		committer = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodFastForward:
-		committer = nil // Not important for fast-forward merge
-	}
-
-	// backfill commit title if none provided
-	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-			// Not used.
-		}
-	}
+	author, committer := mergeCommitIdentities(in.Method, session.Principal.ToPrincipalInfo(), pr)
 
 	// create merge commit(s)
 
@@ -543,37 +489,265 @@ func (c *Controller) Merge(
 
 	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
 
-	mergedBy := session.Principal.ID
+	pr, branchDeleted, err := c.completeMerge(ctx, &session.Principal, targetRepo, sourceWriteParams, pr,
+		mergeResult{
+			method:       in.Method,
+			mergedAt:     now,
+			baseSHA:      mergeOutput.BaseSHA.String(),
+			headSHA:      mergeOutput.HeadSHA.String(),
+			mergeBaseSHA: mergeOutput.MergeBaseSHA.String(),
+			mergeSHA:     mergeOutput.MergeSHA.String(),
+			diffStats: types.NewDiffStats(
+				mergeOutput.CommitCount,
+				mergeOutput.ChangedFileCount,
+				mergeOutput.Additions,
+				mergeOutput.Deletions,
+			),
+			deleteSourceBranch: ruleOut.DeleteSourceBranch,
+			violations:         violations,
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if protection.IsBypassed(violations) {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(
+				audit.ResourceTypeRepository,
+				sourceRepo.Identifier,
+				audit.RepoPath,
+				sourceRepo.Path,
+				audit.BypassedResourceType,
+				audit.BypassedResourceTypePullRequest,
+				audit.BypassedResourceName,
+				strconv.FormatInt(pr.Number, 10),
+				audit.ResourceName,
+				fmt.Sprintf(
+					audit.BypassPullReqLabelFormat,
+					sourceRepo.Identifier,
+					strconv.FormatInt(pr.Number, 10),
+				),
+				audit.BypassAction,
+				audit.BypassActionMerged,
+			),
+			audit.ActionBypassed,
+			paths.Parent(sourceRepo.Path),
+			audit.WithNewObject(audit.PullRequestObject{
+				PullReq:        *pr,
+				RepoPath:       sourceRepo.Path,
+				RuleViolations: violations,
+			}),
+			audit.WithRepoID(sourceRepo.ID),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
+		}
+	}
+
+	err = c.instrumentation.Track(ctx, instrument.Event{
+		Type:      instrument.EventTypeMergePullRequest,
+		Principal: session.Principal.ToPrincipalInfo(),
+		Path:      sourceRepo.Path,
+		Properties: map[instrument.Property]any{
+			instrument.PropertyRepositoryID:   sourceRepo.ID,
+			instrument.PropertyRepositoryName: sourceRepo.Identifier,
+			instrument.PropertyPullRequestID:  pr.Number,
+			instrument.PropertyMergeStrategy:  in.Method,
+		},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for merge pr operation: %s", err)
+	}
+	return &types.MergeResponse{
+		SHA:            mergeOutput.MergeSHA.String(),
+		BranchDeleted:  branchDeleted,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// mergeVerify fetches the protection rules of the target repository and verifies them for merging
+// the pull request. The status checks are evaluated for the checkSHA commit.
+// The commitMessage is the message of the commit created by the merge, it's empty if it isn't known.
+func (c *Controller) mergeVerify(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	sourceRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	commitMessage string,
+	checkSHA string,
+	allowBypass bool,
+	mergeQueue bool,
+) (protection.MergeVerifyOutput, []types.RuleViolations, error) {
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	var checkResults []types.CheckResult
+	if checkSHA != "" {
+		checkResults, err = c.checkStore.ListResults(ctx, targetRepo.ID, checkSHA)
+		if err != nil {
+			return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to list status checks: %w", err)
+		}
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	// the commits of the pull request can be authored either by the actor or by the pull request author.
+	verifiedEmails, err := c.publicKeySvc.ListVerifiedEmails(ctx, session.Principal.ToPrincipalInfo())
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to list verified emails of the user: %w", err)
+	}
+
+	authorVerifiedEmails, err := c.publicKeySvc.ListVerifiedEmails(ctx, &pr.Author)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil,
+			fmt.Errorf("failed to list verified emails of the pull request author: %w", err)
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID:   c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:                &session.Principal,
+		AllowBypass:          allowBypass,
+		IsRepoOwner:          isRepoOwner,
+		TargetRepo:           targetRepo,
+		SourceRepo:           sourceRepo,
+		PullReq:              pr,
+		Reviewers:            reviewers,
+		Method:               method,
+		CheckResults:         checkResults,
+		CodeOwners:           codeOwnerWithApproval,
+		MergeQueue:           mergeQueue,
+		CommitMessage:        commitMessage,
+		VerifiedEmails:       verifiedEmails,
+		AuthorVerifiedEmails: authorVerifiedEmails,
+		ListCommits: controller.OnceFunc(func(ctx context.Context) ([]types.Commit, error) {
+			return c.listCommits(ctx, sourceRepo, pr, 0, 0)
+		}),
+		ListFiles: controller.OnceFunc(func(ctx context.Context) ([]protection.PushedFile, error) {
+			return c.listCommitFiles(ctx, sourceRepo, pr.SourceSHA, pr.MergeBaseSHA)
+		}),
+	})
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	// the source branch of a pull request from another repository (a fork) is deleted
+	// only if the user is allowed to push to that repository.
+	if ruleOut.DeleteSourceBranch && sourceRepo.ID != targetRepo.ID {
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush)
+		if err != nil {
+			ruleOut.DeleteSourceBranch = false
+		}
+	}
+
+	return ruleOut, violations, nil
+}
+
+// mergeCommitIdentities returns the author and the committer of the commit created by merging the pull request.
+func mergeCommitIdentities(
+	method enum.MergeMethod,
+	merger *types.PrincipalInfo,
+	pr *types.PullReq,
+) (author *git.Identity, committer *git.Identity) {
+	switch method {
+	case enum.MergeMethodMerge:
+		author = controller.IdentityFromPrincipalInfo(*merger)
+	case enum.MergeMethodSquash:
+		author = controller.IdentityFromPrincipalInfo(pr.Author)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
+	}
+
+	switch method {
+	case enum.MergeMethodMerge, enum.MergeMethodSquash:
+		committer = controller.SystemServicePrincipalInfo()
+	case enum.MergeMethodRebase:
+		committer = controller.IdentityFromPrincipalInfo(*merger)
+	case enum.MergeMethodFastForward:
+		committer = nil // Not important for fast-forward merge
+	}
+
+	return author, committer
+}
+
+// mergeCommitTitle returns the default title of the commit created by merging the pull request.
+func mergeCommitTitle(method enum.MergeMethod, pr *types.PullReq, sourceRepo *types.RepositoryCore) string {
+	switch method {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		// Not used.
+	}
+
+	return ""
+}
+
+// mergeResult describes a successful merge of a pull request.
+type mergeResult struct {
+	method             enum.MergeMethod
+	mergedAt           time.Time
+	baseSHA            string
+	headSHA            string
+	mergeBaseSHA       string
+	mergeSHA           string
+	diffStats          types.DiffStats
+	deleteSourceBranch bool
+	violations         []types.RuleViolations
+}
+
+// completeMerge marks the pull request as merged after its changes have been written to the target branch.
+// It writes the merge activity, reports the merged event and deletes the source branch if required.
+// It returns the updated pull request and whether the source branch has been deleted.
+func (c *Controller) completeMerge(
+	ctx context.Context,
+	merger *types.Principal,
+	targetRepo *types.RepositoryCore,
+	sourceWriteParams git.WriteParams,
+	pr *types.PullReq,
+	result mergeResult,
+) (*types.PullReq, bool, error) {
+	mergedBy := merger.ID
+	sourceSHA := pr.SourceSHA
 
 	var activitySeqMerge, activitySeqBranchDeleted int64
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+	pr, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.State = enum.PullReqStateMerged
 
-		nowMilli := now.UnixMilli()
+		nowMilli := result.mergedAt.UnixMilli()
 
 		pr.Merged = &nowMilli
 		pr.MergedBy = &mergedBy
-		pr.MergeMethod = &in.Method
+		pr.MergeMethod = &result.method
 
 		// update all Merge specific information (might be empty if previous merge check failed)
 		// since this is the final operation on the PR, we update any sha that might've changed by now.
-		pr.SourceSHA = mergeOutput.HeadSHA.String()
-		pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
-		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
-		pr.MergeSHA = ptr.String(mergeOutput.MergeSHA.String())
+		pr.SourceSHA = result.headSHA
+		pr.MergeTargetSHA = ptr.String(result.baseSHA)
+		pr.MergeBaseSHA = result.mergeBaseSHA
+		pr.MergeSHA = ptr.String(result.mergeSHA)
 		pr.MarkAsMerged()
-		pr.Stats.DiffStats = types.NewDiffStats(
-			mergeOutput.CommitCount,
-			mergeOutput.ChangedFileCount,
-			mergeOutput.Additions,
-			mergeOutput.Deletions,
-		)
+		pr.Stats.DiffStats = result.diffStats
 
 		// update sequence for PR activities
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if result.deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -581,16 +755,16 @@ func (c *Controller) Merge(
 		return nil
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update pull request: %w", err)
+		return nil, false, fmt.Errorf("failed to update pull request: %w", err)
 	}
 
 	pr.ActivitySeq = activitySeqMerge
 	activityPayload := &types.PullRequestActivityPayloadMerge{
-		MergeMethod:   in.Method,
-		MergeSHA:      mergeOutput.MergeSHA.String(),
-		TargetSHA:     mergeOutput.BaseSHA.String(),
-		SourceSHA:     mergeOutput.HeadSHA.String(),
-		RulesBypassed: protection.IsBypassed(violations),
+		MergeMethod:   result.method,
+		MergeSHA:      result.mergeSHA,
+		TargetSHA:     result.baseSHA,
+		SourceSHA:     result.headSHA,
+		RulesBypassed: protection.IsBypassed(result.violations),
 	}
 	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
 		// non-critical error
@@ -598,15 +772,15 @@ func (c *Controller) Merge(
 	}
 
 	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
-		Base:        eventBase(pr, &session.Principal),
-		MergeMethod: in.Method,
-		MergeSHA:    mergeOutput.MergeSHA.String(),
-		TargetSHA:   mergeOutput.BaseSHA.String(),
-		SourceSHA:   mergeOutput.HeadSHA.String(),
+		Base:        eventBase(pr, merger),
+		MergeMethod: result.method,
+		MergeSHA:    result.mergeSHA,
+		TargetSHA:   result.baseSHA,
+		SourceSHA:   result.headSHA,
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if result.deleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
 			WriteParams: sourceWriteParams,
 			BranchName:  pr.SourceBranch,
@@ -621,7 +795,7 @@ func (c *Controller) Merge(
 			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
 			pr.ActivitySeq = activitySeqBranchDeleted
 			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy,
-				&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}, nil); errAct != nil {
+				&types.PullRequestActivityPayloadBranchDelete{SHA: sourceSHA}, nil); errAct != nil {
 				// non-critical error
 				log.Ctx(ctx).Err(errAct).
 					Msgf("failed to write pull request activity for successful automatic branch delete")
@@ -631,57 +805,33 @@ func (c *Controller) Merge(
 
 	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
 
-	if protection.IsBypassed(violations) {
-		err = c.auditService.Log(ctx,
-			session.Principal,
-			audit.NewResource(
-				audit.ResourceTypeRepository,
-				sourceRepo.Identifier,
-				audit.RepoPath,
-				sourceRepo.Path,
-				audit.BypassedResourceType,
-				audit.BypassedResourceTypePullRequest,
-				audit.BypassedResourceName,
-				strconv.FormatInt(pr.Number, 10),
-				audit.ResourceName,
-				fmt.Sprintf(
-					audit.BypassPullReqLabelFormat,
-					sourceRepo.Identifier,
-					strconv.FormatInt(pr.Number, 10),
-				),
-				audit.BypassAction,
-				audit.BypassActionMerged,
-			),
-			audit.ActionBypassed,
-			paths.Parent(sourceRepo.Path),
-			audit.WithNewObject(audit.PullRequestObject{
-				PullReq:        *pr,
-				RepoPath:       sourceRepo.Path,
-				RuleViolations: violations,
-			}),
-		)
-		if err != nil {
-			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
-		}
-	}
+	return pr, branchDeleted, nil
+}
 
-	err = c.instrumentation.Track(ctx, instrument.Event{
-		Type:      instrument.EventTypeMergePullRequest,
-		Principal: session.Principal.ToPrincipalInfo(),
-		Path:      sourceRepo.Path,
-		Properties: map[instrument.Property]any{
-			instrument.PropertyRepositoryID:   sourceRepo.ID,
-			instrument.PropertyRepositoryName: sourceRepo.Identifier,
-			instrument.PropertyPullRequestID:  pr.Number,
-			instrument.PropertyMergeStrategy:  in.Method,
-		},
+// listCommitFiles returns the files changed by the commits reachable from gitRef, but not from after.
+func (c *Controller) listCommitFiles(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+	after string,
+) ([]protection.PushedFile, error) {
+	out, err := c.git.ListNewCommitFiles(ctx, &git.ListNewCommitFilesParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     gitRef,
+		After:      after,
 	})
 	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for merge pr operation: %s", err)
+		return nil, fmt.Errorf("failed to list files of commits: %w", err)
 	}
-	return &types.MergeResponse{
-		SHA:            mergeOutput.MergeSHA.String(),
-		BranchDeleted:  branchDeleted,
-		RuleViolations: violations,
-	}, nil, nil
+
+	files := make([]protection.PushedFile, len(out.Files))
+	for i, file := range out.Files {
+		files[i] = protection.PushedFile{
+			Path:    file.Path,
+			Deleted: file.Deleted,
+			Size:    file.Size,
+		}
+	}
+
+	return files, nil
 }
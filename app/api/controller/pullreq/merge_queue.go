@@ -0,0 +1,592 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MergeQueueAddInput struct {
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+}
+
+func (in *MergeQueueAddInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	if method == enum.MergeMethodFastForward {
+		return usererror.BadRequest("Fast-forward merge method can't be used with the merge queue")
+	}
+
+	in.Method = method
+
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// MergeQueueAdd adds a pull request to the merge queue of its target branch.
+// The merge queue must be enabled for the target branch by a protection rule. All protection rule requirements,
+// except the status checks, must be satisfied. The status checks run against a speculative merge commit
+// created by the merge queue, and the pull request is merged once they pass.
+func (c *Controller) MergeQueueAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueAddInput,
+) (*types.MergeQueueEntry, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be merged. Clear the draft flag first.",
+		)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	title := in.Title
+	if title == "" {
+		title = mergeCommitTitle(in.Method, pr, sourceRepo)
+	}
+
+	ruleOut, violations, err := c.mergeVerify(ctx, session, targetRepo, sourceRepo, pr,
+		in.Method, git.CommitMessage(title, in.Message), "", false, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ruleOut.RequiresMergeQueue {
+		return nil, nil, usererror.BadRequestf("Merge queue isn't enabled for the branch %s.", pr.TargetBranch)
+	}
+
+	// the status checks run after the speculative merge commit is created.
+	violations = protection.WithoutStatusChecks(violations)
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:        targetRepo.ID,
+		Branch:        pr.TargetBranch,
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+		Method:        in.Method,
+		Title:         in.Title,
+		Message:       in.Message,
+		State:         enum.MergeQueueEntryStateWaiting,
+		HeadSHA:       pr.SourceSHA,
+	}
+
+	err = c.mergeQueueStore.Create(ctx, entry)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, nil, usererror.BadRequest("Pull request is already in the merge queue")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	entry.AddedBy = session.Principal.ToPrincipalInfo()
+
+	pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadMergeQueue{Added: true, MergeMethod: in.Method}, nil)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for merge queue addition")
+	}
+
+	pr.MergeQueue = entry
+
+	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqMergeQueueAdded, pr)
+
+	c.eventReporter.MergeQueueAdded(ctx, &pullreqevents.MergeQueueAddedPayload{
+		Base:         eventBase(pr, &session.Principal),
+		TargetBranch: pr.TargetBranch,
+	})
+
+	return entry, nil, nil
+}
+
+// MergeQueueRemove removes a pull request from the merge queue.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if _, err = c.mergeQueueStore.Find(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return c.MergeQueueRemoveNoAuth(ctx, pr, session.Principal.ID, "")
+}
+
+// MergeQueueEvictNoAuth removes a pull request from the merge queue on behalf of the system
+// because it can't be merged, e.g. because a required status check failed for its speculative merge commit.
+func (c *Controller) MergeQueueEvictNoAuth(ctx context.Context, pr *types.PullReq, reason string) error {
+	return c.MergeQueueRemoveNoAuth(ctx, pr, bootstrap.NewSystemServiceSession().Principal.ID, reason)
+}
+
+// MergeQueueRemoveNoAuth removes a pull request from the merge queue without checking access.
+// The reason should be provided if the pull request is removed by the system rather than by a user,
+// e.g. because a required status check failed for its speculative merge commit.
+func (c *Controller) MergeQueueRemoveNoAuth(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	reason string,
+) error {
+	if err := c.mergeQueueStore.Delete(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	repo, err := c.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	c.deleteMergeQueueRef(ctx, repo, pr)
+
+	// the activity would be noise for the pull requests that have been closed or merged in the meantime.
+	if pr.State == enum.PullReqStateOpen {
+		pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+		}
+
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, principalID,
+			&types.PullRequestActivityPayloadMergeQueue{Added: false, Reason: reason}, nil)
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for merge queue removal")
+		}
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqMergeQueueRemoved, pr)
+
+	principal := bootstrap.NewSystemServiceSession().Principal
+	principal.ID = principalID
+
+	c.eventReporter.MergeQueueRemoved(ctx, &pullreqevents.MergeQueueRemovedPayload{
+		Base:         eventBase(pr, &principal),
+		TargetBranch: pr.TargetBranch,
+	})
+
+	return nil
+}
+
+// MergeQueueList returns the merge queue of a branch. If the branch isn't provided,
+// the merge queue of the default branch is returned.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	entries, err := c.mergeQueueStore.List(ctx, repo.ID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	principalIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		principalIDs[i] = entry.CreatedBy
+	}
+
+	principalInfos, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch principal infos from info cache: %w", err)
+	}
+
+	for _, entry := range entries {
+		entry.AddedBy = principalInfos[entry.CreatedBy]
+	}
+
+	return entries, nil
+}
+
+// MergeQueuePrepareNoAuth creates the speculative merge commit of a pull request in the merge queue.
+// The pull request is merged on top of the baseSHA commit, which is either the target branch commit
+// or the speculative merge commit of the previous pull request in the queue. The merge commit is
+// stored to the merge queue reference of the pull request and the status checks should run against it.
+// It returns the conflicting files if the pull request can't be merged.
+func (c *Controller) MergeQueuePrepareNoAuth(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA sha.SHA,
+) ([]string, error) {
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	merger, err := c.principalInfoCache.Get(ctx, entry.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal info of the user who added the pull request: %w", err)
+	}
+
+	author, committer := mergeCommitIdentities(entry.Method, merger, pr)
+
+	title := entry.Title
+	if title == "" {
+		title = mergeCommitTitle(entry.Method, pr, sourceRepo)
+	}
+
+	refMergeQueue, err := git.GetRefPath(strconv.FormatInt(pr.Number, 10), gitenum.RefTypeMergeQueue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate merge queue ref name: %w", err)
+	}
+
+	now := time.Now()
+	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
+		WriteParams:   writeParams,
+		BaseSHA:       baseSHA,
+		HeadRepoUID:   sourceRepo.GitUID,
+		HeadBranch:    pr.SourceBranch,
+		Message:       git.CommitMessage(title, entry.Message),
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+		Refs: []git.RefUpdate{{
+			Name: refMergeQueue,
+			Old:  sha.SHA{}, // don't care about the old value.
+			New:  sha.SHA{}, // update to the result of the merge.
+		}},
+		HeadExpectedSHA: sha.Must(pr.SourceSHA),
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create speculative merge commit: %w", err)
+	}
+
+	if len(mergeOutput.ConflictFiles) > 0 {
+		return mergeOutput.ConflictFiles, nil
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() {
+		return nil, errors.New("merge didn't produce a speculative merge commit")
+	}
+
+	entry.Updated = now.UnixMilli()
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.HeadSHA = mergeOutput.HeadSHA.String()
+	entry.BaseSHA = mergeOutput.BaseSHA.String()
+	entry.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
+	entry.MergeSHA = mergeOutput.MergeSHA.String()
+
+	if err = c.mergeQueueStore.Update(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	c.eventReporter.MergeQueueMergeCreated(ctx, &pullreqevents.MergeQueueMergeCreatedPayload{
+		Base:         eventBase(pr, &systemPrincipal),
+		TargetBranch: pr.TargetBranch,
+		Ref:          refMergeQueue,
+		BaseSHA:      entry.BaseSHA,
+		MergeSHA:     entry.MergeSHA,
+	})
+
+	return nil, nil
+}
+
+// MergeQueueMergeNoAuth merges a pull request whose speculative merge commit passed the status checks.
+// The pull request is merged on behalf of the user who added it to the merge queue by fast-forwarding
+// the target branch to the speculative merge commit, so the target branch must still point to the base
+// of the merge commit. The violations are returned if the user is no longer allowed to push to the repository.
+// The caller must hold the repository merge lock.
+func (c *Controller) MergeQueueMergeNoAuth(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (*types.MergeViolations, error) {
+	principal, err := c.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the user who added the pull request to the merge queue: %w", err)
+	}
+
+	if principal.Blocked {
+		return &types.MergeViolations{
+			Message: "The user who added the pull request to the merge queue is blocked.",
+		}, nil
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	// the repository or the permissions might have changed since the pull request was added to the merge queue.
+	if err = apiauth.CheckRepoState(ctx, session, repo, enum.PermissionRepoPush); err != nil {
+		return &types.MergeViolations{
+			Message: fmt.Sprintf("The repository can't be pushed to in its current state %s.", repo.State),
+		}, nil
+	}
+
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return &types.MergeViolations{
+			Message: "The user who added the pull request to the merge queue is no longer allowed to push.",
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check access of the user who added the pull request: %w", err)
+	}
+
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	title := entry.Title
+	if title == "" {
+		title = mergeCommitTitle(entry.Method, pr, sourceRepo)
+	}
+
+	ruleOut, violations, err := c.mergeVerify(ctx, session, repo, sourceRepo, pr,
+		entry.Method, git.CommitMessage(title, entry.Message), entry.MergeSHA, false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if protection.IsCritical(violations) {
+		return &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	targetWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceWriteParams := targetWriteParams
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+	}
+
+	now := time.Now()
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: targetWriteParams,
+		Name:        pr.TargetBranch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    sha.Must(entry.MergeSHA),
+		OldValue:    sha.Must(entry.BaseSHA),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fast-forward target branch to the speculative merge commit: %w", err)
+	}
+
+	prNumber := strconv.FormatInt(pr.Number, 10)
+
+	// Make sure the PR head ref points to the merged commit.
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: targetWriteParams,
+		Name:        prNumber,
+		Type:        gitenum.RefTypePullReqHead,
+		NewValue:    sha.Must(entry.HeadSHA),
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request head ref after merge queue merge")
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: targetWriteParams,
+		Name:        prNumber,
+		Type:        gitenum.RefTypePullReqMerge,
+		NewValue:    sha.Nil,
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete pull request merge ref after merge queue merge")
+	}
+
+	if err = c.mergeQueueStore.Delete(ctx, pr.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	c.deleteMergeQueueRef(ctx, repo, pr)
+
+	pr, _, err = c.completeMerge(ctx, principal, repo, sourceWriteParams, pr, mergeResult{
+		method:             entry.Method,
+		mergedAt:           now,
+		baseSHA:            entry.BaseSHA,
+		headSHA:            entry.HeadSHA,
+		mergeBaseSHA:       entry.MergeBaseSHA,
+		mergeSHA:           entry.MergeSHA,
+		diffStats:          pr.Stats.DiffStats,
+		deleteSourceBranch: ruleOut.DeleteSourceBranch,
+		violations:         violations,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().Int64("pullreq_id", pr.ID).Msg("pull request has been merged by the merge queue")
+
+	return nil, nil
+}
+
+// deleteMergeQueueRef deletes the reference that points to the speculative merge commit of the pull request.
+func (c *Controller) deleteMergeQueueRef(ctx context.Context, repo *types.RepositoryCore, pr *types.PullReq) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params to delete merge queue ref")
+		return
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.FormatInt(pr.Number, 10),
+		Type:        gitenum.RefTypeMergeQueue,
+		NewValue:    sha.Nil,
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue ref")
+	}
+}
+
+// backfillMergeQueue sets the merge queue entry of the pull request, if the pull request is in the merge queue.
+func (c *Controller) backfillMergeQueue(ctx context.Context, pr *types.PullReq) error {
+	entry, err := c.mergeQueueStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	entry.AddedBy, err = c.principalInfoCache.Get(ctx, entry.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info of the user who added the pull request: %w", err)
+	}
+
+	pr.MergeQueue = entry
+
+	return nil
+}
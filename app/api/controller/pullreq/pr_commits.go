@@ -43,15 +43,25 @@ func (c *Controller) Commits(
 		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
 	}
 
-	gitRef := pr.SourceSHA
-	afterRef := pr.MergeBaseSHA
+	return c.listCommits(ctx, repo, pr, filter.Page, filter.Limit)
+}
 
+// listCommits lists the commits of the pull request with verified commit signatures.
+// If limit is zero, all commits of the pull request are returned.
+func (c *Controller) listCommits(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	page int,
+	limit int,
+) ([]types.Commit, error) {
 	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
-		ReadParams: git.CreateReadParams(repo),
-		GitREF:     gitRef,
-		After:      afterRef,
-		Page:       int32(filter.Page),
-		Limit:      int32(filter.Limit),
+		ReadParams:        git.CreateReadParams(repo),
+		GitREF:            pr.SourceSHA,
+		After:             pr.MergeBaseSHA,
+		Page:              int32(page),
+		Limit:             int32(limit),
+		IncludeSignatures: true,
 	})
 	if err != nil {
 		return nil, err
@@ -67,5 +77,9 @@ func (c *Controller) Commits(
 		commits[i] = *commit
 	}
 
+	if err = c.publicKeySvc.VerifyCommits(ctx, output.Commits, commits); err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	return commits, nil
 }
@@ -84,17 +84,9 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, sourceRepo, err := c.getCreateRepos(ctx, session, repoRef, in.SourceRepoRef)
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
-	}
-
-	sourceRepo := targetRepo
-	if in.SourceRepoRef != "" {
-		sourceRepo, err = c.getRepoCheckAccess(ctx, session, in.SourceRepoRef, enum.PermissionRepoPush)
-		if err != nil {
-			return nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
-		}
+		return nil, err
 	}
 
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
@@ -122,9 +114,20 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	if sourceRepo.ID != targetRepo.ID {
+		err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+			WriteParams:   targetWriteParams,
+			SourceRepoUID: sourceRepo.GitUID,
+			ObjectSHAs:    []sha.SHA{sourceSHA},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch source commits into the target repository: %w", err)
+		}
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA.String(),
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -658,3 +661,58 @@ func newPullReq(
 		Merger:            nil,
 	}
 }
+
+// getCreateRepos returns the target and the source repository of a new pull request.
+// A pull request from the same repository requires push access to it. A pull request
+// from another repository requires push access to the source repository, view access
+// to the target repository, and one of the repositories must be a fork of the other.
+func (c *Controller) getCreateRepos(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	sourceRepoRef string,
+) (*types.RepositoryCore, *types.RepositoryCore, error) {
+	if sourceRepoRef == "" {
+		targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		}
+
+		return targetRepo, targetRepo, nil
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	sourceRepo, err := c.getRepoCheckAccess(ctx, session, sourceRepoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+	}
+
+	if sourceRepo.ID == targetRepo.ID {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		}
+
+		return targetRepo, sourceRepo, nil
+	}
+
+	target, err := c.repoStore.Find(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	source, err := c.repoStore.Find(ctx, sourceRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find source repository: %w", err)
+	}
+
+	if source.ForkID != target.ID && target.ForkID != source.ID {
+		return nil, nil, usererror.BadRequest(
+			"Pull requests can only be opened between a repository and its fork")
+	}
+
+	return targetRepo, sourceRepo, nil
+}
@@ -55,6 +55,18 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to backfill pull request metadata: %w", err)
 	}
 
+	if err := c.backfillAutoMerge(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	if err := c.backfillMergeQueue(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	if err := c.backfillReactions(ctx, pr); err != nil {
+		return nil, err
+	}
+
 	return pr, nil
 }
 
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RevertInput struct {
+	// Branch is the name of the new branch with the revert commit. The default is "revert-pr-<number>".
+	Branch string `json:"branch"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RevertInput) sanitize(pr *types.PullReq) {
+	in.Branch = strings.TrimSpace(in.Branch)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Branch == "" {
+		in.Branch = "revert-pr-" + strconv.FormatInt(pr.Number, 10)
+	}
+
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("Revert %q", pr.Title)
+	}
+
+	if in.Message == "" {
+		in.Message = fmt.Sprintf("Reverts #%d", pr.Number)
+	}
+}
+
+// Revert reverts the changes of a merged pull request. The revert commit is created
+// on top of the target branch in a new branch and a pull request for it is opened.
+func (c *Controller) Revert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *RevertInput,
+) (CommitApplyOutput, *types.MergeViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.Merged == nil || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+		return CommitApplyOutput{}, nil, usererror.BadRequest("Only merged pull requests can be reverted.")
+	}
+
+	mergeSHA, err := sha.New(*pr.MergeSHA)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to parse merge SHA of the pull request: %w", err)
+	}
+
+	mergeTargetSHA, err := sha.New(*pr.MergeTargetSHA)
+	if err != nil {
+		return CommitApplyOutput{}, nil, fmt.Errorf("failed to parse merge target SHA of the pull request: %w", err)
+	}
+
+	in.sanitize(pr)
+
+	applyIn := &CommitApplyInput{
+		TargetBranch: pr.TargetBranch,
+		NewBranch:    in.Branch,
+		DryRunRules:  in.DryRunRules,
+		BypassRules:  in.BypassRules,
+	}
+	if err := applyIn.sanitize(repo); err != nil {
+		return CommitApplyOutput{}, nil, err
+	}
+
+	// The pull request's changes are all changes between the target branch before the merge and the merge result.
+	// This works for all merge methods, including rebase and fast-forward which can add several commits.
+	return c.applyCommit(ctx, session, repo, applyIn, "revert",
+		func(writeParams git.WriteParams, baseSHA sha.SHA, refs []git.RefUpdate) (sha.SHA, []string, error) {
+			return c.revert(ctx, session, writeParams, baseSHA, mergeTargetSHA, mergeSHA,
+				git.CommitMessage(in.Title, in.Message), refs)
+		},
+		func() pullReqDetails {
+			return pullReqDetails{
+				Title:       in.Title,
+				Description: in.Message,
+			}
+		})
+}
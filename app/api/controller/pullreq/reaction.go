@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReactionInput struct {
+	Reaction enum.Reaction `json:"reaction"`
+}
+
+func (in *ReactionInput) sanitize() error {
+	reaction, ok := in.Reaction.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported reaction %q.", in.Reaction)
+	}
+
+	in.Reaction = reaction
+
+	return nil
+}
+
+// ReactionAdd adds a reaction of the current user to the pull request description (if commentID is nil)
+// or to a pull request comment. It returns the updated reactions of the description or the comment.
+func (c *Controller) ReactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID *int64,
+	in *ReactionInput,
+) ([]types.ReactionSummary, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.reactionChange(ctx, session, repoRef, prNum, commentID,
+		func(pr *types.PullReq) error {
+			err := c.reactionStore.Create(ctx, &types.PullReqReaction{
+				PullReqID:  pr.ID,
+				ActivityID: commentID,
+				CreatedBy:  session.Principal.ID,
+				Created:    time.Now().UnixMilli(),
+				Reaction:   in.Reaction,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add reaction: %w", err)
+			}
+
+			return nil
+		})
+}
+
+// ReactionRemove removes a reaction of the current user from the pull request description (if commentID is nil)
+// or from a pull request comment. It returns the updated reactions of the description or the comment.
+func (c *Controller) ReactionRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID *int64,
+	reaction enum.Reaction,
+) ([]types.ReactionSummary, error) {
+	in := &ReactionInput{Reaction: reaction}
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.reactionChange(ctx, session, repoRef, prNum, commentID,
+		func(pr *types.PullReq) error {
+			err := c.reactionStore.Delete(ctx, pr.ID, commentID, session.Principal.ID, in.Reaction)
+			if err != nil {
+				return fmt.Errorf("failed to remove reaction: %w", err)
+			}
+
+			return nil
+		})
+}
+
+func (c *Controller) reactionChange(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID *int64,
+	changeFn func(pr *types.PullReq) error,
+) ([]types.ReactionSummary, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if commentID != nil {
+		if _, err = c.getCommentForPR(ctx, pr, *commentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = changeFn(pr); err != nil {
+		return nil, err
+	}
+
+	reactions, err := c.reactionStore.ListForTarget(ctx, pr.ID, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+
+	summaries, err := c.summarizeReactions(ctx, reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	var targetID int64
+	if commentID != nil {
+		targetID = *commentID
+	}
+
+	targetSummaries := summaries[targetID]
+	if targetSummaries == nil {
+		targetSummaries = []types.ReactionSummary{}
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqReactionsUpdated, &types.PullReqReactionsUpdate{
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		ActivityID:    commentID,
+		Reactions:     targetSummaries,
+	})
+
+	return targetSummaries, nil
+}
+
+// backfillReactions sets the reactions to the pull request description.
+func (c *Controller) backfillReactions(ctx context.Context, pr *types.PullReq) error {
+	reactions, err := c.reactionStore.ListForTarget(ctx, pr.ID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request reactions: %w", err)
+	}
+
+	summaries, err := c.summarizeReactions(ctx, reactions)
+	if err != nil {
+		return err
+	}
+
+	pr.Reactions = summaries[0]
+
+	return nil
+}
+
+// summarizeReactions aggregates the reactions per target. The reactions to the pull request description
+// are under the key 0 of the returned map, the reactions to the comments are under the comment IDs.
+func (c *Controller) summarizeReactions(
+	ctx context.Context,
+	reactions []*types.PullReqReaction,
+) (map[int64][]types.ReactionSummary, error) {
+	principalIDs := make([]int64, 0, len(reactions))
+	for _, reaction := range reactions {
+		principalIDs = append(principalIDs, reaction.CreatedBy)
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reactors from principal info cache: %w", err)
+	}
+
+	return aggregateReactions(reactions, principals), nil
+}
+
+// aggregateReactions groups the reactions by the target and by the reaction type.
+// The reaction types are ordered by the time the first reaction of the type was added.
+func aggregateReactions(
+	reactions []*types.PullReqReaction,
+	principals map[int64]*types.PrincipalInfo,
+) map[int64][]types.ReactionSummary {
+	result := make(map[int64][]types.ReactionSummary)
+
+	for _, reaction := range reactions {
+		var targetID int64
+		if reaction.ActivityID != nil {
+			targetID = *reaction.ActivityID
+		}
+
+		summaries := result[targetID]
+
+		idx := -1
+		for i := range summaries {
+			if summaries[i].Reaction == reaction.Reaction {
+				idx = i
+				break
+			}
+		}
+
+		if idx < 0 {
+			summaries = append(summaries, types.ReactionSummary{Reaction: reaction.Reaction})
+			idx = len(summaries) - 1
+		}
+
+		summaries[idx].Count++
+		if principal, ok := principals[reaction.CreatedBy]; ok {
+			summaries[idx].Reactors = append(summaries[idx].Reactors, principal)
+		}
+
+		result[targetID] = summaries
+	}
+
+	return result
+}
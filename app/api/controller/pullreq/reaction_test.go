@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAggregateReactions(t *testing.T) {
+	alice := &types.PrincipalInfo{ID: 1, UID: "alice"}
+	bob := &types.PrincipalInfo{ID: 2, UID: "bob"}
+	principals := map[int64]*types.PrincipalInfo{1: alice, 2: bob}
+
+	var comment int64 = 10
+
+	tests := []struct {
+		name      string
+		reactions []*types.PullReqReaction
+		want      map[int64][]types.ReactionSummary
+	}{
+		{
+			name:      "empty",
+			reactions: nil,
+			want:      map[int64][]types.ReactionSummary{},
+		},
+		{
+			name: "description-and-comment",
+			reactions: []*types.PullReqReaction{
+				{CreatedBy: 1, Reaction: enum.ReactionRocket},
+				{CreatedBy: 1, ActivityID: &comment, Reaction: enum.ReactionThumbsUp},
+				{CreatedBy: 2, Reaction: enum.ReactionHeart},
+				{CreatedBy: 2, Reaction: enum.ReactionRocket},
+				{CreatedBy: 2, ActivityID: &comment, Reaction: enum.ReactionThumbsUp},
+			},
+			want: map[int64][]types.ReactionSummary{
+				0: {
+					{Reaction: enum.ReactionRocket, Count: 2, Reactors: []*types.PrincipalInfo{alice, bob}},
+					{Reaction: enum.ReactionHeart, Count: 1, Reactors: []*types.PrincipalInfo{bob}},
+				},
+				comment: {
+					{Reaction: enum.ReactionThumbsUp, Count: 2, Reactors: []*types.PrincipalInfo{alice, bob}},
+				},
+			},
+		},
+		{
+			name: "unknown-reactor",
+			reactions: []*types.PullReqReaction{
+				{CreatedBy: 3, Reaction: enum.ReactionEyes},
+			},
+			want: map[int64][]types.ReactionSummary{
+				0: {{Reaction: enum.ReactionEyes, Count: 1}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := aggregateReactions(test.reactions, principals)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%+v, got=%+v", test.want, got)
+			}
+		})
+	}
+}
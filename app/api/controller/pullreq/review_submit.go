@@ -56,6 +56,7 @@ func (in *ReviewSubmitInput) Validate() error {
 }
 
 // ReviewSubmit creates a new pull request review.
+// All pending comments of the reviewer are published together with the review.
 func (c *Controller) ReviewSubmit(
 	ctx context.Context,
 	session *auth.Session,
@@ -113,10 +114,17 @@ func (c *Controller) ReviewSubmit(
 		if err != nil {
 			return err
 		}
+
+		commentIDs, err := c.publishPendingComments(ctx, session, pr)
+		if err != nil {
+			return err
+		}
+
 		c.eventReporter.ReviewSubmitted(ctx, &events.ReviewSubmittedPayload{
 			Base:       eventBase(pr, &session.Principal),
 			Decision:   review.Decision,
 			ReviewerID: review.CreatedBy,
+			CommentIDs: commentIDs,
 		})
 
 		_, err = c.updateReviewer(ctx, session, pr, review, commitSHA.String())
@@ -143,6 +151,8 @@ func (c *Controller) ReviewSubmit(
 		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after review submit")
 	}
 
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
 	err = c.instrumentation.Track(ctx, instrument.Event{
 		Type:      instrument.EventTypeReviewPullRequest,
 		Principal: session.Principal.ToPrincipalInfo(),
@@ -161,6 +171,54 @@ func (c *Controller) ReviewSubmit(
 	return review, nil
 }
 
+// publishPendingComments makes all pending comments of the reviewer visible
+// and updates the pull request comment counters. It returns IDs of the published comments.
+func (c *Controller) publishPendingComments(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+) ([]int64, error) {
+	pending, err := c.activityStore.ListPending(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending comments: %w", err)
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	commentIDs := make([]int64, len(pending))
+	var unresolvedCount int
+
+	for i, act := range pending {
+		published, err := c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
+			act.Pending = false
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish pending comment: %w", err)
+		}
+
+		commentIDs[i] = published.ID
+		if published.IsBlocking() {
+			unresolvedCount++
+		}
+	}
+
+	prUpd, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.CommentCount += len(pending)
+		pr.UnresolvedCount += unresolvedCount
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment pull request comment counters: %w", err)
+	}
+
+	*pr = *prUpd
+
+	return commentIDs, nil
+}
+
 // updateReviewer updates pull request reviewer object.
 func (c *Controller) updateReviewer(
 	ctx context.Context,
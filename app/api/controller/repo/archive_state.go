@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ArchiveRepo archives a repository. Archived repositories are read-only:
+// pushes, pull request changes, pipeline executions and webhooks are blocked until the repository is unarchived.
+func (c *Controller) ArchiveRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*RepositoryOutput, error) {
+	return c.changeArchivedState(ctx, session, repoRef, enum.RepoStateActive, enum.RepoStateArchived,
+		audit.ActionArchived)
+}
+
+// UnarchiveRepo restores an archived repository to the active state.
+func (c *Controller) UnarchiveRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*RepositoryOutput, error) {
+	return c.changeArchivedState(ctx, session, repoRef, enum.RepoStateArchived, enum.RepoStateActive,
+		audit.ActionUnarchived)
+}
+
+func (c *Controller) changeArchivedState(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oldState enum.RepoState,
+	newState enum.RepoState,
+	action audit.Action,
+) (*RepositoryOutput, error) {
+	repoCore, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, enum.RepoStateArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.Find(ctx, repoCore.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository by ID: %w", err)
+	}
+
+	if repo.State == newState {
+		return GetRepoOutput(ctx, c.publicAccess, repo)
+	}
+
+	if repo.State != oldState {
+		return nil, usererror.BadRequestf("Changing the state of a repository from %s to %s is not allowed.",
+			repo.State, newState)
+	}
+
+	if err = c.repoCheck.LifecycleRestriction(ctx, session, repoCore); err != nil {
+		return nil, err
+	}
+
+	var repoClone types.Repository
+
+	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		if repo.State != oldState {
+			return usererror.BadRequestf("Changing the state of a repository from %s to %s is not allowed.",
+				repo.State, newState)
+		}
+
+		repoClone = *repo
+		repo.State = newState
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the repo state: %w", err)
+	}
+
+	c.repoFinder.MarkChanged(ctx, repo.Core())
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		action,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(repoClone),
+		audit.WithNewObject(repo),
+		audit.WithRepoID(repo.ID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for %s repository operation: %s", action, err)
+	}
+
+	// backfill repo url
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	c.eventReporter.StateChanged(ctx, &repoevents.StateChangedPayload{
+		Base:     eventBase(repo.Core(), &session.Principal),
+		OldState: repoClone.State,
+		NewState: repo.State,
+	})
+
+	return GetRepoOutput(ctx, c.publicAccess, repo)
+}
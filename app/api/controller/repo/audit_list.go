@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AuditList returns the audit events recorded for a repository.
+func (c *Controller) AuditList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	if err := audit.ValidateFilter(filter); err != nil {
+		return nil, 0, usererror.BadRequest(err.Error())
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.auditEventStore.CountForRepo(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count repo audit events: %w", err)
+	}
+
+	events, err := c.auditEventStore.ListForRepo(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list repo audit events: %w", err)
+	}
+
+	return events, count, nil
+}
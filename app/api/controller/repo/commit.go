@@ -15,6 +15,7 @@
 package repo
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"encoding/base64"
@@ -30,6 +31,7 @@ import (
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -116,19 +118,6 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
-	if in.DryRunRules {
-		return types.CommitFilesResponse{
-			DryRunRulesOutput: types.DryRunRulesOutput{
-				DryRunRules:    true,
-				RuleViolations: violations,
-			},
-		}, nil, nil
-	}
-
-	if protection.IsCritical(violations) {
-		return types.CommitFilesResponse{}, violations, nil
-	}
-
 	actions := make([]git.CommitFileAction, len(in.Actions))
 	for i, action := range in.Actions {
 		var rawPayload []byte
@@ -153,6 +142,41 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		}
 	}
 
+	author := cmp.Or(in.Author, identityFromPrincipal(session.Principal))
+	message := git.CommitMessage(in.Title, in.Message)
+
+	pushViolations, err := controller.VerifyServerCommit(ctx, c.publicKeySvc, rules, protection.PushVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		BranchName:         branchName,
+	}, controller.ServerCommit{
+		Message:     message,
+		Author:      *author,
+		ParentCount: 1,
+		Files:       commitActionFiles(actions),
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	violations = append(violations, pushViolations...)
+
+	if in.DryRunRules {
+		return types.CommitFilesResponse{
+			DryRunRulesOutput: types.DryRunRulesOutput{
+				DryRunRules:    true,
+				RuleViolations: violations,
+			},
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return types.CommitFilesResponse{}, violations, nil
+	}
+
 	// Create internal write params. Note: This will skip the pre-commit protection rules check.
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
@@ -162,13 +186,13 @@ func (c *Controller) CommitFiles(ctx context.Context,
 	now := time.Now()
 	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
 		WriteParams:   writeParams,
-		Message:       git.CommitMessage(in.Title, in.Message),
+		Message:       message,
 		Branch:        in.Branch,
 		NewBranch:     in.NewBranch,
 		Actions:       actions,
 		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
 		CommitterDate: &now,
-		Author:        cmp.Or(in.Author, identityFromPrincipal(session.Principal)),
+		Author:        author,
 		AuthorDate:    &now,
 	})
 	if err != nil {
@@ -203,6 +227,7 @@ func (c *Controller) CommitFiles(ctx context.Context,
 				RepoPath:       repo.Path,
 				RuleViolations: violations,
 			}),
+			audit.WithRepoID(repo.ID),
 		)
 	}
 	if err != nil {
@@ -216,3 +241,30 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		},
 	}, nil, nil
 }
+
+// commitActionFiles returns the files that the commit actions add, modify or delete.
+// The size of a moved file without new content and of a patched file isn't known before the commit is created,
+// so only the paths of such files are verified.
+func commitActionFiles(actions []git.CommitFileAction) []protection.PushedFile {
+	files := make([]protection.PushedFile, 0, len(actions))
+	for _, action := range actions {
+		filePath := api.CleanUploadFileName(action.Path)
+
+		switch action.Action {
+		case git.CreateAction, git.UpdateAction:
+			files = append(files, protection.PushedFile{Path: filePath, Size: int64(len(action.Payload))})
+		case git.DeleteAction:
+			files = append(files, protection.PushedFile{Path: filePath, Deleted: true})
+		case git.MoveAction:
+			// the payload of the move action is the new path, optionally followed by a NUL byte and the new content.
+			newPath, content, _ := bytes.Cut(action.Payload, []byte{0})
+			files = append(files,
+				protection.PushedFile{Path: filePath, Deleted: true},
+				protection.PushedFile{Path: api.CleanUploadFileName(string(newPath)), Size: int64(len(content))})
+		case git.PatchTextAction:
+			files = append(files, protection.PushedFile{Path: filePath})
+		}
+	}
+
+	return files
+}
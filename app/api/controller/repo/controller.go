@@ -33,8 +33,10 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
@@ -43,6 +45,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -58,6 +61,8 @@ type RepositoryOutput struct {
 	IsPublic  bool `json:"is_public" yaml:"is_public"`
 	Importing bool `json:"importing" yaml:"-"`
 	Archived  bool `json:"archived" yaml:"-"`
+	// PullMirror is true if the repository is a read-only mirror of a remote repository.
+	PullMirror bool `json:"pull_mirror" yaml:"-"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -76,40 +81,49 @@ func (r RepositoryOutput) MarshalJSON() ([]byte, error) {
 type Controller struct {
 	defaultBranch string
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	executionStore     store.ExecutionStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	checkStore         store.CheckStore
-	pullReqStore       store.PullReqStore
-	settings           *settings.Service
-	principalInfoCache store.PrincipalInfoCache
-	userGroupStore     store.UserGroupStore
-	userGroupService   usergroup.SearchService
-	protectionManager  *protection.Manager
-	git                git.Interface
-	spaceFinder        refcache.SpaceFinder
-	repoFinder         refcache.RepoFinder
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	locker             *locker.Locker
-	auditService       audit.Service
-	mtxManager         lock.MutexManager
-	identifierCheck    check.RepoIdentifier
-	repoCheck          Check
-	publicAccess       publicaccess.Service
-	labelSvc           *label.Service
-	instrumentation    instrument.Service
-	rulesSvc           *rules.Service
-	sseStreamer        sse.Streamer
+	tx                       dbtx.Transactor
+	urlProvider              url.Provider
+	authorizer               authz.Authorizer
+	repoStore                store.RepoStore
+	spaceStore               store.SpaceStore
+	pipelineStore            store.PipelineStore
+	executionStore           store.ExecutionStore
+	principalStore           store.PrincipalStore
+	ruleStore                store.RuleStore
+	checkStore               store.CheckStore
+	pullReqStore             store.PullReqStore
+	settings                 *settings.Service
+	principalInfoCache       store.PrincipalInfoCache
+	userGroupStore           store.UserGroupStore
+	userGroupService         usergroup.SearchService
+	protectionManager        *protection.Manager
+	git                      git.Interface
+	spaceFinder              refcache.SpaceFinder
+	repoFinder               refcache.RepoFinder
+	importer                 *importer.Repository
+	codeOwners               *codeowners.Service
+	eventReporter            *repoevents.Reporter
+	indexer                  keywordsearch.Indexer
+	resourceLimiter          limiter.ResourceLimiter
+	locker                   *locker.Locker
+	auditService             audit.Service
+	mtxManager               lock.MutexManager
+	identifierCheck          check.RepoIdentifier
+	repoCheck                Check
+	publicAccess             publicaccess.Service
+	labelSvc                 *label.Service
+	instrumentation          instrument.Service
+	rulesSvc                 *rules.Service
+	sseStreamer              sse.Streamer
+	auditEventStore          store.AuditEventStore
+	publicKeySvc             publickey.Service
+	pullMirrorStore          store.PullMirrorStore
+	secretStore              store.SecretStore
+	encrypter                encrypt.Encrypter
+	mirrorSvc                *mirror.Service
+	pushMirrorStore          store.PushMirrorStore
+	pushMirrorExecutionStore store.PushMirrorExecutionStore
+	webhookStore             store.WebhookStore
 }
 
 func NewController(
@@ -148,43 +162,61 @@ func NewController(
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
+	auditEventStore store.AuditEventStore,
+	publicKeySvc publickey.Service,
+	pullMirrorStore store.PullMirrorStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	mirrorSvc *mirror.Service,
+	pushMirrorStore store.PushMirrorStore,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
+	webhookStore store.WebhookStore,
 ) *Controller {
 	return &Controller{
-		defaultBranch:      config.Git.DefaultBranch,
-		tx:                 tx,
-		urlProvider:        urlProvider,
-		authorizer:         authorizer,
-		repoStore:          repoStore,
-		spaceStore:         spaceStore,
-		pipelineStore:      pipelineStore,
-		executionStore:     executionStore,
-		principalStore:     principalStore,
-		ruleStore:          ruleStore,
-		checkStore:         checkStore,
-		pullReqStore:       pullReqStore,
-		settings:           settings,
-		principalInfoCache: principalInfoCache,
-		protectionManager:  protectionManager,
-		git:                git,
-		spaceFinder:        spaceFinder,
-		repoFinder:         repoFinder,
-		importer:           importer,
-		codeOwners:         codeOwners,
-		eventReporter:      eventReporter,
-		indexer:            indexer,
-		resourceLimiter:    limiter,
-		locker:             locker,
-		auditService:       auditService,
-		mtxManager:         mtxManager,
-		identifierCheck:    identifierCheck,
-		repoCheck:          repoCheck,
-		publicAccess:       publicAccess,
-		labelSvc:           labelSvc,
-		instrumentation:    instrumentation,
-		userGroupStore:     userGroupStore,
-		userGroupService:   userGroupService,
-		rulesSvc:           rulesSvc,
-		sseStreamer:        sseStreamer,
+		defaultBranch:            config.Git.DefaultBranch,
+		tx:                       tx,
+		urlProvider:              urlProvider,
+		authorizer:               authorizer,
+		repoStore:                repoStore,
+		spaceStore:               spaceStore,
+		pipelineStore:            pipelineStore,
+		executionStore:           executionStore,
+		principalStore:           principalStore,
+		ruleStore:                ruleStore,
+		checkStore:               checkStore,
+		pullReqStore:             pullReqStore,
+		settings:                 settings,
+		principalInfoCache:       principalInfoCache,
+		protectionManager:        protectionManager,
+		git:                      git,
+		spaceFinder:              spaceFinder,
+		repoFinder:               repoFinder,
+		importer:                 importer,
+		codeOwners:               codeOwners,
+		eventReporter:            eventReporter,
+		indexer:                  indexer,
+		resourceLimiter:          limiter,
+		locker:                   locker,
+		auditService:             auditService,
+		mtxManager:               mtxManager,
+		identifierCheck:          identifierCheck,
+		repoCheck:                repoCheck,
+		publicAccess:             publicAccess,
+		labelSvc:                 labelSvc,
+		instrumentation:          instrumentation,
+		userGroupStore:           userGroupStore,
+		userGroupService:         userGroupService,
+		rulesSvc:                 rulesSvc,
+		sseStreamer:              sseStreamer,
+		auditEventStore:          auditEventStore,
+		publicKeySvc:             publicKeySvc,
+		pullMirrorStore:          pullMirrorStore,
+		secretStore:              secretStore,
+		encrypter:                encrypter,
+		mirrorSvc:                mirrorSvc,
+		pushMirrorStore:          pushMirrorStore,
+		pushMirrorExecutionStore: pushMirrorExecutionStore,
+		webhookStore:             webhookStore,
 	}
 }
 
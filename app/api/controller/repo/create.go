@@ -162,6 +162,7 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			Repository: repoOutput.Repository,
 			IsPublic:   repoOutput.IsPublic,
 		}),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create repository operation: %s", err)
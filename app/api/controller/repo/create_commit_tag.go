@@ -66,13 +66,25 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 	}
 
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: in.BypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   protection.RefActionCreate,
-		RefType:     protection.RefTypeTag,
-		RefNames:    []string{in.Name},
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          protection.RefActionCreate,
+		RefType:            protection.RefTypeTag,
+		RefNames:           []string{in.Name},
+		ListBranchesContaining: func(ctx context.Context, _ string) ([]string, error) {
+			out, err := c.git.ListBranchesContaining(ctx, &git.ListBranchesContainingParams{
+				ReadParams: git.CreateReadParams(repo),
+				Rev:        in.Target,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list branches containing the tag target: %w", err)
+			}
+
+			return out.BranchNames, nil
+		},
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -122,6 +122,7 @@ func (c *Controller) UpdateDefaultBranch(
 			Repository: *repoFull,
 			IsPublic:   repoOutput.IsPublic,
 		}),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update default branch operation: %s", err)
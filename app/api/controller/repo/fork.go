@@ -0,0 +1,395 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// forkListBatchSize is the number of forks read at once when the accessible forks of a repository are listed.
+const forkListBatchSize = 100
+
+var errPublicForkOfPrivateRepo = usererror.BadRequest("A fork of a private repository can't be public.")
+
+type ForkInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	// DefaultBranchOnly specifies that only the default branch of the upstream repository is copied to the fork.
+	DefaultBranchOnly bool `json:"default_branch_only"`
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, session *auth.Session, upstream *types.Repository) error {
+	if err := ValidateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if in.Identifier == "" {
+		in.Identifier = upstream.Identifier
+	}
+
+	if err := c.identifierCheck(in.Identifier, session); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = upstream.Description
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Fork creates a new repository as a fork of an existing repository.
+// The git objects are shared with the upstream repository, so only the references are copied.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*RepositoryOutput, error) {
+	upstreamCore, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to upstream repo: %w", err)
+	}
+
+	upstream, err := c.repoStore.Find(ctx, upstreamCore.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find upstream repo: %w", err)
+	}
+
+	if upstream.IsEmpty {
+		return nil, usererror.BadRequest("Empty repositories can't be forked.")
+	}
+
+	if err = c.sanitizeForkInput(in, session, upstream); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for parent space %q: %w",
+			parentSpace.Path,
+			err,
+		)
+	}
+	if in.IsPublic && !isPublicAccessSupported {
+		return nil, errPublicRepoCreationDisabled
+	}
+
+	if err = c.checkForkPublicAccess(ctx, upstream.ID, in.IsPublic); err != nil {
+		return nil, err
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: upstream.DefaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		ForkID:        upstream.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(ctx),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	gitResp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:             *identityFromPrincipal(session.Principal),
+		EnvVars:           envVars,
+		UpstreamRepoUID:   upstream.GitUID,
+		DefaultBranch:     upstream.DefaultBranch,
+		DefaultBranchOnly: in.DefaultBranchOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork git repository: %w", err)
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		_, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   in.Description,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			LastGITPush:   now,
+			ForkID:        upstream.ID,
+			DefaultBranch: upstream.DefaultBranch,
+			IsEmpty:       false,
+		}
+
+		return c.repoStore.Create(ctx, repo)
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.DeleteGitRepository(ctx, session, gitResp.UID); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete forked repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic)
+	if err != nil {
+		if dErr := c.publicAccess.Delete(ctx, enum.PublicResourceTypeRepo, repo.Path); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
+		}
+
+		// only cleanup repo itself if cleanup of public access succeeded (to avoid leaking public access)
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to set repo public access (successful cleanup): %w", err)
+	}
+
+	c.updateNumForks(ctx, upstream.ID, 1)
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	repoOutput := GetRepoOutputWithAccess(ctx, in.IsPublic, repo)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: repoOutput.Repository,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+		audit.WithRepoID(repo.ID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for fork repository operation: %s", err)
+	}
+
+	err = c.instrumentation.Track(ctx, instrument.Event{
+		Type:      instrument.EventTypeRepositoryCreate,
+		Principal: session.Principal.ToPrincipalInfo(),
+		Path:      repo.Path,
+		Properties: map[instrument.Property]any{
+			instrument.PropertyRepositoryID:           repo.ID,
+			instrument.PropertyRepositoryName:         repo.Identifier,
+			instrument.PropertyRepositoryCreationType: instrument.CreationTypeFork,
+		},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for fork repository operation: %s", err)
+	}
+
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		Base: eventBase(repo.Core(), &session.Principal),
+		Type: "forked",
+	})
+
+	err = c.indexer.Index(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+	}
+
+	return repoOutput, nil
+}
+
+// ListForks lists the forks of a repository. Only the forks the user has access to are returned.
+// Forks can be in any space, so the access is checked for each of them
+// and the pagination and the total count apply to the accessible forks only.
+func (c *Controller) ListForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoFilter,
+) ([]*RepositoryOutput, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var accessible []*types.Repository
+
+	batchFilter := *filter
+	batchFilter.Size = forkListBatchSize
+
+	for batchFilter.Page = 1; ; batchFilter.Page++ {
+		forks, err := c.repoStore.ListForks(ctx, repo.ID, &batchFilter)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		for _, fork := range forks {
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, fork.Core(), enum.PermissionRepoView)
+			switch {
+			case err == nil:
+				accessible = append(accessible, fork)
+			case errors.Is(err, apiauth.ErrNotAuthorized):
+			default:
+				return nil, 0, fmt.Errorf("failed to check access to fork %q: %w", fork.Path, err)
+			}
+		}
+
+		if len(forks) < forkListBatchSize {
+			break
+		}
+	}
+
+	count := int64(len(accessible))
+
+	if filter.Size > 0 {
+		offset := min(max(filter.Page-1, 0)*filter.Size, len(accessible))
+		accessible = accessible[offset:min(offset+filter.Size, len(accessible))]
+	}
+
+	forksOut := make([]*RepositoryOutput, 0, len(accessible))
+	for _, fork := range accessible {
+		// backfill URLs
+		fork.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, fork.Path)
+		fork.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, fork.Path)
+
+		forkOut, err := GetRepoOutput(ctx, c.publicAccess, fork)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get repo %q output: %w", fork.Path, err)
+		}
+
+		forksOut = append(forksOut, forkOut)
+	}
+
+	return forksOut, count, nil
+}
+
+// checkForkPublicAccess returns an error if a fork of a private repository would become public,
+// because the fork would expose the contents of the upstream repository.
+func (c *Controller) checkForkPublicAccess(ctx context.Context, upstreamID int64, isPublic bool) error {
+	if !isPublic {
+		return nil
+	}
+
+	upstream, err := c.repoFinder.FindByID(ctx, upstreamID)
+	if err != nil {
+		return fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	isUpstreamPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, upstream.Path)
+	if err != nil {
+		return fmt.Errorf("failed to check public access of the upstream repository: %w", err)
+	}
+
+	if !isUpstreamPublic {
+		return errPublicForkOfPrivateRepo
+	}
+
+	return nil
+}
+
+// updateNumForks updates the number of forks of a repository.
+func (c *Controller) updateNumForks(ctx context.Context, repoID int64, delta int) {
+	repo, err := c.repoStore.Find(ctx, repoID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to find repo to update number of forks")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.NumForks = max(repo.NumForks+delta, 0)
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to update number of forks")
+	}
+}
+
+// DissociateForks makes all forks of the repository independent of the repository's git objects.
+// It must be called before the git repository is deleted, otherwise the forks would become corrupted.
+func (c *Controller) DissociateForks(ctx context.Context, session *auth.Session, repoID int64) error {
+	forks, err := c.repoStore.ListAllForks(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := c.createGitWriteParams(ctx, session, fork.GitUID)
+		if err != nil {
+			return err
+		}
+
+		err = c.git.DissociateRepository(ctx, &git.DissociateRepositoryParams{WriteParams: writeParams})
+		if err != nil {
+			return fmt.Errorf("failed to dissociate fork %d from its upstream repository: %w", fork.ID, err)
+		}
+	}
+
+	return nil
+}
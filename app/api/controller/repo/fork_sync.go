@@ -0,0 +1,374 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxSyncVerifyCommits is the maximum number of commits added by a fork sync that are verified against push rules.
+const maxSyncVerifyCommits = 1000
+
+type SyncForkInput struct {
+	// Branch is the branch of the fork that is updated, the default branch of the fork if empty.
+	Branch          string  `json:"branch"`
+	BranchCommitSHA sha.SHA `json:"branch_commit_sha"`
+
+	// UpstreamBranch is the branch of the upstream repository, the same as Branch if empty.
+	UpstreamBranch string `json:"upstream_branch"`
+
+	DryRun      bool `json:"dry_run"`
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+// SyncFork updates a branch of a fork with the latest commit of a branch of the upstream repository.
+// The branch is fast-forwarded if possible, otherwise the upstream branch is merged into it.
+//
+//nolint:gocognit,funlen // refactor if needed
+func (c *Controller) SyncFork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SyncForkInput,
+) (*types.SyncForkResponse, *types.MergeViolations, error) {
+	forkCore, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	fork, err := c.repoStore.Find(ctx, forkCore.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if fork.ForkID == 0 {
+		return nil, nil, usererror.BadRequest("The repository is not a fork")
+	}
+
+	upstream, err := c.repoFinder.FindByID(ctx, fork.ForkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, upstream, enum.PermissionRepoView); err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to upstream repository: %w", err)
+	}
+
+	if in.Branch == "" {
+		in.Branch = forkCore.DefaultBranch
+	}
+	if in.UpstreamBranch == "" {
+		in.UpstreamBranch = in.Branch
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, forkCore)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               forkCore,
+		RefAction:          protection.RefActionUpdate,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{in.Branch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	readParams := git.CreateReadParams(forkCore)
+
+	branch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: in.Branch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get fork branch: %w", err)
+	}
+
+	if !in.BranchCommitSHA.IsEmpty() && !branch.Branch.SHA.Equal(in.BranchCommitSHA) {
+		return nil, nil, usererror.BadRequestf("The commit %s isn't the latest commit on the branch %s",
+			in.BranchCommitSHA, branch.Branch.Name)
+	}
+
+	upstreamBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(upstream),
+		BranchName: in.UpstreamBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upstream branch: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, forkCore)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the commits added to the upstream repository after the fork was created aren't available in the fork.
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: upstream.GitUID,
+		ObjectSHAs:    []sha.SHA{upstreamBranch.Branch.SHA},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch upstream commits: %w", err)
+	}
+
+	isAncestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   upstreamBranch.Branch.SHA,
+		DescendantCommitSHA: branch.Branch.SHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed check ancestor: %w", err)
+	}
+
+	if isAncestor.Ancestor {
+		// The fork branch already contains the latest commit from the upstream branch - nothing to do.
+		return &types.SyncForkResponse{
+			AlreadyAncestor: true,
+			RuleViolations:  violations,
+		}, nil, nil
+	}
+
+	canFastForward, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   branch.Branch.SHA,
+		DescendantCommitSHA: upstreamBranch.Branch.SHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed check ancestor: %w", err)
+	}
+
+	method := gitenum.MergeMethodMerge
+	if canFastForward.Ancestor {
+		method = gitenum.MergeMethodFastForward
+	}
+
+	mergeParams := &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseSHA:         branch.Branch.SHA,
+		HeadRepoUID:     upstream.GitUID,
+		HeadBranch:      in.UpstreamBranch,
+		Message:         fmt.Sprintf("Merge branch '%s' of %s into %s", in.UpstreamBranch, upstream.Path, in.Branch),
+		HeadExpectedSHA: upstreamBranch.Branch.SHA,
+		Method:          method,
+	}
+
+	// The merge is done without updating the branch first,
+	// to verify the commits it adds against the push rules before the branch is updated.
+	trialOutput, err := c.git.Merge(ctx, mergeParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fork sync execution failed: %w", err)
+	}
+
+	if trialOutput.MergeSHA.IsEmpty() || len(trialOutput.ConflictFiles) > 0 {
+		if in.DryRun {
+			// DryRun is true: Just return rule violations and list of conflicted files.
+			return &types.SyncForkResponse{
+				FastForward:    canFastForward.Ancestor,
+				RuleViolations: violations,
+				DryRun:         true,
+				ConflictFiles:  trialOutput.ConflictFiles,
+			}, nil, nil
+		}
+
+		return nil, &types.MergeViolations{
+			ConflictFiles:  trialOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("Fork sync blocked by conflicting files: %v", trialOutput.ConflictFiles),
+		}, nil
+	}
+
+	pushViolations, err := c.verifySyncedCommits(ctx, session, forkCore, protectionRules, isRepoOwner,
+		in.BypassRules, in.Branch, branch.Branch.SHA, trialOutput.MergeSHA)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations = append(violations, pushViolations...)
+
+	if in.DryRunRules {
+		// DryRunRules is true: Just return rule violations and don't attempt to sync.
+		return &types.SyncForkResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if in.DryRun {
+		// DryRun is true: Just return rule violations and list of conflicted files.
+		// No reference is updated, so don't return the resulting commit SHA.
+		return &types.SyncForkResponse{
+			FastForward:    canFastForward.Ancestor,
+			RuleViolations: violations,
+			DryRun:         true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	branchRef, err := git.GetRefPath(in.Branch, gitenum.RefTypeBranch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate ref name: %w", err)
+	}
+
+	mergeParams.Refs = []git.RefUpdate{{
+		Name: branchRef,
+		Old:  branch.Branch.SHA,
+		New:  sha.SHA{}, // update to the result of the merge
+	}}
+
+	mergeOutput, err := c.git.Merge(ctx, mergeParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fork sync execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  mergeOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("Fork sync blocked by conflicting files: %v", mergeOutput.ConflictFiles),
+		}, nil
+	}
+
+	return &types.SyncForkResponse{
+		FastForward:    canFastForward.Ancestor,
+		NewBranchSHA:   mergeOutput.MergeSHA,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// verifySyncedCommits verifies the commits that the fork sync adds to the branch against the push protection rules.
+// The commits of the upstream repository keep their committers and signatures, so they are verified
+// the same way as pushed commits. The merge commit is signed by the server, if the server signing key is configured.
+func (c *Controller) verifySyncedCommits(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	protectionRules protection.Protection,
+	isRepoOwner bool,
+	allowBypass bool,
+	branch string,
+	baseSHA sha.SHA,
+	headSHA sha.SHA,
+) ([]types.RuleViolations, error) {
+	readParams := git.CreateReadParams(repo)
+
+	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: readParams,
+		GitREF:     headSHA.String(),
+		After:      baseSHA.String(),
+		Limit:      maxSyncVerifyCommits + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list synced commits: %w", err)
+	}
+
+	// the commits over the limit aren't verified, the protection rules report that instead.
+	commitsTruncated := len(output.Commits) > maxSyncVerifyCommits
+	if commitsTruncated {
+		output.Commits = output.Commits[:maxSyncVerifyCommits]
+	}
+
+	commits := make([]types.Commit, len(output.Commits))
+	for i := range output.Commits {
+		commit, err := controller.MapCommit(&output.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	if err := c.publicKeySvc.VerifyCommits(ctx, output.Commits, commits); err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	verifiedEmails, err := c.publicKeySvc.ListVerifiedEmails(ctx, session.Principal.ToPrincipalInfo())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verified emails of the principal: %w", err)
+	}
+
+	violations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        allowBypass,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		BranchName:         branch,
+		Commits:            commits,
+		CommitsTruncated:   commitsTruncated,
+		VerifiedEmails:     verifiedEmails,
+		ListFiles: controller.OnceFunc(func(ctx context.Context) ([]protection.PushedFile, error) {
+			return c.listSyncedFiles(ctx, readParams, baseSHA, headSHA)
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify push protection rules: %w", err)
+	}
+
+	return violations, nil
+}
+
+// listSyncedFiles returns the files changed by the commits that the fork sync adds to the branch.
+func (c *Controller) listSyncedFiles(
+	ctx context.Context,
+	readParams git.ReadParams,
+	baseSHA sha.SHA,
+	headSHA sha.SHA,
+) ([]protection.PushedFile, error) {
+	out, err := c.git.ListNewCommitFiles(ctx, &git.ListNewCommitFilesParams{
+		ReadParams: readParams,
+		GitREF:     headSHA.String(),
+		After:      baseSHA.String(),
+		Limit:      maxSyncVerifyCommits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of synced commits: %w", err)
+	}
+
+	files := make([]protection.PushedFile, len(out.Files))
+	for i, file := range out.Files {
+		files[i] = protection.PushedFile{
+			Path:    file.Path,
+			Deleted: file.Deleted,
+			Size:    file.Size,
+		}
+	}
+
+	return files, nil
+}
@@ -125,6 +125,7 @@ func GetRepoOutput(
 		IsPublic:   isPublic,
 		Importing:  slices.Contains(importingStates, repo.State),
 		Archived:   repo.State == enum.RepoStateArchived,
+		PullMirror: repo.State == enum.RepoStatePullMirror,
 	}, nil
 }
 
@@ -138,6 +139,7 @@ func GetRepoOutputWithAccess(
 		IsPublic:   isPublic,
 		Importing:  slices.Contains(importingStates, repo.State),
 		Archived:   repo.State == enum.RepoStateArchived,
+		PullMirror: repo.State == enum.RepoStatePullMirror,
 	}
 }
 
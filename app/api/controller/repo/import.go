@@ -110,6 +110,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			Repository: *repo,
 			IsPublic:   false,
 		}),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Warn().Msgf("failed to insert audit log for import repository operation: %s", err)
@@ -26,13 +26,14 @@ import (
 )
 
 type CommitTag struct {
-	Name        string           `json:"name"`
-	SHA         string           `json:"sha"`
-	IsAnnotated bool             `json:"is_annotated"`
-	Title       string           `json:"title,omitempty"`
-	Message     string           `json:"message,omitempty"`
-	Tagger      *types.Signature `json:"tagger,omitempty"`
-	Commit      *types.Commit    `json:"commit,omitempty"`
+	Name         string                       `json:"name"`
+	SHA          string                       `json:"sha"`
+	IsAnnotated  bool                         `json:"is_annotated"`
+	Title        string                       `json:"title,omitempty"`
+	Message      string                       `json:"message,omitempty"`
+	Tagger       *types.Signature             `json:"tagger,omitempty"`
+	Commit       *types.Commit                `json:"commit,omitempty"`
+	Verification *types.SignatureVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -66,11 +67,39 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 		if err != nil {
 			return nil, fmt.Errorf("failed to map CommitTag: %w", err)
 		}
+
+		if err = c.verifyCommitTag(ctx, &rpcOut.Tags[i], &tags[i]); err != nil {
+			return nil, err
+		}
 	}
 
 	return tags, nil
 }
 
+// verifyCommitTag verifies the signatures of the tag and of the tagged commit (if included).
+func (c *Controller) verifyCommitTag(ctx context.Context, gitTag *git.CommitTag, tag *CommitTag) error {
+	if gitTag.Tagger != nil {
+		verification, err := c.publicKeySvc.VerifySignature(ctx, gitTag.SignedData, gitTag.Tagger.Identity.Email)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature of tag %s: %w", gitTag.Name, err)
+		}
+
+		tag.Verification = verification
+	}
+
+	if gitTag.Commit != nil && tag.Commit != nil {
+		verification, err := c.publicKeySvc.VerifySignature(ctx,
+			gitTag.Commit.SignedData, gitTag.Commit.Committer.Identity.Email)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature of commit %s: %w", gitTag.Commit.SHA, err)
+		}
+
+		tag.Commit.Verification = verification
+	}
+
+	return nil
+}
+
 func mapToRPCTagSortOption(o enum.TagSortOption) git.TagSortOption {
 	switch o {
 	case enum.TagSortOptionDate:
@@ -69,6 +69,8 @@ func (c *Controller) ListCommits(ctx context.Context,
 		Author:       authorRegex,
 		IncludeStats: filter.IncludeStats,
 		Regex:        true,
+
+		IncludeSignatures: true,
 	})
 	if err != nil {
 		return types.ListCommitResponse{}, err
@@ -84,6 +86,10 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	if err = c.publicKeySvc.VerifyCommits(ctx, rpcOut.Commits, commits); err != nil {
+		return types.ListCommitResponse{}, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
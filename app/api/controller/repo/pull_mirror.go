@@ -0,0 +1,568 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PullMirrorRemoteInput contains the remote repository of a pull mirror and the credentials used to access it.
+type PullMirrorRemoteInput struct {
+	RemoteURL string `json:"remote_url"`
+	Username  string `json:"username"`
+	// Password is the password or the access token used to authenticate to the remote.
+	Password string `json:"password"`
+	// SecretSpaceRef and SecretIdentifier reference a secret that contains the password.
+	// If the SecretSpaceRef isn't provided, the secret is searched in the parent space of the repository.
+	SecretSpaceRef   string `json:"secret_space_ref"`
+	SecretIdentifier string `json:"secret_identifier"`
+	// Cron defines how often the mirror is synchronized. If not provided, the system default is used.
+	Cron string `json:"cron"`
+}
+
+type CreatePullMirrorInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	PullMirrorRemoteInput
+}
+
+type UpdatePullMirrorInput struct {
+	RemoteURL        *string `json:"remote_url"`
+	Username         *string `json:"username"`
+	Password         *string `json:"password"`
+	SecretSpaceRef   *string `json:"secret_space_ref"`
+	SecretIdentifier *string `json:"secret_identifier"`
+	Cron             *string `json:"cron"`
+	Enabled          *bool   `json:"enabled"`
+}
+
+func (c *Controller) sanitizeCreatePullMirrorInput(in *CreatePullMirrorInput, session *auth.Session) error {
+	if err := ValidateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if err := c.identifierCheck(in.Identifier, session); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	in.RemoteURL = strings.TrimSpace(in.RemoteURL)
+	if err := validatePullMirrorRemoteURL(in.RemoteURL); err != nil {
+		return err
+	}
+
+	if err := c.mirrorSvc.CheckRemoteURL(in.RemoteURL); err != nil {
+		return err
+	}
+
+	if in.Password != "" && in.SecretIdentifier != "" {
+		return usererror.BadRequest("Either a password or a secret can be provided, but not both.")
+	}
+
+	in.Cron = strings.TrimSpace(in.Cron)
+	if in.Cron == "" {
+		in.Cron = c.mirrorSvc.DefaultCron()
+	}
+
+	if err := validatePullMirrorCron(in.Cron); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validatePullMirrorRemoteURL(remoteURL string) error {
+	if remoteURL == "" {
+		return usererror.BadRequest("Remote URL is required.")
+	}
+
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return usererror.BadRequestf("Invalid remote URL: %s", err.Error())
+	}
+
+	// only remote repositories can be mirrored, local paths and other transports are not allowed.
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return usererror.BadRequest("Remote URL must be an http or https URL.")
+	}
+
+	if u.User != nil {
+		return usererror.BadRequest("Remote URL must not contain credentials.")
+	}
+
+	return nil
+}
+
+func validatePullMirrorCron(cron string) error {
+	if _, err := mirror.NextSync(cron, time.Now()); err != nil {
+		return usererror.BadRequestf("Invalid cron expression: %s", err.Error())
+	}
+
+	return nil
+}
+
+// setPullMirrorCredentials sets the password or the secret reference of the pull mirror.
+func (c *Controller) setPullMirrorCredentials(
+	ctx context.Context,
+	session *auth.Session,
+	repoParentID int64,
+	pullMirror *types.PullMirror,
+	password string,
+	secretSpaceRef string,
+	secretIdentifier string,
+) error {
+	pullMirror.Password = ""
+	pullMirror.SecretSpaceID = 0
+	pullMirror.SecretIdentifier = ""
+
+	if password != "" {
+		encrypted, err := c.encrypter.Encrypt(password)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt password: %w", err)
+		}
+
+		pullMirror.Password = string(encrypted)
+
+		return nil
+	}
+
+	if secretIdentifier == "" {
+		return nil
+	}
+
+	sec, err := c.findMirrorSecret(ctx, session, repoParentID, secretSpaceRef, secretIdentifier)
+	if err != nil {
+		return err
+	}
+
+	pullMirror.SecretSpaceID = sec.SpaceID
+	pullMirror.SecretIdentifier = sec.Identifier
+
+	return nil
+}
+
+// findMirrorSecret finds the secret used to authenticate to the remote of a mirror.
+// If the secretSpaceRef isn't provided, the secret is searched in the parent space of the repository.
+func (c *Controller) findMirrorSecret(
+	ctx context.Context,
+	session *auth.Session,
+	repoParentID int64,
+	secretSpaceRef string,
+	secretIdentifier string,
+) (*types.Secret, error) {
+	var (
+		space *types.SpaceCore
+		err   error
+	)
+	if secretSpaceRef != "" {
+		space, err = c.spaceFinder.FindByRef(ctx, secretSpaceRef)
+	} else {
+		space, err = c.spaceFinder.FindByID(ctx, repoParentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of the secret: %w", err)
+	}
+
+	err = apiauth.CheckSecret(ctx, c.authorizer, session, space.Path, secretIdentifier,
+		enum.PermissionSecretAccess)
+	if err != nil {
+		return nil, fmt.Errorf("access check failed for the secret: %w", err)
+	}
+
+	sec, err := c.secretStore.FindByIdentifier(ctx, space.ID, secretIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	return sec, nil
+}
+
+// CreatePullMirror creates a new read-only repository that is periodically synchronized from a remote repository.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) CreatePullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreatePullMirrorInput,
+) (*RepositoryOutput, error) {
+	if err := c.sanitizeCreatePullMirrorInput(in, session); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for parent space %q: %w",
+			parentSpace.Path,
+			err,
+		)
+	}
+	if in.IsPublic && !isPublicAccessSupported {
+		return nil, errPublicRepoCreationDisabled
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: c.defaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	pullMirror := &types.PullMirror{
+		CreatedBy:      session.Principal.ID,
+		Created:        now,
+		Updated:        now,
+		RemoteURL:      in.RemoteURL,
+		Username:       in.Username,
+		Cron:           in.Cron,
+		Enabled:        true,
+		NextSync:       now,
+		LastSyncStatus: enum.MirrorSyncStatusNone,
+	}
+
+	err = c.setPullMirrorCredentials(ctx, session, parentSpace.ID, pullMirror,
+		in.Password, in.SecretSpaceRef, in.SecretIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	gitResp, _, err := c.createGitRepository(ctx, session, &CreateInput{
+		Identifier:    in.Identifier,
+		DefaultBranch: c.defaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git repository: %w", err)
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		_, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   in.Description,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			DefaultBranch: c.defaultBranch,
+			IsEmpty:       true,
+			State:         enum.RepoStatePullMirror,
+		}
+
+		err = c.repoStore.Create(ctx, repo)
+		if err != nil {
+			return fmt.Errorf("failed to create repository in storage: %w", err)
+		}
+
+		pullMirror.RepoID = repo.ID
+
+		err = c.pullMirrorStore.Create(ctx, pullMirror)
+		if err != nil {
+			return fmt.Errorf("failed to create pull mirror in storage: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.DeleteGitRepository(ctx, session, gitResp.UID); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic)
+	if err != nil {
+		if dErr := c.publicAccess.Delete(ctx, enum.PublicResourceTypeRepo, repo.Path); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
+		}
+
+		// only cleanup repo itself if cleanup of public access succeeded (to avoid leaking public access)
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to set repo public access (successful cleanup): %w", err)
+	}
+
+	// the first synchronization is started immediately. If it fails to start,
+	// the mirror is synchronized when it's picked up by the scheduler.
+	if _, err = c.mirrorSvc.Sync(ctx, pullMirror); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to start pull mirror synchronization")
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	repoOutput := GetRepoOutputWithAccess(ctx, in.IsPublic, repo)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: repoOutput.Repository,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+		audit.WithRepoID(repo.ID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create pull mirror operation: %s", err)
+	}
+
+	err = c.instrumentation.Track(ctx, instrument.Event{
+		Type:      instrument.EventTypeRepositoryCreate,
+		Principal: session.Principal.ToPrincipalInfo(),
+		Path:      repo.Path,
+		Properties: map[instrument.Property]any{
+			instrument.PropertyRepositoryID:           repo.ID,
+			instrument.PropertyRepositoryName:         repo.Identifier,
+			instrument.PropertyRepositoryCreationType: instrument.CreationTypeMirror,
+		},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for create pull mirror operation: %s", err)
+	}
+
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		Base: eventBase(repo.Core(), &session.Principal),
+		Type: "mirrored",
+	})
+
+	return repoOutput, nil
+}
+
+// FindPullMirror returns the pull mirror configuration of a repository.
+func (c *Controller) FindPullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPullMirror(ctx, repo)
+}
+
+// UpdatePullMirror updates the remote, the credentials or the schedule of a pull mirror.
+func (c *Controller) UpdatePullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *UpdatePullMirrorInput,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	pullMirror, err := c.getPullMirror(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeUpdatePullMirrorInput(in); err != nil {
+		return nil, err
+	}
+
+	// the credentials are resolved before the update, because it requires the secret lookup.
+	credentials := *pullMirror
+	credentialsChanged := in.Password != nil || in.SecretIdentifier != nil
+
+	// the stored credentials must not be sent to another remote, so they have to be provided again.
+	remoteURLChanged := in.RemoteURL != nil && *in.RemoteURL != pullMirror.RemoteURL
+	hasCredentials := pullMirror.Password != "" || pullMirror.SecretIdentifier != ""
+	if remoteURLChanged && hasCredentials && !credentialsChanged {
+		return nil, usererror.BadRequest("The password or the secret must be provided when the remote URL is changed.")
+	}
+	if credentialsChanged {
+		var password, secretSpaceRef, secretIdentifier string
+		if in.Password != nil {
+			password = *in.Password
+		}
+		if in.SecretSpaceRef != nil {
+			secretSpaceRef = *in.SecretSpaceRef
+		}
+		if in.SecretIdentifier != nil {
+			secretIdentifier = *in.SecretIdentifier
+		}
+
+		err = c.setPullMirrorCredentials(ctx, session, repo.ParentID, &credentials,
+			password, secretSpaceRef, secretIdentifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var nextSync int64
+	if in.Cron != nil {
+		nextSync, err = mirror.NextSync(*in.Cron, time.Now())
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid cron expression: %s", err.Error())
+		}
+	}
+
+	pullMirror, err = c.pullMirrorStore.UpdateOptLock(ctx, pullMirror, func(pullMirror *types.PullMirror) error {
+		if in.RemoteURL != nil {
+			pullMirror.RemoteURL = *in.RemoteURL
+		}
+		if in.Username != nil {
+			pullMirror.Username = *in.Username
+		}
+		if credentialsChanged {
+			pullMirror.Password = credentials.Password
+			pullMirror.SecretSpaceID = credentials.SecretSpaceID
+			pullMirror.SecretIdentifier = credentials.SecretIdentifier
+		}
+		if in.Cron != nil {
+			pullMirror.Cron = *in.Cron
+			pullMirror.NextSync = nextSync
+		}
+		if in.Enabled != nil {
+			pullMirror.Enabled = *in.Enabled
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull mirror: %w", err)
+	}
+
+	return pullMirror, nil
+}
+
+func (c *Controller) sanitizeUpdatePullMirrorInput(in *UpdatePullMirrorInput) error {
+	if in.RemoteURL != nil {
+		*in.RemoteURL = strings.TrimSpace(*in.RemoteURL)
+		if err := validatePullMirrorRemoteURL(*in.RemoteURL); err != nil {
+			return err
+		}
+
+		if err := c.mirrorSvc.CheckRemoteURL(*in.RemoteURL); err != nil {
+			return err
+		}
+	}
+
+	if in.Password != nil && *in.Password != "" && in.SecretIdentifier != nil && *in.SecretIdentifier != "" {
+		return usererror.BadRequest("Either a password or a secret can be provided, but not both.")
+	}
+
+	if in.Cron != nil {
+		*in.Cron = strings.TrimSpace(*in.Cron)
+		if *in.Cron == "" {
+			*in.Cron = c.mirrorSvc.DefaultCron()
+		}
+
+		if err := validatePullMirrorCron(*in.Cron); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SyncPullMirror starts the synchronization of a pull mirror from its remote repository.
+func (c *Controller) SyncPullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	pullMirror, err := c.getPullMirror(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	pullMirror, err = c.mirrorSvc.Sync(ctx, pullMirror)
+	if errors.Is(err, mirror.ErrSyncInProgress) {
+		return nil, usererror.Conflict("Synchronization of the mirror is already in progress.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pullMirror, nil
+}
+
+func (c *Controller) getPullMirror(ctx context.Context, repo *types.RepositoryCore) (*types.PullMirror, error) {
+	if repo.State != enum.RepoStatePullMirror {
+		return nil, usererror.BadRequest("Repository is not a pull mirror.")
+	}
+
+	pullMirror, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	return pullMirror, nil
+}
@@ -81,7 +81,10 @@ func (c *Controller) PurgeNoAuth(
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
-	if err := c.DeleteGitRepository(ctx, session, repo.GitUID); err != nil {
+	if err := c.DissociateForks(ctx, session, repo.ID); err != nil {
+		// the repository is deleted from the db already, keep the git repository to not corrupt the forks.
+		log.Ctx(ctx).Err(err).Msg("failed to dissociate forks of the repository, git repository not removed")
+	} else if err := c.DeleteGitRepository(ctx, session, repo.GitUID); err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
 
@@ -101,6 +104,27 @@ func (c *Controller) DeleteGitRepository(
 	gitUID string,
 ) error {
 	// create custom write params for delete as repo might or might not exist in db (similar to create).
+	writeParams, err := c.createGitWriteParams(ctx, session, gitUID)
+	if err != nil {
+		return err
+	}
+
+	err = c.git.DeleteRepository(ctx, &git.DeleteRepositoryParams{
+		WriteParams: writeParams,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove git repository %s: %w", gitUID, err)
+	}
+
+	return nil
+}
+
+// createGitWriteParams creates git write params for a repository that might or might not exist in db.
+func (c *Controller) createGitWriteParams(
+	ctx context.Context,
+	session *auth.Session,
+	gitUID string,
+) (git.WriteParams, error) {
 	envVars, err := githook.GenerateEnvironmentVariables(
 		ctx,
 		c.urlProvider.GetInternalAPIURL(ctx),
@@ -110,24 +134,15 @@ func (c *Controller) DeleteGitRepository(
 		true,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
 	}
 
-	writeParams := git.WriteParams{
+	return git.WriteParams{
 		Actor: git.Identity{
 			Name:  session.Principal.DisplayName,
 			Email: session.Principal.Email,
 		},
 		RepoUID: gitUID,
 		EnvVars: envVars,
-	}
-
-	err = c.git.DeleteRepository(ctx, &git.DeleteRepositoryParams{
-		WriteParams: writeParams,
-	})
-	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("failed to remove git repository %s: %w", gitUID, err)
-	}
-
-	return nil
+	}, nil
 }
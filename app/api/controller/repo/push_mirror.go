@@ -0,0 +1,392 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreatePushMirrorInput struct {
+	Identifier string `json:"identifier"`
+	RemoteURL  string `json:"remote_url"`
+	Username   string `json:"username"`
+	// SecretSpaceRef and SecretIdentifier reference a secret that contains the password used for the push.
+	// If the SecretSpaceRef isn't provided, the secret is searched in the parent space of the repository.
+	SecretSpaceRef   string `json:"secret_space_ref"`
+	SecretIdentifier string `json:"secret_identifier"`
+	// BranchPatterns and TagPatterns restrict the pushed branches and tags. All are pushed if empty.
+	BranchPatterns []string `json:"branch_patterns"`
+	TagPatterns    []string `json:"tag_patterns"`
+	Force          bool     `json:"force"`
+	Enabled        *bool    `json:"enabled"`
+}
+
+type UpdatePushMirrorInput struct {
+	Identifier       *string   `json:"identifier"`
+	RemoteURL        *string   `json:"remote_url"`
+	Username         *string   `json:"username"`
+	SecretSpaceRef   *string   `json:"secret_space_ref"`
+	SecretIdentifier *string   `json:"secret_identifier"`
+	BranchPatterns   *[]string `json:"branch_patterns"`
+	TagPatterns      *[]string `json:"tag_patterns"`
+	Force            *bool     `json:"force"`
+	Enabled          *bool     `json:"enabled"`
+}
+
+func (in *CreatePushMirrorInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.RemoteURL = strings.TrimSpace(in.RemoteURL)
+	if err := validatePullMirrorRemoteURL(in.RemoteURL); err != nil {
+		return err
+	}
+
+	if err := sanitizePushMirrorPatterns(in.BranchPatterns); err != nil {
+		return err
+	}
+
+	if err := sanitizePushMirrorPatterns(in.TagPatterns); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (in *UpdatePushMirrorInput) sanitize() error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.RemoteURL != nil {
+		*in.RemoteURL = strings.TrimSpace(*in.RemoteURL)
+		if err := validatePullMirrorRemoteURL(*in.RemoteURL); err != nil {
+			return err
+		}
+	}
+
+	if in.BranchPatterns != nil {
+		if err := sanitizePushMirrorPatterns(*in.BranchPatterns); err != nil {
+			return err
+		}
+	}
+
+	if in.TagPatterns != nil {
+		if err := sanitizePushMirrorPatterns(*in.TagPatterns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sanitizePushMirrorPatterns(patterns []string) error {
+	for i := range patterns {
+		patterns[i] = strings.TrimSpace(patterns[i])
+		if err := mirror.ValidatePushPattern(patterns[i]); err != nil {
+			return usererror.BadRequest(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// CreatePushMirror adds a remote repository to which the branches and tags of the repository are pushed.
+func (c *Controller) CreatePushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreatePushMirrorInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err = c.mirrorSvc.CheckRemoteURL(in.RemoteURL); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	pushMirror := &types.PushMirror{
+		RepoID:         repo.ID,
+		Identifier:     in.Identifier,
+		CreatedBy:      session.Principal.ID,
+		Created:        now,
+		Updated:        now,
+		RemoteURL:      in.RemoteURL,
+		Username:       in.Username,
+		BranchPatterns: in.BranchPatterns,
+		TagPatterns:    in.TagPatterns,
+		Force:          in.Force,
+		Enabled:        in.Enabled == nil || *in.Enabled,
+	}
+
+	if in.SecretIdentifier != "" {
+		sec, err := c.findMirrorSecret(ctx, session, repo.ParentID, in.SecretSpaceRef, in.SecretIdentifier)
+		if err != nil {
+			return nil, err
+		}
+
+		pushMirror.SecretSpaceID = sec.SpaceID
+		pushMirror.SecretIdentifier = sec.Identifier
+	}
+
+	err = c.pushMirrorStore.Create(ctx, pushMirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push mirror: %w", err)
+	}
+
+	return pushMirror, nil
+}
+
+// ListPushMirrors lists the push mirrors of a repository.
+func (c *Controller) ListPushMirrors(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.PushMirrorFilter,
+) ([]*types.PushMirror, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pushMirrors, err := c.pushMirrorStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	if filter.Page == 1 && len(pushMirrors) < filter.Size {
+		return pushMirrors, int64(len(pushMirrors)), nil
+	}
+
+	count, err := c.pushMirrorStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count push mirrors: %w", err)
+	}
+
+	return pushMirrors, count, nil
+}
+
+// FindPushMirror returns a push mirror of a repository.
+func (c *Controller) FindPushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPushMirror(ctx, repo, identifier)
+}
+
+// UpdatePushMirror updates a push mirror of a repository.
+func (c *Controller) UpdatePushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *UpdatePushMirrorInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	pushMirror, err := c.getPushMirror(ctx, repo, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.RemoteURL != nil {
+		if err = c.mirrorSvc.CheckRemoteURL(*in.RemoteURL); err != nil {
+			return nil, err
+		}
+	}
+
+	// the stored secret must not be sent to another remote, so it has to be provided again.
+	remoteURLChanged := in.RemoteURL != nil && *in.RemoteURL != pushMirror.RemoteURL
+	if remoteURLChanged && pushMirror.SecretIdentifier != "" && in.SecretIdentifier == nil {
+		return nil, usererror.BadRequest("The secret must be provided when the remote URL is changed.")
+	}
+
+	// the secret is resolved before the update, because it requires the access check.
+	var secretSpaceID int64
+	var secretIdentifier string
+	if in.SecretIdentifier != nil && *in.SecretIdentifier != "" {
+		var secretSpaceRef string
+		if in.SecretSpaceRef != nil {
+			secretSpaceRef = *in.SecretSpaceRef
+		}
+
+		sec, err := c.findMirrorSecret(ctx, session, repo.ParentID, secretSpaceRef, *in.SecretIdentifier)
+		if err != nil {
+			return nil, err
+		}
+
+		secretSpaceID = sec.SpaceID
+		secretIdentifier = sec.Identifier
+	}
+
+	pushMirror, err = c.pushMirrorStore.UpdateOptLock(ctx, pushMirror, func(pushMirror *types.PushMirror) error {
+		if in.Identifier != nil {
+			pushMirror.Identifier = *in.Identifier
+		}
+		if in.RemoteURL != nil {
+			pushMirror.RemoteURL = *in.RemoteURL
+		}
+		if in.Username != nil {
+			pushMirror.Username = *in.Username
+		}
+		if in.SecretIdentifier != nil {
+			pushMirror.SecretSpaceID = secretSpaceID
+			pushMirror.SecretIdentifier = secretIdentifier
+		}
+		if in.BranchPatterns != nil {
+			pushMirror.BranchPatterns = *in.BranchPatterns
+		}
+		if in.TagPatterns != nil {
+			pushMirror.TagPatterns = *in.TagPatterns
+		}
+		if in.Force != nil {
+			pushMirror.Force = *in.Force
+		}
+		if in.Enabled != nil {
+			pushMirror.Enabled = *in.Enabled
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push mirror: %w", err)
+	}
+
+	return pushMirror, nil
+}
+
+// DeletePushMirror deletes a push mirror of a repository.
+func (c *Controller) DeletePushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	pushMirror, err := c.getPushMirror(ctx, repo, identifier)
+	if err != nil {
+		return err
+	}
+
+	err = c.pushMirrorStore.Delete(ctx, pushMirror.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete push mirror: %w", err)
+	}
+
+	return nil
+}
+
+// PushPushMirror starts a push of the repository to a push mirror.
+func (c *Controller) PushPushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	pushMirror, err := c.getPushMirror(ctx, repo, identifier)
+	if err != nil {
+		return err
+	}
+
+	return c.mirrorSvc.Push(ctx, pushMirror, enum.PushMirrorTriggerManual)
+}
+
+// ListPushMirrorExecutions lists the executions of a push mirror, newest first.
+func (c *Controller) ListPushMirrorExecutions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	filter *types.PushMirrorExecutionFilter,
+) ([]*types.PushMirrorExecution, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pushMirror, err := c.getPushMirror(ctx, repo, identifier)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	executions, err := c.pushMirrorExecutionStore.ListForPushMirror(ctx, pushMirror.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list push mirror executions: %w", err)
+	}
+
+	count, err := c.pushMirrorExecutionStore.CountForPushMirror(ctx, pushMirror.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count push mirror executions: %w", err)
+	}
+
+	return executions, count, nil
+}
+
+func (c *Controller) getPushMirror(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	identifier string,
+) (*types.PushMirror, error) {
+	pushMirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	return pushMirror, nil
+}
@@ -97,6 +97,10 @@ func (c *Controller) RestoreNoAuth(
 		return nil, fmt.Errorf("failed to restore the repo: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		c.updateNumForks(ctx, repo.ForkID, 1)
+	}
+
 	// Repos restored as private since public access data has been deleted upon deletion.
 	return GetRepoOutputWithAccess(ctx, false, repo), nil
 }
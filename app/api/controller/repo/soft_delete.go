@@ -88,6 +88,7 @@ func (c *Controller) SoftDelete(
 			Repository: *repo,
 			IsPublic:   isPublic,
 		}),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete repository operation: %s", err)
@@ -117,6 +118,10 @@ func (c *Controller) SoftDeleteNoAuth(
 
 	c.repoFinder.MarkChanged(ctx, repo.Core())
 
+	if repo.ForkID != 0 {
+		c.updateNumForks(ctx, repo.ForkID, -1)
+	}
+
 	if repo.Deleted != nil {
 		c.eventReporter.SoftDeleted(ctx, &repoevents.SoftDeletedPayload{
 			Base:     eventBase(repo.Core(), &session.Principal),
@@ -0,0 +1,343 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// templateMaxBranches is the maximum number of template branches that can be copied.
+	templateMaxBranches = 100
+
+	// templateCopyPageSize is the number of protection rules and webhooks read at once when they are copied.
+	templateCopyPageSize = 100
+)
+
+type CreateFromTemplateInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	// IncludeAllBranches specifies that all branches of the template are copied, not only the default branch.
+	IncludeAllBranches bool `json:"include_all_branches"`
+
+	CopyLabels   bool `json:"copy_labels"`
+	CopyRules    bool `json:"copy_rules"`
+	CopyWebhooks bool `json:"copy_webhooks"`
+}
+
+// CreateFromTemplate creates a new repository with the files of a template repository.
+// Unlike forks, the new repository doesn't share the history with the template:
+// the files of each copied branch are committed as a single commit, with the placeholders
+// (e.g. {{repo_name}}) in text files replaced with the values of the new repository.
+// File modes, symbolic links and submodules are copied as they are.
+func (c *Controller) CreateFromTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	templateRef string,
+	in *CreateFromTemplateInput,
+) (*RepositoryOutput, error) {
+	templateCore, err := c.getRepoCheckAccess(ctx, session, templateRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to template repo: %w", err)
+	}
+
+	if in.CopyRules || in.CopyWebhooks {
+		// protection rules and webhooks are settings of the repository, so only users who can edit them can copy them.
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, templateCore, enum.PermissionRepoEdit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	template, err := c.repoStore.Find(ctx, templateCore.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find template repo: %w", err)
+	}
+
+	if !template.IsTemplate {
+		return nil, usererror.BadRequest("The repository is not a template.")
+	}
+
+	if template.IsEmpty {
+		return nil, usererror.BadRequest("Empty repositories can't be used as templates.")
+	}
+
+	description := strings.TrimSpace(in.Description)
+	if description == "" {
+		description = template.Description
+	}
+
+	repoOut, err := c.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: template.DefaultBranch,
+		Description:   description,
+		IsPublic:      in.IsPublic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &repoOut.Repository
+
+	err = c.populateFromTemplate(ctx, session, template, repo, in)
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to purge repo created from template for cleanup")
+		}
+		return nil, err
+	}
+
+	repo, err = c.repoStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find created repo: %w", err)
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	return GetRepoOutputWithAccess(ctx, repoOut.IsPublic, repo), nil
+}
+
+// populateFromTemplate commits the template files to the new repository and copies the requested template settings.
+func (c *Controller) populateFromTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	template *types.Repository,
+	repo *types.Repository,
+	in *CreateFromTemplateInput,
+) error {
+	placeholders := templatePlaceholders(repo)
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo.Core())
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	message := fmt.Sprintf("Initial commit from template %s", template.Path)
+
+	err = c.copyTemplateTree(ctx, session, writeParams, template, template.DefaultBranch,
+		repo.DefaultBranch, "", placeholders, message)
+	if err != nil {
+		return fmt.Errorf("failed to commit files of the template: %w", err)
+	}
+
+	if in.IncludeAllBranches {
+		err = c.copyTemplateBranches(ctx, session, template, repo, writeParams, placeholders, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	if in.CopyLabels {
+		err = c.labelSvc.CopyRepoLabels(ctx, session.Principal.ID, template.ID, repo.ID)
+		if err != nil {
+			return fmt.Errorf("failed to copy labels of the template: %w", err)
+		}
+	}
+
+	if in.CopyRules {
+		if err = c.copyTemplateRules(ctx, session, template.ID, repo.ID); err != nil {
+			return fmt.Errorf("failed to copy protection rules of the template: %w", err)
+		}
+	}
+
+	if in.CopyWebhooks {
+		if err = c.copyTemplateWebhooks(ctx, session, template.ID, repo.ID); err != nil {
+			return fmt.Errorf("failed to copy webhooks of the template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyTemplateBranches creates all non-default branches of the template in the new repository.
+// Each branch gets a commit on top of the default branch with the differences between the template branches.
+func (c *Controller) copyTemplateBranches(
+	ctx context.Context,
+	session *auth.Session,
+	template *types.Repository,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	placeholders map[string]string,
+	message string,
+) error {
+	branchesOut, err := c.git.ListBranches(ctx, &git.ListBranchesParams{
+		ReadParams: git.CreateReadParams(template),
+		Page:       1,
+		PageSize:   templateMaxBranches + 1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list branches of the template: %w", err)
+	}
+
+	if len(branchesOut.Branches) > templateMaxBranches {
+		return usererror.BadRequestf("Templates with more than %d branches can't be copied with all branches.",
+			templateMaxBranches)
+	}
+
+	for _, branch := range branchesOut.Branches {
+		if branch.Name == template.DefaultBranch {
+			continue
+		}
+
+		err = c.copyTemplateTree(ctx, session, writeParams, template, branch.Name,
+			branch.Name, repo.DefaultBranch, placeholders, message)
+		if err != nil {
+			return fmt.Errorf("failed to copy files of the template branch %q: %w", branch.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// copyTemplateTree creates the branch in the new repository with the files of the template branch.
+func (c *Controller) copyTemplateTree(
+	ctx context.Context,
+	session *auth.Session,
+	writeParams git.WriteParams,
+	template *types.Repository,
+	templateBranch string,
+	branch string,
+	parentBranch string,
+	placeholders map[string]string,
+	message string,
+) error {
+	now := time.Now()
+	_, err := c.git.CopyTree(ctx, &git.CopyTreeParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: template.GitUID,
+		SourceBranch:  templateBranch,
+		Branch:        branch,
+		ParentBranch:  parentBranch,
+		Message:       message,
+		Replacements:  placeholders,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
+	})
+
+	return err
+}
+
+// copyTemplateRules copies the protection rules defined directly on the template to the new repository.
+func (c *Controller) copyTemplateRules(ctx context.Context, session *auth.Session, templateID, repoID int64) error {
+	parents := []types.RuleParentInfo{{Type: enum.RuleParentRepo, ID: templateID}}
+
+	for page := 1; ; page++ {
+		rules, err := c.ruleStore.List(ctx, parents, &types.RuleFilter{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: templateCopyPageSize},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list rules: %w", err)
+		}
+
+		for _, rule := range rules {
+			now := time.Now().UnixMilli()
+
+			rule.ID = 0
+			rule.Version = 0
+			rule.CreatedBy = session.Principal.ID
+			rule.Created = now
+			rule.Updated = now
+			rule.RepoID = &repoID
+			rule.SpaceID = nil
+			rule.Scope = 0
+
+			if err := c.ruleStore.Create(ctx, &rule); err != nil {
+				return fmt.Errorf("failed to create rule %q: %w", rule.Identifier, err)
+			}
+		}
+
+		if len(rules) < templateCopyPageSize {
+			return nil
+		}
+	}
+}
+
+// copyTemplateWebhooks copies the webhooks defined directly on the template to the new repository.
+// Internal webhooks are not copied because they are managed by the system.
+func (c *Controller) copyTemplateWebhooks(ctx context.Context, session *auth.Session, templateID, repoID int64) error {
+	parents := []types.WebhookParentInfo{{Type: enum.WebhookParentRepo, ID: templateID}}
+
+	for page := 1; ; page++ {
+		hooks, err := c.webhookStore.List(ctx, parents, &types.WebhookFilter{
+			Page:         page,
+			Size:         templateCopyPageSize,
+			SkipInternal: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		for _, hook := range hooks {
+			now := time.Now().UnixMilli()
+
+			// the secret is copied as is because it's stored encrypted with the same key.
+			hookCopy := *hook
+			hookCopy.ID = 0
+			hookCopy.Version = 0
+			hookCopy.ParentID = repoID
+			hookCopy.ParentType = enum.WebhookParentRepo
+			hookCopy.Scope = 0
+			hookCopy.CreatedBy = session.Principal.ID
+			hookCopy.Created = now
+			hookCopy.Updated = now
+			hookCopy.LatestExecutionResult = nil
+
+			if err := c.webhookStore.Create(ctx, &hookCopy); err != nil {
+				return fmt.Errorf("failed to create webhook %q: %w", hook.Identifier, err)
+			}
+		}
+
+		if len(hooks) < templateCopyPageSize {
+			return nil
+		}
+	}
+}
+
+// templatePlaceholders returns the placeholders supported in the template files with their values.
+func templatePlaceholders(repo *types.Repository) map[string]string {
+	return map[string]string{
+		"{{repo_name}}":        repo.Identifier,
+		"{{repo_path}}":        repo.Path,
+		"{{repo_description}}": repo.Description,
+		"{{space_path}}":       paths.Parent(repo.Path),
+		"{{default_branch}}":   repo.DefaultBranch,
+	}
+}
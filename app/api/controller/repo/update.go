@@ -37,6 +37,7 @@ import (
 type UpdateInput struct {
 	Description *string         `json:"description"`
 	State       *enum.RepoState `json:"state"`
+	IsTemplate  *bool           `json:"is_template"`
 }
 
 var allowedRepoStateTransitions = map[enum.RepoState][]enum.RepoState{
@@ -53,6 +54,9 @@ func (in *UpdateInput) hasChanges(repo *types.Repository) bool {
 	if in.State != nil && *in.State != repo.State {
 		return true
 	}
+	if in.IsTemplate != nil && *in.IsTemplate != repo.IsTemplate {
+		return true
+	}
 
 	return false
 }
@@ -119,6 +123,9 @@ func (c *Controller) Update(ctx context.Context,
 		if in.State != nil {
 			repo.State = *in.State
 		}
+		if in.IsTemplate != nil {
+			repo.IsTemplate = *in.IsTemplate
+		}
 
 		return nil
 	})
@@ -135,6 +142,7 @@ func (c *Controller) Update(ctx context.Context,
 		paths.Parent(repo.Path),
 		audit.WithOldObject(repoClone),
 		audit.WithNewObject(repo),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository operation: %s", err)
@@ -73,6 +73,12 @@ func (c *Controller) UpdatePublicAccess(ctx context.Context,
 		return GetRepoOutputWithAccess(ctx, isPublic, repo), nil
 	}
 
+	if repo.ForkID != 0 {
+		if err = c.checkForkPublicAccess(ctx, repo.ForkID, in.IsPublic); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic); err != nil {
 		return nil, fmt.Errorf("failed to update repo public access: %w", err)
 	}
@@ -94,6 +100,7 @@ func (c *Controller) UpdatePublicAccess(ctx context.Context,
 			Repository: *repo,
 			IsPublic:   in.IsPublic,
 		}),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository operation: %s", err)
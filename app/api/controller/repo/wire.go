@@ -24,8 +24,10 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
@@ -34,6 +36,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -84,6 +87,15 @@ func ProvideController(
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
+	auditEventStore store.AuditEventStore,
+	publicKeySvc publickey.Service,
+	pullMirrorStore store.PullMirrorStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	mirrorSvc *mirror.Service,
+	pushMirrorStore store.PushMirrorStore,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
+	webhookStore store.WebhookStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
@@ -92,7 +104,8 @@ func ProvideController(
 		principalInfoCache, protectionManager, rpcClient, spaceFinder, repoFinder, importer,
 		codeOwners, repoReporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
-		rulesSvc, sseStreamer,
+		rulesSvc, sseStreamer, auditEventStore, publicKeySvc, pullMirrorStore, secretStore, encrypter, mirrorSvc,
+		pushMirrorStore, pushMirrorExecutionStore, webhookStore,
 	)
 }
 
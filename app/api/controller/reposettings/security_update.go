@@ -66,6 +66,7 @@ func (c *Controller) SecurityUpdate(
 		paths.Parent(repo.Path),
 		audit.WithOldObject(old),
 		audit.WithNewObject(out),
+		audit.WithRepoID(repo.ID),
 	)
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository settings operation: %s", err)
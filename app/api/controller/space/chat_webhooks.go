@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateChatWebhooksInput struct {
+	Webhooks []types.ChatWebhook `json:"webhooks"`
+}
+
+// ListChatWebhooks lists the chat webhooks configured for the space. Webhook URLs are never returned.
+func (c *Controller) ListChatWebhooks(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]types.ChatWebhook, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	webhooks, err := c.chatWebhooks.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// UpdateChatWebhooks replaces the chat webhooks configured for the space.
+// A webhook provided without a URL keeps the URL of the existing webhook with the same identifier.
+func (c *Controller) UpdateChatWebhooks(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *UpdateChatWebhooksInput,
+) ([]types.ChatWebhook, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	webhooks, err := c.chatWebhooks.Update(ctx, space.ID, in.Webhooks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chat webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
@@ -105,6 +106,8 @@ type Controller struct {
 	usageMetricStore    store.UsageMetricStore
 	repoIdentifierCheck check.RepoIdentifier
 	infraProviderSvc    *infraprovider.Service
+	auditEventStore     store.AuditEventStore
+	chatWebhooks        *notification.ChatWebhooks
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -120,6 +123,8 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider.Service,
+	auditEventStore store.AuditEventStore,
+	chatWebhooks *notification.ChatWebhooks,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -153,6 +158,8 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		usageMetricStore:    usageMetricStore,
 		repoIdentifierCheck: repoIdentifierCheck,
 		infraProviderSvc:    infraProviderSvc,
+		auditEventStore:     auditEventStore,
+		chatWebhooks:        chatWebhooks,
 	}
 }
 
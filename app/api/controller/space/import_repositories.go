@@ -147,6 +147,7 @@ func (c *Controller) ImportRepositories(
 				Repository: *repo,
 				IsPublic:   false, // in import we configure public access and create a new audit log.
 			}),
+			audit.WithRepoID(repo.ID),
 		)
 		if err != nil {
 			log.Warn().Msgf("failed to insert audit log for import repository operation: %s", err)
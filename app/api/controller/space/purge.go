@@ -77,7 +77,18 @@ func (c *Controller) PurgeNoAuth(
 	// permanently purge all repositories in the space and its subspaces after successful space purge tnx.
 	// cleanup will handle failed repository deletions.
 	for _, repo := range toBeDeletedRepos {
-		err := c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
+		err := c.repoCtrl.DissociateForks(ctx, session, repo.ID)
+		if err != nil {
+			// keep the git repository to not corrupt the forks.
+			log.Ctx(ctx).Warn().Err(err).
+				Str("repo_identifier", repo.Identifier).
+				Int64("repo_id", repo.ID).
+				Int64("repo_parent_id", repo.ParentID).
+				Msg("failed to dissociate forks of repository")
+			continue
+		}
+
+		err = c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).
 				Str("repo_identifier", repo.Identifier).
@@ -24,6 +24,7 @@ import (
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
@@ -57,6 +58,8 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	labelSvc *label.Service, instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider2.Service,
+	auditEventStore store.AuditEventStore,
+	chatWebhooks *notification.ChatWebhooks,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		sseStreamer, identifierCheck, authorizer,
@@ -70,5 +73,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 		labelSvc, instrumentation, executionStore,
 		rulesSvc, usageMetricStore, repoIdentifierCheck,
 		infraProviderSvc,
+		auditEventStore,
+		chatWebhooks,
 	)
 }
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SetServerSigningKeyInput struct {
+	Scheme     enum.PublicKeyScheme `json:"scheme"`
+	PrivateKey string               `json:"private_key"`
+}
+
+// GetServerSigningKey returns the public key that is used to verify the commits signed by the server.
+func (c *Controller) GetServerSigningKey(ctx context.Context) (*types.ServerSigningKey, error) {
+	return c.serverKey.Get(ctx)
+}
+
+// SetServerSigningKey sets the key that is used by the server to sign the commits it creates.
+func (c *Controller) SetServerSigningKey(
+	ctx context.Context,
+	session *auth.Session,
+	in *SetServerSigningKeyInput,
+) (*types.ServerSigningKey, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	scheme, ok := in.Scheme.Sanitize()
+	if !ok {
+		return nil, usererror.BadRequest("invalid value for key scheme")
+	}
+
+	return c.serverKey.Set(ctx, scheme, in.PrivateKey)
+}
+
+// DeleteServerSigningKey removes the server signing key. Without it, the server doesn't sign commits.
+func (c *Controller) DeleteServerSigningKey(
+	ctx context.Context,
+	session *auth.Session,
+) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	return c.serverKey.Delete(ctx)
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationPreferenceScope defines the scope of a notification preference.
+// If neither the space nor the repository is provided, the preference applies globally.
+type NotificationPreferenceScope struct {
+	SpaceRef string `json:"space_ref"`
+	RepoRef  string `json:"repo_ref"`
+}
+
+type UpdateNotificationPreferenceInput struct {
+	NotificationPreferenceScope
+	DisabledEvents []enum.NotificationEvent  `json:"disabled_events"`
+	Delivery       enum.NotificationDelivery `json:"delivery"`
+}
+
+func (in *UpdateNotificationPreferenceInput) Sanitize() error {
+	if err := in.NotificationPreferenceScope.Sanitize(); err != nil {
+		return err
+	}
+
+	disabledEvents := make([]enum.NotificationEvent, 0, len(in.DisabledEvents))
+	for _, event := range in.DisabledEvents {
+		sanitized, ok := event.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid notification event: %q", event)
+		}
+
+		if !slices.Contains(disabledEvents, sanitized) {
+			disabledEvents = append(disabledEvents, sanitized)
+		}
+	}
+
+	in.DisabledEvents = disabledEvents
+
+	delivery, ok := in.Delivery.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid notification delivery: %q", in.Delivery)
+	}
+
+	in.Delivery = delivery
+
+	return nil
+}
+
+func (in *NotificationPreferenceScope) Sanitize() error {
+	if in.SpaceRef != "" && in.RepoRef != "" {
+		return usererror.BadRequest("Only one of space and repository can be provided.")
+	}
+
+	return nil
+}
+
+// ListNotificationPreferences returns all notification preferences of the user.
+func (c *Controller) ListNotificationPreferences(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.NotificationPreference, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user by uid: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.notificationPrefs.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	for _, pref := range prefs {
+		if err = c.setNotificationPreferencePath(ctx, pref); err != nil {
+			return nil, err
+		}
+	}
+
+	return prefs, nil
+}
+
+// UpdateNotificationPreference creates or replaces the notification preference of the user for the provided scope.
+func (c *Controller) UpdateNotificationPreference(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *UpdateNotificationPreferenceInput,
+) (*types.NotificationPreference, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user by uid: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	spaceID, repoID, err := c.getNotificationPreferenceScope(ctx, session, &in.NotificationPreferenceScope)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	pref := &types.NotificationPreference{
+		PrincipalID:    user.ID,
+		SpaceID:        spaceID,
+		RepoID:         repoID,
+		DisabledEvents: in.DisabledEvents,
+		Delivery:       in.Delivery,
+		Created:        now,
+		Updated:        now,
+	}
+
+	if err = c.notificationPrefs.Upsert(ctx, pref); err != nil {
+		return nil, fmt.Errorf("failed to save notification preference: %w", err)
+	}
+
+	if err = c.setNotificationPreferencePath(ctx, pref); err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+// DeleteNotificationPreference deletes the notification preference of the user for the provided scope.
+// Afterward, the preference of the enclosing scope applies.
+func (c *Controller) DeleteNotificationPreference(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	scope *NotificationPreferenceScope,
+) error {
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user by uid: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	if err = scope.Sanitize(); err != nil {
+		return err
+	}
+
+	spaceID, repoID, err := c.getNotificationPreferenceScope(ctx, session, scope)
+	if err != nil {
+		return err
+	}
+
+	if err = c.notificationPrefs.Delete(ctx, user.ID, spaceID, repoID); err != nil {
+		return fmt.Errorf("failed to delete notification preference: %w", err)
+	}
+
+	return nil
+}
+
+// getNotificationPreferenceScope returns the space ID and the repository ID of the scope.
+// The user must have view access to the space or repository.
+func (c *Controller) getNotificationPreferenceScope(
+	ctx context.Context,
+	session *auth.Session,
+	scope *NotificationPreferenceScope,
+) (*int64, *int64, error) {
+	switch {
+	case scope.RepoRef != "":
+		repo, err := c.repoFinder.FindByRef(ctx, scope.RepoRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &repo.ID, nil
+	case scope.SpaceRef != "":
+		space, err := c.spaceFinder.FindByRef(ctx, scope.SpaceRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return nil, nil, err
+		}
+
+		return &space.ID, nil, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+func (c *Controller) setNotificationPreferencePath(ctx context.Context, pref *types.NotificationPreference) error {
+	switch {
+	case pref.RepoID != nil:
+		repo, err := c.repoFinder.FindByID(ctx, *pref.RepoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find repository of notification preference: %w", err)
+		}
+
+		pref.RepoPath = repo.Path
+	case pref.SpaceID != nil:
+		space, err := c.spaceFinder.FindByID(ctx, *pref.SpaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space of notification preference: %w", err)
+		}
+
+		pref.SpacePath = space.Path
+	}
+
+	return nil
+}
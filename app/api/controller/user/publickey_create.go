@@ -30,9 +30,10 @@ import (
 )
 
 type CreatePublicKeyInput struct {
-	Identifier string              `json:"identifier"`
-	Usage      enum.PublicKeyUsage `json:"usage"`
-	Content    string              `json:"content"`
+	Identifier string               `json:"identifier"`
+	Usage      enum.PublicKeyUsage  `json:"usage"`
+	Scheme     enum.PublicKeyScheme `json:"scheme"`
+	Content    string               `json:"content"`
 }
 
 func (c *Controller) CreatePublicKey(
@@ -54,11 +55,6 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
-	key, comment, err := publickey.ParseString(in.Content)
-	if err != nil {
-		return nil, errors.InvalidArgument("could not parse public key")
-	}
-
 	now := time.Now().UnixMilli()
 
 	k := &types.PublicKey{
@@ -67,10 +63,40 @@ func (c *Controller) CreatePublicKey(
 		Verified:    nil, // the key is created as unverified
 		Identifier:  in.Identifier,
 		Usage:       in.Usage,
-		Fingerprint: key.Fingerprint(),
+		Scheme:      in.Scheme,
 		Content:     in.Content,
-		Comment:     comment,
-		Type:        key.Type(),
+	}
+
+	// matches reports whether an existing key with the same fingerprint is the same key as the new one.
+	var matches func(existingKey types.PublicKey) bool
+
+	switch in.Scheme {
+	case enum.PublicKeySchemePGP:
+		key, err := publickey.ParsePGP(in.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		k.Fingerprint = key.Fingerprint()
+		k.Comment = key.Comment()
+		k.Type = key.Type()
+
+		matches = func(existingKey types.PublicKey) bool {
+			return existingKey.Scheme == enum.PublicKeySchemePGP
+		}
+	case enum.PublicKeySchemeSSH:
+		key, comment, err := publickey.ParseString(in.Content)
+		if err != nil {
+			return nil, errors.InvalidArgument("could not parse public key")
+		}
+
+		k.Fingerprint = key.Fingerprint()
+		k.Comment = comment
+		k.Type = key.Type()
+
+		matches = func(existingKey types.PublicKey) bool {
+			return existingKey.Scheme == enum.PublicKeySchemeSSH && key.Matches(existingKey.Content)
+		}
 	}
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
@@ -80,7 +106,7 @@ func (c *Controller) CreatePublicKey(
 		}
 
 		for _, existingKey := range existingKeys {
-			if key.Matches(existingKey.Content) {
+			if matches(existingKey) {
 				return errors.InvalidArgument("Key is already in use")
 			}
 		}
@@ -110,6 +136,16 @@ func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
 	}
 	in.Usage = usage
 
+	scheme, ok := in.Scheme.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("invalid value for public key scheme")
+	}
+	in.Scheme = scheme
+
+	if in.Scheme == enum.PublicKeySchemePGP && in.Usage != enum.PublicKeyUsageSign {
+		return errors.InvalidArgument("PGP keys can only be used for signing")
+	}
+
 	in.Content = strings.TrimSpace(in.Content)
 	if in.Content == "" {
 		return errors.InvalidArgument("public key not provided")
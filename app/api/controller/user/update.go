@@ -21,6 +21,7 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -31,11 +32,15 @@ import (
 
 // UpdateInput store infos to update an existing user.
 type UpdateInput struct {
-	Email       *string `json:"email"`
-	Password    *string `json:"password"`
-	DisplayName *string `json:"display_name"`
+	Email       *string            `json:"email"`
+	Password    *string            `json:"password"`
+	DisplayName *string            `json:"display_name"`
+	ChatHandles *types.ChatHandles `json:"chat_handles"`
 }
 
+// maxChatHandleLength is the maximum length of a chat handle of a user.
+const maxChatHandleLength = 256
+
 // Update updates the provided user.
 func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	userUID string, in *UpdateInput) (*types.User, error) {
@@ -59,6 +64,9 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	if in.Email != nil {
 		user.Email = *in.Email
 	}
+	if in.ChatHandles != nil {
+		user.ChatHandles = *in.ChatHandles
+	}
 	if in.Password != nil {
 		var hash []byte
 		hash, err = hashPassword([]byte(*in.Password), bcrypt.DefaultCost)
@@ -98,5 +106,14 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.ChatHandles != nil {
+		for _, handle := range []*string{&in.ChatHandles.Slack, &in.ChatHandles.Teams, &in.ChatHandles.Generic} {
+			*handle = strings.TrimSpace(*handle)
+			if len(*handle) > maxChatHandleLength {
+				return usererror.BadRequestf("chat handle can't be longer than %d characters", maxChatHandleLength)
+			}
+		}
+	}
+
 	return nil
 }
@@ -16,6 +16,7 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -36,6 +37,9 @@ func ProvideController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	notificationPrefs store.NotificationPreferenceStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -44,5 +48,8 @@ func ProvideController(
 		principalStore,
 		tokenStore,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		spaceFinder,
+		repoFinder,
+		notificationPrefs)
 }
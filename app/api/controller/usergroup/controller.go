@@ -15,28 +15,76 @@
 package usergroup
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
-	userGroupStore store.UserGroupStore
-	spaceStore     store.SpaceStore
-	authorizer     authz.Authorizer
-	searchSvc      usergroup.SearchService
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	spaceStore           store.SpaceStore
+	principalStore       store.PrincipalStore
+	spaceFinder          refcache.SpaceFinder
+	authorizer           authz.Authorizer
+	searchSvc            usergroup.SearchService
 }
 
 func NewController(
 	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
 	spaceStore store.SpaceStore,
+	principalStore store.PrincipalStore,
+	spaceFinder refcache.SpaceFinder,
 	authorizer authz.Authorizer,
 	searchSvc usergroup.SearchService,
 ) *Controller {
 	return &Controller{
-		userGroupStore: userGroupStore,
-		spaceStore:     spaceStore,
-		authorizer:     authorizer,
-		searchSvc:      searchSvc,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		spaceStore:           spaceStore,
+		principalStore:       principalStore,
+		spaceFinder:          spaceFinder,
+		authorizer:           authorizer,
+		searchSvc:            searchSvc,
 	}
 }
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.SpaceCore, error) {
+	return space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, permission)
+}
+
+// getUserGroupCheckAccess fetches the usergroup defined in the space
+// and checks whether the user has the requested permission on the space.
+func (c *Controller) getUserGroupCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.UserGroup, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	userGroup, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usergroup: %w", err)
+	}
+
+	return userGroup, nil
+}
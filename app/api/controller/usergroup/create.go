@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier  string `json:"identifier"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (in *CreateInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		in.Name = in.Identifier
+	}
+
+	if err := check.DisplayName(in.Name); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	return check.Description(in.Description)
+}
+
+// Create creates a new usergroup in the space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.UserGroup, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	userGroup := &types.UserGroup{
+		Identifier:  in.Identifier,
+		Name:        in.Name,
+		Description: in.Description,
+		SpaceID:     space.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.userGroupStore.Create(ctx, space.ID, userGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create usergroup: %w", err)
+	}
+
+	return userGroup, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes the usergroup together with all of its memberships.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	err = c.userGroupStore.Delete(ctx, userGroup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete usergroup: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MemberAddInput struct {
+	UserUID string `json:"user_uid"`
+}
+
+// MemberAdd adds a user to the usergroup.
+func (c *Controller) MemberAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *MemberAddInput,
+) (*types.UserGroupMemberInfo, error) {
+	userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.UserUID == "" {
+		return nil, usererror.BadRequest("UserUID must be provided")
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	member := types.UserGroupMember{
+		UserGroupID: userGroup.ID,
+		PrincipalID: user.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.userGroupMemberStore.Create(ctx, &member)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("User '%s' is already a member of the usergroup", in.UserUID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add usergroup member: %w", err)
+	}
+
+	return &types.UserGroupMemberInfo{
+		UserGroupMember: member,
+		Principal:       *user.ToPrincipalInfo(),
+		AddedBy:         *session.Principal.ToPrincipalInfo(),
+	}, nil
+}
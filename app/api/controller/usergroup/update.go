@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Name != nil {
+		*in.Name = strings.TrimSpace(*in.Name)
+		if err := check.DisplayName(*in.Name); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates the name and the description of the usergroup.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.UserGroup, error) {
+	userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	if in.Name == nil && in.Description == nil {
+		return userGroup, nil
+	}
+
+	if in.Name != nil {
+		userGroup.Name = *in.Name
+	}
+	if in.Description != nil {
+		userGroup.Description = *in.Description
+	}
+	userGroup.Updated = time.Now().UnixMilli()
+
+	err = c.userGroupStore.Update(ctx, userGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update usergroup: %w", err)
+	}
+
+	return userGroup, nil
+}
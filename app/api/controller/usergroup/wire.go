@@ -16,6 +16,7 @@ package usergroup
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 
@@ -29,9 +30,20 @@ var WireSet = wire.NewSet(
 
 func ProvideController(
 	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
 	spaceStore store.SpaceStore,
+	principalStore store.PrincipalStore,
+	spaceFinder refcache.SpaceFinder,
 	authorizer authz.Authorizer,
 	searchSvc usergroup.SearchService,
 ) *Controller {
-	return NewController(userGroupStore, spaceStore, authorizer, searchSvc)
+	return NewController(
+		userGroupStore,
+		userGroupMemberStore,
+		spaceStore,
+		principalStore,
+		spaceFinder,
+		authorizer,
+		searchSvc,
+	)
 }
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// newCommitLabel is listed in violation messages in place of the SHA of a commit that doesn't exist yet.
+const newCommitLabel = "(new)"
+
+// ServerCommit describes a commit that the server is about to create on behalf of a user.
+type ServerCommit struct {
+	Message     string
+	Author      git.Identity
+	ParentCount int
+	Files       []protection.PushedFile
+}
+
+// VerifyServerCommit verifies the commit that the server is about to create on behalf of the actor
+// against the push protection rules, before the branch is updated with it.
+// The input must contain everything except the commits and the files, which are provided by this function.
+func VerifyServerCommit(
+	ctx context.Context,
+	publicKeySvc publickey.Service,
+	protectionRules protection.Protection,
+	in protection.PushVerifyInput,
+	commit ServerCommit,
+) ([]types.RuleViolations, error) {
+	title, _, _ := strings.Cut(commit.Message, "\n")
+
+	author := types.Signature{
+		Identity: types.Identity{
+			Name:  commit.Author.Name,
+			Email: commit.Author.Email,
+		},
+	}
+
+	commits := []types.Commit{{
+		SHA:        newCommitLabel,
+		ParentSHAs: make([]string, commit.ParentCount),
+		Title:      title,
+		Message:    commit.Message,
+		Author:     author,
+	}}
+
+	return VerifyServerCommits(ctx, publicKeySvc, protectionRules, in, commits, commit.Files)
+}
+
+// VerifyServerCommits verifies the commits that the server is about to add to a branch on behalf of the actor
+// against the push protection rules, before the branch is updated with them.
+// The input must contain everything except the commits and the files, which are provided by this function.
+// The server is the committer of the commits and signs them with the server signing key, if it's configured,
+// so only the authors of the commits are subject to the email rule. Commits that keep their original authors,
+// like cherry-picked commits, are verified the same way as if the actor pushed them:
+// a branch that requires verified emails accepts only the commits authored by the actor.
+func VerifyServerCommits(
+	ctx context.Context,
+	publicKeySvc publickey.Service,
+	protectionRules protection.Protection,
+	in protection.PushVerifyInput,
+	commits []types.Commit,
+	files []protection.PushedFile,
+) ([]types.RuleViolations, error) {
+	verification, err := publicKeySvc.ServerCommitVerification(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server commit signature verification: %w", err)
+	}
+
+	in.VerifiedEmails, err = publicKeySvc.ListVerifiedEmails(ctx, in.Actor.ToPrincipalInfo())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verified emails of the principal: %w", err)
+	}
+
+	in.Commits = make([]types.Commit, len(commits))
+	for i := range commits {
+		in.Commits[i] = commits[i]
+		in.Commits[i].Committer = commits[i].Author
+		in.Commits[i].Verification = verification
+	}
+
+	in.ListFiles = func(context.Context) ([]protection.PushedFile, error) {
+		return files, nil
+	}
+
+	violations, err := protectionRules.PushVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify push protection rules: %w", err)
+	}
+
+	return violations, nil
+}
+
+// OnceFunc wraps the listing function of the protection rules input, like the commit or the file listing,
+// so that the items are listed only once, even if more than one protection rule requires them.
+func OnceFunc[T any](fn func(ctx context.Context) (T, error)) func(ctx context.Context) (T, error) {
+	var (
+		once   sync.Once
+		result T
+		err    error
+	)
+
+	return func(ctx context.Context) (T, error) {
+		once.Do(func() {
+			result, err = fn(ctx)
+		})
+
+		return result, err
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyServerCommitsAuthorEmails verifies that the commits created by the server that keep
+// their original authors, like cherry-picked commits, are subject to the email rule.
+func TestVerifyServerCommitsAuthorEmails(t *testing.T) {
+	ctx := context.Background()
+
+	actor := &types.Principal{ID: 1, Email: "actor@example.com"}
+	rules := testPushProtection{push: &protection.DefPush{VerifiedEmailRequired: true}}
+
+	newCommit := func(sha, email string) types.Commit {
+		return types.Commit{
+			SHA:    sha,
+			Title:  "change",
+			Author: types.Signature{Identity: types.Identity{Name: "author", Email: email}},
+			// the server is the committer of the commits.
+			Committer: types.Signature{Identity: types.Identity{Name: "system", Email: "system@example.com"}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		commits []types.Commit
+		expCode string
+	}{
+		{
+			name:    "commit authored by the actor",
+			commits: []types.Commit{newCommit("1111111", "ACTOR@example.com")},
+		},
+		{
+			name: "commit authored by another user",
+			commits: []types.Commit{
+				newCommit("1111111", "actor@example.com"),
+				newCommit("2222222", "other@example.com"),
+			},
+			expCode: "push.commit.email",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := VerifyServerCommits(ctx, testPublicKeyService{}, rules, protection.PushVerifyInput{
+				Actor:      actor,
+				Repo:       &types.RepositoryCore{ID: 1},
+				BranchName: "main",
+			}, test.commits, nil)
+			require.NoError(t, err)
+
+			if test.expCode == "" {
+				require.Empty(t, violations)
+				return
+			}
+
+			require.Len(t, violations, 1)
+			require.Len(t, violations[0].Violations, 1)
+			require.Equal(t, test.expCode, violations[0].Violations[0].Code)
+		})
+	}
+}
+
+func TestOnceFunc(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	listFiles := OnceFunc(func(context.Context) ([]protection.PushedFile, error) {
+		calls++
+		return []protection.PushedFile{{Path: "a.txt"}}, nil
+	})
+
+	for range 3 {
+		files, err := listFiles(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []protection.PushedFile{{Path: "a.txt"}}, files)
+	}
+
+	require.Equal(t, 1, calls)
+}
+
+// testPushProtection is a protection that verifies the pushes with the push rule.
+type testPushProtection struct {
+	protection.Protection
+	push *protection.DefPush
+}
+
+func (p testPushProtection) PushVerify(
+	ctx context.Context,
+	in protection.PushVerifyInput,
+) ([]types.RuleViolations, error) {
+	return p.push.PushVerify(ctx, in)
+}
+
+type testPublicKeyService struct {
+	publickey.Service
+}
+
+func (testPublicKeyService) ServerCommitVerification(context.Context) (*types.SignatureVerification, error) {
+	return nil, nil
+}
+
+func (testPublicKeyService) ListVerifiedEmails(context.Context, *types.PrincipalInfo) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleReactionAdd handles API that adds a reaction to the pull request description.
+func HandleReactionAdd(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionAdd(pullreqCtrl, false)
+}
+
+// HandleReactionRemove handles API that removes a reaction from the pull request description.
+func HandleReactionRemove(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionRemove(pullreqCtrl, false)
+}
+
+// HandleCommentReactionAdd handles API that adds a reaction to a pull request comment.
+func HandleCommentReactionAdd(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionAdd(pullreqCtrl, true)
+}
+
+// HandleCommentReactionRemove handles API that removes a reaction from a pull request comment.
+func HandleCommentReactionRemove(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionRemove(pullreqCtrl, true)
+}
+
+func handleReactionAdd(pullreqCtrl *pullreq.Controller, forComment bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, pullreqNumber, commentID, err := getReactionTarget(r, forComment)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(pullreq.ReactionInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		reactions, err := pullreqCtrl.ReactionAdd(ctx, session, repoRef, pullreqNumber, commentID, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, reactions)
+	}
+}
+
+func handleReactionRemove(pullreqCtrl *pullreq.Controller, forComment bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, pullreqNumber, commentID, err := getReactionTarget(r, forComment)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		reaction, err := request.GetReactionFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		reactions, err := pullreqCtrl.ReactionRemove(ctx, session, repoRef, pullreqNumber, commentID, reaction)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, reactions)
+	}
+}
+
+func getReactionTarget(r *http.Request, forComment bool) (string, int64, *int64, error) {
+	repoRef, err := request.GetRepoRefFromPath(r)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	if !forComment {
+		return repoRef, pullreqNumber, nil, nil
+	}
+
+	commentID, err := request.GetPullReqCommentIDPath(r)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	return repoRef, pullreqNumber, &commentID, nil
+}
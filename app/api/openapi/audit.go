@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterActorIDAudit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamActorID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of principal IDs who performed the audited actions."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterResourceTypeAudit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The types of the audited resources to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: []interface{}{
+							audit.ResourceTypeRepository,
+							audit.ResourceTypeBranchRule,
+							audit.ResourceTypeBranch,
+							audit.ResourceTypePullRequest,
+							audit.ResourceTypeRepositorySettings,
+							audit.ResourceTypeRegistry,
+							audit.ResourceTypeRegistryUpstreamProxy,
+							audit.ResourceTypeRegistryArtifact,
+						},
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterActionAudit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The audited actions to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: []interface{}{
+							audit.ActionCreated,
+							audit.ActionUpdated,
+							audit.ActionDeleted,
+							audit.ActionBypassed,
+							audit.ActionArchived,
+							audit.ActionUnarchived,
+						},
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+func auditOperations(reflector *openapi3.Reflector) {
+	opSpaceAuditList := openapi3.Operation{}
+	opSpaceAuditList.WithTags("space")
+	opSpaceAuditList.WithMapOfAnything(map[string]interface{}{"operationId": "spaceAuditEventList"})
+	opSpaceAuditList.WithParameters(
+		queryParameterActorIDAudit, queryParameterResourceTypeAudit, queryParameterActionAudit,
+		queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opSpaceAuditList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpaceAuditList, []types.AuditEvent{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceAuditList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceAuditList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceAuditList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceAuditList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceAuditList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-events", opSpaceAuditList)
+
+	opRepoAuditList := openapi3.Operation{}
+	opRepoAuditList.WithTags("repository")
+	opRepoAuditList.WithMapOfAnything(map[string]interface{}{"operationId": "repoAuditEventList"})
+	opRepoAuditList.WithParameters(
+		queryParameterActorIDAudit, queryParameterResourceTypeAudit, queryParameterActionAudit,
+		queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opRepoAuditList, &struct {
+		repoRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoAuditList, []types.AuditEvent{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoAuditList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoAuditList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAuditList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAuditList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAuditList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/audit-events", opRepoAuditList)
+}
@@ -62,6 +62,8 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	pluginOperations(&reflector)
 	repoOperations(&reflector)
 	rulesOperations(&reflector)
+	auditOperations(&reflector)
+	userGroupOperations(&reflector)
 	pipelineOperations(&reflector)
 	connectorOperations(&reflector)
 	templateOperations(&reflector)
@@ -72,6 +72,21 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type revertPullReq struct {
+	pullReqRequest
+	pullreq.RevertInput
+}
+
+type autoMergeEnablePullReqRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeEnableInput
+}
+
+type mergeQueueAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.MergeQueueAddInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -101,6 +116,26 @@ type commentStatusPullReqRequest struct {
 	pullreq.CommentStatusInput
 }
 
+type reactionAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.ReactionInput
+}
+
+type reactionRemovePullReqRequest struct {
+	pullReqRequest
+	Reaction enum.Reaction `path:"reaction"`
+}
+
+type commentReactionAddPullReqRequest struct {
+	pullReqCommentRequest
+	pullreq.ReactionInput
+}
+
+type commentReactionRemovePullReqRequest struct {
+	pullReqCommentRequest
+	Reaction enum.Reaction `path:"reaction"`
+}
+
 type reviewerListPullReqRequest struct {
 	pullReqRequest
 }
@@ -197,6 +232,20 @@ var queryParameterSourceBranchPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterMergeQueueBranch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBranch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Branch of the merge queue. The default branch is used if not provided."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterTargetBranchPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamTargetBranch,
@@ -628,6 +677,59 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/status", commentStatusPullReq)
 
+	reactionAddPullReq := openapi3.Operation{}
+	reactionAddPullReq.WithTags("pullreq")
+	reactionAddPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "reactionAddPullReq"})
+	_ = reflector.SetRequest(&reactionAddPullReq, new(reactionAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions", reactionAddPullReq)
+
+	reactionRemovePullReq := openapi3.Operation{}
+	reactionRemovePullReq.WithTags("pullreq")
+	reactionRemovePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "reactionRemovePullReq"})
+	_ = reflector.SetRequest(&reactionRemovePullReq, new(reactionRemovePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&reactionRemovePullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reactionRemovePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reactionRemovePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reactionRemovePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reactionRemovePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reactionRemovePullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions/{reaction}", reactionRemovePullReq)
+
+	commentReactionAddPullReq := openapi3.Operation{}
+	commentReactionAddPullReq.WithTags("pullreq")
+	commentReactionAddPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentReactionAddPullReq"})
+	_ = reflector.SetRequest(&commentReactionAddPullReq, new(commentReactionAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions", commentReactionAddPullReq)
+
+	commentReactionRemovePullReq := openapi3.Operation{}
+	commentReactionRemovePullReq.WithTags("pullreq")
+	commentReactionRemovePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentReactionRemovePullReq"})
+	_ = reflector.SetRequest(&commentReactionRemovePullReq, new(commentReactionRemovePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&commentReactionRemovePullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentReactionRemovePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentReactionRemovePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentReactionRemovePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentReactionRemovePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentReactionRemovePullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions/{reaction}",
+		commentReactionRemovePullReq)
+
 	commentApplySuggestions := openapi3.Operation{}
 	commentApplySuggestions.WithTags("pullreq")
 	commentApplySuggestions.WithMapOfAnything(map[string]interface{}{"operationId": "commentApplySuggestions"})
@@ -704,6 +806,84 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	revertPullReqOp := openapi3.Operation{}
+	revertPullReqOp.WithTags("pullreq")
+	revertPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReqOp"})
+	_ = reflector.SetRequest(&revertPullReqOp, new(revertPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(pullreq.CommitApplyOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", revertPullReqOp)
+
+	opAutoMergeEnable := openapi3.Operation{}
+	opAutoMergeEnable.WithTags("pullreq")
+	opAutoMergeEnable.WithMapOfAnything(map[string]interface{}{"operationId": "enablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeEnable, new(autoMergeEnablePullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeEnable)
+
+	opAutoMergeDisable := openapi3.Operation{}
+	opAutoMergeDisable.WithTags("pullreq")
+	opAutoMergeDisable.WithMapOfAnything(map[string]interface{}{"operationId": "disablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeDisable, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeDisable)
+
+	opMergeQueueAdd := openapi3.Operation{}
+	opMergeQueueAdd.WithTags("pullreq")
+	opMergeQueueAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addPullReqToMergeQueue"})
+	_ = reflector.SetRequest(&opMergeQueueAdd, new(mergeQueueAddPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueAdd)
+
+	opMergeQueueRemove := openapi3.Operation{}
+	opMergeQueueRemove.WithTags("pullreq")
+	opMergeQueueRemove.WithMapOfAnything(map[string]interface{}{"operationId": "removePullReqFromMergeQueue"})
+	_ = reflector.SetRequest(&opMergeQueueRemove, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueRemove)
+
+	opMergeQueueList := openapi3.Operation{}
+	opMergeQueueList.WithTags("pullreq")
+	opMergeQueueList.WithMapOfAnything(map[string]interface{}{"operationId": "listMergeQueue"})
+	opMergeQueueList.WithParameters(queryParameterMergeQueueBranch)
+	_ = reflector.SetRequest(&opMergeQueueList, new(listPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new([]types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/merge-queue", opMergeQueueList)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
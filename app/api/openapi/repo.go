@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
 	"github.com/harness/gitness/app/api/request"
@@ -220,6 +221,11 @@ type LabelValueRequest struct {
 	Color enum.LabelColor `json:"color"`
 }
 
+type pushMirrorRequest struct {
+	repoRequest
+	Identifier string `path:"push_mirror_identifier"`
+}
+
 var queryParameterGitRef = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamGitRef,
@@ -757,6 +763,20 @@ var queryParameterIncludeValues = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryPushMirror = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the push mirrors by their identifier."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func repoOperations(reflector *openapi3.Reflector) {
 	createRepository := openapi3.Operation{}
@@ -879,6 +899,30 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodPost, "/repos/{repo_ref}/public-access", opUpdatePublicAccess)
 
+	opArchiveRepo := openapi3.Operation{}
+	opArchiveRepo.WithTags("repository")
+	opArchiveRepo.WithMapOfAnything(map[string]interface{}{"operationId": "archiveRepo"})
+	_ = reflector.SetRequest(&opArchiveRepo, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opArchiveRepo, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opArchiveRepo, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opArchiveRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchiveRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchiveRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchiveRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/archive", opArchiveRepo)
+
+	opUnarchiveRepo := openapi3.Operation{}
+	opUnarchiveRepo.WithTags("repository")
+	opUnarchiveRepo.WithMapOfAnything(map[string]interface{}{"operationId": "unarchiveRepo"})
+	_ = reflector.SetRequest(&opUnarchiveRepo, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUnarchiveRepo, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnarchiveRepo, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUnarchiveRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnarchiveRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnarchiveRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnarchiveRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/unarchive", opUnarchiveRepo)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -1476,4 +1520,248 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSquashBranch, new(types.MergeViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/squash", opSquashBranch)
+
+	opRevertCommit := openapi3.Operation{}
+	opRevertCommit.WithTags("repository")
+	opRevertCommit.WithMapOfAnything(
+		map[string]interface{}{"operationId": "revertCommit"})
+	_ = reflector.SetRequest(&opRevertCommit, &struct {
+		repoRequest
+		pullreq.RevertCommitInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(pullreq.CommitApplyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/revert", opRevertCommit)
+
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(
+		map[string]interface{}{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, &struct {
+		repoRequest
+		pullreq.CherryPickInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(pullreq.CommitApplyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/cherry-pick", opCherryPick)
+
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, &struct {
+		repoRequest
+		repo.ForkInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
+	opSyncFork := openapi3.Operation{}
+	opSyncFork.WithTags("repository")
+	opSyncFork.WithMapOfAnything(map[string]interface{}{"operationId": "syncFork"})
+	_ = reflector.SetRequest(&opSyncFork, &struct {
+		repoRequest
+		repo.SyncForkInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.SyncForkResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork/sync", opSyncFork)
+
+	opCreateFromTemplate := openapi3.Operation{}
+	opCreateFromTemplate.WithTags("repository")
+	opCreateFromTemplate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepositoryFromTemplate"})
+	_ = reflector.SetRequest(&opCreateFromTemplate, &struct {
+		repoRequest
+		repo.CreateFromTemplateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateFromTemplate, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateFromTemplate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateFromTemplate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateFromTemplate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateFromTemplate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateFromTemplate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/generate", opCreateFromTemplate)
+
+	opCreatePullMirror := openapi3.Operation{}
+	opCreatePullMirror.WithTags("repository")
+	opCreatePullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "createPullMirror"})
+	opCreatePullMirror.WithParameters(queryParameterSpacePath)
+	_ = reflector.SetRequest(&opCreatePullMirror, new(repo.CreatePullMirrorInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePullMirror, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePullMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreatePullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreatePullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/mirror", opCreatePullMirror)
+
+	opFindPullMirror := openapi3.Operation{}
+	opFindPullMirror.WithTags("repository")
+	opFindPullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findPullMirror"})
+	_ = reflector.SetRequest(&opFindPullMirror, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPullMirror, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPullMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindPullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPullMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opFindPullMirror)
+
+	opUpdatePullMirror := openapi3.Operation{}
+	opUpdatePullMirror.WithTags("repository")
+	opUpdatePullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "updatePullMirror"})
+	_ = reflector.SetRequest(&opUpdatePullMirror, &struct {
+		repoRequest
+		repo.UpdatePullMirrorInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdatePullMirror, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePullMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePullMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/mirror", opUpdatePullMirror)
+
+	opSyncPullMirror := openapi3.Operation{}
+	opSyncPullMirror.WithTags("repository")
+	opSyncPullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "syncPullMirror"})
+	_ = reflector.SetRequest(&opSyncPullMirror, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(types.PullMirror), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opSyncPullMirror)
+
+	opCreatePushMirror := openapi3.Operation{}
+	opCreatePushMirror.WithTags("repository")
+	opCreatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "createPushMirror"})
+	_ = reflector.SetRequest(&opCreatePushMirror, &struct {
+		repoRequest
+		repo.CreatePushMirrorInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(types.PushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors", opCreatePushMirror)
+
+	opListPushMirrors := openapi3.Operation{}
+	opListPushMirrors.WithTags("repository")
+	opListPushMirrors.WithMapOfAnything(map[string]interface{}{"operationId": "listPushMirrors"})
+	opListPushMirrors.WithParameters(queryParameterQueryPushMirror, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListPushMirrors, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new([]types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors", opListPushMirrors)
+
+	opFindPushMirror := openapi3.Operation{}
+	opFindPushMirror.WithTags("repository")
+	opFindPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findPushMirror"})
+	_ = reflector.SetRequest(&opFindPushMirror, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opFindPushMirror)
+
+	opUpdatePushMirror := openapi3.Operation{}
+	opUpdatePushMirror.WithTags("repository")
+	opUpdatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "updatePushMirror"})
+	_ = reflector.SetRequest(&opUpdatePushMirror, &struct {
+		pushMirrorRequest
+		repo.UpdatePushMirrorInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opUpdatePushMirror)
+
+	opDeletePushMirror := openapi3.Operation{}
+	opDeletePushMirror.WithTags("repository")
+	opDeletePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "deletePushMirror"})
+	_ = reflector.SetRequest(&opDeletePushMirror, new(pushMirrorRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opDeletePushMirror)
+
+	opPushPushMirror := openapi3.Operation{}
+	opPushPushMirror.WithTags("repository")
+	opPushPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "pushPushMirror"})
+	_ = reflector.SetRequest(&opPushPushMirror, new(pushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}/push", opPushPushMirror)
+
+	opListPushMirrorExecutions := openapi3.Operation{}
+	opListPushMirrorExecutions.WithTags("repository")
+	opListPushMirrorExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "listPushMirrorExecutions"})
+	opListPushMirrorExecutions.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListPushMirrorExecutions, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPushMirrorExecutions, new([]types.PushMirrorExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPushMirrorExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListPushMirrorExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPushMirrorExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPushMirrorExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPushMirrorExecutions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}/executions", opListPushMirrorExecutions)
 }
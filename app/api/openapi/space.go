@@ -65,6 +65,11 @@ type importRepositoriesRequest struct {
 	space.ImportRepositoriesInput
 }
 
+type updateChatWebhooksRequest struct {
+	spaceRequest
+	space.UpdateChatWebhooksInput
+}
+
 var queryParameterSortRepo = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -99,6 +104,21 @@ var queryParameterQueryRepo = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterArchivedRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamArchived,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("If true, only archived repositories are returned. " +
+			"If false, only repositories that aren't archived are returned."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeBoolean),
+			},
+		},
+	},
+}
+
 var queryParameterSortSpace = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -367,7 +387,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opRepos.WithTags("space")
 	opRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listRepos"})
 	opRepos.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
-		QueryParameterPage, QueryParameterLimit)
+		QueryParameterPage, QueryParameterLimit, queryParameterArchivedRepo)
 	_ = reflector.SetRequest(&opRepos, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opRepos, []repo.RepositoryOutput{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusInternalServerError)
@@ -710,4 +730,25 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetUsageMetrics, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opGetUsageMetrics, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usage/metric", opGetUsageMetrics)
+
+	opListChatWebhooks := openapi3.Operation{}
+	opListChatWebhooks.WithTags("space")
+	opListChatWebhooks.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceChatWebhooks"})
+	_ = reflector.SetRequest(&opListChatWebhooks, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListChatWebhooks, new([]types.ChatWebhook), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListChatWebhooks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListChatWebhooks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListChatWebhooks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/chat-webhooks", opListChatWebhooks)
+
+	opUpdateChatWebhooks := openapi3.Operation{}
+	opUpdateChatWebhooks.WithTags("space")
+	opUpdateChatWebhooks.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceChatWebhooks"})
+	_ = reflector.SetRequest(&opUpdateChatWebhooks, new(updateChatWebhooksRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateChatWebhooks, new([]types.ChatWebhook), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateChatWebhooks, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateChatWebhooks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateChatWebhooks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateChatWebhooks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/chat-webhooks", opUpdateChatWebhooks)
 }
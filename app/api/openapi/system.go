@@ -17,8 +17,10 @@ package openapi
 import (
 	"net/http"
 
+	controllersystem "github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -34,4 +36,34 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opGetSigningKey := openapi3.Operation{}
+	opGetSigningKey.WithTags("system")
+	opGetSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "getServerSigningKey"})
+	_ = reflector.SetRequest(&opGetSigningKey, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetSigningKey, new(types.ServerSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetSigningKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/signing-key", opGetSigningKey)
+
+	opSetSigningKey := openapi3.Operation{}
+	opSetSigningKey.WithTags("admin")
+	opSetSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "adminSetServerSigningKey"})
+	_ = reflector.SetRequest(&opSetSigningKey, new(controllersystem.SetServerSigningKeyInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetSigningKey, new(types.ServerSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetSigningKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSetSigningKey, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetSigningKey, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/signing-key", opSetSigningKey)
+
+	opDeleteSigningKey := openapi3.Operation{}
+	opDeleteSigningKey.WithTags("admin")
+	opDeleteSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteServerSigningKey"})
+	_ = reflector.SetRequest(&opDeleteSigningKey, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/signing-key", opDeleteSigningKey)
 }
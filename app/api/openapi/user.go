@@ -153,6 +153,37 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opKeyList)
 
+	opNotificationPrefList := openapi3.Operation{}
+	opNotificationPrefList.WithTags("user")
+	opNotificationPrefList.WithMapOfAnything(map[string]interface{}{"operationId": "listNotificationPreferences"})
+	_ = reflector.SetRequest(&opNotificationPrefList, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opNotificationPrefList, new([]types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationPrefList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-preferences", opNotificationPrefList)
+
+	opNotificationPrefUpdate := openapi3.Operation{}
+	opNotificationPrefUpdate.WithTags("user")
+	opNotificationPrefUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotificationPreference"})
+	_ = reflector.SetRequest(&opNotificationPrefUpdate, new(user.UpdateNotificationPreferenceInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opNotificationPrefUpdate, new(types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationPrefUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opNotificationPrefUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opNotificationPrefUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/notification-preferences", opNotificationPrefUpdate)
+
+	opNotificationPrefDelete := openapi3.Operation{}
+	opNotificationPrefDelete.WithTags("user")
+	opNotificationPrefDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteNotificationPreference"})
+	_ = reflector.SetRequest(&opNotificationPrefDelete, struct {
+		SpaceRef string `query:"space_ref"`
+		RepoRef  string `query:"repo_ref"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opNotificationPrefDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opNotificationPrefDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opNotificationPrefDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opNotificationPrefDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/notification-preferences", opNotificationPrefDelete)
+
 	opListTokens := openapi3.Operation{}
 	opListTokens.WithTags("user")
 	opListTokens.WithMapOfAnything(map[string]interface{}{"operationId": "listTokens"})
@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/usergroup"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type userGroupRequest struct {
+	spaceRequest
+	Identifier string `path:"usergroup_identifier"`
+}
+
+var queryParameterQueryUserGroup = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the usergroups by their identifier or name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterQueryUserGroupMember = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the members by their UID or display name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func userGroupOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("usergroup")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listUserGroups"})
+	opList.WithParameters(queryParameterQueryUserGroup, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.UserGroupInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("usergroup")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createUserGroup"})
+	_ = reflector.SetRequest(&opCreate, &struct {
+		spaceRequest
+		usergroup.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.UserGroup), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroups", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("usergroup")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findUserGroup"})
+	_ = reflector.SetRequest(&opFind, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.UserGroup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("usergroup")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserGroup"})
+	_ = reflector.SetRequest(&opUpdate, &struct {
+		userGroupRequest
+		usergroup.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.UserGroup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("usergroup")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserGroup"})
+	_ = reflector.SetRequest(&opDelete, new(userGroupRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opDelete)
+
+	opMemberList := openapi3.Operation{}
+	opMemberList.WithTags("usergroup")
+	opMemberList.WithMapOfAnything(map[string]interface{}{"operationId": "listUserGroupMembers"})
+	opMemberList.WithParameters(queryParameterQueryUserGroupMember, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opMemberList, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMemberList, []types.UserGroupMemberInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups/{usergroup_identifier}/members", opMemberList)
+
+	opMemberAdd := openapi3.Operation{}
+	opMemberAdd.WithTags("usergroup")
+	opMemberAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addUserGroupMember"})
+	_ = reflector.SetRequest(&opMemberAdd, &struct {
+		userGroupRequest
+		usergroup.MemberAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(types.UserGroupMemberInfo), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroups/{usergroup_identifier}/members", opMemberAdd)
+
+	opMemberDelete := openapi3.Operation{}
+	opMemberDelete.WithTags("usergroup")
+	opMemberDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserGroupMember"})
+	_ = reflector.SetRequest(&opMemberDelete, &struct {
+		userGroupRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMemberDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/usergroups/{usergroup_identifier}/members/{user_uid}", opMemberDelete)
+}
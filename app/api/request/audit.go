@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamActorID      = "actor_id"
+	QueryParamResourceType = "resource_type"
+	QueryParamAction       = "action"
+)
+
+// ParseAuditEventFilter extracts the audit event query parameters from the url.
+func ParseAuditEventFilter(r *http.Request) (*types.AuditEventFilter, error) {
+	actorIDs, err := QueryParamListAsPositiveInt64(r, QueryParamActorID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing actor ID filter: %w", err)
+	}
+
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing audit event created filter: %w", err)
+	}
+
+	resourceTypes, _ := QueryParamList(r, QueryParamResourceType)
+	actions, _ := QueryParamList(r, QueryParamAction)
+
+	return &types.AuditEventFilter{
+		Pagination:    ParsePaginationFromRequest(r),
+		CreatedFilter: createdFilter,
+		ActorIDs:      actorIDs,
+		ResourceTypes: resourceTypes,
+		Actions:       actions,
+	}, nil
+}
@@ -30,6 +30,7 @@ const (
 	PathParamUserGroupID      = "user_group_id"
 	PathParamSourceBranch     = "source_branch"
 	PathParamTargetBranch     = "target_branch"
+	PathParamReaction         = "reaction"
 
 	QueryParamCommenterID        = "commenter_id"
 	QueryParamReviewerID         = "reviewer_id"
@@ -56,6 +57,15 @@ func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
 
+func GetReactionFromPath(r *http.Request) (enum.Reaction, error) {
+	reaction, err := PathParamOrError(r, PathParamReaction)
+	if err != nil {
+		return "", err
+	}
+
+	return enum.Reaction(reaction), nil
+}
+
 func GetPullReqSourceBranchFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamSourceBranch)
 }
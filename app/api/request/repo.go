@@ -22,8 +22,10 @@ import (
 )
 
 const (
-	PathParamRepoRef = "repo_ref"
-	QueryParamRepoID = "repo_id"
+	PathParamRepoRef   = "repo_ref"
+	QueryParamRepoID   = "repo_id"
+	QueryParamRepoRef  = "repo_ref"
+	QueryParamArchived = "archived"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -65,6 +67,16 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		deletedAt = &deletedAtVal
 	}
 
+	// archived is optional to retrieve only archived or only not archived repos.
+	var archived *bool
+	if _, ok := QueryParam(r, QueryParamArchived); ok {
+		archivedVal, err := QueryParamAsBoolOrDefault(r, QueryParamArchived, false)
+		if err != nil {
+			return nil, err
+		}
+		archived = &archivedVal
+	}
+
 	return &types.RepoFilter{
 		Query:             ParseQuery(r),
 		Order:             ParseOrder(r),
@@ -74,5 +86,6 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		Recursive:         recursive,
 		DeletedAt:         deletedAt,
 		DeletedBeforeOrAt: deletedBeforeOrAt,
+		Archived:          archived,
 	}, nil
 }
@@ -26,6 +26,7 @@ const (
 	PathParamSpaceRef = "space_ref"
 
 	QueryParamIncludeSubspaces = "include_subspaces"
+	QueryParamSpaceRef         = "space_ref"
 )
 
 func GetSpaceRefFromPath(r *http.Request) (string, error) {
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usererror
+
+import (
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/errors"
+)
+
+// IsClientError returns true if the error is caused by the request rather than by a server failure,
+// so repeating the same request wouldn't help. It's used by background services that call the controllers.
+func IsClientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return true
+	}
+
+	var uErr *Error
+	if errors.As(err, &uErr) {
+		return uErr.Status < http.StatusInternalServerError
+	}
+
+	return errors.AsStatus(err) != errors.StatusInternal
+}
+
+// IsAccessDenied returns true if the error is caused by the missing authentication or authorization.
+func IsAccessDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return true
+	}
+
+	var uErr *Error
+	if errors.As(err, &uErr) {
+		return uErr.Status == http.StatusUnauthorized || uErr.Status == http.StatusForbidden
+	}
+
+	status := errors.AsStatus(err)
+	return status == errors.StatusUnauthorized || status == errors.StatusForbidden
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usererror
+
+import (
+	"fmt"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/errors"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expClientError  bool
+		expAccessDenied bool
+	}{
+		{name: "nil"},
+		{name: "internal", err: fmt.Errorf("failed: %w", ErrInternal)},
+		{name: "plain", err: fmt.Errorf("connection refused")},
+		{name: "bad-request", err: fmt.Errorf("failed: %w", ErrBadRequest), expClientError: true},
+		{name: "forbidden", err: ErrForbidden, expClientError: true, expAccessDenied: true},
+		{name: "unauthorized", err: ErrUnauthorized, expClientError: true, expAccessDenied: true},
+		{
+			name:            "not-authorized",
+			err:             fmt.Errorf("failed: %w", apiauth.ErrNotAuthorized),
+			expClientError:  true,
+			expAccessDenied: true,
+		},
+		{name: "app-invalid-argument", err: errors.InvalidArgument("invalid"), expClientError: true},
+		{name: "app-forbidden", err: errors.Forbidden("forbidden"), expClientError: true, expAccessDenied: true},
+		{name: "app-internal", err: errors.Internal(nil, "internal")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsClientError(test.err); got != test.expClientError {
+				t.Errorf("IsClientError: want=%t got=%t", test.expClientError, got)
+			}
+			if got := IsAccessDenied(test.err); got != test.expAccessDenied {
+				t.Errorf("IsAccessDenied: want=%t got=%t", test.expAccessDenied, got)
+			}
+		})
+	}
+}
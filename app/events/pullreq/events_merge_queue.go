@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueAddedEvent events.EventType = "merge-queue-added"
+
+type MergeQueueAddedPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+}
+
+func (r *Reporter) MergeQueueAdded(ctx context.Context, payload *MergeQueueAddedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueAddedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue added event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue added event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueAdded(fn events.HandlerFunc[*MergeQueueAddedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueAddedEvent, fn, opts...)
+}
+
+const MergeQueueRemovedEvent events.EventType = "merge-queue-removed"
+
+type MergeQueueRemovedPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+}
+
+func (r *Reporter) MergeQueueRemoved(ctx context.Context, payload *MergeQueueRemovedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueRemovedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue removed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue removed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueRemoved(fn events.HandlerFunc[*MergeQueueRemovedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueRemovedEvent, fn, opts...)
+}
+
+// MergeQueueMergeCreatedEvent is reported when a speculative merge commit is created for a pull request
+// in the merge queue. The status checks of the pull request should run against the merge commit.
+const MergeQueueMergeCreatedEvent events.EventType = "merge-queue-merge-created"
+
+type MergeQueueMergeCreatedPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+	Ref          string `json:"ref"`
+	BaseSHA      string `json:"base_sha"`
+	MergeSHA     string `json:"merge_sha"`
+}
+
+func (r *Reporter) MergeQueueMergeCreated(ctx context.Context, payload *MergeQueueMergeCreatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueMergeCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue merge created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue merge created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueMergeCreated(fn events.HandlerFunc[*MergeQueueMergeCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueMergeCreatedEvent, fn, opts...)
+}
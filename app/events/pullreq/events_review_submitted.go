@@ -29,6 +29,8 @@ type ReviewSubmittedPayload struct {
 	Base
 	ReviewerID int64
 	Decision   enum.PullReqReviewDecision
+	// CommentIDs are IDs of the pending comments published with the review.
+	CommentIDs []int64
 }
 
 func (r *Reporter) ReviewSubmitted(
@@ -164,7 +164,7 @@ func NewAPIHandler(
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, usageSender)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, sysCtrl, usageSender)
 		})
 	})
 
@@ -214,6 +214,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	gitspaceCtrl *gitspace.Controller,
 	infraProviderCtrl *infraprovider.Controller,
 	migrateCtrl *migrate.Controller,
+	sysCtrl *system.Controller,
 	usageSender usage.Sender,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
@@ -227,7 +228,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, sysCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -266,7 +267,6 @@ func setupSpaces(
 			r.Get("/pipelines", handlerspace.HandleListPipelines(spaceCtrl))
 			r.Get("/executions", handlerspace.HandleListExecutions(spaceCtrl))
 			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
-			r.Get("/usergroups", handlerUserGroup.HandleList(userGroupCtrl))
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
@@ -277,6 +277,9 @@ func setupSpaces(
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
+			r.Get("/audit-events", handlerspace.HandleAuditList(spaceCtrl))
+			r.Get("/chat-webhooks", handlerspace.HandleListChatWebhooks(spaceCtrl))
+			r.Put("/chat-webhooks", handlerspace.HandleUpdateChatWebhooks(spaceCtrl))
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -287,6 +290,21 @@ func setupSpaces(
 				})
 			})
 
+			r.Route("/usergroups", func(r chi.Router) {
+				r.Get("/", handlerUserGroup.HandleList(userGroupCtrl))
+				r.Post("/", handlerUserGroup.HandleCreate(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupIdentifier), func(r chi.Router) {
+					r.Get("/", handlerUserGroup.HandleFind(userGroupCtrl))
+					r.Patch("/", handlerUserGroup.HandleUpdate(userGroupCtrl))
+					r.Delete("/", handlerUserGroup.HandleDelete(userGroupCtrl))
+					r.Route("/members", func(r chi.Router) {
+						r.Get("/", handlerUserGroup.HandleMemberList(userGroupCtrl))
+						r.Post("/", handlerUserGroup.HandleMemberAdd(userGroupCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamUserUID), handlerUserGroup.HandleMemberDelete(userGroupCtrl))
+					})
+				})
+			})
+
 			SetupSpaceLabels(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
@@ -374,6 +392,7 @@ func setupRepos(r chi.Router,
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerrepo.HandleCreate(repoCtrl))
 		r.Post("/import", handlerrepo.HandleImport(repoCtrl))
+		r.Post("/mirror", handlerrepo.HandleCreatePullMirror(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 			// repo level operations
 			r.Get("/", handlerrepo.HandleFind(repoCtrl))
@@ -382,6 +401,8 @@ func setupRepos(r chi.Router,
 			r.Post("/purge", handlerrepo.HandlePurge(repoCtrl))
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
 			r.Post("/public-access", handlerrepo.HandleUpdatePublicAccess(repoCtrl))
+			r.Post("/archive", handlerrepo.HandleArchiveRepo(repoCtrl))
+			r.Post("/unarchive", handlerrepo.HandleUnarchiveRepo(repoCtrl))
 
 			r.Route("/settings", func(r chi.Router) {
 				r.Get("/security", handlerreposettings.HandleSecurityFind(repoSettingsCtrl))
@@ -396,6 +417,7 @@ func setupRepos(r chi.Router,
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
+			r.Get("/audit-events", handlerrepo.HandleAuditList(repoCtrl))
 
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
@@ -464,6 +486,31 @@ func setupRepos(r chi.Router,
 
 			r.Post("/rebase", handlerrepo.HandleRebase(repoCtrl))
 			r.Post("/squash", handlerrepo.HandleSquash(repoCtrl))
+			r.Post("/revert", handlerpullreq.HandleRevertCommit(pullreqCtrl))
+			r.Post("/cherry-pick", handlerpullreq.HandleCherryPick(pullreqCtrl))
+
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
+			r.Post("/fork/sync", handlerrepo.HandleSyncFork(repoCtrl))
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
+			r.Post("/generate", handlerrepo.HandleCreateFromTemplate(repoCtrl))
+
+			r.Route("/mirror", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindPullMirror(repoCtrl))
+				r.Patch("/", handlerrepo.HandleUpdatePullMirror(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleSyncPullMirror(repoCtrl))
+			})
+
+			r.Route("/push-mirrors", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleListPushMirrors(repoCtrl))
+				r.Post("/", handlerrepo.HandleCreatePushMirror(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorIdentifier), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandleFindPushMirror(repoCtrl))
+					r.Patch("/", handlerrepo.HandleUpdatePushMirror(repoCtrl))
+					r.Delete("/", handlerrepo.HandleDeletePushMirror(repoCtrl))
+					r.Post("/push", handlerrepo.HandlePushPushMirror(repoCtrl))
+					r.Get("/executions", handlerrepo.HandleListPushMirrorExecutions(repoCtrl))
+				})
+			})
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
@@ -650,6 +697,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
 		r.Get(
 			fmt.Sprintf("/{%s}...{%s}", request.PathParamTargetBranch, request.PathParamSourceBranch),
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
@@ -667,8 +715,17 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
 					r.Put("/status", handlerpullreq.HandleCommentStatus(pullreqCtrl))
+					r.Route("/reactions", func(r chi.Router) {
+						r.Put("/", handlerpullreq.HandleCommentReactionAdd(pullreqCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamReaction),
+							handlerpullreq.HandleCommentReactionRemove(pullreqCtrl))
+					})
 				})
 			})
+			r.Route("/reactions", func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleReactionAdd(pullreqCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamReaction), handlerpullreq.HandleReactionRemove(pullreqCtrl))
+			})
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
@@ -689,6 +746,15 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleMergeQueueAdd(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 			r.Route("/branch", func(r chi.Router) {
@@ -802,6 +868,13 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
 				handleruser.HandleDeletePublicKey(userCtrl))
 		})
+
+		// Notification preferences
+		r.Route("/notification-preferences", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListNotificationPreferences(userCtrl))
+			r.Put("/", handleruser.HandleUpdateNotificationPreference(userCtrl))
+			r.Delete("/", handleruser.HandleDeleteNotificationPreference(userCtrl))
+		})
 	})
 }
 
@@ -833,6 +906,7 @@ func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller)
 		r.Get("/health", handlersystem.HandleHealth)
 		r.Get("/version", handlersystem.HandleVersion)
 		r.Get("/config", handlersystem.HandleGetConfig(config, sysCtrl))
+		r.Get("/signing-key", handlersystem.HandleGetServerSigningKey(sysCtrl))
 	})
 }
 
@@ -881,7 +955,7 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -895,6 +969,10 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Route("/signing-key", func(r chi.Router) {
+			r.Put("/", handlersystem.HandleSetServerSigningKey(sysCtrl))
+			r.Delete("/", handlersystem.HandleDeleteServerSigningKey(sysCtrl))
+		})
 	})
 }
 
@@ -0,0 +1,264 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	reasonPushedByOther = "new commits were pushed by a user who isn't the author of the pull request"
+	reasonUserBlocked   = "the user who enabled auto-merge is blocked"
+	reasonNoPermission  = "the user who enabled auto-merge isn't allowed to merge the pull request"
+	reasonFastForward   = "the fast-forward merge method can't be used with the merge queue"
+)
+
+func (s *Service) mergeOnAutoMergeEnabled(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.merge(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.merge(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnCommentStatusUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	return s.merge(ctx, event.Payload.PullReqID)
+}
+
+// mergeOnCheckReported attempts to merge the pull requests with enabled auto-merge
+// whose latest source commit is the commit the status check has been reported for.
+func (s *Service) mergeOnCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	autoMerges, err := s.autoMergeStore.ListByRepo(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request auto-merges: %w", err)
+	}
+
+	for _, autoMerge := range autoMerges {
+		pr, err := s.pullreqStore.Find(ctx, autoMerge.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.SourceSHA != event.Payload.CommitSHA {
+			continue
+		}
+
+		if err := s.mergePullReq(ctx, autoMerge, pr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleBranchUpdated disables auto-merge if the source branch has been updated by a user
+// other than the pull request author or the user who enabled auto-merge. Otherwise, it attempts to merge.
+func (s *Service) handleBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	autoMerge, pr, err := s.find(ctx, event.Payload.PullReqID)
+	if err != nil || autoMerge == nil {
+		return err
+	}
+
+	pusherID := event.Payload.PrincipalID
+	if pusherID != pr.CreatedBy && pusherID != autoMerge.CreatedBy {
+		return s.pullreqCtrl.AutoMergeDisableNoAuth(ctx, pr, pusherID, reasonPushedByOther)
+	}
+
+	return s.mergePullReq(ctx, autoMerge, pr)
+}
+
+func (s *Service) deleteOnMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.delete(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) deleteOnClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.delete(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) delete(ctx context.Context, pullReqID int64) error {
+	if err := s.autoMergeStore.Delete(ctx, pullReqID); err != nil {
+		return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// find returns the auto-merge settings and the pull request. It returns nil if auto-merge isn't enabled.
+func (s *Service) find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, *types.PullReq, error) {
+	autoMerge, err := s.autoMergeStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return autoMerge, pr, nil
+}
+
+func (s *Service) merge(ctx context.Context, pullReqID int64) error {
+	autoMerge, pr, err := s.find(ctx, pullReqID)
+	if err != nil || autoMerge == nil {
+		return err
+	}
+
+	return s.mergePullReq(ctx, autoMerge, pr)
+}
+
+// mergePullReq merges the pull request on behalf of the user who enabled auto-merge.
+// If the target branch requires the merge queue, the pull request is added to the merge queue instead.
+// Nothing happens if the protection rules or merge conflicts still block the merge.
+func (s *Service) mergePullReq(ctx context.Context, autoMerge *types.PullReqAutoMerge, pr *types.PullReq) error {
+	if pr.State != enum.PullReqStateOpen {
+		return s.delete(ctx, pr.ID)
+	}
+
+	principal, err := s.principalStore.Find(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find the user who enabled auto-merge: %w", err)
+	}
+
+	if principal.Blocked {
+		return s.pullreqCtrl.AutoMergeDisableNoAuth(ctx, pr, principal.ID, reasonUserBlocked)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	repoRef := strconv.FormatInt(pr.TargetRepoID, 10)
+
+	_, violations, err := s.pullreqCtrl.Merge(ctx, session, repoRef, pr.Number,
+		&pullreq.MergeInput{
+			Method:    autoMerge.Method,
+			SourceSHA: pr.SourceSHA,
+			Title:     autoMerge.Title,
+			Message:   autoMerge.Message,
+		})
+	if usererror.IsAccessDenied(err) {
+		return s.pullreqCtrl.AutoMergeDisableNoAuth(ctx, pr, principal.ID, reasonNoPermission)
+	}
+	if usererror.IsClientError(err) {
+		// the pull request can't be merged in the current state, e.g. it's a draft.
+		log.Ctx(ctx).Debug().Err(err).Int64("pullreq_id", pr.ID).Msg("auto-merge is waiting")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to auto-merge pull request: %w", err)
+	}
+
+	if violations != nil && protection.RequiresMergeQueue(violations.RuleViolations) {
+		return s.addToMergeQueue(ctx, session, repoRef, autoMerge, pr)
+	}
+
+	if violations != nil {
+		log.Ctx(ctx).Debug().Int64("pullreq_id", pr.ID).
+			Msgf("auto-merge is waiting for requirements: %s", violations.Message)
+		return nil
+	}
+
+	log.Ctx(ctx).Info().Int64("pullreq_id", pr.ID).Msg("pull request has been auto-merged")
+
+	return nil
+}
+
+// addToMergeQueue adds the pull request to the merge queue on behalf of the user who enabled auto-merge.
+// The merge queue merges the pull request once the status checks pass for its speculative merge commit.
+func (s *Service) addToMergeQueue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	autoMerge *types.PullReqAutoMerge,
+	pr *types.PullReq,
+) error {
+	if autoMerge.Method == enum.MergeMethodFastForward {
+		return s.pullreqCtrl.AutoMergeDisableNoAuth(ctx, pr, session.Principal.ID, reasonFastForward)
+	}
+
+	_, violations, err := s.pullreqCtrl.MergeQueueAdd(ctx, session, repoRef, pr.Number,
+		&pullreq.MergeQueueAddInput{
+			Method:  autoMerge.Method,
+			Title:   autoMerge.Title,
+			Message: autoMerge.Message,
+		})
+	if usererror.IsAccessDenied(err) {
+		return s.pullreqCtrl.AutoMergeDisableNoAuth(ctx, pr, session.Principal.ID, reasonNoPermission)
+	}
+	if usererror.IsClientError(err) {
+		// the pull request can't be added to the merge queue, e.g. it's already in the queue.
+		log.Ctx(ctx).Debug().Err(err).Int64("pullreq_id", pr.ID).Msg("auto-merge is waiting for the merge queue")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	if violations != nil {
+		log.Ctx(ctx).Debug().Int64("pullreq_id", pr.ID).
+			Msgf("auto-merge is waiting for requirements: %s", violations.Message)
+		return nil
+	}
+
+	log.Ctx(ctx).Info().Int64("pullreq_id", pr.ID).Msg("pull request has been added to the merge queue by auto-merge")
+
+	return nil
+}
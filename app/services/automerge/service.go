@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+// Service merges pull requests with enabled auto-merge once all protection rule requirements are satisfied.
+// A merge is attempted whenever something that could affect the requirements happens:
+// a status check is reported, a review is submitted, a comment is resolved or the source branch is updated.
+type Service struct {
+	pullreqStore   store.PullReqStore
+	autoMergeStore store.PullReqAutoMergeStore
+	principalStore store.PrincipalStore
+	pullreqCtrl    *pullreq.Controller
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqStore store.PullReqStore,
+	autoMergeStore store.PullReqAutoMergeStore,
+	principalStore store.PrincipalStore,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	service := &Service{
+		pullreqStore:   pullreqStore,
+		autoMergeStore: autoMergeStore,
+		principalStore: principalStore,
+		pullreqCtrl:    pullreqCtrl,
+	}
+
+	const groupPullReq = "gitness:automerge:pullreq"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 5 * time.Minute // merging can take a while
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterAutoMergeEnabled(service.mergeOnAutoMergeEnabled)
+			_ = r.RegisterReviewSubmitted(service.mergeOnReviewSubmitted)
+			_ = r.RegisterCommentStatusUpdated(service.mergeOnCommentStatusUpdated)
+			_ = r.RegisterBranchUpdated(service.handleBranchUpdated)
+			_ = r.RegisterMerged(service.deleteOnMerged)
+			_ = r.RegisterClosed(service.deleteOnClosed)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupCheck = "gitness:automerge:check"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheck, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.mergeOnCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqStore store.PullReqStore,
+	autoMergeStore store.PullReqAutoMergeStore,
+	principalStore store.PrincipalStore,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		pullreqEvReaderFactory,
+		checkEvReaderFactory,
+		pullreqStore,
+		autoMergeStore,
+		principalStore,
+		pullreqCtrl,
+	)
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePushMirrorExecutions        = "gitness:cleanup:push-mirror-executions"
+	jobCronPushMirrorExecutions        = "33 */4 * * *" // At minute 33 past every 4th hour.
+	jobMaxDurationPushMirrorExecutions = 1 * time.Minute
+)
+
+type pushMirrorExecutionsCleanupJob struct {
+	retentionTime time.Duration
+
+	pushMirrorExecutionStore store.PushMirrorExecutionStore
+}
+
+func newPushMirrorExecutionsCleanupJob(
+	retentionTime time.Duration,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
+) *pushMirrorExecutionsCleanupJob {
+	return &pushMirrorExecutionsCleanupJob{
+		retentionTime: retentionTime,
+
+		pushMirrorExecutionStore: pushMirrorExecutionStore,
+	}
+}
+
+// Handle purges old push mirror executions that are past the retention time.
+func (j *pushMirrorExecutionsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging push mirror executions older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.pushMirrorExecutionStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old push mirror executions: %w", err)
+	}
+
+	result := "no old push mirror executions found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d push mirror executions", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
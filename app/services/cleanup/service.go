@@ -26,8 +26,10 @@ import (
 )
 
 type Config struct {
-	WebhookExecutionsRetentionTime   time.Duration
-	DeletedRepositoriesRetentionTime time.Duration
+	WebhookExecutionsRetentionTime    time.Duration
+	DeletedRepositoriesRetentionTime  time.Duration
+	AuditEventsRetentionTime          time.Duration
+	PushMirrorExecutionsRetentionTime time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,18 +43,28 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.AuditEventsRetentionTime <= 0 {
+		return errors.New("config.AuditEventsRetentionTime has to be provided")
+	}
+
+	if c.PushMirrorExecutionsRetentionTime <= 0 {
+		return errors.New("config.PushMirrorExecutionsRetentionTime has to be provided")
+	}
 	return nil
 }
 
 // Service is responsible for cleaning up data in db / git / ...
 type Service struct {
-	config                Config
-	scheduler             *job.Scheduler
-	executor              *job.Executor
-	webhookExecutionStore store.WebhookExecutionStore
-	tokenStore            store.TokenStore
-	repoStore             store.RepoStore
-	repoCtrl              *repo.Controller
+	config                   Config
+	scheduler                *job.Scheduler
+	executor                 *job.Executor
+	webhookExecutionStore    store.WebhookExecutionStore
+	tokenStore               store.TokenStore
+	repoStore                store.RepoStore
+	repoCtrl                 *repo.Controller
+	auditEventStore          store.AuditEventStore
+	pushMirrorExecutionStore store.PushMirrorExecutionStore
 }
 
 func NewService(
@@ -63,6 +75,8 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditEventStore store.AuditEventStore,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -71,12 +85,14 @@ func NewService(
 	return &Service{
 		config: config,
 
-		scheduler:             scheduler,
-		executor:              executor,
-		webhookExecutionStore: webhookExecutionStore,
-		tokenStore:            tokenStore,
-		repoStore:             repoStore,
-		repoCtrl:              repoCtrl,
+		scheduler:                scheduler,
+		executor:                 executor,
+		webhookExecutionStore:    webhookExecutionStore,
+		tokenStore:               tokenStore,
+		repoStore:                repoStore,
+		repoCtrl:                 repoCtrl,
+		auditEventStore:          auditEventStore,
+		pushMirrorExecutionStore: pushMirrorExecutionStore,
 	}, nil
 }
 
@@ -126,6 +142,28 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeAuditEvents,
+		jobTypeAuditEvents,
+		jobCronAuditEvents,
+		jobMaxDurationAuditEvents,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule audit events cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePushMirrorExecutions,
+		jobTypePushMirrorExecutions,
+		jobCronPushMirrorExecutions,
+		jobMaxDurationPushMirrorExecutions,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule push mirror executions cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +198,25 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeAuditEvents,
+		newAuditEventsCleanupJob(
+			s.config.AuditEventsRetentionTime,
+			s.auditEventStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for audit events cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypePushMirrorExecutions,
+		newPushMirrorExecutionsCleanupJob(
+			s.config.PushMirrorExecutionsRetentionTime,
+			s.pushMirrorExecutionStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for push mirror executions cleanup: %w", err)
+	}
 	return nil
 }
@@ -34,6 +34,8 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditEventStore store.AuditEventStore,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +45,7 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		auditEventStore,
+		pushMirrorExecutionStore,
 	)
 }
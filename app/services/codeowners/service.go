@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/errors"
@@ -436,7 +437,7 @@ func (s *Service) Evaluate(
 		for _, owner := range entry.Owners {
 			// check for usrgrp
 			if strings.HasPrefix(owner, userGroupPrefixMarker) {
-				userGroupCodeOwner, err := s.resolveUserGroupCodeOwner(ctx, owner[1:], reviewers)
+				userGroupCodeOwner, err := s.resolveUserGroupCodeOwner(ctx, repo, owner[1:], reviewers)
 				if errors.Is(err, usergroup.ErrNotFound) {
 					log.Ctx(ctx).Debug().Msgf("usergroup %q not found hence skipping for code owner", owner)
 					continue
@@ -480,10 +481,11 @@ func (s *Service) Evaluate(
 
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
+	repo *types.RepositoryCore,
 	owner string,
 	reviewers []*types.PullReqReviewer,
 ) (*UserGroupOwnerEvaluation, error) {
-	usrgrp, err := s.userGroupResolver.Resolve(ctx, owner)
+	usrgrp, err := s.resolveUserGroup(ctx, repo, owner)
 	if err != nil {
 		return nil, fmt.Errorf("not able to resolve usergroup : %w", err)
 	}
@@ -516,6 +518,34 @@ func (s *Service) resolveUserGroupCodeOwner(
 	return userGroupEvaluation, nil
 }
 
+// resolveUserGroup resolves the usergroup code owner.
+// A usergroup can be referenced by its scoped ID (e.g. @space/developers),
+// or just by its identifier (e.g. @developers), in which case the closest space
+// of the repository that defines a usergroup with that identifier is used.
+func (s *Service) resolveUserGroup(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	owner string,
+) (*types.UserGroup, error) {
+	if strings.Contains(owner, types.PathSeparatorAsString) {
+		return s.userGroupResolver.Resolve(ctx, owner)
+	}
+
+	for spacePath := paths.Parent(repo.Path); spacePath != ""; spacePath = paths.Parent(spacePath) {
+		usrgrp, err := s.userGroupResolver.Resolve(ctx, paths.Concatenate(spacePath, owner))
+		if errors.Is(err, usergroup.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return usrgrp, nil
+	}
+
+	return nil, usergroup.ErrNotFound
+}
+
 func (s *Service) resolveUserCodeOwnerByEmail(
 	ctx context.Context,
 	owner string,
@@ -553,8 +583,16 @@ func (s *Service) Validate(
 	for _, entry := range codeowners.Entries {
 		// check for users in file
 		for _, owner := range entry.Owners {
-			// todo: handle usergroup better
 			if strings.HasPrefix(owner, userGroupPrefixMarker) {
+				_, err := s.resolveUserGroup(ctx, repo, owner[1:])
+				if errors.Is(err, usergroup.ErrNotFound) {
+					codeOwnerValidation.Addf(enum.CodeOwnerViolationCodeUserGroupNotFound,
+						"usergroup %q not found", owner)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("error encountered resolving usergroup %q: %w", owner, err)
+				}
 				continue
 			}
 			_, err := s.principalStore.FindByEmail(ctx, owner)
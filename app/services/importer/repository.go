@@ -289,6 +289,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 				Repository: *repo,
 				IsPublic:   true,
 			}),
+			audit.WithRepoID(repo.ID),
 		)
 		if err != nil {
 			log.Warn().Msgf("failed to insert audit log for updating repo to public: %s", err)
@@ -26,6 +26,8 @@ type CreationType string
 const (
 	CreationTypeCreate CreationType = "CREATE"
 	CreationTypeImport CreationType = "IMPORT"
+	CreationTypeFork   CreationType = "FORK"
+	CreationTypeMirror CreationType = "MIRROR"
 )
 
 type Property string
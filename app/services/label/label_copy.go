@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// copyPageSize is the number of labels (and label values) read at once when labels are copied.
+const copyPageSize = 100
+
+// CopyRepoLabels defines all labels and label values of the source repository in the target repository.
+func (s *Service) CopyRepoLabels(
+	ctx context.Context,
+	principalID int64,
+	sourceRepoID int64,
+	targetRepoID int64,
+) error {
+	for page := 1; ; page++ {
+		labels, err := s.labelStore.List(ctx, nil, &sourceRepoID, &types.LabelFilter{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: copyPageSize},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list labels: %w", err)
+		}
+
+		for _, label := range labels {
+			if err := s.copyLabel(ctx, principalID, label, targetRepoID); err != nil {
+				return err
+			}
+		}
+
+		if len(labels) < copyPageSize {
+			return nil
+		}
+	}
+}
+
+func (s *Service) copyLabel(
+	ctx context.Context,
+	principalID int64,
+	label *types.Label,
+	targetRepoID int64,
+) error {
+	labelCopy, err := s.Define(ctx, principalID, nil, &targetRepoID, &types.DefineLabelInput{
+		Key:         label.Key,
+		Type:        label.Type,
+		Description: label.Description,
+		Color:       label.Color,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to define label %q: %w", label.Key, err)
+	}
+
+	for page := 1; ; page++ {
+		values, err := s.labelValueStore.List(ctx, label.ID, types.ListQueryFilter{
+			Pagination: types.Pagination{Page: page, Size: copyPageSize},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list values of label %q: %w", label.Key, err)
+		}
+
+		for _, value := range values {
+			_, err = s.DefineValue(ctx, principalID, labelCopy.ID, &types.DefineValueInput{
+				Value: value.Value,
+				Color: value.Color,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to define value %q of label %q: %w", value.Value, label.Key, err)
+			}
+		}
+
+		if len(values) < copyPageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mailreply
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// maxReplySize is the maximum size of the text part of a reply email that is read.
+const maxReplySize = 64 << 10
+
+var (
+	errNoTextPart = errors.New("message has no text/plain part")
+
+	// replyHeaderRegexp matches the line email clients put above the quoted original message,
+	// e.g. "On Mon, 1 Jan 2024 at 10:00, Gitness <reply@example.com> wrote:".
+	replyHeaderRegexp = regexp.MustCompile(`^On\s.+wrote:$`)
+)
+
+// ExtractReplyText returns the text of a reply email without the quoted original message and the signature.
+func ExtractReplyText(msg *mail.Message) (string, error) {
+	text, err := findTextPart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return StripQuotedReply(text), nil
+}
+
+func findTextPart(contentType, transferEncoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// messages without a content type are plain text.
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				return "", errNoTextPart
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to read multipart message: %w", err)
+			}
+
+			// multipart.Reader decodes quoted-printable parts and removes their transfer encoding header.
+			text, err := findTextPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if errors.Is(err, errNoTextPart) {
+				continue
+			}
+
+			return text, err
+		}
+	}
+
+	if mediaType != "text/plain" {
+		return "", errNoTextPart
+	}
+
+	switch strings.ToLower(strings.TrimSpace(transferEncoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxReplySize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read text part: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// StripQuotedReply removes the quoted original message and the signature from the text of a reply.
+func StripQuotedReply(text string) string {
+	var lines []string
+
+	scanner := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(text, "\r\n", "\n")))
+	scanner.Buffer(make([]byte, 0, 4096), maxReplySize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ">") ||
+			replyHeaderRegexp.MatchString(trimmed) ||
+			strings.HasPrefix(trimmed, "-----Original Message-----") ||
+			line == "-- " || trimmed == "--" ||
+			strings.HasPrefix(trimmed, "________") {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mailreply
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestExtractReplyText(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		exp     string
+		expErr  bool
+	}{
+		{
+			name: "plain",
+			message: "Subject: Re: comment\r\n\r\n" +
+				"Looks good to me.\r\n\r\n" +
+				"On Mon, 1 Jan 2024 at 10:00, Gitness <reply@example.com> wrote:\r\n" +
+				"> original comment\r\n",
+			exp: "Looks good to me.",
+		},
+		{
+			name: "quoted-printable",
+			message: "Content-Type: text/plain; charset=utf-8\r\n" +
+				"Content-Transfer-Encoding: quoted-printable\r\n\r\n" +
+				"Caf=C3=A9 is fine.\r\n" +
+				"-- \r\n" +
+				"John\r\n",
+			exp: "Café is fine.",
+		},
+		{
+			name: "multipart",
+			message: "Content-Type: multipart/alternative; boundary=b1\r\n\r\n" +
+				"--b1\r\n" +
+				"Content-Type: text/html\r\n\r\n" +
+				"<p>html reply</p>\r\n" +
+				"--b1\r\n" +
+				"Content-Type: text/plain\r\n" +
+				"Content-Transfer-Encoding: base64\r\n\r\n" +
+				"dGV4dCByZXBseQ==\r\n" +
+				"--b1--\r\n",
+			exp: "text reply",
+		},
+		{
+			name: "outlook",
+			message: "Subject: RE: comment\r\n\r\n" +
+				"Agreed.\r\n" +
+				"-----Original Message-----\r\n" +
+				"From: Gitness\r\n",
+			exp: "Agreed.",
+		},
+		{
+			name: "html-only",
+			message: "Content-Type: text/html\r\n\r\n" +
+				"<p>html reply</p>\r\n",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg, err := mail.ReadMessage(strings.NewReader(test.message))
+			if err != nil {
+				t.Fatalf("failed to parse message: %s", err.Error())
+			}
+
+			text, err := ExtractReplyText(msg)
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if text != test.exp {
+				t.Errorf("expected %q, got %q", test.exp, text)
+			}
+		})
+	}
+}
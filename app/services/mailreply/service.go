@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mailreply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:notification:mail-reply"
+	jobMaxDuration = 5 * time.Minute
+
+	maildirNew = "new"
+	maildirCur = "cur"
+	// maildirSeenSuffix marks a message as seen when it's moved to the cur directory.
+	maildirSeenSuffix = ":2,S"
+)
+
+// errRejected is returned for messages that can never be posted as a comment.
+var errRejected = errors.New("reply rejected")
+
+// Service periodically reads the replies to notification emails from a maildir
+// and posts them as pull request comments on behalf of the notification recipients.
+type Service struct {
+	config       notification.ReplyConfig
+	scheduler    *job.Scheduler
+	executor     *job.Executor
+	replies      *notification.ReplyAddresses
+	pullReqStore store.PullReqStore
+	pullreqCtrl  *pullreq.Controller
+}
+
+func NewService(
+	config notification.ReplyConfig,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	replies *notification.ReplyAddresses,
+	pullReqStore store.PullReqStore,
+	pullreqCtrl *pullreq.Controller,
+) *Service {
+	return &Service{
+		config:       config,
+		scheduler:    scheduler,
+		executor:     executor,
+		replies:      replies,
+		pullReqStore: pullReqStore,
+		pullreqCtrl:  pullreqCtrl,
+	}
+}
+
+// Register registers the job that polls the maildir. It's a no-op if replying to emails isn't configured.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled() {
+		return nil
+	}
+
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register mail reply job handler: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.config.PollCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule mail reply job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle processes all new messages in the maildir.
+// Messages that are posted or rejected are moved to the cur directory,
+// messages that failed with a transient error are left in place to be retried.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	dirNew := filepath.Join(s.config.Maildir, maildirNew)
+
+	entries, err := os.ReadDir(dirNew)
+	if err != nil {
+		return "", fmt.Errorf("failed to read maildir: %w", err)
+	}
+
+	var posted, rejected int
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		path := filepath.Join(dirNew, entry.Name())
+
+		err := s.processMessage(ctx, path)
+		switch {
+		case errors.Is(err, errRejected):
+			log.Ctx(ctx).Warn().Err(err).Str("message", entry.Name()).Msg("rejected reply email")
+			rejected++
+		case err != nil:
+			log.Ctx(ctx).Error().Err(err).Str("message", entry.Name()).Msg("failed to process reply email")
+			continue
+		default:
+			posted++
+		}
+
+		err = os.Rename(path, filepath.Join(s.config.Maildir, maildirCur, entry.Name()+maildirSeenSuffix))
+		if err != nil {
+			return "", fmt.Errorf("failed to move processed message %s: %w", entry.Name(), err)
+		}
+	}
+
+	return fmt.Sprintf("posted %d and rejected %d reply emails", posted, rejected), nil
+}
+
+func (s *Service) processMessage(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open message: %w", err)
+	}
+	defer f.Close()
+
+	msg, err := mail.ReadMessage(f)
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse message: %w", errRejected, err)
+	}
+
+	target, principal, err := s.findReplyTarget(ctx, msg.Header)
+	if err != nil {
+		return err
+	}
+
+	if principal.Blocked {
+		return fmt.Errorf("%w: principal %d is blocked", errRejected, principal.ID)
+	}
+
+	text, err := ExtractReplyText(msg)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errRejected, err)
+	}
+
+	if text == "" {
+		return fmt.Errorf("%w: reply is empty", errRejected)
+	}
+
+	pr, err := s.pullReqStore.Find(ctx, target.PullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("%w: pull request %d not found", errRejected, target.PullReqID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	_, err = s.pullreqCtrl.CommentCreate(ctx, session, strconv.FormatInt(pr.TargetRepoID, 10), pr.Number,
+		&pullreq.CommentCreateInput{
+			ParentID: target.ParentID,
+			Text:     text,
+		})
+	if usererror.IsClientError(err) {
+		return fmt.Errorf("%w: %w", errRejected, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
+
+// findReplyTarget returns the target of the first recipient address of the message that has a valid reply token.
+func (s *Service) findReplyTarget(
+	ctx context.Context,
+	header mail.Header,
+) (*notification.ReplyTarget, *types.Principal, error) {
+	for _, key := range []string{"Delivered-To", "X-Original-To", "To", "Cc"} {
+		addresses, err := header.AddressList(key)
+		if err != nil {
+			continue
+		}
+
+		for _, address := range addresses {
+			target, principal, err := s.replies.Verify(ctx, address.Address)
+			if errors.Is(err, notification.ErrInvalidReplyAddress) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return target, principal, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: no valid reply address", errRejected)
+}
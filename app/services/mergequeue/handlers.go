@@ -0,0 +1,350 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	reasonConflicts     = "the pull request conflicts with the target branch or the pull requests ahead of it in the queue"
+	reasonChecksFailed  = "a required status check failed for the speculative merge commit"
+	reasonBranchUpdated = "the source branch has been updated"
+)
+
+// checksStatus is the combined status of the required status checks of a speculative merge commit.
+type checksStatus int
+
+const (
+	checksPending checksStatus = iota
+	checksSucceeded
+	checksFailed
+)
+
+func (s *Service) processOnAdded(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueAddedPayload],
+) error {
+	return s.process(ctx, event.Payload.TargetRepoID, event.Payload.TargetBranch)
+}
+
+func (s *Service) processOnRemoved(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueRemovedPayload],
+) error {
+	return s.process(ctx, event.Payload.TargetRepoID, event.Payload.TargetBranch)
+}
+
+// processOnSourceBranchUpdated processes the queue of a pull request whose source branch has been updated.
+// The pull request is removed from the queue because the new commits haven't been verified.
+func (s *Service) processOnSourceBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) processOnClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) processOnMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+// processOnTargetBranchUpdated processes the queue of the updated branch
+// because the speculative merge commits must be based on the latest commit of the branch.
+func (s *Service) processOnTargetBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	const refPrefix = "refs/heads/"
+
+	branch, ok := strings.CutPrefix(event.Payload.Ref, refPrefix)
+	if !ok {
+		return nil
+	}
+
+	entries, err := s.mergeQueueStore.List(ctx, event.Payload.RepoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return s.process(ctx, event.Payload.RepoID, branch)
+}
+
+// processOnCheckReported processes the queue if a status check completed for a speculative merge commit.
+func (s *Service) processOnCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	entry, err := s.mergeQueueStore.FindByMergeSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry by merge SHA: %w", err)
+	}
+
+	return s.process(ctx, entry.RepoID, entry.Branch)
+}
+
+// processForPullReq processes the queue the pull request is in. Nothing happens if it isn't in a queue.
+func (s *Service) processForPullReq(ctx context.Context, pullReqID int64) error {
+	entry, err := s.mergeQueueStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return s.process(ctx, entry.RepoID, entry.Branch)
+}
+
+// process brings the merge queue of the branch up to date. It creates the missing or outdated speculative
+// merge commits, removes the pull requests that can't be merged and merges the pull requests at the front
+// of the queue whose required status checks passed. It holds the repository merge lock while doing so.
+func (s *Service) process(ctx context.Context, repoID int64, branch string) error {
+	// the max time we give a single queue processing to complete
+	const timeout = 4 * time.Minute
+
+	unlock, err := s.locker.LockPR(ctx, repoID, 0, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue processing: %w", err)
+	}
+	defer unlock()
+
+	repo, err := s.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	// every step either completes the processing or removes a pull request from the queue.
+	for {
+		done, err := s.processStep(ctx, repo, branch)
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+	}
+}
+
+//nolint:gocognit // the queue is processed in a single pass.
+func (s *Service) processStep(ctx context.Context, repo *types.RepositoryCore, branch string) (bool, error) {
+	entries, err := s.mergeQueueStore.List(ctx, repo.ID, branch)
+	if err != nil {
+		return false, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return true, nil
+	}
+
+	targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       branch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get target branch commit: %w", err)
+	}
+
+	// create the speculative merge commits that are missing or based on an outdated commit.
+
+	pullReqs := make([]*types.PullReq, len(entries))
+	baseSHA := targetRef.SHA
+
+	for i, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateOpen {
+			return false, s.remove(ctx, pr, "")
+		}
+
+		if pr.SourceSHA != entry.HeadSHA {
+			return false, s.remove(ctx, pr, reasonBranchUpdated)
+		}
+
+		if entry.State == enum.MergeQueueEntryStateWaiting || entry.BaseSHA != baseSHA.String() {
+			conflicts, err := s.pullreqCtrl.MergeQueuePrepareNoAuth(ctx, repo, pr, entry, baseSHA)
+			if err != nil {
+				return false, fmt.Errorf("failed to prepare speculative merge commit: %w", err)
+			}
+
+			if len(conflicts) > 0 {
+				return false, s.remove(ctx, pr, reasonConflicts)
+			}
+		}
+
+		baseSHA, err = sha.New(entry.MergeSHA)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse speculative merge commit SHA: %w", err)
+		}
+
+		pullReqs[i] = pr
+	}
+
+	requiredChecks, err := s.requiredChecks(ctx, repo, pullReqs[0])
+	if err != nil {
+		return false, err
+	}
+
+	// remove the pull requests with failed status checks.
+
+	statuses := make([]checksStatus, len(entries))
+	for i, entry := range entries {
+		statuses[i], err = s.checksStatus(ctx, repo, entry, requiredChecks)
+		if err != nil {
+			return false, err
+		}
+
+		if statuses[i] == checksFailed {
+			return false, s.remove(ctx, pullReqs[i], reasonChecksFailed)
+		}
+	}
+
+	// merge the pull request at the front of the queue.
+
+	if statuses[0] != checksSucceeded {
+		return true, nil
+	}
+
+	violations, err := s.pullreqCtrl.MergeQueueMergeNoAuth(ctx, repo, pullReqs[0], entries[0])
+	if err != nil {
+		return false, fmt.Errorf("failed to merge pull request from the merge queue: %w", err)
+	}
+
+	if violations != nil {
+		return false, s.remove(ctx, pullReqs[0], violations.Message)
+	}
+
+	return false, nil
+}
+
+// requiredChecks returns the identifiers of the status checks required by the protection rules of the branch.
+// The merge queue never bypasses the rules, so the status checks that could be bypassed are required too.
+func (s *Service) requiredChecks(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) (map[string]struct{}, error) {
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	out, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Repo:    repo,
+		PullReq: pr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	required := make(map[string]struct{}, len(out.RequiredIdentifiers)+len(out.BypassableIdentifiers))
+	for identifier := range out.RequiredIdentifiers {
+		required[identifier] = struct{}{}
+	}
+	for identifier := range out.BypassableIdentifiers {
+		required[identifier] = struct{}{}
+	}
+
+	return required, nil
+}
+
+// checksStatus returns the combined status of the required status checks of the speculative merge commit.
+func (s *Service) checksStatus(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	entry *types.MergeQueueEntry,
+	requiredChecks map[string]struct{},
+) (checksStatus, error) {
+	if len(requiredChecks) == 0 {
+		return checksSucceeded, nil
+	}
+
+	results, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return checksPending, fmt.Errorf("failed to list status check results: %w", err)
+	}
+
+	succeeded := 0
+	for _, result := range results {
+		if _, ok := requiredChecks[result.Identifier]; !ok {
+			continue
+		}
+
+		switch result.Status {
+		case enum.CheckStatusFailure, enum.CheckStatusError:
+			return checksFailed, nil
+		case enum.CheckStatusSuccess:
+			succeeded++
+		case enum.CheckStatusPending, enum.CheckStatusRunning:
+		}
+	}
+
+	if succeeded == len(requiredChecks) {
+		return checksSucceeded, nil
+	}
+
+	return checksPending, nil
+}
+
+// remove removes the pull request from the merge queue on behalf of the system.
+func (s *Service) remove(ctx context.Context, pr *types.PullReq, reason string) error {
+	log.Ctx(ctx).Info().Int64("pullreq_id", pr.ID).Str("reason", reason).
+		Msg("removing pull request from the merge queue")
+
+	return s.pullreqCtrl.MergeQueueEvictNoAuth(ctx, pr, reason)
+}
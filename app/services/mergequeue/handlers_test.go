@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessMergesAfterChecksPass(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, "ci")
+	q.add(1)
+
+	// the speculative merge commit is created on top of the target branch, the checks are pending.
+	q.process(ctx)
+
+	entry := q.entries[0]
+	require.Equal(t, enum.MergeQueueEntryStateChecking, entry.State)
+	require.Equal(t, q.target.String(), entry.BaseSHA)
+	require.Empty(t, q.merged)
+
+	q.checks[entry.MergeSHA] = []types.CheckResult{{Identifier: "ci", Status: enum.CheckStatusRunning}}
+	q.process(ctx)
+	require.Empty(t, q.merged)
+
+	// the target branch is fast-forwarded to the speculative merge commit once the checks pass.
+	mergeSHA := entry.MergeSHA
+	q.checks[mergeSHA] = []types.CheckResult{{Identifier: "ci", Status: enum.CheckStatusSuccess}}
+	q.process(ctx)
+
+	require.Equal(t, []int64{1}, q.merged)
+	require.Equal(t, mergeSHA, q.target.String())
+	require.Empty(t, q.entries)
+}
+
+func TestProcessRemovesFailedChecks(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, "ci")
+	q.add(1)
+	q.add(2)
+
+	q.process(ctx)
+	require.Len(t, q.entries, 2)
+
+	// the second pull request is merged on top of the speculative merge commit of the first one.
+	require.Equal(t, q.entries[0].MergeSHA, q.entries[1].BaseSHA)
+
+	q.checks[q.entries[0].MergeSHA] = []types.CheckResult{{Identifier: "ci", Status: enum.CheckStatusFailure}}
+	q.process(ctx)
+
+	require.Equal(t, map[int64]string{1: reasonChecksFailed}, q.removed)
+	require.Len(t, q.entries, 1)
+	require.Equal(t, q.target.String(), q.entries[0].BaseSHA, "the pull request should be rebuilt on the branch")
+}
+
+func TestProcessBaseMoved(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t, "ci")
+	q.add(1)
+	q.add(2)
+
+	q.process(ctx)
+	require.Len(t, q.entries, 2)
+
+	// the target branch is updated outside the merge queue, the first pull request conflicts with the update.
+	q.target = q.newSHA()
+	q.conflicts[1] = true
+
+	q.process(ctx)
+
+	require.Equal(t, map[int64]string{1: reasonConflicts}, q.removed)
+	require.Len(t, q.entries, 1)
+	require.Equal(t, int64(2), q.entries[0].PullReqID)
+	require.Equal(t, q.target.String(), q.entries[0].BaseSHA)
+
+	// the merge commit that was based on the old target branch commit can't be merged anymore.
+	q.checks[q.entries[0].MergeSHA] = []types.CheckResult{{Identifier: "ci", Status: enum.CheckStatusSuccess}}
+	q.process(ctx)
+
+	require.Equal(t, []int64{2}, q.merged)
+}
+
+func TestProcessRemovesOnMergeViolations(t *testing.T) {
+	ctx := context.Background()
+	q := newTestQueue(t)
+	q.add(1)
+	q.violations[1] = "The user who added the pull request to the merge queue is no longer allowed to push."
+
+	q.process(ctx)
+
+	require.Empty(t, q.merged)
+	require.Equal(t, map[int64]string{1: q.violations[1]}, q.removed)
+	require.Empty(t, q.entries)
+}
+
+// testQueue is the merge queue of the main branch. It's also the pull request controller,
+// which creates fake speculative merge commits and merges them by fast-forwarding the target branch.
+type testQueue struct {
+	t       *testing.T
+	service *Service
+	repo    *types.RepositoryCore
+	target  sha.SHA
+	nextSHA int
+
+	entries    []*types.MergeQueueEntry
+	pullReqs   map[int64]*types.PullReq
+	checks     map[string][]types.CheckResult
+	conflicts  map[int64]bool
+	violations map[int64]string
+	merged     []int64
+	removed    map[int64]string
+}
+
+func newTestQueue(t *testing.T, requiredChecks ...string) *testQueue {
+	q := &testQueue{
+		t:          t,
+		repo:       &types.RepositoryCore{ID: 1, DefaultBranch: "main"},
+		pullReqs:   map[int64]*types.PullReq{},
+		checks:     map[string][]types.CheckResult{},
+		conflicts:  map[int64]bool{},
+		violations: map[int64]string{},
+		removed:    map[int64]string{},
+	}
+	q.target = q.newSHA()
+
+	protectionManager, err := protection.ProvideManager(testRuleStore{requiredChecks: requiredChecks})
+	require.NoError(t, err)
+
+	q.service = &Service{
+		pullreqStore:      testPullReqStore{q: q},
+		mergeQueueStore:   testMergeQueueStore{q: q},
+		checkStore:        testCheckStore{q: q},
+		git:               testGit{q: q},
+		protectionManager: protectionManager,
+		pullreqCtrl:       q,
+	}
+
+	return q
+}
+
+func (q *testQueue) newSHA() sha.SHA {
+	q.nextSHA++
+	return sha.Must(fmt.Sprintf("%040x", q.nextSHA))
+}
+
+func (q *testQueue) add(pullReqID int64) {
+	headSHA := q.newSHA().String()
+
+	q.pullReqs[pullReqID] = &types.PullReq{
+		ID:           pullReqID,
+		Number:       pullReqID,
+		TargetRepoID: q.repo.ID,
+		SourceRepoID: q.repo.ID,
+		TargetBranch: "main",
+		State:        enum.PullReqStateOpen,
+		SourceSHA:    headSHA,
+	}
+
+	q.entries = append(q.entries, &types.MergeQueueEntry{
+		RepoID:    q.repo.ID,
+		Branch:    "main",
+		PullReqID: pullReqID,
+		Method:    enum.MergeMethodMerge,
+		State:     enum.MergeQueueEntryStateWaiting,
+		HeadSHA:   headSHA,
+	})
+}
+
+// process processes the queue the same way as Service.process, but without the lock.
+func (q *testQueue) process(ctx context.Context) {
+	for {
+		done, err := q.service.processStep(ctx, q.repo, "main")
+		require.NoError(q.t, err)
+
+		if done {
+			return
+		}
+	}
+}
+
+func (q *testQueue) delete(pullReqID int64) {
+	for i, entry := range q.entries {
+		if entry.PullReqID == pullReqID {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+type testMergeQueueStore struct {
+	store.MergeQueueStore
+	q *testQueue
+}
+
+func (s testMergeQueueStore) List(context.Context, int64, string) ([]*types.MergeQueueEntry, error) {
+	return append([]*types.MergeQueueEntry(nil), s.q.entries...), nil
+}
+
+type testPullReqStore struct {
+	store.PullReqStore
+	q *testQueue
+}
+
+func (s testPullReqStore) Find(_ context.Context, pullReqID int64) (*types.PullReq, error) {
+	return s.q.pullReqs[pullReqID], nil
+}
+
+type testCheckStore struct {
+	store.CheckStore
+	q *testQueue
+}
+
+func (s testCheckStore) ListResults(_ context.Context, _ int64, commitSHA string) ([]types.CheckResult, error) {
+	return s.q.checks[commitSHA], nil
+}
+
+type testGit struct {
+	git.Interface
+	q *testQueue
+}
+
+func (g testGit) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{SHA: g.q.target}, nil
+}
+
+func (q *testQueue) MergeQueuePrepareNoAuth(
+	_ context.Context,
+	_ *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA sha.SHA,
+) ([]string, error) {
+	if q.conflicts[pr.ID] {
+		return []string{"file.txt"}, nil
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = baseSHA.String()
+	entry.MergeSHA = q.newSHA().String()
+
+	return nil, nil
+}
+
+func (q *testQueue) MergeQueueMergeNoAuth(
+	_ context.Context,
+	_ *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (*types.MergeViolations, error) {
+	if message := q.violations[pr.ID]; message != "" {
+		return &types.MergeViolations{Message: message}, nil
+	}
+
+	// the fast-forward fails if the target branch has been updated since the merge commit was created.
+	if q.target.String() != entry.BaseSHA {
+		return nil, fmt.Errorf("target branch %s isn't the base %s", q.target, entry.BaseSHA)
+	}
+
+	q.target = sha.Must(entry.MergeSHA)
+	q.merged = append(q.merged, pr.ID)
+	q.delete(pr.ID)
+	pr.State = enum.PullReqStateMerged
+
+	return nil, nil
+}
+
+func (q *testQueue) MergeQueueEvictNoAuth(_ context.Context, pr *types.PullReq, reason string) error {
+	q.removed[pr.ID] = reason
+	q.delete(pr.ID)
+
+	return nil
+}
+
+// testRuleStore returns a branch rule for the default branch that requires the status checks.
+type testRuleStore struct {
+	store.RuleStore
+	requiredChecks []string
+}
+
+func (s testRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	if len(s.requiredChecks) == 0 {
+		return nil, nil
+	}
+
+	definition, err := protection.ToJSON(&protection.Branch{
+		PullReq: protection.DefPullReq{
+			StatusChecks: protection.DefStatusChecks{RequireIdentifiers: s.requiredChecks},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []types.RuleInfoInternal{{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "checks",
+			Type:       protection.TypeBranch,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    (&protection.Pattern{Default: true}).JSON(),
+		Definition: definition,
+	}}, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+// Service processes the merge queues of the branches.
+//
+// For every pull request in a merge queue a speculative merge commit is created that merges the pull request
+// on top of the speculative merge commit of the previous pull request in the queue (or on top of the target
+// branch for the first one). The status checks run against the speculative merge commits. The first pull request
+// in the queue is merged as soon as the required status checks pass for its merge commit, and a pull request is
+// removed from the queue if a required status check fails or if it can't be merged.
+type Service struct {
+	pullreqStore      store.PullReqStore
+	mergeQueueStore   store.MergeQueueStore
+	checkStore        store.CheckStore
+	repoFinder        refcache.RepoFinder
+	git               git.Interface
+	protectionManager *protection.Manager
+	locker            *locker.Locker
+	pullreqCtrl       pullReqController
+}
+
+// pullReqController is the part of the pull request controller that prepares, merges and removes
+// the pull requests in the merge queues.
+type pullReqController interface {
+	MergeQueuePrepareNoAuth(
+		ctx context.Context,
+		repo *types.RepositoryCore,
+		pr *types.PullReq,
+		entry *types.MergeQueueEntry,
+		baseSHA sha.SHA,
+	) ([]string, error)
+
+	MergeQueueMergeNoAuth(
+		ctx context.Context,
+		repo *types.RepositoryCore,
+		pr *types.PullReq,
+		entry *types.MergeQueueEntry,
+	) (*types.MergeViolations, error)
+
+	MergeQueueEvictNoAuth(ctx context.Context, pr *types.PullReq, reason string) error
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	gitEvReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqStore store.PullReqStore,
+	mergeQueueStore store.MergeQueueStore,
+	checkStore store.CheckStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	protectionManager *protection.Manager,
+	locker *locker.Locker,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	service := &Service{
+		pullreqStore:      pullreqStore,
+		mergeQueueStore:   mergeQueueStore,
+		checkStore:        checkStore,
+		repoFinder:        repoFinder,
+		git:               git,
+		protectionManager: protectionManager,
+		locker:            locker,
+		pullreqCtrl:       pullreqCtrl,
+	}
+
+	// processing a queue creates merge commits and merges pull requests, which can take a while.
+	const idleTimeout = 5 * time.Minute
+
+	const groupPullReq = "gitness:mergequeue:pullreq"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMergeQueueAdded(service.processOnAdded)
+			_ = r.RegisterMergeQueueRemoved(service.processOnRemoved)
+			_ = r.RegisterBranchUpdated(service.processOnSourceBranchUpdated)
+			_ = r.RegisterClosed(service.processOnClosed)
+			_ = r.RegisterMerged(service.processOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupGit = "gitness:mergequeue:git"
+	_, err = gitEvReaderFactory.Launch(ctx, groupGit, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.processOnTargetBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupCheck = "gitness:mergequeue:check"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheck, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.processOnCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	gitEvReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqStore store.PullReqStore,
+	mergeQueueStore store.MergeQueueStore,
+	checkStore store.CheckStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	protectionManager *protection.Manager,
+	locker *locker.Locker,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		pullreqEvReaderFactory,
+		gitEvReaderFactory,
+		checkEvReaderFactory,
+		pullreqStore,
+		mergeQueueStore,
+		checkStore,
+		repoFinder,
+		git,
+		protectionManager,
+		locker,
+		pullreqCtrl,
+	)
+}
@@ -0,0 +1,348 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/secret"
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	refPrefixBranch = "refs/heads/"
+	refPrefixTag    = "refs/tags/"
+
+	redacted = "******"
+)
+
+type pullSyncJob struct {
+	service *Service
+}
+
+// Handle synchronizes a pull mirror from its remote repository.
+// The job data is the ID of the mirrored repository.
+func (j *pullSyncJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	repoID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid pull mirror job data %q: %w", data, err)
+	}
+
+	s := j.service
+
+	mirror, err := s.pullMirrorStore.Find(ctx, repoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	mirror, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		mirror.LastSyncStatus = enum.MirrorSyncStatusRunning
+		mirror.LastSyncStarted = time.Now().UnixMilli()
+		mirror.LastSyncError = ""
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to mark pull mirror as running: %w", err)
+	}
+
+	refUpdates, password, err := s.syncPullMirror(ctx, mirror)
+	if err != nil {
+		msg := redact(err.Error(), password)
+
+		s.markFailed(context.WithoutCancel(ctx), mirror, msg)
+
+		return "", errors.New(msg)
+	}
+
+	_, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		mirror.LastSyncStatus = enum.MirrorSyncStatusSuccess
+		mirror.LastSyncFinished = time.Now().UnixMilli()
+		mirror.LastSyncError = ""
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to mark pull mirror as synchronized: %w", err)
+	}
+
+	return fmt.Sprintf("updated %d references", refUpdates), nil
+}
+
+// syncPullMirror fetches all branches and tags from the remote repository of the mirror
+// and reports the events of the updated references. It returns the number of updated references
+// and the password used to authenticate to the remote, so that it can be removed from error messages.
+func (s *Service) syncPullMirror(ctx context.Context, mirror *types.PullMirror) (int, string, error) {
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.State != enum.RepoStatePullMirror {
+		return 0, "", fmt.Errorf("repository %s is not a pull mirror", repo.Identifier)
+	}
+
+	password, err := s.getPassword(ctx, mirror)
+	if err != nil {
+		return 0, "", err
+	}
+
+	remoteURL, err := url.Parse(mirror.RemoteURL)
+	if err != nil {
+		return 0, password, fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	if err := s.checkRemoteHost(ctx, remoteURL); err != nil {
+		return 0, password, err
+	}
+
+	if mirror.Username != "" || password != "" {
+		remoteURL.User = url.UserPassword(mirror.Username, password)
+	}
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(ctx),
+		repo.ID,
+		systemPrincipal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return 0, password, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	// the default branch of an empty repository is taken from the remote.
+	defaultBranch := repo.DefaultBranch
+	if repo.IsEmpty {
+		defaultBranch = ""
+	}
+
+	out, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams: git.WriteParams{
+			Actor: git.Identity{
+				Name:  systemPrincipal.DisplayName,
+				Email: systemPrincipal.Email,
+			},
+			RepoUID: repo.GitUID,
+			EnvVars: envVars,
+		},
+		Source:            remoteURL.String(),
+		CreateIfNotExists: false,
+		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		DefaultBranch:     defaultBranch,
+	})
+	if repo.IsEmpty && errors.Is(err, api.ErrNoDefaultBranch) {
+		// the remote repository is empty as well, there is nothing to synchronize.
+		return 0, password, nil
+	}
+	if err != nil {
+		return 0, password, fmt.Errorf("failed to sync repository from %s: %w", remoteURL.Redacted(), err)
+	}
+
+	if repo.IsEmpty && len(out.RefUpdates) > 0 || repo.DefaultBranch != out.DefaultBranch {
+		repo, err = s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.IsEmpty = false
+			repo.DefaultBranch = out.DefaultBranch
+			return nil
+		})
+		if err != nil {
+			return 0, password, fmt.Errorf("failed to update repository: %w", err)
+		}
+
+		s.repoFinder.MarkChanged(ctx, repo.Core())
+	}
+
+	refs := make([]string, len(out.RefUpdates))
+	for i, refUpdate := range out.RefUpdates {
+		s.reportRefUpdate(ctx, repo, systemPrincipal.ID, refUpdate)
+		refs[i] = refUpdate.Name
+	}
+
+	// the synchronized references are pushed further to the push mirrors of the repository.
+	if len(refs) > 0 {
+		if err := s.TriggerPush(ctx, repo.ID, refs); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Msg("failed to trigger push mirrors")
+		}
+	}
+
+	return len(out.RefUpdates), password, nil
+}
+
+// getPassword returns the password used to authenticate to the remote repository.
+func (s *Service) getPassword(ctx context.Context, mirror *types.PullMirror) (string, error) {
+	if mirror.SecretIdentifier != "" {
+		return s.getSecretData(ctx, mirror.SecretSpaceID, mirror.SecretIdentifier)
+	}
+
+	if mirror.Password == "" {
+		return "", nil
+	}
+
+	password, err := s.encrypter.Decrypt([]byte(mirror.Password))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt password: %w", err)
+	}
+
+	return password, nil
+}
+
+// getSecretData returns the decrypted content of the secret.
+func (s *Service) getSecretData(ctx context.Context, spaceID int64, identifier string) (string, error) {
+	sec, err := s.secretStore.FindByIdentifier(ctx, spaceID, identifier)
+	if err != nil {
+		return "", fmt.Errorf("failed to find secret %q: %w", identifier, err)
+	}
+
+	sec, err = secret.Dec(s.encrypter, sec)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret %q: %w", identifier, err)
+	}
+
+	return sec.Data, nil
+}
+
+// redact removes all forms of the password that could appear in a remote URL from the message.
+func redact(msg, password string) string {
+	if password == "" {
+		return msg
+	}
+
+	msg = strings.ReplaceAll(msg, password, redacted)
+	msg = strings.ReplaceAll(msg, url.PathEscape(password), redacted)
+	msg = strings.ReplaceAll(msg, url.QueryEscape(password), redacted)
+
+	return msg
+}
+
+// reportRefUpdate reports the git event of a branch or tag updated by a synchronization.
+// The fetch doesn't run the git hooks, so the events that are normally reported by the
+// post-receive hook are reported here.
+func (s *Service) reportRefUpdate(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	refUpdate git.RefUpdate,
+) {
+	switch {
+	case strings.HasPrefix(refUpdate.Name, refPrefixBranch):
+		s.reportBranchUpdate(ctx, repo, principalID, refUpdate)
+
+	case strings.HasPrefix(refUpdate.Name, refPrefixTag):
+		switch {
+		case refUpdate.Old.IsEmpty():
+			payload := &gitevents.TagCreatedPayload{
+				RepoID:      repo.ID,
+				PrincipalID: principalID,
+				Ref:         refUpdate.Name,
+				SHA:         refUpdate.New.String(),
+			}
+			s.gitReporter.TagCreated(ctx, payload)
+			s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeTagCreated, payload)
+
+		case refUpdate.New.IsEmpty():
+			payload := &gitevents.TagDeletedPayload{
+				RepoID:      repo.ID,
+				PrincipalID: principalID,
+				Ref:         refUpdate.Name,
+				SHA:         refUpdate.Old.String(),
+			}
+			s.gitReporter.TagDeleted(ctx, payload)
+			s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeTagDeleted, payload)
+
+		default:
+			payload := &gitevents.TagUpdatedPayload{
+				RepoID:      repo.ID,
+				PrincipalID: principalID,
+				Ref:         refUpdate.Name,
+				OldSHA:      refUpdate.Old.String(),
+				NewSHA:      refUpdate.New.String(),
+				Forced:      true,
+			}
+			s.gitReporter.TagUpdated(ctx, payload)
+			s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeTagUpdated, payload)
+		}
+	}
+}
+
+func (s *Service) reportBranchUpdate(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	refUpdate git.RefUpdate,
+) {
+	switch {
+	case refUpdate.Old.IsEmpty():
+		payload := &gitevents.BranchCreatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Name,
+			SHA:         refUpdate.New.String(),
+		}
+		s.gitReporter.BranchCreated(ctx, payload)
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchCreated, payload)
+
+	case refUpdate.New.IsEmpty():
+		payload := &gitevents.BranchDeletedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Name,
+			SHA:         refUpdate.Old.String(),
+		}
+		s.gitReporter.BranchDeleted(ctx, payload)
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchDeleted, payload)
+
+	default:
+		ancestor, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			AncestorCommitSHA:   refUpdate.Old,
+			DescendantCommitSHA: refUpdate.New,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("ref", refUpdate.Name).
+				Msg("failed to check ancestor")
+		}
+
+		payload := &gitevents.BranchUpdatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Name,
+			OldSHA:      refUpdate.Old.String(),
+			NewSHA:      refUpdate.New.String(),
+			// in case of an error consider the update as forced.
+			Forced: err != nil || !ancestor.Ancestor,
+		}
+		s.gitReporter.BranchUpdated(ctx, payload)
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchUpdated, payload)
+	}
+}
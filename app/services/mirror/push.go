@@ -0,0 +1,343 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePushScheduler = "gitness:mirror:push-scheduler"
+	jobTypePush          = "gitness:mirror:push"
+
+	jobMaxDurationPushScheduler = time.Minute
+
+	pushSchedulerBatchSize = 100
+)
+
+var errInvalidPushPattern = errors.New("invalid push mirror pattern")
+
+type pushJobData struct {
+	PushMirrorID int64                  `json:"push_mirror_id"`
+	Trigger      enum.PushMirrorTrigger `json:"trigger"`
+}
+
+// ValidatePushPattern validates a branch or tag pattern of a push mirror.
+// A pattern is a reference name relative to refs/heads/ or refs/tags/ that can contain a single '*'.
+func ValidatePushPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("%w: pattern must not be empty", errInvalidPushPattern)
+	}
+
+	if strings.Count(pattern, "*") > 1 {
+		return fmt.Errorf("%w: pattern %q contains more than one '*'", errInvalidPushPattern, pattern)
+	}
+
+	if strings.ContainsAny(pattern, " :+^~?[\\\t\n") || strings.Contains(pattern, "..") ||
+		strings.Contains(pattern, "//") || strings.Contains(pattern, "@{") ||
+		strings.HasPrefix(pattern, "/") || strings.HasPrefix(pattern, "-") ||
+		strings.HasSuffix(pattern, "/") || strings.HasSuffix(pattern, ".") || strings.HasSuffix(pattern, ".lock") {
+		return fmt.Errorf("%w: pattern %q is not a valid reference name", errInvalidPushPattern, pattern)
+	}
+
+	return nil
+}
+
+// TriggerPush starts a push to every enabled push mirror of the repository
+// that mirrors at least one of the updated references.
+func (s *Service) TriggerPush(ctx context.Context, repoID int64, refs []string) error {
+	mirrors, err := s.pushMirrorStore.ListEnabled(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list enabled push mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		if !pushMirrorMatchesAny(mirror, refs) {
+			continue
+		}
+
+		if err := s.Push(ctx, mirror, enum.PushMirrorTriggerPush); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Push starts a push of the repository to the push mirror.
+func (s *Service) Push(ctx context.Context, mirror *types.PushMirror, trigger enum.PushMirrorTrigger) error {
+	data, err := json.Marshal(pushJobData{
+		PushMirrorID: mirror.ID,
+		Trigger:      trigger,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal push mirror job data: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("%s-%d-%d", jobTypePush, mirror.ID, time.Now().UnixNano()),
+		Type:       jobTypePush,
+		MaxRetries: 0,
+		Timeout:    s.config.Mirror.PushMaxDuration,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run push mirror job: %w", err)
+	}
+
+	return nil
+}
+
+type pushSchedulerJob struct {
+	service *Service
+}
+
+// Handle starts a push to every enabled push mirror.
+func (j *pushSchedulerJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	var (
+		afterID int64
+		started int
+	)
+
+	for {
+		mirrors, err := j.service.pushMirrorStore.ListAllEnabled(ctx, afterID, pushSchedulerBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list enabled push mirrors: %w", err)
+		}
+
+		for _, mirror := range mirrors {
+			afterID = mirror.ID
+
+			if err := j.service.Push(ctx, mirror, enum.PushMirrorTriggerSchedule); err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("push_mirror_id", mirror.ID).
+					Msg("failed to start push to push mirror")
+				continue
+			}
+
+			started++
+		}
+
+		if len(mirrors) < pushSchedulerBatchSize {
+			break
+		}
+	}
+
+	return fmt.Sprintf("started %d pushes to push mirrors", started), nil
+}
+
+type pushJob struct {
+	service *Service
+}
+
+// Handle pushes the branches and tags of a repository to a push mirror and records the execution.
+func (j *pushJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input pushJobData
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("invalid push mirror job data %q: %w", data, err)
+	}
+
+	s := j.service
+
+	mirror, err := s.pushMirrorStore.Find(ctx, input.PushMirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "push mirror doesn't exist anymore", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if !mirror.Enabled && input.Trigger != enum.PushMirrorTriggerManual {
+		return "push mirror is disabled", nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.IsEmpty {
+		return "repository is empty", nil
+	}
+
+	start := time.Now()
+
+	password, err := s.pushToMirror(ctx, repo, mirror)
+
+	execution := &types.PushMirrorExecution{
+		PushMirrorID: mirror.ID,
+		Created:      start.UnixMilli(),
+		Trigger:      input.Trigger,
+		Result:       enum.PushMirrorExecutionResultSuccess,
+		Duration:     time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		execution.Result = enum.PushMirrorExecutionResultFailed
+		execution.Error = redact(err.Error(), password)
+	}
+
+	// the execution is recorded even if the job got canceled.
+	ctx = context.WithoutCancel(ctx)
+
+	if errCreate := s.pushMirrorExecutionStore.Create(ctx, execution); errCreate != nil {
+		log.Ctx(ctx).Warn().Err(errCreate).
+			Int64("push_mirror_id", mirror.ID).
+			Msg("failed to create push mirror execution")
+	}
+
+	_, errUpdate := s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		mirror.LatestExecutionResult = &execution.Result
+		return nil
+	})
+	if errUpdate != nil {
+		log.Ctx(ctx).Warn().Err(errUpdate).
+			Int64("push_mirror_id", mirror.ID).
+			Msg("failed to update latest execution result of push mirror")
+	}
+
+	if err != nil {
+		return "", errors.New(execution.Error)
+	}
+
+	return "pushed to " + mirror.Identifier, nil
+}
+
+// pushToMirror pushes the branches and tags that match the patterns of the push mirror to its remote repository.
+// It returns the password used to authenticate to the remote, so that it can be removed from error messages.
+func (s *Service) pushToMirror(ctx context.Context, repo *types.Repository, mirror *types.PushMirror) (string, error) {
+	var password string
+	if mirror.SecretIdentifier != "" {
+		var err error
+		password, err = s.getSecretData(ctx, mirror.SecretSpaceID, mirror.SecretIdentifier)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	remoteURL, err := url.Parse(mirror.RemoteURL)
+	if err != nil {
+		return password, fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	if err := s.checkRemoteHost(ctx, remoteURL); err != nil {
+		return password, err
+	}
+
+	if mirror.Username != "" || password != "" {
+		remoteURL.User = url.UserPassword(mirror.Username, password)
+	}
+
+	err = s.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		RemoteURL:  remoteURL.String(),
+		RefSpecs:   pushRefSpecs(mirror),
+		Prune:      pushMirrorPrunes(mirror),
+	})
+	if err != nil {
+		return password, fmt.Errorf("failed to push to %s: %w", remoteURL.Redacted(), err)
+	}
+
+	return password, nil
+}
+
+// pushRefSpecs returns the refspecs of all branches and tags pushed to the push mirror.
+func pushRefSpecs(mirror *types.PushMirror) []string {
+	var refSpecs []string
+
+	add := func(prefix string, patterns []string) {
+		if len(patterns) == 0 {
+			patterns = []string{"*"}
+		}
+
+		for _, pattern := range patterns {
+			refSpec := prefix + pattern + ":" + prefix + pattern
+			if mirror.Force {
+				refSpec = "+" + refSpec
+			}
+
+			refSpecs = append(refSpecs, refSpec)
+		}
+	}
+
+	add(refPrefixBranch, mirror.BranchPatterns)
+	add(refPrefixTag, mirror.TagPatterns)
+
+	return refSpecs
+}
+
+// pushMirrorPrunes returns true if the remote branches and tags deleted locally are deleted by the push.
+// Only the full mirrors, which push all branches and tags, and the force mirrors delete the remote references,
+// otherwise the references created directly in the remote repository are kept.
+func pushMirrorPrunes(mirror *types.PushMirror) bool {
+	return mirror.Force || (len(mirror.BranchPatterns) == 0 && len(mirror.TagPatterns) == 0)
+}
+
+// pushMirrorMatchesAny returns true if any of the references is mirrored by the push mirror.
+func pushMirrorMatchesAny(mirror *types.PushMirror, refs []string) bool {
+	for _, ref := range refs {
+		var (
+			name     string
+			patterns []string
+		)
+
+		switch {
+		case strings.HasPrefix(ref, refPrefixBranch):
+			name, patterns = ref[len(refPrefixBranch):], mirror.BranchPatterns
+		case strings.HasPrefix(ref, refPrefixTag):
+			name, patterns = ref[len(refPrefixTag):], mirror.TagPatterns
+		default:
+			continue
+		}
+
+		if len(patterns) == 0 {
+			return true
+		}
+
+		for _, pattern := range patterns {
+			if pushPatternMatches(pattern, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pushPatternMatches matches the reference name against the pattern the same way git matches refspecs:
+// the '*' matches any sequence of characters, including '/'.
+func pushPatternMatches(pattern, name string) bool {
+	prefix, suffix, found := strings.Cut(pattern, "*")
+	if !found {
+		return pattern == name
+	}
+
+	return len(name) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(name, prefix) &&
+		strings.HasSuffix(name, suffix)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestValidatePushPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		expErr  bool
+	}{
+		{pattern: "main"},
+		{pattern: "release/*"},
+		{pattern: "feature-*-ready"},
+		{pattern: "", expErr: true},
+		{pattern: "*/*", expErr: true},
+		{pattern: "a:b", expErr: true},
+		{pattern: "+main", expErr: true},
+		{pattern: "a..b", expErr: true},
+		{pattern: "/main", expErr: true},
+		{pattern: "release/", expErr: true},
+		{pattern: "main.lock", expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			err := ValidatePushPattern(test.pattern)
+			if test.expErr && err == nil {
+				t.Error("expected an error but got none")
+			} else if !test.expErr && err != nil {
+				t.Errorf("got an error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestPushMirrorMatchesAny(t *testing.T) {
+	mirror := &types.PushMirror{
+		BranchPatterns: []string{"main", "release/*"},
+		TagPatterns:    []string{"v*"},
+	}
+
+	tests := []struct {
+		name     string
+		mirror   *types.PushMirror
+		refs     []string
+		expMatch bool
+	}{
+		{name: "branch-exact", mirror: mirror, refs: []string{"refs/heads/main"}, expMatch: true},
+		{name: "branch-wildcard", mirror: mirror, refs: []string{"refs/heads/release/1/2"}, expMatch: true},
+		{name: "tag-wildcard", mirror: mirror, refs: []string{"refs/tags/v1.0"}, expMatch: true},
+		{name: "no-match", mirror: mirror, refs: []string{"refs/heads/dev", "refs/tags/x1.0"}},
+		{name: "other-refs", mirror: mirror, refs: []string{"refs/pullreq/1/head"}},
+		{name: "no-patterns", mirror: &types.PushMirror{}, refs: []string{"refs/heads/dev"}, expMatch: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if match := pushMirrorMatchesAny(test.mirror, test.refs); match != test.expMatch {
+				t.Errorf("expected match=%t, got %t", test.expMatch, match)
+			}
+		})
+	}
+}
+
+func TestPushRefSpecs(t *testing.T) {
+	tests := []struct {
+		name   string
+		mirror *types.PushMirror
+		exp    []string
+	}{
+		{
+			name:   "all",
+			mirror: &types.PushMirror{},
+			exp:    []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		},
+		{
+			name:   "patterns-forced",
+			mirror: &types.PushMirror{BranchPatterns: []string{"main"}, TagPatterns: []string{"v*"}, Force: true},
+			exp:    []string{"+refs/heads/main:refs/heads/main", "+refs/tags/v*:refs/tags/v*"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if refSpecs := pushRefSpecs(test.mirror); !slices.Equal(refSpecs, test.exp) {
+				t.Errorf("expected %v, got %v", test.exp, refSpecs)
+			}
+		})
+	}
+}
+
+func TestPushMirrorPrunes(t *testing.T) {
+	tests := []struct {
+		name   string
+		mirror *types.PushMirror
+		exp    bool
+	}{
+		{name: "full", mirror: &types.PushMirror{}, exp: true},
+		{name: "branch-patterns", mirror: &types.PushMirror{BranchPatterns: []string{"main"}}},
+		{name: "tag-patterns", mirror: &types.PushMirror{TagPatterns: []string{"v*"}}},
+		{name: "patterns-forced", mirror: &types.PushMirror{BranchPatterns: []string{"main"}, Force: true}, exp: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := pushMirrorPrunes(test.mirror); got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
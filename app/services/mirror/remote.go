@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/harness/gitness/types/check"
+)
+
+// CheckRemoteURL validates the host of the remote repository URL of a mirror.
+// Loopback and private network addresses are blocked unless allowed in the configuration.
+// The check is only sanitary to give the user an early error,
+// the resolved addresses are checked again before every synchronization.
+func (s *Service) CheckRemoteURL(remoteURL string) error {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return check.NewValidationErrorf("Invalid remote URL: %s", err)
+	}
+
+	host := u.Hostname()
+
+	if host == "localhost" && !s.config.Mirror.AllowLoopback {
+		return check.NewValidationError("localhost is not allowed.")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return s.checkRemoteIP(ip)
+	}
+
+	return nil
+}
+
+// checkRemoteHost resolves the host of the remote repository URL and checks all its addresses.
+// Unlike for webhooks the addresses can't be checked when the connection is established,
+// because git connects to the remote repository by itself.
+func (s *Service) checkRemoteHost(ctx context.Context, remoteURL *url.URL) error {
+	if s.config.Mirror.AllowLoopback && s.config.Mirror.AllowPrivateNetwork {
+		return nil
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", remoteURL.Hostname())
+	if err != nil {
+		return fmt.Errorf("failed to resolve host of the remote repository: %w", err)
+	}
+
+	for _, ip := range ips {
+		if err := s.checkRemoteIP(ip); err != nil {
+			return fmt.Errorf("host of the remote repository %q isn't allowed: %w", remoteURL.Hostname(), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) checkRemoteIP(ip net.IP) error {
+	if !s.config.Mirror.AllowLoopback && ip.IsLoopback() {
+		return check.NewValidationError("Loopback IP addresses are not allowed.")
+	}
+
+	if !s.config.Mirror.AllowPrivateNetwork && ip.IsPrivate() {
+		return check.NewValidationError("Private IP addresses are not allowed.")
+	}
+
+	return nil
+}
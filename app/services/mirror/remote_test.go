@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestCheckRemoteURL(t *testing.T) {
+	tests := []struct {
+		name                string
+		remoteURL           string
+		allowLoopback       bool
+		allowPrivateNetwork bool
+		expErr              bool
+	}{
+		{name: "public-host", remoteURL: "https://github.com/harness/gitness.git"},
+		{name: "public-ip", remoteURL: "https://8.8.8.8/repo.git"},
+		{name: "localhost", remoteURL: "http://localhost:3000/repo.git", expErr: true},
+		{name: "loopback", remoteURL: "http://127.0.0.1/repo.git", expErr: true},
+		{name: "loopback-ipv6", remoteURL: "http://[::1]/repo.git", expErr: true},
+		{name: "loopback-allowed", remoteURL: "http://127.0.0.1/repo.git", allowLoopback: true},
+		{name: "private", remoteURL: "https://10.0.0.1/repo.git", expErr: true},
+		{name: "private-allowed", remoteURL: "https://10.0.0.1/repo.git", allowPrivateNetwork: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &types.Config{}
+			config.Mirror.AllowLoopback = test.allowLoopback
+			config.Mirror.AllowPrivateNetwork = test.allowPrivateNetwork
+
+			s := &Service{config: config}
+
+			err := s.CheckRemoteURL(test.remoteURL)
+			if test.expErr && err == nil {
+				t.Error("expected an error but got none")
+			} else if !test.expErr && err != nil {
+				t.Errorf("got an error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestCheckRemoteHost(t *testing.T) {
+	s := &Service{config: &types.Config{}}
+
+	// the host name is resolved to the loopback address.
+	remoteURL, err := url.Parse("http://localhost:3000/repo.git")
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err.Error())
+	}
+
+	if err := s.checkRemoteHost(context.Background(), remoteURL); err == nil {
+		t.Error("expected an error but got none")
+	}
+}
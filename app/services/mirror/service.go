@@ -0,0 +1,247 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePullScheduler = "gitness:mirror:pull-scheduler"
+	jobTypePullSync      = "gitness:mirror:pull-sync"
+
+	jobMaxDurationPullScheduler = time.Minute
+)
+
+var ErrSyncInProgress = errors.New("mirror synchronization is already in progress")
+
+// Service synchronizes pull mirrors from their remote repositories
+// and pushes repositories to their push mirrors.
+//
+// A recurring scheduler job looks for the pull mirrors whose synchronization is due
+// and starts a separate synchronization job for each of them. Push mirrors are pushed
+// after every update of the repository and periodically by another recurring job.
+type Service struct {
+	config                   *types.Config
+	scheduler                *job.Scheduler
+	executor                 *job.Executor
+	pullMirrorStore          store.PullMirrorStore
+	pushMirrorStore          store.PushMirrorStore
+	pushMirrorExecutionStore store.PushMirrorExecutionStore
+	repoStore                store.RepoStore
+	repoFinder               refcache.RepoFinder
+	secretStore              store.SecretStore
+	encrypter                encrypt.Encrypter
+	git                      git.Interface
+	urlProvider              url.Provider
+	gitReporter              *gitevents.Reporter
+	sseStreamer              sse.Streamer
+}
+
+func NewService(
+	config *types.Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
+	repoStore store.RepoStore,
+	repoFinder refcache.RepoFinder,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	git git.Interface,
+	urlProvider url.Provider,
+	gitReporter *gitevents.Reporter,
+	sseStreamer sse.Streamer,
+) *Service {
+	return &Service{
+		config:                   config,
+		scheduler:                scheduler,
+		executor:                 executor,
+		pullMirrorStore:          pullMirrorStore,
+		pushMirrorStore:          pushMirrorStore,
+		pushMirrorExecutionStore: pushMirrorExecutionStore,
+		repoStore:                repoStore,
+		repoFinder:               repoFinder,
+		secretStore:              secretStore,
+		encrypter:                encrypter,
+		git:                      git,
+		urlProvider:              urlProvider,
+		gitReporter:              gitReporter,
+		sseStreamer:              sseStreamer,
+	}
+}
+
+// Register registers the mirror job handlers and schedules the recurring mirror scheduler jobs.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypePullScheduler, &pullSchedulerJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for pull mirror scheduler: %w", err)
+	}
+
+	err = s.executor.Register(jobTypePullSync, &pullSyncJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for pull mirror synchronization: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypePullScheduler, jobTypePullScheduler,
+		s.config.Mirror.SchedulerCron, jobMaxDurationPullScheduler)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pull mirror scheduler job: %w", err)
+	}
+
+	err = s.executor.Register(jobTypePushScheduler, &pushSchedulerJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for push mirror scheduler: %w", err)
+	}
+
+	err = s.executor.Register(jobTypePush, &pushJob{service: s})
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for push mirror push: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypePushScheduler, jobTypePushScheduler,
+		s.config.Mirror.PushSchedulerCron, jobMaxDurationPushScheduler)
+	if err != nil {
+		return fmt.Errorf("failed to schedule push mirror scheduler job: %w", err)
+	}
+
+	return nil
+}
+
+// DefaultCron returns the synchronization schedule used for mirrors that don't define their own.
+func (s *Service) DefaultCron() string {
+	return s.config.Mirror.DefaultCron
+}
+
+// NextSync returns the time (in milliseconds) of the next synchronization after now.
+func NextSync(cron string, now time.Time) (int64, error) {
+	exp, err := cronexpr.Parse(cron)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cron expression %q: %w", cron, err)
+	}
+
+	next := exp.Next(now)
+	if next.IsZero() {
+		return 0, fmt.Errorf("cron expression %q doesn't define any future time", cron)
+	}
+
+	return next.UnixMilli(), nil
+}
+
+// Sync starts the synchronization of the pull mirror.
+// It returns ErrSyncInProgress if a synchronization of the mirror is already queued or running.
+func (s *Service) Sync(ctx context.Context, mirror *types.PullMirror) (*types.PullMirror, error) {
+	now := time.Now()
+
+	nextSync, err := NextSync(mirror.Cron, now)
+	if err != nil {
+		return nil, err
+	}
+
+	staleBefore := now.Add(-s.config.Mirror.MaxDuration).UnixMilli()
+
+	mirror, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		if mirror.LastSyncStatus.IsInProgress() && mirror.Updated >= staleBefore {
+			return ErrSyncInProgress
+		}
+
+		mirror.LastSyncStatus = enum.MirrorSyncStatusQueued
+		mirror.NextSync = nextSync
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark pull mirror as queued: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("%s-%d-%d", jobTypePullSync, mirror.RepoID, now.UnixNano()),
+		Type:       jobTypePullSync,
+		MaxRetries: 0,
+		Timeout:    s.config.Mirror.MaxDuration,
+		Data:       strconv.FormatInt(mirror.RepoID, 10),
+	})
+	if err != nil {
+		s.markFailed(ctx, mirror, fmt.Sprintf("failed to start synchronization: %s", err.Error()))
+		return nil, fmt.Errorf("failed to run pull mirror synchronization job: %w", err)
+	}
+
+	return mirror, nil
+}
+
+func (s *Service) markFailed(ctx context.Context, mirror *types.PullMirror, msg string) {
+	now := time.Now().UnixMilli()
+
+	_, err := s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		mirror.LastSyncStatus = enum.MirrorSyncStatusFailed
+		mirror.LastSyncFinished = now
+		mirror.LastSyncError = msg
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", mirror.RepoID).
+			Msg("failed to mark pull mirror synchronization as failed")
+	}
+}
+
+type pullSchedulerJob struct {
+	service *Service
+}
+
+// Handle starts the synchronization of all pull mirrors whose synchronization is due.
+func (j *pullSchedulerJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+	staleBefore := now.Add(-j.service.config.Mirror.MaxDuration).UnixMilli()
+
+	mirrors, err := j.service.pullMirrorStore.ListDue(ctx, now.UnixMilli(), staleBefore,
+		j.service.config.Mirror.MaxSyncsPerRun)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull mirrors due for synchronization: %w", err)
+	}
+
+	var started int
+	for _, mirror := range mirrors {
+		if _, err := j.service.Sync(ctx, mirror); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", mirror.RepoID).
+				Msg("failed to start pull mirror synchronization")
+			continue
+		}
+
+		started++
+	}
+
+	return fmt.Sprintf("started %d pull mirror synchronizations", started), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	pushMirrorExecutionStore store.PushMirrorExecutionStore,
+	repoStore store.RepoStore,
+	repoFinder refcache.RepoFinder,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	git git.Interface,
+	urlProvider url.Provider,
+	gitReporter *gitevents.Reporter,
+	sseStreamer sse.Streamer,
+) *Service {
+	return NewService(
+		config,
+		scheduler,
+		executor,
+		pullMirrorStore,
+		pushMirrorStore,
+		pushMirrorExecutionStore,
+		repoStore,
+		repoFinder,
+		secretStore,
+		encrypter,
+		git,
+		urlProvider,
+		gitReporter,
+		sseStreamer,
+	)
+}
@@ -64,7 +64,7 @@ func (s *Service) processPullReqBranchUpdatedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.BranchUpdatedPayload],
 ) (*PullReqBranchUpdatedPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
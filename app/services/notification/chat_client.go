@@ -0,0 +1,513 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	webhooksservice "github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	chatRequestTimeout = 10 * time.Second
+	chatMaxTextLength  = 1000
+
+	// chatSentTTL is how long the sent messages are remembered to skip the duplicates of an event.
+	// It has to be longer than the time it takes to retry a failed event.
+	chatSentTTL = time.Hour
+)
+
+// ChatEvent identifies the type of a chat notification. It's sent to generic chat webhooks.
+type ChatEvent string
+
+const (
+	ChatEventCommentPRAuthor      ChatEvent = "comment_pr_author"
+	ChatEventCommentMentions      ChatEvent = "comment_mentions"
+	ChatEventCommentParticipants  ChatEvent = "comment_participants"
+	ChatEventReviewerAdded        ChatEvent = "reviewer_added"
+	ChatEventPullReqBranchUpdated ChatEvent = "pullreq_branch_updated"
+	ChatEventReviewSubmitted      ChatEvent = "review_submitted"
+	ChatEventPullReqStateChanged  ChatEvent = "pullreq_state_changed"
+)
+
+// ChatClient sends notifications to chat webhooks (Slack, Microsoft Teams or generic JSON webhooks)
+// configured on the parent space of the repository and on its ancestor spaces.
+// Every channel gets at most one message per event, even if the event notifies several groups of recipients
+// (e.g. the mentioned users and the participants of a comment thread) or if the event is retried.
+type ChatClient struct {
+	webhooks       *ChatWebhooks
+	principalStore store.PrincipalStore
+	httpClient     *http.Client
+
+	sentMx    sync.Mutex
+	sent      map[chatSentKey]time.Time
+	nextPrune time.Time
+}
+
+// chatSentKey identifies a message sent to a channel, the channel is identified by the webhook URL.
+type chatSentKey struct {
+	eventID    string
+	webhookURL string
+}
+
+func NewChatClient(webhooks *ChatWebhooks, principalStore store.PrincipalStore) *ChatClient {
+	// copy the client as it can be the shared default client
+	httpClient := *webhooksservice.NewHTTPClient(webhooks.allowLoopback, webhooks.allowPrivateNetwork, false)
+	httpClient.Timeout = chatRequestTimeout
+
+	return &ChatClient{
+		webhooks:       webhooks,
+		principalStore: principalStore,
+		httpClient:     &httpClient,
+		sent:           make(map[chatSentKey]time.Time),
+	}
+}
+
+var _ Client = (*ChatClient)(nil)
+
+// chatMessage is a provider independent chat notification.
+type chatMessage struct {
+	Event      ChatEvent
+	Base       *BasePullReqPayload
+	Text       string
+	Quote      string
+	Recipients []*types.PrincipalInfo
+}
+
+func (c *ChatClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, chatMessage{
+		Event:      ChatEventCommentPRAuthor,
+		Base:       payload.Base,
+		Text:       fmt.Sprintf("%s commented on pull request %s", payload.Commenter.DisplayName, prTitle(payload.Base)),
+		Quote:      payload.Text,
+		Recipients: recipients,
+	})
+}
+
+func (c *ChatClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, chatMessage{
+		Event: ChatEventCommentMentions,
+		Base:  payload.Base,
+		Text: fmt.Sprintf("%s mentioned users in a comment on pull request %s",
+			payload.Commenter.DisplayName, prTitle(payload.Base)),
+		Quote:      payload.Text,
+		Recipients: recipients,
+	})
+}
+
+func (c *ChatClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, chatMessage{
+		Event: ChatEventCommentParticipants,
+		Base:  payload.Base,
+		Text: fmt.Sprintf("%s replied to a comment thread on pull request %s",
+			payload.Commenter.DisplayName, prTitle(payload.Base)),
+		Quote:      payload.Text,
+		Recipients: recipients,
+	})
+}
+
+func (c *ChatClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.send(ctx, chatMessage{
+		Event: ChatEventReviewerAdded,
+		Base:  payload.Base,
+		Text: fmt.Sprintf("%s was added as a reviewer to pull request %s",
+			payload.Reviewer.DisplayName, prTitle(payload.Base)),
+		Recipients: recipients,
+	})
+}
+
+func (c *ChatClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	newSHA := payload.NewSHA
+	if len(newSHA) > 8 {
+		newSHA = newSHA[:8]
+	}
+
+	return c.send(ctx, chatMessage{
+		Event: ChatEventPullReqBranchUpdated,
+		Base:  payload.Base,
+		Text: fmt.Sprintf("%s pushed new commits to pull request %s (now at %s)",
+			payload.Committer.DisplayName, prTitle(payload.Base), newSHA),
+		Recipients: recipients,
+	})
+}
+
+func (c *ChatClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	var action string
+	switch payload.Decision {
+	case enum.PullReqReviewDecisionApproved:
+		action = "approved"
+	case enum.PullReqReviewDecisionChangeReq:
+		action = "requested changes on"
+	default:
+		action = "reviewed"
+	}
+
+	return c.send(ctx, chatMessage{
+		Event:      ChatEventReviewSubmitted,
+		Base:       payload.Base,
+		Text:       fmt.Sprintf("%s %s pull request %s", payload.Reviewer.DisplayName, action, prTitle(payload.Base)),
+		Recipients: recipients,
+	})
+}
+
+func (c *ChatClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.send(ctx, chatMessage{
+		Event: ChatEventPullReqStateChanged,
+		Base:  payload.Base,
+		Text: fmt.Sprintf("%s %s pull request %s",
+			payload.ChangedBy.DisplayName, payload.State, prTitle(payload.Base)),
+		Recipients: recipients,
+	})
+}
+
+// send posts the message to all enabled chat webhooks of the repository.
+// A failure of one webhook doesn't prevent sending the message to the other webhooks.
+func (c *ChatClient) send(ctx context.Context, msg chatMessage) error {
+	webhooks, err := c.webhooks.ListEnabledForRepo(ctx, msg.Base.Repo)
+	if err != nil {
+		return fmt.Errorf("failed to list chat webhooks: %w", err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	handles, err := c.chatHandles(ctx, msg.Recipients)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, webhook := range webhooks {
+		key := chatSentKey{eventID: msg.Base.EventID, webhookURL: webhook.URL}
+		if !c.claimSent(key) {
+			continue
+		}
+
+		body, err := renderChatMessage(webhook.Provider, msg, handles)
+		if err != nil {
+			c.releaseSent(key)
+			errs = append(errs, fmt.Errorf("failed to render message for chat webhook %q: %w", webhook.Identifier, err))
+			continue
+		}
+
+		if err := c.post(ctx, webhook.URL, body); err != nil {
+			c.releaseSent(key)
+			errs = append(errs, fmt.Errorf("failed to post message to chat webhook %q: %w", webhook.Identifier, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// claimSent returns false if a message of the event has already been sent to the channel.
+// Otherwise, it marks the message as sent and returns true. Messages without an event ID are always sent.
+func (c *ChatClient) claimSent(key chatSentKey) bool {
+	if key.eventID == "" {
+		return true
+	}
+
+	c.sentMx.Lock()
+	defer c.sentMx.Unlock()
+
+	now := time.Now()
+
+	if now.After(c.nextPrune) {
+		for k, expires := range c.sent {
+			if now.After(expires) {
+				delete(c.sent, k)
+			}
+		}
+		c.nextPrune = now.Add(chatSentTTL)
+	}
+
+	if expires, ok := c.sent[key]; ok && now.Before(expires) {
+		return false
+	}
+
+	c.sent[key] = now.Add(chatSentTTL)
+
+	return true
+}
+
+// releaseSent forgets the message, so it's sent again by the next notification of the event.
+func (c *ChatClient) releaseSent(key chatSentKey) {
+	c.sentMx.Lock()
+	defer c.sentMx.Unlock()
+
+	delete(c.sent, key)
+}
+
+// chatHandles returns the chat handles of the recipients, indexed by principal ID.
+func (c *ChatClient) chatHandles(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+) (map[int64]types.ChatHandles, error) {
+	handles := make(map[int64]types.ChatHandles, len(recipients))
+
+	for _, recipient := range recipients {
+		if _, ok := handles[recipient.ID]; ok {
+			continue
+		}
+
+		user, err := c.principalStore.FindUser(ctx, recipient.ID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// not a user (e.g. a service account), it can only be mentioned by name.
+			handles[recipient.ID] = types.ChatHandles{}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user %d: %w", recipient.ID, err)
+		}
+
+		handles[recipient.ID] = user.ChatHandles
+	}
+
+	return handles, nil
+}
+
+func (c *ChatClient) post(ctx context.Context, webhookURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func renderChatMessage(
+	provider enum.ChatProvider,
+	msg chatMessage,
+	handles map[int64]types.ChatHandles,
+) ([]byte, error) {
+	switch provider {
+	case enum.ChatProviderSlack:
+		return json.Marshal(renderSlackMessage(msg, handles))
+	case enum.ChatProviderTeams:
+		return json.Marshal(renderTeamsMessage(msg, handles))
+	case enum.ChatProviderGeneric:
+		return json.Marshal(renderGenericMessage(msg, handles))
+	default:
+		return nil, fmt.Errorf("unsupported chat provider %q", provider)
+	}
+}
+
+// renderSlackMessage renders the message for a Slack incoming webhook.
+// Recipients with a Slack member ID are mentioned, others are referred to by name.
+func renderSlackMessage(msg chatMessage, handles map[int64]types.ChatHandles) any {
+	var text strings.Builder
+
+	text.WriteString(slackEscape(msg.Text))
+	text.WriteString(" <" + msg.Base.PullReqURL + "|View pull request>")
+
+	if msg.Quote != "" {
+		text.WriteString("\n>" + strings.ReplaceAll(slackEscape(truncate(msg.Quote)), "\n", "\n>"))
+	}
+
+	if mentions := chatMentions(msg.Recipients, handles, enum.ChatProviderSlack, func(handle string) string {
+		return "<@" + handle + ">"
+	}); mentions != "" {
+		text.WriteString("\ncc " + mentions)
+	}
+
+	return struct {
+		Text string `json:"text"`
+	}{
+		Text: text.String(),
+	}
+}
+
+// renderTeamsMessage renders the message as a legacy actionable message card
+// accepted by the Microsoft Teams incoming webhook connector.
+func renderTeamsMessage(msg chatMessage, handles map[int64]types.ChatHandles) any {
+	type target struct {
+		OS  string `json:"os"`
+		URI string `json:"uri"`
+	}
+	type action struct {
+		Type    string   `json:"@type"`
+		Name    string   `json:"name"`
+		Targets []target `json:"targets"`
+	}
+
+	var text strings.Builder
+
+	if msg.Quote != "" {
+		text.WriteString("> " + strings.ReplaceAll(truncate(msg.Quote), "\n", "\n> "))
+	}
+
+	if mentions := chatMentions(msg.Recipients, handles, enum.ChatProviderTeams, func(handle string) string {
+		return handle
+	}); mentions != "" {
+		if text.Len() > 0 {
+			text.WriteString("\n\n")
+		}
+		text.WriteString("cc " + mentions)
+	}
+
+	return struct {
+		Type            string   `json:"@type"`
+		Context         string   `json:"@context"`
+		Summary         string   `json:"summary"`
+		Title           string   `json:"title"`
+		Text            string   `json:"text,omitempty"`
+		PotentialAction []action `json:"potentialAction"`
+	}{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: msg.Text,
+		Title:   msg.Text,
+		Text:    text.String(),
+		PotentialAction: []action{{
+			Type:    "OpenUri",
+			Name:    "View pull request",
+			Targets: []target{{OS: "default", URI: msg.Base.PullReqURL}},
+		}},
+	}
+}
+
+// renderGenericMessage renders the message as a JSON document with all details of the notification.
+func renderGenericMessage(msg chatMessage, handles map[int64]types.ChatHandles) any {
+	type recipient struct {
+		ID          int64  `json:"id"`
+		UID         string `json:"uid"`
+		DisplayName string `json:"display_name"`
+		Email       string `json:"email"`
+		Handle      string `json:"handle,omitempty"`
+	}
+
+	recipients := make([]recipient, len(msg.Recipients))
+	for i, r := range msg.Recipients {
+		recipients[i] = recipient{
+			ID:          r.ID,
+			UID:         r.UID,
+			DisplayName: r.DisplayName,
+			Email:       r.Email,
+			Handle:      handles[r.ID].Generic,
+		}
+	}
+
+	return struct {
+		Event         ChatEvent   `json:"event"`
+		Text          string      `json:"text"`
+		Quote         string      `json:"quote,omitempty"`
+		RepoPath      string      `json:"repo_path"`
+		PullReqNumber int64       `json:"pullreq_number"`
+		PullReqTitle  string      `json:"pullreq_title"`
+		PullReqURL    string      `json:"pullreq_url"`
+		Recipients    []recipient `json:"recipients"`
+	}{
+		Event:         msg.Event,
+		Text:          msg.Text,
+		Quote:         msg.Quote,
+		RepoPath:      msg.Base.Repo.Path,
+		PullReqNumber: msg.Base.PullReq.Number,
+		PullReqTitle:  msg.Base.PullReq.Title,
+		PullReqURL:    msg.Base.PullReqURL,
+		Recipients:    recipients,
+	}
+}
+
+// chatMentions returns the mentions of the recipients, separated by commas.
+// Recipients without a handle for the provider are referred to by their display name.
+func chatMentions(
+	recipients []*types.PrincipalInfo,
+	handles map[int64]types.ChatHandles,
+	provider enum.ChatProvider,
+	mention func(handle string) string,
+) string {
+	mentions := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if handle := handles[recipient.ID].Get(provider); handle != "" {
+			mentions = append(mentions, mention(handle))
+		} else {
+			mentions = append(mentions, recipient.DisplayName)
+		}
+	}
+
+	return strings.Join(mentions, ", ")
+}
+
+func prTitle(base *BasePullReqPayload) string {
+	return fmt.Sprintf("#%d %q in %s", base.PullReq.Number, base.PullReq.Title, base.Repo.Path)
+}
+
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func truncate(s string) string {
+	if len(s) <= chatMaxTextLength {
+		return s
+	}
+
+	return strings.ToValidUTF8(s[:chatMaxTextLength], "") + "…"
+}
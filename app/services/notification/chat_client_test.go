@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatClientClaimSent(t *testing.T) {
+	c := &ChatClient{sent: make(map[chatSentKey]time.Time)}
+
+	slack := chatSentKey{eventID: "1-0", webhookURL: "https://hooks.slack.com/services/1"}
+	teams := chatSentKey{eventID: "1-0", webhookURL: "https://example.webhook.office.com/1"}
+
+	// one message per event per channel, even if the event notifies several groups of recipients.
+	require.True(t, c.claimSent(slack))
+	require.True(t, c.claimSent(teams))
+	require.False(t, c.claimSent(slack))
+	require.False(t, c.claimSent(teams))
+
+	// another event is sent to the same channel.
+	require.True(t, c.claimSent(chatSentKey{eventID: "2-0", webhookURL: slack.webhookURL}))
+
+	// a message that failed to be sent is sent again by the next notification or the retry of the event.
+	c.releaseSent(slack)
+	require.True(t, c.claimSent(slack))
+
+	// messages without an event ID can't be deduplicated.
+	noEvent := chatSentKey{webhookURL: slack.webhookURL}
+	require.True(t, c.claimSent(noEvent))
+	require.True(t, c.claimSent(noEvent))
+
+	// the sent messages are forgotten once they expire.
+	c.sent[slack] = time.Now().Add(-time.Second)
+	require.True(t, c.claimSent(slack))
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/settings"
+	webhooksservice "github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxChatWebhooks is the maximum number of chat webhooks of a single space.
+const maxChatWebhooks = 10
+
+// ChatWebhooks manages chat webhooks of spaces. The webhooks are stored in the space settings.
+type ChatWebhooks struct {
+	settings            *settings.Service
+	encrypter           encrypt.Encrypter
+	spaceStore          store.SpaceStore
+	allowLoopback       bool
+	allowPrivateNetwork bool
+}
+
+func NewChatWebhooks(
+	settings *settings.Service,
+	encrypter encrypt.Encrypter,
+	spaceStore store.SpaceStore,
+	allowLoopback bool,
+	allowPrivateNetwork bool,
+) *ChatWebhooks {
+	return &ChatWebhooks{
+		settings:            settings,
+		encrypter:           encrypter,
+		spaceStore:          spaceStore,
+		allowLoopback:       allowLoopback,
+		allowPrivateNetwork: allowPrivateNetwork,
+	}
+}
+
+// storedChatWebhook is the chat webhook as it's stored in the space settings.
+type storedChatWebhook struct {
+	Identifier string            `json:"identifier"`
+	Provider   enum.ChatProvider `json:"provider"`
+	URL        []byte            `json:"url"` // encrypted
+	Enabled    bool              `json:"enabled"`
+}
+
+// List returns the chat webhooks of the space. The URLs of the webhooks are not returned.
+func (w *ChatWebhooks) List(ctx context.Context, spaceID int64) ([]types.ChatWebhook, error) {
+	stored, err := w.load(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]types.ChatWebhook, len(stored))
+	for i := range stored {
+		webhooks[i] = types.ChatWebhook{
+			Identifier: stored[i].Identifier,
+			Provider:   stored[i].Provider,
+			Enabled:    stored[i].Enabled,
+		}
+	}
+
+	return webhooks, nil
+}
+
+// Update replaces the chat webhooks of the space. If the URL of a webhook is not provided,
+// the URL of the existing webhook with the same identifier is kept.
+func (w *ChatWebhooks) Update(
+	ctx context.Context,
+	spaceID int64,
+	webhooks []types.ChatWebhook,
+) ([]types.ChatWebhook, error) {
+	if len(webhooks) > maxChatWebhooks {
+		return nil, errors.InvalidArgument("A space can't have more than %d chat webhooks.", maxChatWebhooks)
+	}
+
+	existing, err := w.load(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingURLs := make(map[string][]byte, len(existing))
+	for _, webhook := range existing {
+		existingURLs[webhook.Identifier] = webhook.URL
+	}
+
+	stored := make([]storedChatWebhook, len(webhooks))
+	seen := make(map[string]struct{}, len(webhooks))
+
+	for i := range webhooks {
+		webhook := &webhooks[i]
+
+		if err := w.sanitizeChatWebhook(webhook); err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[webhook.Identifier]; ok {
+			return nil, errors.InvalidArgument("Chat webhook identifier %q is not unique.", webhook.Identifier)
+		}
+		seen[webhook.Identifier] = struct{}{}
+
+		encryptedURL, ok := existingURLs[webhook.Identifier]
+		if webhook.URL != "" {
+			encryptedURL, err = w.encrypter.Encrypt(webhook.URL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt chat webhook url: %w", err)
+			}
+		} else if !ok {
+			return nil, errors.InvalidArgument("URL of chat webhook %q is required.", webhook.Identifier)
+		}
+
+		stored[i] = storedChatWebhook{
+			Identifier: webhook.Identifier,
+			Provider:   webhook.Provider,
+			URL:        encryptedURL,
+			Enabled:    webhook.Enabled,
+		}
+
+		webhook.URL = ""
+	}
+
+	if err := w.settings.SpaceSet(ctx, spaceID, settings.KeyChatWebhooks, stored); err != nil {
+		return nil, fmt.Errorf("failed to store chat webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// ListEnabledForRepo returns the enabled chat webhooks, with decrypted URLs,
+// of the parent space of a repository and of all its ancestor spaces.
+func (w *ChatWebhooks) ListEnabledForRepo(ctx context.Context, repo *types.Repository) ([]types.ChatWebhook, error) {
+	spaceIDs, err := w.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	var webhooks []types.ChatWebhook
+
+	for _, spaceID := range spaceIDs {
+		stored, err := w.load(ctx, spaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, webhook := range stored {
+			if !webhook.Enabled {
+				continue
+			}
+
+			webhookURL, err := w.encrypter.Decrypt(webhook.URL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt url of chat webhook %q: %w", webhook.Identifier, err)
+			}
+
+			webhooks = append(webhooks, types.ChatWebhook{
+				Identifier: webhook.Identifier,
+				Provider:   webhook.Provider,
+				URL:        webhookURL,
+				Enabled:    true,
+			})
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (w *ChatWebhooks) load(ctx context.Context, spaceID int64) ([]storedChatWebhook, error) {
+	var stored []storedChatWebhook
+
+	if _, err := w.settings.SpaceGet(ctx, spaceID, settings.KeyChatWebhooks, &stored); err != nil {
+		return nil, fmt.Errorf("failed to read chat webhooks of space %d: %w", spaceID, err)
+	}
+
+	return stored, nil
+}
+
+func (w *ChatWebhooks) sanitizeChatWebhook(webhook *types.ChatWebhook) error {
+	if err := check.Identifier(webhook.Identifier); err != nil {
+		return err
+	}
+
+	var ok bool
+	webhook.Provider, ok = webhook.Provider.Sanitize()
+	if !ok || webhook.Provider == "" {
+		return errors.InvalidArgument("Chat webhook provider must be one of: slack, teams, generic.")
+	}
+
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	if webhook.URL == "" {
+		return nil
+	}
+
+	// the same restrictions as for the repository webhooks apply, the http client enforces them on delivery.
+	return webhooksservice.CheckURL(webhook.URL, w.allowLoopback, w.allowPrivateNetwork, false)
+}
@@ -32,6 +32,8 @@ type CommentPayload struct {
 	Base      *BasePullReqPayload
 	Commenter *types.PrincipalInfo
 	Text      string
+	// ThreadID is the ID of the first comment of the comment thread.
+	ThreadID int64
 }
 
 func (s *Service) notifyCommentCreated(
@@ -101,7 +103,7 @@ func (s *Service) processCommentCreatedEvent(
 	author *types.PrincipalInfo,
 	err error,
 ) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -120,10 +122,16 @@ func (s *Service) processCommentCreatedEvent(
 		return nil, nil, nil, nil, fmt.Errorf("failed to fetch commenter from principalInfoView: %w", err)
 	}
 
+	threadID := activity.ID
+	if activity.ParentID != nil {
+		threadID = *activity.ParentID
+	}
+
 	payload = &CommentPayload{
 		Base:      base,
 		Commenter: commenter,
 		Text:      activity.Text,
+		ThreadID:  threadID,
 	}
 
 	seen := make(map[int64]bool)
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TemplateDigest = "digest.html"
+
+	jobTypeDigestHourly  = "gitness:notification:digest:hourly"
+	jobTypeDigestDaily   = "gitness:notification:digest:daily"
+	jobMaxDurationDigest = 10 * time.Minute
+
+	// digestMaxItems is the maximum number of notifications in a single digest email.
+	digestMaxItems = 200
+)
+
+// Digest sends the notifications of users that prefer an hourly or a daily digest.
+// The digests are sent by recurring jobs.
+type Digest struct {
+	config             Config
+	scheduler          *job.Scheduler
+	executor           *job.Executor
+	digestStore        store.NotificationDigestStore
+	principalInfoCache store.PrincipalInfoCache
+	mailer             mailer.Mailer
+}
+
+func NewDigest(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	digestStore store.NotificationDigestStore,
+	principalInfoCache store.PrincipalInfoCache,
+	mailer mailer.Mailer,
+) *Digest {
+	return &Digest{
+		config:             config,
+		scheduler:          scheduler,
+		executor:           executor,
+		digestStore:        digestStore,
+		principalInfoCache: principalInfoCache,
+		mailer:             mailer,
+	}
+}
+
+// Register registers the digest job handlers and schedules the recurring digest jobs.
+func (d *Digest) Register(ctx context.Context) error {
+	jobs := []struct {
+		jobType  string
+		cron     string
+		delivery enum.NotificationDelivery
+	}{
+		{jobType: jobTypeDigestHourly, cron: d.config.DigestHourlyCron, delivery: enum.NotificationDeliveryHourly},
+		{jobType: jobTypeDigestDaily, cron: d.config.DigestDailyCron, delivery: enum.NotificationDeliveryDaily},
+	}
+
+	for _, j := range jobs {
+		err := d.executor.Register(j.jobType, &digestJob{digest: d, delivery: j.delivery})
+		if err != nil {
+			return fmt.Errorf("failed to register job handler for %s notification digest: %w", j.delivery, err)
+		}
+
+		err = d.scheduler.AddRecurring(ctx, j.jobType, j.jobType, j.cron, jobMaxDurationDigest)
+		if err != nil {
+			return fmt.Errorf("failed to schedule %s notification digest job: %w", j.delivery, err)
+		}
+	}
+
+	return nil
+}
+
+type digestJob struct {
+	digest   *Digest
+	delivery enum.NotificationDelivery
+}
+
+// Handle sends a digest email to every user with pending notifications.
+func (j *digestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	principalIDs, err := j.digest.digestStore.ListPrincipalIDs(ctx, j.delivery)
+	if err != nil {
+		return "", fmt.Errorf("failed to list principals with pending notifications: %w", err)
+	}
+
+	var sent int
+	for _, principalID := range principalIDs {
+		n, err := j.digest.send(ctx, principalID, j.delivery)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("principal_id", principalID).
+				Msgf("failed to send %s notification digest", j.delivery)
+			continue
+		}
+
+		sent += n
+	}
+
+	return fmt.Sprintf("sent %d %s notification digests", sent, j.delivery), nil
+}
+
+type digestGroup struct {
+	Subject string
+	URL     string
+	Items   []string
+}
+
+type digestPayload struct {
+	Delivery enum.NotificationDelivery
+	Groups   []*digestGroup
+}
+
+// send sends all pending notifications of the principal and returns the number of emails sent.
+func (d *Digest) send(ctx context.Context, principalID int64, delivery enum.NotificationDelivery) (int, error) {
+	principal, err := d.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	var sent int
+	for {
+		items, err := d.digestStore.List(ctx, principalID, delivery, digestMaxItems)
+		if err != nil {
+			return sent, fmt.Errorf("failed to list pending notifications: %w", err)
+		}
+
+		if len(items) == 0 {
+			return sent, nil
+		}
+
+		email, err := generateDigestEmail(principal, delivery, items)
+		if err != nil {
+			return sent, err
+		}
+
+		if err = d.mailer.Send(ctx, *email); err != nil {
+			return sent, fmt.Errorf("failed to send digest email: %w", err)
+		}
+
+		sent++
+
+		err = d.digestStore.DeleteUpTo(ctx, principalID, delivery, items[len(items)-1].ID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to delete sent notifications: %w", err)
+		}
+
+		if len(items) < digestMaxItems {
+			return sent, nil
+		}
+	}
+}
+
+func generateDigestEmail(
+	principal *types.PrincipalInfo,
+	delivery enum.NotificationDelivery,
+	items []*types.NotificationDigestItem,
+) (*mailer.Payload, error) {
+	payload := digestPayload{Delivery: delivery}
+
+	// notifications are grouped by pull request, groups are ordered by their first notification.
+	groups := make(map[string]*digestGroup)
+	for _, item := range items {
+		group, ok := groups[item.URL]
+		if !ok {
+			group = &digestGroup{Subject: item.Subject, URL: item.URL}
+			groups[item.URL] = group
+			payload.Groups = append(payload.Groups, group)
+		}
+
+		group.Items = append(group.Items, item.Text)
+	}
+
+	body, err := GetHTMLBody(TemplateDigest, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mailer.Payload{
+		ToRecipients: []string{principal.Email},
+		Subject:      fmt.Sprintf("Your %s notification digest (%d)", delivery, len(items)),
+		Body:         string(body),
+	}, nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestRegister(t *testing.T) {
+	jobStore := &fakeJobStore{jobs: map[string]*job.Job{}}
+	executor := job.NewExecutor(jobStore, nil)
+	scheduler, err := job.NewScheduler(jobStore, executor, nil, nil, "test", 1, time.Hour)
+	require.NoError(t, err)
+
+	config := Config{DigestHourlyCron: "5 * * * *", DigestDailyCron: "0 8 * * *"}
+	digest := NewDigest(config, scheduler, executor, newFakeDigestStore(), nil, nil)
+
+	require.NoError(t, digest.Register(context.Background()))
+
+	require.Len(t, jobStore.jobs, 2)
+	for jobType, cron := range map[string]string{
+		jobTypeDigestHourly: config.DigestHourlyCron,
+		jobTypeDigestDaily:  config.DigestDailyCron,
+	} {
+		j := jobStore.jobs[jobType]
+		require.NotNil(t, j, jobType)
+		require.Equal(t, jobType, j.Type)
+		require.True(t, j.IsRecurring)
+		require.Equal(t, cron, j.RecurringCron)
+	}
+
+	// the job handlers are already registered
+	require.Error(t, executor.Register(jobTypeDigestHourly, &digestJob{}))
+	require.Error(t, executor.Register(jobTypeDigestDaily, &digestJob{}))
+}
+
+func TestDigestRegisterInvalidCron(t *testing.T) {
+	jobStore := &fakeJobStore{jobs: map[string]*job.Job{}}
+	executor := job.NewExecutor(jobStore, nil)
+	scheduler, err := job.NewScheduler(jobStore, executor, nil, nil, "test", 1, time.Hour)
+	require.NoError(t, err)
+
+	config := Config{DigestHourlyCron: "5 * * * *", DigestDailyCron: "invalid"}
+	digest := NewDigest(config, scheduler, executor, newFakeDigestStore(), nil, nil)
+
+	require.Error(t, digest.Register(context.Background()))
+}
+
+func TestDigestJobHandle(t *testing.T) {
+	ctx := context.Background()
+
+	digestStore := newFakeDigestStore()
+	principals := fakePrincipalInfoCache{
+		1: {ID: 1, Email: "one@example.com"},
+		2: {ID: 2, Email: "two@example.com"},
+	}
+	mails := &fakeMailer{}
+
+	digest := NewDigest(Config{}, nil, nil, digestStore, principals, mails)
+
+	// principal 1 has more hourly notifications than fit into a single digest email
+	for i := range digestMaxItems + 1 {
+		require.NoError(t, digestStore.Create(ctx, &types.NotificationDigestItem{
+			PrincipalID: 1,
+			Delivery:    enum.NotificationDeliveryHourly,
+			EventID:     fmt.Sprintf("%d-0", i),
+			Event:       enum.NotificationEventComment,
+			Subject:     fmt.Sprintf("PR #%d", i%2),
+			URL:         fmt.Sprintf("https://example.com/pulls/%d", i%2),
+			Text:        fmt.Sprintf("comment %d", i),
+		}))
+	}
+
+	for eventID, delivery := range map[string]enum.NotificationDelivery{
+		"1-0": enum.NotificationDeliveryHourly,
+		"2-0": enum.NotificationDeliveryDaily,
+	} {
+		require.NoError(t, digestStore.Create(ctx, &types.NotificationDigestItem{
+			PrincipalID: 2,
+			Delivery:    delivery,
+			EventID:     eventID,
+			Event:       enum.NotificationEventReviewRequested,
+			Subject:     "PR #7",
+			URL:         "https://example.com/pulls/7",
+			Text:        "review requested",
+		}))
+	}
+
+	result, err := (&digestJob{digest: digest, delivery: enum.NotificationDeliveryHourly}).Handle(ctx, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "sent 3 hourly notification digests", result)
+
+	require.Len(t, mails.sent, 3)
+
+	var subjects []string
+	for _, mail := range mails.sent {
+		subjects = append(subjects, mail.ToRecipients[0]+": "+mail.Subject)
+	}
+	require.ElementsMatch(t, []string{
+		fmt.Sprintf("one@example.com: Your hourly notification digest (%d)", digestMaxItems),
+		"one@example.com: Your hourly notification digest (1)",
+		"two@example.com: Your hourly notification digest (1)",
+	}, subjects)
+
+	// notifications of the same pull request are grouped
+	for _, mail := range mails.sent {
+		if strings.Contains(mail.Subject, fmt.Sprintf("(%d)", digestMaxItems)) {
+			require.Equal(t, 1, strings.Count(mail.Body, "https://example.com/pulls/0\""))
+			require.Equal(t, 1, strings.Count(mail.Body, "https://example.com/pulls/1\""))
+		}
+	}
+
+	// the hourly notifications are removed, the daily are kept
+	principalIDs, err := digestStore.ListPrincipalIDs(ctx, enum.NotificationDeliveryHourly)
+	require.NoError(t, err)
+	require.Empty(t, principalIDs)
+
+	principalIDs, err = digestStore.ListPrincipalIDs(ctx, enum.NotificationDeliveryDaily)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, principalIDs)
+}
+
+func TestDigestJobHandleMailFailure(t *testing.T) {
+	ctx := context.Background()
+
+	digestStore := newFakeDigestStore()
+	principals := fakePrincipalInfoCache{1: {ID: 1, Email: "one@example.com"}}
+	mails := &fakeMailer{err: fmt.Errorf("smtp unavailable")}
+
+	digest := NewDigest(Config{}, nil, nil, digestStore, principals, mails)
+
+	require.NoError(t, digestStore.Create(ctx, &types.NotificationDigestItem{
+		PrincipalID: 1,
+		Delivery:    enum.NotificationDeliveryDaily,
+		EventID:     "1-0",
+		Event:       enum.NotificationEventComment,
+		URL:         "https://example.com/pulls/1",
+	}))
+
+	result, err := (&digestJob{digest: digest, delivery: enum.NotificationDeliveryDaily}).Handle(ctx, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "sent 0 daily notification digests", result)
+
+	// the notifications are kept for the next digest
+	items, err := digestStore.List(ctx, 1, enum.NotificationDeliveryDaily, digestMaxItems)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+// fakeDigestStore is an in-memory store.NotificationDigestStore.
+type fakeDigestStore struct {
+	items  []*types.NotificationDigestItem
+	lastID int64
+}
+
+var _ store.NotificationDigestStore = (*fakeDigestStore)(nil)
+
+func newFakeDigestStore() *fakeDigestStore {
+	return &fakeDigestStore{}
+}
+
+func (s *fakeDigestStore) Create(_ context.Context, item *types.NotificationDigestItem) error {
+	for _, existing := range s.items {
+		if existing.PrincipalID == item.PrincipalID && existing.EventID == item.EventID &&
+			existing.Event == item.Event {
+			return nil
+		}
+	}
+
+	s.lastID++
+	item.ID = s.lastID
+	s.items = append(s.items, item)
+
+	return nil
+}
+
+func (s *fakeDigestStore) ListPrincipalIDs(
+	_ context.Context,
+	delivery enum.NotificationDelivery,
+) ([]int64, error) {
+	principalIDs := make([]int64, 0)
+	seen := map[int64]struct{}{}
+	for _, item := range s.items {
+		if _, ok := seen[item.PrincipalID]; ok || item.Delivery != delivery {
+			continue
+		}
+		seen[item.PrincipalID] = struct{}{}
+		principalIDs = append(principalIDs, item.PrincipalID)
+	}
+
+	return principalIDs, nil
+}
+
+func (s *fakeDigestStore) List(
+	_ context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+	limit int,
+) ([]*types.NotificationDigestItem, error) {
+	items := make([]*types.NotificationDigestItem, 0)
+	for _, item := range s.items {
+		if len(items) == limit {
+			break
+		}
+		if item.PrincipalID == principalID && item.Delivery == delivery {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+func (s *fakeDigestStore) DeleteUpTo(
+	_ context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+	maxID int64,
+) error {
+	kept := s.items[:0]
+	for _, item := range s.items {
+		if item.PrincipalID == principalID && item.Delivery == delivery && item.ID <= maxID {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	s.items = kept
+
+	return nil
+}
+
+type fakePrincipalInfoCache map[int64]*types.PrincipalInfo
+
+func (c fakePrincipalInfoCache) Stats() (int64, int64) { return 0, 0 }
+
+func (c fakePrincipalInfoCache) Evict(context.Context, int64) {}
+
+func (c fakePrincipalInfoCache) Get(_ context.Context, id int64) (*types.PrincipalInfo, error) {
+	principal, ok := c[id]
+	if !ok {
+		return nil, fmt.Errorf("principal %d not found", id)
+	}
+
+	return principal, nil
+}
+
+func (c fakePrincipalInfoCache) Map(
+	ctx context.Context,
+	ids []int64,
+) (map[int64]*types.PrincipalInfo, error) {
+	m := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		principal, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		m[id] = principal
+	}
+
+	return m, nil
+}
+
+type fakeMailer struct {
+	sent []mailer.Payload
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, payload mailer.Payload) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, payload)
+
+	return nil
+}
+
+// fakeJobStore records the upserted jobs, other methods of job.Store are not used.
+type fakeJobStore struct {
+	job.Store
+	jobs map[string]*job.Job
+}
+
+func (s *fakeJobStore) Upsert(_ context.Context, j *job.Job) error {
+	s.jobs[j.UID] = j
+	return nil
+}
@@ -17,6 +17,7 @@ package notification
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -36,11 +37,13 @@ const (
 
 type MailClient struct {
 	mailer.Mailer
+	replies *ReplyAddresses
 }
 
-func NewMailClient(mailer mailer.Mailer) MailClient {
+func NewMailClient(mailer mailer.Mailer, replies *ReplyAddresses) MailClient {
 	return MailClient{
-		Mailer: mailer,
+		Mailer:  mailer,
+		replies: replies,
 	}
 }
 
@@ -78,7 +81,7 @@ func (m MailClient) SendCommentMentions(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.sendWithReply(ctx, email, recipients, payload.Base, payload.ThreadID)
 }
 func (m MailClient) SendCommentParticipants(
 	ctx context.Context,
@@ -96,7 +99,7 @@ func (m MailClient) SendCommentParticipants(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.sendWithReply(ctx, email, recipients, payload.Base, payload.ThreadID)
 }
 
 func (m MailClient) SendReviewerAdded(
@@ -115,7 +118,8 @@ func (m MailClient) SendReviewerAdded(
 			pullreqevents.ReviewerAddedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	// a reply to the reviewer added notification is posted as a new comment.
+	return m.sendWithReply(ctx, email, recipients, payload.Base, 0)
 }
 
 func (m MailClient) SendPullReqBranchUpdated(
@@ -169,6 +173,43 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+// sendWithReply sends the email. If replying to notification emails is enabled,
+// every recipient gets a separate email with a personal reply address.
+func (m MailClient) sendWithReply(
+	ctx context.Context,
+	email *mailer.Payload,
+	recipients []*types.PrincipalInfo,
+	base *BasePullReqPayload,
+	parentID int64,
+) error {
+	if !m.replies.Enabled() {
+		return m.Mailer.Send(ctx, *email)
+	}
+
+	var errs []error
+	for _, recipient := range recipients {
+		replyTo, err := m.replies.Generate(ctx, ReplyTarget{
+			PrincipalID: recipient.ID,
+			PullReqID:   base.PullReq.ID,
+			ParentID:    parentID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate reply address: %w", err))
+			continue
+		}
+
+		personal := *email
+		personal.ToRecipients = []string{recipient.Email}
+		personal.ReplyTo = replyTo
+
+		if err = m.Mailer.Send(ctx, personal); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
@@ -35,6 +35,8 @@ type Payload struct {
 	Body         string
 	ContentType  string
 	RepoRef      string
+	// ReplyTo is the address replies to the email are sent to. It's optional.
+	ReplyTo string
 }
 
 func ToGoMail(dto Payload) *gomail.Message {
@@ -42,6 +44,9 @@ func ToGoMail(dto Payload) *gomail.Message {
 	mail.SetHeader("To", dto.ToRecipients...)
 	mail.SetHeader("Cc", dto.CCRecipients...)
 	mail.SetHeader("Subject", dto.Subject)
+	if dto.ReplyTo != "" {
+		mail.SetHeader("Reply-To", dto.ReplyTo)
+	}
 	mail.SetBody(mailContentType, dto.Body)
 	return mail
 }
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiClientSendsBestEffortIfPrimaryFails(t *testing.T) {
+	errMail := errors.New("mail server unavailable")
+
+	primary := &countingClient{err: errMail}
+	chat := &countingClient{}
+
+	client := NewMultiClient(primary, chat)
+
+	err := client.SendCommentMentions(context.Background(), nil, &CommentPayload{})
+	require.ErrorIs(t, err, errMail)
+	require.Equal(t, 1, primary.calls)
+	require.Equal(t, 1, chat.calls)
+
+	// a failure of a best effort client isn't returned.
+	chat.err = errors.New("chat webhook unavailable")
+	primary.err = nil
+
+	err = client.SendCommentMentions(context.Background(), nil, &CommentPayload{})
+	require.NoError(t, err)
+	require.Equal(t, 2, chat.calls)
+}
+
+type countingClient struct {
+	Client
+	calls int
+	err   error
+}
+
+func (c *countingClient) SendCommentMentions(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	c.calls++
+	return c.err
+}
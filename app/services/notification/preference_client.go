@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PreferenceClient applies the notification preferences of the recipients before sending
+// a notification with the underlying client. Recipients that opted out of the event are skipped
+// and notifications of recipients that prefer a digest are stored until the next digest is sent.
+type PreferenceClient struct {
+	client      Client
+	preferences *Preferences
+	digestStore store.NotificationDigestStore
+}
+
+var _ Client = (*PreferenceClient)(nil)
+
+func NewPreferenceClient(
+	client Client,
+	preferences *Preferences,
+	digestStore store.NotificationDigestStore,
+) *PreferenceClient {
+	return &PreferenceClient{
+		client:      client,
+		preferences: preferences,
+		digestStore: digestStore,
+	}
+}
+
+func (c *PreferenceClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	recipients, err := c.apply(ctx, recipients, payload.Base, enum.NotificationEventComment,
+		fmt.Sprintf("%s commented: %s", payload.Commenter.DisplayName, truncate(payload.Text)))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendCommentPRAuthor(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	recipients, err := c.apply(ctx, recipients, payload.Base, enum.NotificationEventMention,
+		fmt.Sprintf("%s mentioned you: %s", payload.Commenter.DisplayName, truncate(payload.Text)))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendCommentMentions(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	recipients, err := c.apply(ctx, recipients, payload.Base, enum.NotificationEventComment,
+		fmt.Sprintf("%s replied: %s", payload.Commenter.DisplayName, truncate(payload.Text)))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendCommentParticipants(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	recipients, err := c.apply(ctx, recipients, payload.Base, enum.NotificationEventReviewRequested,
+		fmt.Sprintf("%s was added as a reviewer", payload.Reviewer.DisplayName))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendReviewerAdded(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	recipients, err := c.apply(ctx, recipients, payload.Base, enum.NotificationEventBranchUpdated,
+		fmt.Sprintf("%s pushed new commits", payload.Committer.DisplayName))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendPullReqBranchUpdated(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	recipients, err := c.apply(ctx, recipients, payload.Base, enum.NotificationEventReviewSubmitted,
+		fmt.Sprintf("%s submitted a review: %s", payload.Reviewer.DisplayName, payload.Decision))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendReviewSubmitted(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	event := enum.NotificationEventPullReqStateChanged
+	if payload.State == PullReqStateMerged {
+		event = enum.NotificationEventPullReqMerged
+	}
+
+	recipients, err := c.apply(ctx, recipients, payload.Base, event,
+		fmt.Sprintf("%s %s the pull request", payload.ChangedBy.DisplayName, payload.State))
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendPullReqStateChanged(ctx, recipients, payload)
+}
+
+// apply returns the recipients that should be notified immediately.
+// The notification is stored for the recipients that prefer a digest.
+func (c *PreferenceClient) apply(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	base *BasePullReqPayload,
+	event enum.NotificationEvent,
+	digestText string,
+) ([]*types.PrincipalInfo, error) {
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	prefs, err := c.preferences.Resolve(ctx, principalIDs, base.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve notification preferences: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	immediate := make([]*types.PrincipalInfo, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		pref := prefs[recipient.ID]
+		if !pref.IsEnabled(event) {
+			continue
+		}
+
+		if pref.Delivery == enum.NotificationDeliveryImmediate {
+			immediate = append(immediate, recipient)
+			continue
+		}
+
+		err = c.digestStore.Create(ctx, &types.NotificationDigestItem{
+			PrincipalID: recipient.ID,
+			Delivery:    pref.Delivery,
+			EventID:     base.EventID,
+			Event:       event,
+			RepoID:      base.Repo.ID,
+			Subject:     prTitle(base),
+			Text:        digestText,
+			URL:         base.PullReqURL,
+			Created:     now,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to store notification for digest: %w", err)
+		}
+	}
+
+	return immediate, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Preferences resolves the notification preferences of users.
+type Preferences struct {
+	preferenceStore store.NotificationPreferenceStore
+	spaceStore      store.SpaceStore
+}
+
+func NewPreferences(
+	preferenceStore store.NotificationPreferenceStore,
+	spaceStore store.SpaceStore,
+) *Preferences {
+	return &Preferences{
+		preferenceStore: preferenceStore,
+		spaceStore:      spaceStore,
+	}
+}
+
+// DefaultPreference returns the preference of users that haven't configured any:
+// all events are enabled and delivered immediately.
+func DefaultPreference() *types.NotificationPreference {
+	return &types.NotificationPreference{
+		DisabledEvents: []enum.NotificationEvent{},
+		Delivery:       enum.NotificationDeliveryImmediate,
+	}
+}
+
+// Resolve returns the notification preferences of the principals that apply to the repository.
+// The repository preference takes precedence over the preference of the closest space,
+// which takes precedence over the global preference of the user.
+func (p *Preferences) Resolve(
+	ctx context.Context,
+	principalIDs []int64,
+	repo *types.Repository,
+) (map[int64]*types.NotificationPreference, error) {
+	spaceIDs, err := p.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestors of the repository space: %w", err)
+	}
+
+	result := make(map[int64]*types.NotificationPreference, len(principalIDs))
+	for _, principalID := range principalIDs {
+		if _, ok := result[principalID]; ok {
+			continue
+		}
+
+		prefs, err := p.preferenceStore.List(ctx, principalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notification preferences of principal %d: %w", principalID, err)
+		}
+
+		result[principalID] = selectPreference(prefs, repo.ID, spaceIDs)
+	}
+
+	return result, nil
+}
+
+// selectPreference returns the most specific preference for the repository.
+// The spaceIDs must start with the parent space of the repository followed by its ancestors.
+func selectPreference(
+	prefs []*types.NotificationPreference,
+	repoID int64,
+	spaceIDs []int64,
+) *types.NotificationPreference {
+	var global, space *types.NotificationPreference
+	spaceLevel := len(spaceIDs)
+
+	for _, pref := range prefs {
+		switch {
+		case pref.RepoID != nil:
+			if *pref.RepoID == repoID {
+				return pref
+			}
+		case pref.SpaceID != nil:
+			if level := slices.Index(spaceIDs, *pref.SpaceID); level >= 0 && level < spaceLevel {
+				space, spaceLevel = pref, level
+			}
+		default:
+			global = pref
+		}
+	}
+
+	if space != nil {
+		return space
+	}
+
+	if global != nil {
+		return global
+	}
+
+	return DefaultPreference()
+}
@@ -27,7 +27,7 @@ func (s *Service) notifyPullReqCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
 ) error {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -41,7 +41,7 @@ func (s *Service) notifyPullReqStateMerged(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateMerged)
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.ID, event.Payload.Base, PullReqStateMerged)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -70,7 +70,7 @@ func (s *Service) notifyPullReqStateClosed(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateClosed)
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.ID, event.Payload.Base, PullReqStateClosed)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -99,7 +99,7 @@ func (s *Service) notifyPullReqStateReOpened(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateReopened)
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.ID, event.Payload.Base, PullReqStateReopened)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -126,10 +126,11 @@ func (s *Service) notifyPullReqStateReOpened(
 
 func (s *Service) processPullReqStateChangedEvent(
 	ctx context.Context,
+	eventID string,
 	baseEvent pullreqevents.Base,
 	state PullReqState,
 ) (*PullReqStateChangedPayload, []*types.PrincipalInfo, error) {
-	basePayload, err := s.getBasePayload(ctx, baseEvent)
+	basePayload, err := s.getBasePayload(ctx, eventID, baseEvent)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	replyTokenSeparator = "-"
+	replyTokenSigLength = 10 // bytes of the HMAC included in the token
+)
+
+var ErrInvalidReplyAddress = errors.New("invalid reply address")
+
+// ReplyConfig configures creating pull request comments by replying to notification emails.
+type ReplyConfig struct {
+	// Address is the address replies are sent to, e.g. reply@example.com.
+	// Reply tokens are added to its local part as a subaddress: reply+<token>@example.com.
+	Address string
+	// Maildir is the path of the maildir to which the mail server delivers the replies.
+	Maildir  string
+	PollCron string
+	TokenTTL time.Duration
+	// Secret is the server secret from which the keys of the reply token signatures are derived.
+	Secret string
+}
+
+// Enabled returns true if replying to notification emails is configured.
+func (c ReplyConfig) Enabled() bool {
+	return c.Address != "" && c.Maildir != ""
+}
+
+// ReplyTarget is the pull request comment thread a reply email is posted to.
+type ReplyTarget struct {
+	PrincipalID int64
+	PullReqID   int64
+	// ParentID is the ID of the comment thread. If zero, the reply is posted as a new comment.
+	ParentID int64
+}
+
+// ReplyAddresses generates and verifies the reply addresses of notification emails.
+// Every address contains a token signed with a key derived from the server secret and the recipient,
+// so a reply can only be posted on behalf of the recipient of the notification.
+type ReplyAddresses struct {
+	config         ReplyConfig
+	principalStore store.PrincipalStore
+}
+
+func NewReplyAddresses(config ReplyConfig, principalStore store.PrincipalStore) *ReplyAddresses {
+	return &ReplyAddresses{
+		config:         config,
+		principalStore: principalStore,
+	}
+}
+
+// Enabled returns true if notification emails should contain a reply address.
+func (r *ReplyAddresses) Enabled() bool {
+	return r != nil && r.config.Enabled()
+}
+
+// Generate returns the reply address of a notification email for the reply target.
+func (r *ReplyAddresses) Generate(_ context.Context, target ReplyTarget) (string, error) {
+	expires := time.Now().Add(r.config.TokenTTL).Unix()
+	payload := replyTokenPayload(target, expires)
+
+	local, domain, _ := strings.Cut(r.config.Address, "@")
+
+	return local + "+" + payload + replyTokenSeparator + r.signature(target.PrincipalID, payload) +
+		"@" + domain, nil
+}
+
+// Verify verifies the token in the reply address and returns the reply target and the replying principal.
+func (r *ReplyAddresses) Verify(ctx context.Context, address string) (*ReplyTarget, *types.Principal, error) {
+	local, domain, ok := strings.Cut(strings.ToLower(address), "@")
+	if !ok {
+		return nil, nil, ErrInvalidReplyAddress
+	}
+
+	replyLocal, replyDomain, _ := strings.Cut(strings.ToLower(r.config.Address), "@")
+	if domain != replyDomain || !strings.HasPrefix(local, replyLocal+"+") {
+		return nil, nil, ErrInvalidReplyAddress
+	}
+
+	token := strings.TrimPrefix(local, replyLocal+"+")
+
+	parts := strings.Split(token, replyTokenSeparator)
+	if len(parts) != 5 {
+		return nil, nil, ErrInvalidReplyAddress
+	}
+
+	var values [4]int64
+	for i := range values {
+		v, err := strconv.ParseInt(parts[i], 36, 64)
+		if err != nil || v < 0 {
+			return nil, nil, ErrInvalidReplyAddress
+		}
+		values[i] = v
+	}
+
+	target := ReplyTarget{
+		PrincipalID: values[0],
+		PullReqID:   values[1],
+		ParentID:    values[2],
+	}
+	expires := values[3]
+
+	if time.Now().Unix() > expires {
+		return nil, nil, fmt.Errorf("%w: the reply token has expired", ErrInvalidReplyAddress)
+	}
+
+	principal, err := r.principalStore.Find(ctx, target.PrincipalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("%w: unknown principal", ErrInvalidReplyAddress)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	payload := strings.Join(parts[:4], replyTokenSeparator)
+	if !hmac.Equal([]byte(parts[4]), []byte(r.signature(principal.ID, payload))) {
+		return nil, nil, fmt.Errorf("%w: invalid reply token signature", ErrInvalidReplyAddress)
+	}
+
+	return &target, principal, nil
+}
+
+func replyTokenPayload(target ReplyTarget, expires int64) string {
+	return strings.Join([]string{
+		strconv.FormatInt(target.PrincipalID, 36),
+		strconv.FormatInt(target.PullReqID, 36),
+		strconv.FormatInt(target.ParentID, 36),
+		strconv.FormatInt(expires, 36),
+	}, replyTokenSeparator)
+}
+
+// signature signs the token payload with the key of the principal,
+// which is the HMAC of the principal ID keyed with the server secret.
+func (r *ReplyAddresses) signature(principalID int64, payload string) string {
+	key := hmac.New(sha256.New, []byte(r.config.Secret))
+	key.Write([]byte(strconv.FormatInt(principalID, 10)))
+
+	mac := hmac.New(sha256.New, key.Sum(nil))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil)[:replyTokenSigLength])
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplyAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	principals := fakePrincipalStore{principals: map[int64]*types.Principal{
+		1: {ID: 1, UID: "alice", Salt: "salt"},
+		2: {ID: 2, UID: "bob", Salt: "salt"},
+	}}
+
+	config := ReplyConfig{
+		Address:  "reply@example.com",
+		Maildir:  "/tmp/maildir",
+		TokenTTL: time.Hour,
+		Secret:   "secret",
+	}
+	replies := NewReplyAddresses(config, principals)
+
+	target := ReplyTarget{PrincipalID: 1, PullReqID: 10, ParentID: 100}
+
+	address, err := replies.Generate(ctx, target)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(address, "reply+"))
+	require.True(t, strings.HasSuffix(address, "@example.com"))
+
+	verifiedTarget, principal, err := replies.Verify(ctx, strings.ToUpper(address))
+	require.NoError(t, err)
+	require.Equal(t, target, *verifiedTarget)
+	require.Equal(t, int64(1), principal.ID)
+
+	// the token of one principal can't be used on behalf of another principal, even if their salts are equal.
+	otherAddress, err := replies.Generate(ctx, ReplyTarget{PrincipalID: 2, PullReqID: 10, ParentID: 100})
+	require.NoError(t, err)
+
+	forged := strings.Replace(address, "reply+1-", "reply+2-", 1)
+	require.NotEqual(t, otherAddress, forged)
+	_, _, err = replies.Verify(ctx, forged)
+	require.ErrorIs(t, err, ErrInvalidReplyAddress)
+
+	// the tokens can't be verified with another server secret.
+	config.Secret = "other secret"
+	_, _, err = NewReplyAddresses(config, principals).Verify(ctx, address)
+	require.ErrorIs(t, err, ErrInvalidReplyAddress)
+
+	// the tokens expire.
+	config.Secret = "secret"
+	config.TokenTTL = -time.Minute
+	expired, err := NewReplyAddresses(config, principals).Generate(ctx, target)
+	require.NoError(t, err)
+	_, _, err = replies.Verify(ctx, expired)
+	require.ErrorIs(t, err, ErrInvalidReplyAddress)
+}
+
+func TestProvideReplyAddressesRequiresSecret(t *testing.T) {
+	config := Config{Reply: ReplyConfig{Address: "reply@example.com", Maildir: "/tmp/maildir"}}
+
+	_, err := ProvideReplyAddresses(config, fakePrincipalStore{})
+	require.Error(t, err)
+
+	config.Reply.Secret = "secret"
+	_, err = ProvideReplyAddresses(config, fakePrincipalStore{})
+	require.NoError(t, err)
+
+	// the secret isn't required if replying isn't enabled.
+	_, err = ProvideReplyAddresses(Config{}, fakePrincipalStore{})
+	require.NoError(t, err)
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return principal, nil
+}
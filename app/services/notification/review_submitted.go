@@ -29,6 +29,8 @@ type ReviewSubmittedPayload struct {
 	Author   *types.PrincipalInfo
 	Reviewer *types.PrincipalInfo
 	Decision enum.PullReqReviewDecision
+	// CommentCount is the number of comments published with the review.
+	CommentCount int
 }
 
 func (s *Service) notifyReviewSubmitted(
@@ -66,7 +68,7 @@ func (s *Service) processReviewSubmittedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
 ) (*ReviewSubmittedPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -91,10 +93,34 @@ func (s *Service) processReviewSubmittedEvent(
 		)
 	}
 
+	recipients := []*types.PrincipalInfo{authorPrincipal}
+
+	// users mentioned in the comments published with the review are notified with the same message.
+	seen := map[int64]bool{
+		authorPrincipal.ID:   true,
+		reviewerPrincipal.ID: true,
+	}
+	for _, commentID := range event.Payload.CommentIDs {
+		activity, err := s.pullReqActivityStore.Find(ctx, commentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch activity from pullReqActivityStore: %w", err)
+		}
+
+		mentions, err := s.processMentions(ctx, activity.Metadata, seen)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, mention := range mentions {
+			recipients = append(recipients, mention)
+		}
+	}
+
 	return &ReviewSubmittedPayload{
-		Base:     base,
-		Author:   authorPrincipal,
-		Decision: event.Payload.Decision,
-		Reviewer: reviewerPrincipal,
-	}, []*types.PrincipalInfo{authorPrincipal}, nil
+		Base:         base,
+		Author:       authorPrincipal,
+		Decision:     event.Payload.Decision,
+		Reviewer:     reviewerPrincipal,
+		CommentCount: len(event.Payload.CommentIDs),
+	}, recipients, nil
 }
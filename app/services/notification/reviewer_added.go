@@ -59,7 +59,7 @@ func (s *Service) processReviewerAddedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReviewerAddedPayload],
 ) (*ReviewerAddedPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -72,6 +72,7 @@ func LoadTemplates() error {
 }
 
 type BasePullReqPayload struct {
+	EventID    string
 	Repo       *types.Repository
 	PullReq    *types.PullReq
 	Author     *types.PrincipalInfo
@@ -79,9 +80,12 @@ type BasePullReqPayload struct {
 }
 
 type Config struct {
-	EventReaderName string
-	Concurrency     int
-	MaxRetries      int
+	EventReaderName  string
+	Concurrency      int
+	MaxRetries       int
+	DigestHourlyCron string
+	DigestDailyCron  string
+	Reply            ReplyConfig
 }
 
 type Service struct {
@@ -96,6 +100,7 @@ type Service struct {
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
 	urlProvider           url.Provider
+	digest                *Digest
 }
 
 func NewService(
@@ -111,6 +116,7 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	digest *Digest,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
@@ -124,6 +130,7 @@ func NewService(
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
 		urlProvider:           urlProvider,
+		digest:                digest,
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -158,8 +165,14 @@ func NewService(
 	return service, nil
 }
 
+// Register schedules the recurring jobs that send the notification digests.
+func (s *Service) Register(ctx context.Context) error {
+	return s.digest.Register(ctx)
+}
+
 func (s *Service) getBasePayload(
 	ctx context.Context,
+	eventID string,
 	base pullreqevents.Base,
 ) (*BasePullReqPayload, error) {
 	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
@@ -179,6 +192,7 @@ func (s *Service) getBasePayload(
 	}
 
 	return &BasePullReqPayload{
+		EventID:    eventID,
 		Repo:       repo,
 		PullReq:    pullReq,
 		Author:     author,
@@ -16,18 +16,29 @@ package notification
 
 import (
 	"context"
+	"errors"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
+	ProvideReplyAddresses,
 	ProvideMailClient,
+	ProvideChatWebhooks,
+	ProvideChatClient,
+	ProvidePreferences,
+	ProvideClient,
+	ProvideDigest,
 	ProvideNotificationService,
 )
 
@@ -44,6 +55,7 @@ func ProvideNotificationService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	digest *Digest,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -58,9 +70,66 @@ func ProvideNotificationService(
 		pullReqActivityStore,
 		spacePathStore,
 		urlProvider,
+		digest,
 	)
 }
 
-func ProvideMailClient(mailer mailer.Mailer) Client {
-	return NewMailClient(mailer)
+func ProvideReplyAddresses(config Config, principalStore store.PrincipalStore) (*ReplyAddresses, error) {
+	if config.Reply.Enabled() && config.Reply.Secret == "" {
+		return nil, errors.New("secret of the notification reply tokens is required if replying is enabled")
+	}
+
+	return NewReplyAddresses(config.Reply, principalStore), nil
+}
+
+func ProvideMailClient(mailer mailer.Mailer, replies *ReplyAddresses) MailClient {
+	return NewMailClient(mailer, replies)
+}
+
+func ProvideChatWebhooks(
+	settings *settings.Service,
+	encrypter encrypt.Encrypter,
+	spaceStore store.SpaceStore,
+	webhookConfig webhook.Config,
+) *ChatWebhooks {
+	return NewChatWebhooks(
+		settings,
+		encrypter,
+		spaceStore,
+		webhookConfig.AllowLoopback,
+		webhookConfig.AllowPrivateNetwork,
+	)
+}
+
+func ProvideChatClient(webhooks *ChatWebhooks, principalStore store.PrincipalStore) *ChatClient {
+	return NewChatClient(webhooks, principalStore)
+}
+
+func ProvidePreferences(
+	preferenceStore store.NotificationPreferenceStore,
+	spaceStore store.SpaceStore,
+) *Preferences {
+	return NewPreferences(preferenceStore, spaceStore)
+}
+
+// ProvideClient provides the notification client. User notification preferences apply only to emails,
+// chat notifications are sent, best effort, to channels configured on the spaces.
+func ProvideClient(
+	mailClient MailClient,
+	chatClient *ChatClient,
+	preferences *Preferences,
+	digestStore store.NotificationDigestStore,
+) Client {
+	return NewMultiClient(NewPreferenceClient(mailClient, preferences, digestStore), chatClient)
+}
+
+func ProvideDigest(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	digestStore store.NotificationDigestStore,
+	principalInfoCache store.PrincipalInfoCache,
+	mailer mailer.Mailer,
+) *Digest {
+	return NewDigest(config, scheduler, executor, digestStore, principalInfoCache, mailer)
 }
@@ -55,5 +55,9 @@ func (v DefBypass) Sanitize() error {
 		return fmt.Errorf("user IDs error: %w", err)
 	}
 
+	if err := validateIDSlice(v.UserGroupIDs); err != nil {
+		return fmt.Errorf("usergroup IDs error: %w", err)
+	}
+
 	return nil
 }
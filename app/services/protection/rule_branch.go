@@ -28,6 +28,7 @@ type Branch struct {
 	Bypass    DefBypass    `json:"bypass"`
 	PullReq   DefPullReq   `json:"pullreq"`
 	Lifecycle DefLifecycle `json:"lifecycle"`
+	Push      DefPush      `json:"push"`
 }
 
 var (
@@ -45,6 +46,13 @@ func (v *Branch) MergeVerify(
 		return out, violations, fmt.Errorf("merge verify error: %w", err)
 	}
 
+	pushViolations, err := v.Push.MergeVerify(ctx, in)
+	if err != nil {
+		return out, violations, fmt.Errorf("merge verify commits error: %w", err)
+	}
+
+	violations = append(violations, pushViolations...)
+
 	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
@@ -130,6 +138,29 @@ func (v *Branch) RefChangeVerify(
 	return
 }
 
+func (v *Branch) PushVerify(
+	ctx context.Context,
+	in PushVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if len(in.Commits) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Push.PushVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("push error: %w", err)
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
 func (v *Branch) UserIDs() ([]int64, error) {
 	uniqueUserMap := make(map[int64]struct{}, len(v.Bypass.UserIDs)+len(v.PullReq.Reviewers.DefaultReviewerIDs))
 	for _, id := range v.Bypass.UserIDs {
@@ -148,7 +179,21 @@ func (v *Branch) UserIDs() ([]int64, error) {
 }
 
 func (v *Branch) UserGroupIDs() ([]int64, error) {
-	return v.Bypass.UserGroupIDs, nil
+	uniqueUserGroupMap := make(map[int64]struct{},
+		len(v.Bypass.UserGroupIDs)+len(v.PullReq.Reviewers.DefaultUserGroupReviewerIDs))
+	for _, id := range v.Bypass.UserGroupIDs {
+		uniqueUserGroupMap[id] = struct{}{}
+	}
+	for _, id := range v.PullReq.Reviewers.DefaultUserGroupReviewerIDs {
+		uniqueUserGroupMap[id] = struct{}{}
+	}
+
+	ids := make([]int64, 0, len(uniqueUserGroupMap))
+	for id := range uniqueUserGroupMap {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
 }
 
 func (v *Branch) Sanitize() error {
@@ -164,5 +209,9 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Push.Sanitize(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypeTag types.RuleType = "tag"
+
+// Tag implements protection rules for the rule type TypeTag.
+type Tag struct {
+	Bypass    DefBypass       `json:"bypass"`
+	Lifecycle DefTagLifecycle `json:"lifecycle"`
+}
+
+var (
+	// ensures that the Tag type implements Definition interface.
+	_ Definition = (*Tag)(nil)
+	_ Protection = (*Tag)(nil)
+)
+
+// MergeVerify doesn't restrict pull requests because tag rules don't apply to branches.
+func (v *Tag) MergeVerify(
+	context.Context,
+	MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	return MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}, nil, nil
+}
+
+func (v *Tag) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *Tag) CreatePullReqVerify(
+	context.Context,
+	CreatePullReqVerifyInput,
+) (CreatePullReqVerifyOutput, []types.RuleViolations, error) {
+	return CreatePullReqVerifyOutput{}, nil, nil
+}
+
+func (v *Tag) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if in.RefType != RefTypeTag || len(in.RefNames) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("tag lifecycle error: %w", err)
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
+func (v *Tag) PushVerify(
+	context.Context,
+	PushVerifyInput,
+) ([]types.RuleViolations, error) {
+	return []types.RuleViolations{}, nil
+}
+
+func (v *Tag) UserIDs() ([]int64, error) {
+	uniqueUserMap := make(map[int64]struct{}, len(v.Bypass.UserIDs)+len(v.Lifecycle.CreateUserIDs))
+	for _, id := range v.Bypass.UserIDs {
+		uniqueUserMap[id] = struct{}{}
+	}
+	for _, id := range v.Lifecycle.CreateUserIDs {
+		uniqueUserMap[id] = struct{}{}
+	}
+
+	ids := make([]int64, 0, len(uniqueUserMap))
+	for id := range uniqueUserMap {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (v *Tag) UserGroupIDs() ([]int64, error) {
+	uniqueUserGroupMap := make(map[int64]struct{},
+		len(v.Bypass.UserGroupIDs)+len(v.Lifecycle.CreateUserGroupIDs))
+	for _, id := range v.Bypass.UserGroupIDs {
+		uniqueUserGroupMap[id] = struct{}{}
+	}
+	for _, id := range v.Lifecycle.CreateUserGroupIDs {
+		uniqueUserGroupMap[id] = struct{}{}
+	}
+
+	ids := make([]int64, 0, len(uniqueUserGroupMap))
+	for id := range uniqueUserGroupMap {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (v *Tag) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.Lifecycle.Sanitize(); err != nil {
+		return fmt.Errorf("lifecycle: %w", err)
+	}
+
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
@@ -35,6 +36,7 @@ type (
 	Protection interface {
 		MergeVerifier
 		RefChangeVerifier
+		PushVerifier
 		CreatePullReqVerifier
 		UserIDs() ([]int64, error)
 		UserGroupIDs() ([]int64, error)
@@ -80,6 +82,35 @@ func IsBypassed(violations []types.RuleViolations) bool {
 	return false
 }
 
+// WithoutStatusChecks returns the rule violations without the violations of the required status checks.
+// The merge queue uses it to verify a pull request before the status checks ran for its speculative merge commit.
+func WithoutStatusChecks(violations []types.RuleViolations) []types.RuleViolations {
+	result := make([]types.RuleViolations, 0, len(violations))
+	for _, ruleViolations := range violations {
+		ruleViolations.Violations = slices.DeleteFunc(slices.Clone(ruleViolations.Violations),
+			func(v types.Violation) bool {
+				return v.Code == codePullReqStatusChecksReqIdentifiers
+			})
+		if len(ruleViolations.Violations) > 0 {
+			result = append(result, ruleViolations)
+		}
+	}
+	return result
+}
+
+// RequiresMergeQueue returns true if the rule violations require the pull request
+// to be merged through the merge queue.
+func RequiresMergeQueue(violations []types.RuleViolations) bool {
+	for _, ruleViolations := range violations {
+		for _, v := range ruleViolations.Violations {
+			if v.Code == codePullReqMergeQueue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // NewManager creates new protection Manager.
 func NewManager(ruleStore store.RuleStore) *Manager {
 	return &Manager{
@@ -76,6 +76,70 @@ func TestIsCritical(t *testing.T) {
 	}
 }
 
+func TestWithoutStatusChecks(t *testing.T) {
+	rule1 := types.RuleInfo{Identifier: "rule1", State: enum.RuleStateActive}
+	rule2 := types.RuleInfo{Identifier: "rule2", State: enum.RuleStateActive}
+
+	input := []types.RuleViolations{
+		{
+			Rule: rule1,
+			Violations: []types.Violation{
+				{Code: codePullReqStatusChecksReqIdentifiers},
+				{Code: codePullReqCommentsReqResolveAll},
+			},
+		},
+		{
+			Rule:       rule2,
+			Violations: []types.Violation{{Code: codePullReqStatusChecksReqIdentifiers}},
+		},
+	}
+
+	got := WithoutStatusChecks(input)
+
+	if len(got) != 1 || got[0].Rule.Identifier != "rule1" ||
+		len(got[0].Violations) != 1 || got[0].Violations[0].Code != codePullReqCommentsReqResolveAll {
+		t.Errorf("unexpected result: %+v", got)
+	}
+
+	if len(input[0].Violations) != 2 {
+		t.Error("input violations have been modified")
+	}
+}
+
+func TestRequiresMergeQueue(t *testing.T) {
+	rule := types.RuleInfo{Identifier: "rule", State: enum.RuleStateActive}
+
+	tests := []struct {
+		name       string
+		violations []types.RuleViolations
+		exp        bool
+	}{
+		{name: "empty"},
+		{
+			name: "other-violations",
+			violations: []types.RuleViolations{
+				{Rule: rule, Violations: []types.Violation{{Code: codePullReqCommentsReqResolveAll}}},
+			},
+		},
+		{
+			name: "merge-queue",
+			violations: []types.RuleViolations{
+				{Rule: rule, Violations: []types.Violation{{Code: codePullReqCommentsReqResolveAll}}},
+				{Rule: rule, Violations: []types.Violation{{Code: codePullReqMergeQueue}}},
+			},
+			exp: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := RequiresMergeQueue(test.violations); got != test.exp {
+				t.Errorf("want=%t got=%t", test.exp, got)
+			}
+		})
+	}
+}
+
 func TestManager_SanitizeJSON(t *testing.T) {
 	tests := []struct {
 		name      string
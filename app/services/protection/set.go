@@ -58,6 +58,7 @@ func (s ruleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
 			out.DefaultReviewerApprovals = append(out.DefaultReviewerApprovals, rOut.DefaultReviewerApprovals...)
 
 			return nil
@@ -142,6 +143,9 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 		func(r *types.RuleInfoInternal, p Protection, matched []string) error {
 			ruleIn := in
 			ruleIn.RefNames = matched
+			ruleIn.isProtectedBranch = func(branchName string) (bool, error) {
+				return s.isProtectedBranch(in.Repo.DefaultBranch, branchName)
+			}
 
 			rVs, err := p.RefChangeVerify(ctx, ruleIn)
 			if err != nil {
@@ -159,6 +163,27 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 	return violations, nil
 }
 
+func (s ruleSet) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	var violations []types.RuleViolations
+
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.BranchName,
+		func(r *types.RuleInfoInternal, p Protection) error {
+			rVs, err := p.PushVerify(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to process each rule in ruleSet: %w", err)
+	}
+
+	return violations, nil
+}
+
 func (s ruleSet) UserIDs() ([]int64, error) {
 	mapIDs := make(map[int64]struct{})
 	err := s.forEachRule(func(_ *types.RuleInfoInternal, p Protection) error {
@@ -297,6 +322,27 @@ func (s ruleSet) forEachRuleMatchRefs(
 	return nil
 }
 
+// isProtectedBranch returns true if any active branch rule of the set matches the branch.
+func (s ruleSet) isProtectedBranch(defaultBranch string, branchName string) (bool, error) {
+	for i := range s.rules {
+		r := &s.rules[i]
+
+		if r.Type != TypeBranch || r.State != enum.RuleStateActive {
+			continue
+		}
+
+		matches, err := matchesName(r.Pattern, defaultBranch, branchName)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func backFillRule(vs []types.RuleViolations, rule types.RuleInfo) []types.RuleViolations {
 	for i := range vs {
 		vs[i].Rule = rule
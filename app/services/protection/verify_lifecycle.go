@@ -34,6 +34,14 @@ type (
 		RefAction          RefAction
 		RefType            RefType
 		RefNames           []string
+
+		// ListBranchesContaining returns names of branches that contain the commit the provided ref points to.
+		// It's used only for created or updated tags.
+		ListBranchesContaining func(ctx context.Context, refName string) ([]string, error)
+
+		// isProtectedBranch returns true if the branch is matched by an active branch rule.
+		// It's provided by the rule set of the repository.
+		isProtectedBranch func(branchName string) (bool, error)
 	}
 
 	RefType int
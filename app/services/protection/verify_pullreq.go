@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -46,6 +47,31 @@ type (
 		Method             enum.MergeMethod
 		CheckResults       []types.CheckResult
 		CodeOwners         *codeowners.Evaluation
+
+		// MergeQueue is true if the pull request is being merged by the merge queue.
+		// In that case the CheckResults belong to the speculative merge commit created by the queue.
+		MergeQueue bool
+
+		// ListCommits returns the commits of the pull request with verified signatures.
+		// It's called only if a rule verifies the commits.
+		ListCommits func(ctx context.Context) ([]types.Commit, error)
+
+		// CommitMessage is the message of the commit created by the merge or the squash merge method.
+		// It's empty if the message isn't known, e.g. for a dry run, in which case it isn't verified.
+		CommitMessage string
+
+		// ListFiles returns the files changed by the commits of the pull request.
+		// It's called only if a rule restricts the changed files.
+		ListFiles func(ctx context.Context) ([]PushedFile, error)
+
+		// VerifiedEmails contains the verified emails of the actor.
+		// If empty, the email of the actor is used.
+		VerifiedEmails []string
+
+		// AuthorVerifiedEmails contains the verified emails of the pull request author.
+		// Every commit of the pull request must be authored and committed with the verified emails
+		// of the same principal, either of the actor or of the pull request author.
+		AuthorVerifiedEmails []string
 	}
 
 	MergeVerifyOutput struct {
@@ -57,6 +83,7 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		RequiresMergeQueue                  bool
 		DefaultReviewerApprovals            []*types.DefaultReviewerApprovalsResponse
 	}
 
@@ -119,6 +146,7 @@ const (
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
+	codePullReqMergeQueue             = "pullreq.merge.queue"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
@@ -126,7 +154,7 @@ const (
 
 //nolint:gocognit,gocyclo,cyclop // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
@@ -136,6 +164,7 @@ func (v *DefPullReq) MergeVerify(
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
+	out.RequiresMergeQueue = v.Merge.Queue
 
 	// output that depends on approval of latest commit
 	if v.Approvals.RequireLatestCommit {
@@ -189,8 +218,13 @@ func (v *DefPullReq) MergeVerify(
 		}
 	}
 
-	effectiveDefaultReviewerIDs := make([]int64, 0, len(v.Reviewers.DefaultReviewerIDs))
-	for _, id := range v.Reviewers.DefaultReviewerIDs {
+	defaultReviewerIDs, err := v.Reviewers.resolveDefaultReviewerIDs(ctx, in.ResolveUserGroupID)
+	if err != nil {
+		return out, nil, err
+	}
+
+	effectiveDefaultReviewerIDs := make([]int64, 0, len(defaultReviewerIDs))
+	for _, id := range defaultReviewerIDs {
 		if id == in.PullReq.Author.ID {
 			continue
 		}
@@ -199,8 +233,8 @@ func (v *DefPullReq) MergeVerify(
 
 	// if author is default reviewer and required minimum == number of default reviewers, reduce minimum by one.
 	effectiveMinimumRequiredDefaultReviewerCount := v.Approvals.RequireMinimumDefaultReviewerCount
-	if len(effectiveDefaultReviewerIDs) < len(v.Reviewers.DefaultReviewerIDs) &&
-		len(v.Reviewers.DefaultReviewerIDs) == v.Approvals.RequireMinimumDefaultReviewerCount {
+	if len(effectiveDefaultReviewerIDs) < len(defaultReviewerIDs) &&
+		len(defaultReviewerIDs) == v.Approvals.RequireMinimumDefaultReviewerCount {
 		effectiveMinimumRequiredDefaultReviewerCount--
 	}
 
@@ -323,6 +357,13 @@ func (v *DefPullReq) MergeVerify(
 			"The merge for the branch %s is not allowed.", in.PullReq.TargetBranch)
 	}
 
+	if v.Merge.Queue && !in.MergeQueue {
+		violations.Addf(
+			codePullReqMergeQueue,
+			"Pull requests targeting the branch %s must be merged through the merge queue.",
+			in.PullReq.TargetBranch)
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -344,13 +385,18 @@ func (v *DefPullReq) RequiredChecks(
 }
 
 func (v *DefPullReq) CreatePullReqVerify(
-	context.Context,
-	CreatePullReqVerifyInput,
+	ctx context.Context,
+	in CreatePullReqVerifyInput,
 ) (CreatePullReqVerifyOutput, []types.RuleViolations, error) {
 	var out CreatePullReqVerifyOutput
 
+	defaultReviewerIDs, err := v.Reviewers.resolveDefaultReviewerIDs(ctx, in.ResolveUserGroupID)
+	if err != nil {
+		return out, nil, err
+	}
+
 	out.RequestCodeOwners = v.Reviewers.RequestCodeOwners
-	out.DefaultReviewerIDs = v.Reviewers.DefaultReviewerIDs
+	out.DefaultReviewerIDs = defaultReviewerIDs
 
 	return out, nil, nil
 }
@@ -436,6 +482,7 @@ type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
 	Block             bool               `json:"block,omitempty"`
+	Queue             bool               `json:"queue,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -458,18 +505,45 @@ func (v *DefMerge) Sanitize() error {
 }
 
 type DefReviewers struct {
-	RequestCodeOwners  bool    `json:"request_code_owners,omitempty"`
-	DefaultReviewerIDs []int64 `json:"default_reviewer_ids,omitempty"`
+	RequestCodeOwners           bool    `json:"request_code_owners,omitempty"`
+	DefaultReviewerIDs          []int64 `json:"default_reviewer_ids,omitempty"`
+	DefaultUserGroupReviewerIDs []int64 `json:"default_user_group_reviewer_ids,omitempty"`
 }
 
-type DefPush struct {
-	Block bool `json:"block,omitempty"`
-}
+func (v *DefReviewers) Sanitize() error {
+	if err := validateIDSlice(v.DefaultReviewerIDs); err != nil {
+		return fmt.Errorf("default reviewer IDs error: %w", err)
+	}
+
+	if err := validateIDSlice(v.DefaultUserGroupReviewerIDs); err != nil {
+		return fmt.Errorf("default usergroup reviewer IDs error: %w", err)
+	}
 
-func (v *DefPush) Sanitize() error {
 	return nil
 }
 
+// resolveDefaultReviewerIDs returns IDs of all default reviewers,
+// including the members of the default reviewer usergroups.
+func (v *DefReviewers) resolveDefaultReviewerIDs(
+	ctx context.Context,
+	userGroupResolverFn func(context.Context, []int64) ([]int64, error),
+) ([]int64, error) {
+	if len(v.DefaultUserGroupReviewerIDs) == 0 || userGroupResolverFn == nil {
+		return v.DefaultReviewerIDs, nil
+	}
+
+	userIDs, err := userGroupResolverFn(ctx, v.DefaultUserGroupReviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve default reviewer usergroups: %w", err)
+	}
+
+	ids := make([]int64, 0, len(v.DefaultReviewerIDs)+len(userIDs))
+	ids = append(ids, v.DefaultReviewerIDs...)
+	ids = append(ids, userIDs...)
+
+	return cache.Deduplicate(ids), nil
+}
+
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
@@ -495,6 +569,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.Reviewers.Sanitize(); err != nil {
+		return fmt.Errorf("reviewers: %w", err)
+	}
+
 	return nil
 }
 
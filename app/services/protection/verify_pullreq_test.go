@@ -174,6 +174,36 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: codePullReqApprovalReqDefaultReviewerMinCount + "-usergroup-success",
+			def: DefPullReq{
+				Approvals: DefApprovals{RequireMinimumDefaultReviewerCount: 2},
+				Reviewers: DefReviewers{
+					DefaultReviewerIDs:          []int64{reviewer1.ID},
+					DefaultUserGroupReviewerIDs: []int64{1},
+				},
+			},
+			in: MergeVerifyInput{
+				ResolveUserGroupID: func(context.Context, []int64) ([]int64, error) {
+					return []int64{reviewer1.ID, reviewer3.ID}, nil
+				},
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc", Reviewer: reviewer1},
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc", Reviewer: reviewer2},
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc", Reviewer: reviewer3},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+				DefaultReviewerApprovals: []*types.DefaultReviewerApprovalsResponse{{
+					PrincipalIDs:         []int64{reviewer1.ID, reviewer3.ID},
+					CurrentCount:         2,
+					MinimumRequiredCount: 2,
+				}},
+			},
+		},
 		{
 			name: codePullReqApprovalReqDefaultReviewerMinCount + "-with-author-count-1-exact",
 			def: DefPullReq{
@@ -715,6 +745,45 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqMergeQueue,
+			def: DefPullReq{
+				Merge: DefMerge{
+					Queue: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				PullReq: &types.PullReq{
+					TargetBranch: "abc",
+				},
+			},
+			expCodes:  []string{codePullReqMergeQueue},
+			expParams: [][]any{{"abc"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
+		{
+			name: codePullReqMergeQueue + "-queued",
+			def: DefPullReq{
+				Merge: DefMerge{
+					Queue: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+				PullReq: &types.PullReq{
+					TargetBranch: "abc",
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
 	}
 
 	for _, test := range tests {
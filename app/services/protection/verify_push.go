@@ -0,0 +1,518 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	PushVerifier interface {
+		PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error)
+	}
+
+	PushVerifyInput struct {
+		ResolveUserGroupID func(ctx context.Context, userGroupIDs []int64) ([]int64, error)
+		Actor              *types.Principal
+		AllowBypass        bool
+		IsRepoOwner        bool
+		Repo               *types.RepositoryCore
+		BranchName         string
+
+		// Commits contains the new commits that are pushed to the branch.
+		Commits []types.Commit
+
+		// CommitsTruncated is true if there are more new commits than the caller is able to verify,
+		// in which case Commits contains only some of them.
+		CommitsTruncated bool
+
+		// VerifiedEmails contains the verified emails of the actor.
+		// If empty, the email of the actor is used.
+		VerifiedEmails []string
+
+		// ListFiles returns the files changed by the new commits.
+		// It's called only if a rule restricts the pushed files.
+		ListFiles func(ctx context.Context) ([]PushedFile, error)
+	}
+
+	// PushedFile is a file added, modified or deleted by a pushed commit.
+	PushedFile struct {
+		Path    string
+		Deleted bool
+		Size    int64
+	}
+
+	DefPush struct {
+		CommitMessagePattern   string `json:"commit_message_pattern,omitempty"`
+		CommitSubjectMaxLength int    `json:"commit_subject_max_length,omitempty"`
+		MergeCommitsForbidden  bool   `json:"merge_commits_forbidden,omitempty"`
+		VerifiedEmailRequired  bool   `json:"verified_email_required,omitempty"`
+		SignedCommitsRequired  bool   `json:"signed_commits_required,omitempty"`
+
+		ProtectedPaths    []string `json:"protected_paths,omitempty"`
+		FileSizeLimit     int64    `json:"file_size_limit,omitempty"`
+		BlockedExtensions []string `json:"blocked_extensions,omitempty"`
+	}
+)
+
+// ensures that the DefPush type implements Sanitizer and PushVerifier interfaces.
+var (
+	_ Sanitizer    = (*DefPush)(nil)
+	_ PushVerifier = (*DefPush)(nil)
+)
+
+const (
+	codePushCommitMessage       = "push.commit.message"
+	codePushCommitSubjectLength = "push.commit.subject_length"
+	codePushCommitMerge         = "push.commit.merge"
+	codePushCommitEmail         = "push.commit.email"
+	codePushCommitSignature     = "push.commit.signature"
+	codePushCommitLimit         = "push.commit.limit"
+	codePushFileProtectedPath   = "push.file.protected_path"
+	codePushFileSize            = "push.file.size"
+	codePushFileExtension       = "push.file.extension"
+
+	codePullReqMergeCommitSignature = "pullreq.merge.commit_signature"
+)
+
+// maxPushViolationItems is the maximum number of commit SHAs or file paths listed in a single violation message.
+const maxPushViolationItems = 10
+
+// Labels of the commits that are created by the server when a pull request is merged.
+// They are listed in violation messages instead of commit SHAs, because the commits don't exist yet.
+const (
+	mergeCommitLabel  = "(merge)"
+	squashCommitLabel = "(squash)"
+)
+
+func (v *DefPush) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	if in.CommitsTruncated && (v.hasCommitRules() || v.hasFileRules()) {
+		violations.Addf(codePushCommitLimit,
+			"Too many new commits are pushed to branch %q to verify them against the push rules. "+
+				"Push the commits in smaller batches.",
+			in.BranchName)
+	}
+
+	checks, err := v.newCommitChecks()
+	if err != nil {
+		return nil, err
+	}
+
+	emails := verifiedEmails(in.Actor, in.VerifiedEmails)
+
+	var unsigned []string
+
+	for i := range in.Commits {
+		commit := &in.Commits[i]
+
+		v.checkCommit(checks, commit, true, emails)
+
+		if v.SignedCommitsRequired && !commit.Verification.IsVerified() {
+			unsigned = append(unsigned, commit.SHA)
+		}
+	}
+
+	v.addCommitViolations(checks, in.BranchName, emails, &violations)
+
+	if len(unsigned) > 0 {
+		violations.Addf(codePushCommitSignature,
+			"Commits must have a verified signature. Offending commits: %s",
+			formatCommitSHAs(unsigned))
+	}
+
+	if err := v.verifyFiles(ctx, in.ListFiles, in.BranchName, &violations); err != nil {
+		return nil, err
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// hasFileRules returns true if any of the rules that verify the changed files is set.
+func (v *DefPush) hasFileRules() bool {
+	return len(v.ProtectedPaths) > 0 || v.FileSizeLimit > 0 || len(v.BlockedExtensions) > 0
+}
+
+// hasCommitRules returns true if any of the rules that verify individual commits is set.
+func (v *DefPush) hasCommitRules() bool {
+	return v.CommitMessagePattern != "" ||
+		v.CommitSubjectMaxLength > 0 ||
+		v.MergeCommitsForbidden ||
+		v.VerifiedEmailRequired ||
+		v.SignedCommitsRequired
+}
+
+// commitChecks collects the SHAs of the commits that violate the commit rules.
+type commitChecks struct {
+	messageRegexp   *regexp.Regexp
+	messageMismatch []string
+	subjectTooLong  []string
+	mergeCommits    []string
+	emailMismatch   []string
+}
+
+func (v *DefPush) newCommitChecks() (*commitChecks, error) {
+	checks := &commitChecks{}
+
+	if v.CommitMessagePattern != "" {
+		var err error
+		checks.messageRegexp, err = regexp.Compile(v.CommitMessagePattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile commit message pattern: %w", err)
+		}
+	}
+
+	return checks, nil
+}
+
+// checkCommit checks the commit against the commit message, subject length, merge commit and email rules.
+// The message isn't checked if checkMessage is false and the emails aren't checked if the emails are nil.
+func (v *DefPush) checkCommit(checks *commitChecks, commit *types.Commit, checkMessage bool, emails []string) {
+	if checkMessage && checks.messageRegexp != nil && !checks.messageRegexp.MatchString(commit.Message) {
+		checks.messageMismatch = append(checks.messageMismatch, commit.SHA)
+	}
+
+	if checkMessage && v.CommitSubjectMaxLength > 0 &&
+		utf8.RuneCountInString(commit.Title) > v.CommitSubjectMaxLength {
+		checks.subjectTooLong = append(checks.subjectTooLong, commit.SHA)
+	}
+
+	if v.MergeCommitsForbidden && len(commit.ParentSHAs) > 1 {
+		checks.mergeCommits = append(checks.mergeCommits, commit.SHA)
+	}
+
+	if v.VerifiedEmailRequired && emails != nil &&
+		(!containsEmail(emails, commit.Author.Identity.Email) ||
+			!containsEmail(emails, commit.Committer.Identity.Email)) {
+		checks.emailMismatch = append(checks.emailMismatch, commit.SHA)
+	}
+}
+
+// addCommitViolations adds a violation for each commit rule that any of the checked commits violates.
+func (v *DefPush) addCommitViolations(
+	checks *commitChecks,
+	branchName string,
+	emails []string,
+	violations *types.RuleViolations,
+) {
+	if len(checks.messageMismatch) > 0 {
+		violations.Addf(codePushCommitMessage,
+			"Commit message must match the pattern %q. Offending commits: %s",
+			v.CommitMessagePattern, formatCommitSHAs(checks.messageMismatch))
+	}
+
+	if len(checks.subjectTooLong) > 0 {
+		violations.Addf(codePushCommitSubjectLength,
+			"Commit subject must not be longer than %d characters. Offending commits: %s",
+			v.CommitSubjectMaxLength, formatCommitSHAs(checks.subjectTooLong))
+	}
+
+	if len(checks.mergeCommits) > 0 {
+		violations.Addf(codePushCommitMerge,
+			"Merge commits are not allowed on branch %q. Offending commits: %s",
+			branchName, formatCommitSHAs(checks.mergeCommits))
+	}
+
+	if len(checks.emailMismatch) > 0 {
+		violations.Addf(codePushCommitEmail,
+			"Commit author and committer email must be one of the verified emails %s. Offending commits: %s",
+			formatList(emails), formatCommitSHAs(checks.emailMismatch))
+	}
+}
+
+// verifyFiles checks the files changed by the commits against the protected paths,
+// the file size limit and the blocked file extensions.
+func (v *DefPush) verifyFiles(
+	ctx context.Context,
+	listFiles func(ctx context.Context) ([]PushedFile, error),
+	branchName string,
+	violations *types.RuleViolations,
+) error {
+	if !v.hasFileRules() || listFiles == nil {
+		return nil
+	}
+
+	files, err := listFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pushed files: %w", err)
+	}
+
+	var (
+		protectedPaths    []string
+		oversizeFiles     []string
+		blockedExtensions []string
+	)
+
+	for _, file := range files {
+		if slices.ContainsFunc(v.ProtectedPaths, func(pattern string) bool {
+			return patternMatches(pattern, file.Path)
+		}) {
+			protectedPaths = appendUnique(protectedPaths, file.Path)
+		}
+
+		if file.Deleted {
+			continue
+		}
+
+		if v.FileSizeLimit > 0 && file.Size > v.FileSizeLimit {
+			oversizeFiles = appendUnique(oversizeFiles, file.Path)
+		}
+
+		fileName := strings.ToLower(file.Path)
+		if slices.ContainsFunc(v.BlockedExtensions, func(ext string) bool {
+			return strings.HasSuffix(fileName, ext)
+		}) {
+			blockedExtensions = appendUnique(blockedExtensions, file.Path)
+		}
+	}
+
+	if len(protectedPaths) > 0 {
+		violations.Addf(codePushFileProtectedPath,
+			"Changes to protected paths are not allowed on branch %q. Offending files: %s",
+			branchName, formatList(protectedPaths))
+	}
+
+	if len(oversizeFiles) > 0 {
+		violations.Addf(codePushFileSize,
+			"Files must not be larger than %d bytes. Offending files: %s",
+			v.FileSizeLimit, formatList(oversizeFiles))
+	}
+
+	if len(blockedExtensions) > 0 {
+		violations.Addf(codePushFileExtension,
+			"Files with extensions %s are not allowed. Offending files: %s",
+			strings.Join(v.BlockedExtensions, ", "), formatList(blockedExtensions))
+	}
+
+	return nil
+}
+
+// MergeVerify verifies the commits that merging the pull request adds to the target branch.
+// The fast-forward and the rebase merge methods add the commits of the pull request,
+// the merge method adds them along with a new merge commit and the squash method adds only a new commit.
+// The new commits are created by the server, so only their message and parents are verified.
+// If signed commits are required, all commits of the pull request must be signed, regardless of the merge method.
+// The files changed by the pull request are verified for every merge method.
+func (v *DefPush) MergeVerify(ctx context.Context, in MergeVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	if err := v.verifyMergeCommits(ctx, in, &violations); err != nil {
+		return nil, err
+	}
+
+	if v.hasFileRules() {
+		if err := v.verifyFiles(ctx, in.ListFiles, in.PullReq.TargetBranch, &violations); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// verifyMergeCommits verifies the commits that merging the pull request adds to the target branch
+// against the commit rules.
+func (v *DefPush) verifyMergeCommits(
+	ctx context.Context,
+	in MergeVerifyInput,
+	violations *types.RuleViolations,
+) error {
+	if !v.hasCommitRules() || in.ListCommits == nil {
+		return nil
+	}
+
+	commits, err := in.ListCommits(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	checks, err := v.newCommitChecks()
+	if err != nil {
+		return err
+	}
+
+	actorEmails := verifiedEmails(in.Actor, in.VerifiedEmails)
+
+	// a commit is verified against the emails of the principal that authored it.
+	commitEmails := func(commit *types.Commit) []string {
+		if containsEmail(in.AuthorVerifiedEmails, commit.Author.Identity.Email) {
+			return in.AuthorVerifiedEmails
+		}
+		return actorEmails
+	}
+
+	switch in.Method {
+	case enum.MergeMethodMerge, enum.MergeMethodFastForward:
+		for i := range commits {
+			v.checkCommit(checks, &commits[i], true, commitEmails(&commits[i]))
+		}
+	case enum.MergeMethodRebase:
+		// the rebased commits keep their authors, but the actor becomes their committer,
+		// so only the authors are verified.
+		for i := range commits {
+			rebased := commits[i]
+			rebased.Committer = rebased.Author
+			v.checkCommit(checks, &rebased, true, commitEmails(&rebased))
+		}
+	case enum.MergeMethodSquash:
+		// the commits of the pull request aren't added to the target branch.
+	}
+
+	newCommitMessage := in.CommitMessage != ""
+
+	switch in.Method {
+	case enum.MergeMethodMerge:
+		v.checkCommit(checks, newMergeCommit(mergeCommitLabel, in.CommitMessage, 2), newCommitMessage, nil)
+	case enum.MergeMethodSquash:
+		v.checkCommit(checks, newMergeCommit(squashCommitLabel, in.CommitMessage, 1), newCommitMessage, nil)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		// no new commit is created.
+	}
+
+	emails := slices.Clone(actorEmails)
+	for _, email := range in.AuthorVerifiedEmails {
+		if !containsEmail(emails, email) {
+			emails = append(emails, email)
+		}
+	}
+
+	v.addCommitViolations(checks, in.PullReq.TargetBranch, emails, violations)
+
+	if v.SignedCommitsRequired {
+		var unsigned []string
+		for i := range commits {
+			if !commits[i].Verification.IsVerified() {
+				unsigned = append(unsigned, commits[i].SHA)
+			}
+		}
+
+		if len(unsigned) > 0 {
+			violations.Addf(codePullReqMergeCommitSignature,
+				"All commits of the pull request must have a verified signature. Offending commits: %s",
+				formatCommitSHAs(unsigned))
+		}
+	}
+
+	return nil
+}
+
+// newMergeCommit returns the commit that the server creates when merging a pull request.
+// The commit doesn't exist yet, so the label is used in place of its SHA.
+func newMergeCommit(label, message string, parentCount int) *types.Commit {
+	title, _, _ := strings.Cut(message, "\n")
+
+	return &types.Commit{
+		SHA:        label,
+		ParentSHAs: make([]string, parentCount),
+		Title:      title,
+		Message:    message,
+	}
+}
+
+func (v *DefPush) Sanitize() error {
+	if v.CommitMessagePattern != "" {
+		if _, err := regexp.Compile(v.CommitMessagePattern); err != nil {
+			return fmt.Errorf("invalid commit message pattern: %w", err)
+		}
+	}
+
+	if v.CommitSubjectMaxLength < 0 {
+		return errors.New("commit subject max length must be zero or a positive integer")
+	}
+
+	for _, pattern := range v.ProtectedPaths {
+		if err := patternValidate(pattern); err != nil {
+			return fmt.Errorf("invalid protected path pattern %q: %w", pattern, err)
+		}
+	}
+
+	if v.FileSizeLimit < 0 {
+		return errors.New("file size limit must be zero or a positive integer")
+	}
+
+	for i, ext := range v.BlockedExtensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" || ext == "." {
+			return errors.New("blocked file extension must not be empty")
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		v.BlockedExtensions[i] = ext
+	}
+
+	return nil
+}
+
+// verifiedEmails returns the verified emails of the actor, or the email of the actor if none are provided.
+func verifiedEmails(actor *types.Principal, emails []string) []string {
+	if len(emails) == 0 {
+		return []string{actor.Email}
+	}
+
+	return emails
+}
+
+func containsEmail(emails []string, email string) bool {
+	return slices.ContainsFunc(emails, func(e string) bool {
+		return strings.EqualFold(e, email)
+	})
+}
+
+func formatCommitSHAs(shas []string) string {
+	short := make([]string, len(shas))
+	for i, sha := range shas {
+		if len(sha) > 8 {
+			sha = sha[:8]
+		}
+		short[i] = sha
+	}
+
+	return formatList(short)
+}
+
+func formatList(items []string) string {
+	result := strings.Join(items[:min(len(items), maxPushViolationItems)], ", ")
+	if len(items) > maxPushViolationItems {
+		result += fmt.Sprintf(" and %d more", len(items)-maxPushViolationItems)
+	}
+
+	return result
+}
+
+func appendUnique(items []string, item string) []string {
+	if slices.Contains(items, item) {
+		return items
+	}
+
+	return append(items, item)
+}
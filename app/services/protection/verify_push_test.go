@@ -0,0 +1,480 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// nolint:gocognit // it's a unit test
+func TestDefPush_PushVerify(t *testing.T) {
+	const branchName = "main"
+	const email = "john@example.com"
+
+	signature := types.Signature{Identity: types.Identity{Name: "John", Email: email}}
+	commitOK := types.Commit{
+		SHA:        "1111111111111111111111111111111111111111",
+		ParentSHAs: []string{"0000000000000000000000000000000000000000"},
+		Title:      "JIRA-1 short subject",
+		Message:    "JIRA-1 short subject\n\nbody",
+		Author:     signature,
+		Committer:  signature,
+		Verification: &types.SignatureVerification{
+			Result:    enum.GitSignatureVerified,
+			KeyScheme: enum.PublicKeySchemeSSH,
+		},
+	}
+	commitBadMessage := types.Commit{
+		SHA:        "2222222222222222222222222222222222222222",
+		ParentSHAs: []string{"0000000000000000000000000000000000000000"},
+		Title:      "a very long subject without ticket",
+		Message:    "a very long subject without ticket",
+		Author:     signature,
+		Committer:  signature,
+	}
+	commitMerge := types.Commit{
+		SHA: "3333333333333333333333333333333333333333",
+		ParentSHAs: []string{
+			"0000000000000000000000000000000000000000",
+			"1111111111111111111111111111111111111111",
+		},
+		Title:     "JIRA-2 merge",
+		Message:   "JIRA-2 merge",
+		Author:    types.Signature{Identity: types.Identity{Name: "Jane", Email: "jane@example.com"}},
+		Committer: signature,
+	}
+
+	files := []PushedFile{
+		{Path: "README.md", Size: 100},
+		{Path: "deploy/prod/config.yaml", Size: 200},
+		{Path: "bin/tool.EXE", Size: 5000},
+		{Path: "secrets.env", Deleted: true},
+	}
+
+	tests := []struct {
+		name      string
+		def       DefPush
+		commits   []types.Commit
+		truncated bool
+		emails    []string
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:    "empty",
+			commits: []types.Commit{commitOK, commitBadMessage, commitMerge},
+		},
+		{
+			name:      "empty-truncated",
+			commits:   []types.Commit{commitOK},
+			truncated: true,
+		},
+		{
+			name:    "file-rules-pass",
+			def:     DefPush{ProtectedPaths: []string{"docs/**"}, FileSizeLimit: 5000, BlockedExtensions: []string{"zip"}},
+			commits: []types.Commit{commitOK},
+		},
+		{
+			name:      "push.file.protected_path-fail",
+			def:       DefPush{ProtectedPaths: []string{"deploy/**", "*.env"}},
+			commits:   []types.Commit{commitOK},
+			expCodes:  []string{"push.file.protected_path"},
+			expParams: [][]any{{branchName, "deploy/prod/config.yaml, secrets.env"}},
+		},
+		{
+			name:      "push.file.size-fail",
+			def:       DefPush{FileSizeLimit: 1000},
+			commits:   []types.Commit{commitOK},
+			expCodes:  []string{"push.file.size"},
+			expParams: [][]any{{int64(1000), "bin/tool.EXE"}},
+		},
+		{
+			name:      "push.file.extension-fail",
+			def:       DefPush{BlockedExtensions: []string{"exe", ".ENV"}},
+			commits:   []types.Commit{commitOK},
+			expCodes:  []string{"push.file.extension"},
+			expParams: [][]any{{".exe, .env", "bin/tool.EXE"}},
+		},
+		{
+			name:    "all-rules-pass",
+			def:     DefPush{CommitMessagePattern: `^JIRA-\d+`, CommitSubjectMaxLength: 20, VerifiedEmailRequired: true},
+			commits: []types.Commit{commitOK},
+		},
+		{
+			name:      "push.commit.message-fail",
+			def:       DefPush{CommitMessagePattern: `^JIRA-\d+`},
+			commits:   []types.Commit{commitOK, commitBadMessage},
+			expCodes:  []string{"push.commit.message"},
+			expParams: [][]any{{`^JIRA-\d+`, "22222222"}},
+		},
+		{
+			name:      "push.commit.subject_length-fail",
+			def:       DefPush{CommitSubjectMaxLength: 20},
+			commits:   []types.Commit{commitOK, commitBadMessage},
+			expCodes:  []string{"push.commit.subject_length"},
+			expParams: [][]any{{20, "22222222"}},
+		},
+		{
+			name:      "push.commit.merge-fail",
+			def:       DefPush{MergeCommitsForbidden: true},
+			commits:   []types.Commit{commitOK, commitMerge},
+			expCodes:  []string{"push.commit.merge"},
+			expParams: [][]any{{branchName, "33333333"}},
+		},
+		{
+			name:      "push.commit.email-fail",
+			def:       DefPush{VerifiedEmailRequired: true},
+			commits:   []types.Commit{commitOK, commitMerge},
+			expCodes:  []string{"push.commit.email"},
+			expParams: [][]any{{email, "33333333"}},
+		},
+		{
+			name:    "push.commit.email-verified-emails-pass",
+			def:     DefPush{VerifiedEmailRequired: true},
+			commits: []types.Commit{commitOK, commitMerge},
+			emails:  []string{email, "JANE@example.com"},
+		},
+		{
+			name:      "push.commit.email-verified-emails-fail",
+			def:       DefPush{VerifiedEmailRequired: true},
+			commits:   []types.Commit{commitOK, commitMerge},
+			emails:    []string{"jane@example.com"},
+			expCodes:  []string{"push.commit.email"},
+			expParams: [][]any{{"jane@example.com", "11111111, 33333333"}},
+		},
+		{
+			name:      "push.commit.limit-fail",
+			def:       DefPush{CommitMessagePattern: `^JIRA-\d+`},
+			commits:   []types.Commit{commitOK},
+			truncated: true,
+			expCodes:  []string{"push.commit.limit"},
+			expParams: [][]any{{branchName}},
+		},
+		{
+			name:      "push.commit.limit-file-rules-fail",
+			def:       DefPush{FileSizeLimit: 10000},
+			commits:   []types.Commit{commitOK},
+			truncated: true,
+			expCodes:  []string{"push.commit.limit"},
+			expParams: [][]any{{branchName}},
+		},
+		{
+			name:      "push.commit.signature-fail",
+			def:       DefPush{SignedCommitsRequired: true},
+			commits:   []types.Commit{commitOK, commitBadMessage},
+			expCodes:  []string{"push.commit.signature"},
+			expParams: [][]any{{"22222222"}},
+		},
+		{
+			name:     "multiple-fail",
+			def:      DefPush{CommitMessagePattern: `^JIRA-\d+`, MergeCommitsForbidden: true},
+			commits:  []types.Commit{commitOK, commitBadMessage, commitMerge},
+			expCodes: []string{"push.commit.message", "push.commit.merge"},
+			expParams: [][]any{
+				{`^JIRA-\d+`, "22222222"},
+				{branchName, "33333333"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := PushVerifyInput{
+				Actor:            &types.Principal{ID: 1, Email: email},
+				BranchName:       branchName,
+				Commits:          test.commits,
+				CommitsTruncated: test.truncated,
+				VerifiedEmails:   test.emails,
+				ListFiles: func(context.Context) ([]PushedFile, error) {
+					return files, nil
+				},
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.PushVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+// nolint:gocognit // it's a unit test
+func TestDefPush_MergeVerify(t *testing.T) {
+	const targetBranch = "main"
+	const email = "john@example.com"
+
+	signature := types.Signature{Identity: types.Identity{Name: "John", Email: email}}
+	commitVerified := types.Commit{
+		SHA:          "1111111111111111111111111111111111111111",
+		ParentSHAs:   []string{"0000000000000000000000000000000000000000"},
+		Title:        "JIRA-1 feature",
+		Message:      "JIRA-1 feature",
+		Author:       signature,
+		Committer:    signature,
+		Verification: &types.SignatureVerification{Result: enum.GitSignatureVerified},
+	}
+	commitUnsigned := types.Commit{
+		SHA:        "2222222222222222222222222222222222222222",
+		ParentSHAs: []string{"1111111111111111111111111111111111111111"},
+		Title:      "fix",
+		Message:    "fix",
+		Author:     types.Signature{Identity: types.Identity{Name: "Jane", Email: "jane@example.com"}},
+		Committer:  types.Signature{Identity: types.Identity{Name: "Jane", Email: "jane@example.com"}},
+	}
+	commitBadSignature := types.Commit{
+		SHA: "3333333333333333333333333333333333333333",
+		ParentSHAs: []string{
+			"2222222222222222222222222222222222222222",
+			"0000000000000000000000000000000000000000",
+		},
+		Title:        "JIRA-2 merge main",
+		Message:      "JIRA-2 merge main",
+		Author:       signature,
+		Committer:    signature,
+		Verification: &types.SignatureVerification{Result: enum.GitSignatureBad},
+	}
+
+	const authorEmail = "jane@example.com"
+
+	commitMixedIdentities := types.Commit{
+		SHA:        "4444444444444444444444444444444444444444",
+		ParentSHAs: []string{"1111111111111111111111111111111111111111"},
+		Title:      "JIRA-1 fix",
+		Message:    "JIRA-1 fix",
+		Author:     signature,
+		Committer:  types.Signature{Identity: types.Identity{Name: "Jane", Email: authorEmail}},
+	}
+
+	tests := []struct {
+		name         string
+		def          DefPush
+		method       enum.MergeMethod
+		message      string
+		commits      []types.Commit
+		authorEmails []string
+		expCodes     []string
+		expParams    [][]any
+	}{
+		{
+			name:    "empty",
+			method:  enum.MergeMethodMerge,
+			commits: []types.Commit{commitVerified, commitUnsigned},
+		},
+		{
+			name:    "signed-commits-pass",
+			def:     DefPush{SignedCommitsRequired: true},
+			commits: []types.Commit{commitVerified},
+		},
+		{
+			name:      "signed-commits-fail",
+			def:       DefPush{SignedCommitsRequired: true},
+			commits:   []types.Commit{commitVerified, commitUnsigned, commitBadSignature},
+			expCodes:  []string{"pullreq.merge.commit_signature"},
+			expParams: [][]any{{"22222222, 33333333"}},
+		},
+		{
+			name:      "message-merge-fail",
+			def:       DefPush{CommitMessagePattern: `^JIRA-\d+`},
+			method:    enum.MergeMethodMerge,
+			message:   "Merge branch 'feature'",
+			commits:   []types.Commit{commitVerified, commitUnsigned},
+			expCodes:  []string{"push.commit.message"},
+			expParams: [][]any{{`^JIRA-\d+`, "22222222, (merge)"}},
+		},
+		{
+			name:    "message-squash-pass",
+			def:     DefPush{CommitMessagePattern: `^JIRA-\d+`},
+			method:  enum.MergeMethodSquash,
+			message: "JIRA-3 feature (#1)",
+			commits: []types.Commit{commitVerified, commitUnsigned},
+		},
+		{
+			name:      "message-squash-fail",
+			def:       DefPush{CommitMessagePattern: `^JIRA-\d+`},
+			method:    enum.MergeMethodSquash,
+			message:   "feature (#1)",
+			commits:   []types.Commit{commitVerified},
+			expCodes:  []string{"push.commit.message"},
+			expParams: [][]any{{`^JIRA-\d+`, "(squash)"}},
+		},
+		{
+			name:    "message-unknown-pass",
+			def:     DefPush{CommitMessagePattern: `^JIRA-\d+`},
+			method:  enum.MergeMethodSquash,
+			commits: []types.Commit{commitUnsigned},
+		},
+		{
+			name:      "merge-commits-merge-fail",
+			def:       DefPush{MergeCommitsForbidden: true},
+			method:    enum.MergeMethodMerge,
+			message:   "JIRA-3 merge",
+			commits:   []types.Commit{commitVerified},
+			expCodes:  []string{"push.commit.merge"},
+			expParams: [][]any{{targetBranch, "(merge)"}},
+		},
+		{
+			name:      "merge-commits-fast-forward-fail",
+			def:       DefPush{MergeCommitsForbidden: true},
+			method:    enum.MergeMethodFastForward,
+			commits:   []types.Commit{commitVerified, commitBadSignature},
+			expCodes:  []string{"push.commit.merge"},
+			expParams: [][]any{{targetBranch, "33333333"}},
+		},
+		{
+			name:    "merge-commits-squash-pass",
+			def:     DefPush{MergeCommitsForbidden: true},
+			method:  enum.MergeMethodSquash,
+			message: "JIRA-3 squash",
+			commits: []types.Commit{commitVerified, commitBadSignature},
+		},
+		{
+			name:      "email-rebase-fail",
+			def:       DefPush{VerifiedEmailRequired: true},
+			method:    enum.MergeMethodRebase,
+			commits:   []types.Commit{commitVerified, commitUnsigned},
+			expCodes:  []string{"push.commit.email"},
+			expParams: [][]any{{email, "22222222"}},
+		},
+		{
+			name:         "email-pull-request-author-pass",
+			def:          DefPush{VerifiedEmailRequired: true},
+			method:       enum.MergeMethodRebase,
+			commits:      []types.Commit{commitVerified, commitUnsigned},
+			authorEmails: []string{authorEmail},
+		},
+		{
+			name:         "email-mixed-identities-fail",
+			def:          DefPush{VerifiedEmailRequired: true},
+			method:       enum.MergeMethodFastForward,
+			commits:      []types.Commit{commitVerified, commitUnsigned, commitMixedIdentities},
+			authorEmails: []string{authorEmail},
+			expCodes:     []string{"push.commit.email"},
+			expParams:    [][]any{{email + ", " + authorEmail, "44444444"}},
+		},
+		{
+			name:    "email-squash-pass",
+			def:     DefPush{VerifiedEmailRequired: true},
+			method:  enum.MergeMethodSquash,
+			message: "JIRA-3 squash",
+			commits: []types.Commit{commitVerified, commitUnsigned},
+		},
+		{
+			name:      "files-squash-fail",
+			def:       DefPush{ProtectedPaths: []string{"deploy/**"}, FileSizeLimit: 1000},
+			method:    enum.MergeMethodSquash,
+			message:   "JIRA-3 squash",
+			commits:   []types.Commit{commitVerified},
+			expCodes:  []string{"push.file.protected_path", "push.file.size"},
+			expParams: [][]any{{targetBranch, "deploy/prod/config.yaml"}, {int64(1000), "bin/tool.exe"}},
+		},
+	}
+
+	files := []PushedFile{
+		{Path: "README.md", Size: 100},
+		{Path: "deploy/prod/config.yaml", Size: 200},
+		{Path: "bin/tool.exe", Size: 5000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := MergeVerifyInput{
+				Actor:         &types.Principal{ID: 1, Email: email},
+				PullReq:       &types.PullReq{TargetBranch: targetBranch},
+				Method:        test.method,
+				CommitMessage: test.message,
+				ListCommits: func(context.Context) ([]types.Commit, error) {
+					return test.commits, nil
+				},
+				ListFiles: func(context.Context) ([]PushedFile, error) {
+					return files, nil
+				},
+				AuthorVerifiedEmails: test.authorEmails,
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.MergeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestDefPush_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    DefPush
+		expErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "invalid-pattern",
+			def:    DefPush{CommitMessagePattern: "JIRA-("},
+			expErr: true,
+		},
+		{
+			name:   "negative-subject-length",
+			def:    DefPush{CommitSubjectMaxLength: -1},
+			expErr: true,
+		},
+		{
+			name:   "invalid-protected-path",
+			def:    DefPush{ProtectedPaths: []string{"deploy/[a"}},
+			expErr: true,
+		},
+		{
+			name:   "negative-file-size-limit",
+			def:    DefPush{FileSizeLimit: -1},
+			expErr: true,
+		},
+		{
+			name:   "empty-extension",
+			def:    DefPush{BlockedExtensions: []string{" "}},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr && err == nil {
+				t.Error("expected an error but got none")
+			} else if !test.expErr && err != nil {
+				t.Errorf("got an error: %s", err.Error())
+			}
+		})
+	}
+}
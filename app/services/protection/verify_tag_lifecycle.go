@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+// DefTagLifecycle defines restrictions on creation, deletion and retargeting of tags.
+type DefTagLifecycle struct {
+	CreateForbidden    bool    `json:"create_forbidden,omitempty"`
+	CreateUserIDs      []int64 `json:"create_user_ids,omitempty"`
+	CreateUserGroupIDs []int64 `json:"create_user_group_ids,omitempty"`
+	DeleteForbidden    bool    `json:"delete_forbidden,omitempty"`
+	UpdateForbidden    bool    `json:"update_forbidden,omitempty"`
+
+	// ProtectedTargetRequired requires the tagged commit to be reachable from a protected branch,
+	// that is a branch matched by an active branch rule of the repository.
+	ProtectedTargetRequired bool `json:"protected_target_required,omitempty"`
+}
+
+// ensures that the DefTagLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefTagLifecycle)(nil)
+	_ RefChangeVerifier = (*DefTagLifecycle)(nil)
+)
+
+const (
+	codeTagLifecycleCreate          = "tag.lifecycle.create"
+	codeTagLifecycleCreateUser      = "tag.lifecycle.create.user"
+	codeTagLifecycleDelete          = "tag.lifecycle.delete"
+	codeTagLifecycleUpdate          = "tag.lifecycle.update"
+	codeTagLifecycleProtectedTarget = "tag.lifecycle.protected_target"
+)
+
+func (v *DefTagLifecycle) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	switch in.RefAction {
+	case RefActionCreate:
+		if v.CreateForbidden {
+			violations.Addf(codeTagLifecycleCreate,
+				"Creation of tags is not allowed. Offending tags: %s", formatList(in.RefNames))
+			break
+		}
+
+		isCreator, err := v.isCreator(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isCreator {
+			violations.Addf(codeTagLifecycleCreateUser,
+				"Only selected users are allowed to create tags. Offending tags: %s", formatList(in.RefNames))
+		}
+	case RefActionDelete:
+		if v.DeleteForbidden {
+			violations.Addf(codeTagLifecycleDelete,
+				"Delete of tags is not allowed. Offending tags: %s", formatList(in.RefNames))
+		}
+	case RefActionUpdate, RefActionUpdateForce:
+		if v.UpdateForbidden {
+			violations.Addf(codeTagLifecycleUpdate,
+				"Moving tags to another target is not allowed. Offending tags: %s", formatList(in.RefNames))
+		}
+	}
+
+	if in.RefAction != RefActionDelete {
+		if err := v.verifyProtectedTargets(ctx, in, &violations); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// isCreator returns true if the actor is allowed to create tags.
+// If no users or user groups are set, everyone is allowed to create tags.
+func (v *DefTagLifecycle) isCreator(ctx context.Context, in RefChangeVerifyInput) (bool, error) {
+	if len(v.CreateUserIDs) == 0 && len(v.CreateUserGroupIDs) == 0 {
+		return true, nil
+	}
+
+	if in.Actor == nil {
+		return false, nil
+	}
+
+	if slices.Contains(v.CreateUserIDs, in.Actor.ID) {
+		return true, nil
+	}
+
+	if len(v.CreateUserGroupIDs) == 0 {
+		return false, nil
+	}
+
+	if in.ResolveUserGroupID == nil {
+		return false, errors.New("user group resolver is required to verify tag creators")
+	}
+
+	userIDs, err := in.ResolveUserGroupID(ctx, v.CreateUserGroupIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve members of the tag creator user groups: %w", err)
+	}
+
+	return slices.Contains(userIDs, in.Actor.ID), nil
+}
+
+// verifyProtectedTargets checks that the tagged commits are reachable from a protected branch.
+func (v *DefTagLifecycle) verifyProtectedTargets(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+	violations *types.RuleViolations,
+) error {
+	if !v.ProtectedTargetRequired {
+		return nil
+	}
+
+	if in.ListBranchesContaining == nil || in.isProtectedBranch == nil {
+		return errors.New("branch listing and branch rules are required to verify tag targets")
+	}
+
+	var unreachable []string
+
+	for _, tagName := range in.RefNames {
+		branchNames, err := in.ListBranchesContaining(ctx, tagName)
+		if err != nil {
+			return fmt.Errorf("failed to list branches containing the target of tag %q: %w", tagName, err)
+		}
+
+		var protected bool
+		for _, branchName := range branchNames {
+			protected, err = in.isProtectedBranch(branchName)
+			if err != nil {
+				return fmt.Errorf("failed to check if branch %q is protected: %w", branchName, err)
+			}
+			if protected {
+				break
+			}
+		}
+
+		if !protected {
+			unreachable = append(unreachable, tagName)
+		}
+	}
+
+	if len(unreachable) > 0 {
+		violations.Addf(codeTagLifecycleProtectedTarget,
+			"Tagged commits must be reachable from a protected branch. Offending tags: %s",
+			formatList(unreachable))
+	}
+
+	return nil
+}
+
+func (v *DefTagLifecycle) Sanitize() error {
+	if err := validateIDSlice(v.CreateUserIDs); err != nil {
+		return fmt.Errorf("create user IDs error: %w", err)
+	}
+
+	if err := validateIDSlice(v.CreateUserGroupIDs); err != nil {
+		return fmt.Errorf("create usergroup IDs error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// nolint:gocognit // it's a unit test
+func TestDefTagLifecycle_RefChangeVerify(t *testing.T) {
+	const tagName = "v1.0.0"
+
+	listBranches := func(context.Context, string) ([]string, error) {
+		return []string{"feature/x", "release/1.0"}, nil
+	}
+
+	// the tagged commit exists only on unprotected branches.
+	listUnprotectedBranches := func(context.Context, string) ([]string, error) {
+		return []string{"feature/x"}, nil
+	}
+
+	isProtectedBranch := func(branchName string) (bool, error) {
+		return branchName == "main" || strings.HasPrefix(branchName, "release/"), nil
+	}
+
+	tests := []struct {
+		name         string
+		def          DefTagLifecycle
+		actorID      int64
+		action       RefAction
+		listBranches func(context.Context, string) ([]string, error)
+		expCodes     []string
+		expParams    [][]any
+	}{
+		{
+			name:   "empty",
+			action: RefActionCreate,
+		},
+		{
+			name:      "tag.lifecycle.create-fail",
+			def:       DefTagLifecycle{CreateForbidden: true},
+			action:    RefActionCreate,
+			expCodes:  []string{"tag.lifecycle.create"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:    "tag.lifecycle.create.user-pass",
+			def:     DefTagLifecycle{CreateUserIDs: []int64{42}},
+			actorID: 42,
+			action:  RefActionCreate,
+		},
+		{
+			name:    "tag.lifecycle.create.user-group-pass",
+			def:     DefTagLifecycle{CreateUserGroupIDs: []int64{1}},
+			actorID: 43,
+			action:  RefActionCreate,
+		},
+		{
+			name:      "tag.lifecycle.create.user-group-fail",
+			def:       DefTagLifecycle{CreateUserGroupIDs: []int64{1}},
+			actorID:   66,
+			action:    RefActionCreate,
+			expCodes:  []string{"tag.lifecycle.create.user"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:      "tag.lifecycle.create.user-fail",
+			def:       DefTagLifecycle{CreateUserIDs: []int64{42}},
+			actorID:   66,
+			action:    RefActionCreate,
+			expCodes:  []string{"tag.lifecycle.create.user"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:      "tag.lifecycle.delete-fail",
+			def:       DefTagLifecycle{DeleteForbidden: true},
+			action:    RefActionDelete,
+			expCodes:  []string{"tag.lifecycle.delete"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:      "tag.lifecycle.update-fail",
+			def:       DefTagLifecycle{UpdateForbidden: true},
+			action:    RefActionUpdate,
+			expCodes:  []string{"tag.lifecycle.update"},
+			expParams: [][]any{{tagName}},
+		},
+		{
+			name:   "tag.lifecycle.protected_target-pass",
+			def:    DefTagLifecycle{ProtectedTargetRequired: true},
+			action: RefActionCreate,
+		},
+		{
+			name:         "tag.lifecycle.protected_target-fail",
+			def:          DefTagLifecycle{ProtectedTargetRequired: true},
+			action:       RefActionCreate,
+			listBranches: listUnprotectedBranches,
+			expCodes:     []string{"tag.lifecycle.protected_target"},
+			expParams:    [][]any{{tagName}},
+		},
+		{
+			name:         "tag.lifecycle.protected_target-update-fail",
+			def:          DefTagLifecycle{ProtectedTargetRequired: true},
+			action:       RefActionUpdate,
+			listBranches: listUnprotectedBranches,
+			expCodes:     []string{"tag.lifecycle.protected_target"},
+			expParams:    [][]any{{tagName}},
+		},
+		{
+			name:         "tag.lifecycle.protected_target-delete",
+			def:          DefTagLifecycle{ProtectedTargetRequired: true},
+			action:       RefActionDelete,
+			listBranches: listUnprotectedBranches,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				ResolveUserGroupID:     mockUserGroupResolver,
+				Actor:                  &types.Principal{ID: test.actorID},
+				RefNames:               []string{tagName},
+				RefAction:              test.action,
+				RefType:                RefTypeTag,
+				ListBranchesContaining: listBranches,
+				isProtectedBranch:      isProtectedBranch,
+			}
+			if test.listBranches != nil {
+				in.ListBranchesContaining = test.listBranches
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+// TestDefTagLifecycle_ProtectedTargetFailsClosed verifies that the tag targets aren't accepted
+// if the branches containing them can't be listed.
+func TestDefTagLifecycle_ProtectedTargetFailsClosed(t *testing.T) {
+	def := DefTagLifecycle{ProtectedTargetRequired: true}
+
+	_, err := def.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+		Actor:     &types.Principal{ID: 1},
+		RefNames:  []string{"v1"},
+		RefAction: RefActionCreate,
+		RefType:   RefTypeTag,
+		isProtectedBranch: func(string) (bool, error) {
+			return true, nil
+		},
+	})
+	if err == nil {
+		t.Error("expected an error but got none")
+	}
+}
+
+func TestTag_RefChangeVerify(t *testing.T) {
+	tag := Tag{
+		Bypass:    DefBypass{UserIDs: []int64{42}},
+		Lifecycle: DefTagLifecycle{DeleteForbidden: true},
+	}
+
+	tests := []struct {
+		name          string
+		refType       RefType
+		actorID       int64
+		expViolations int
+		expBypassed   bool
+	}{
+		{
+			name:    "branch-ignored",
+			refType: RefTypeBranch,
+			actorID: 66,
+		},
+		{
+			name:          "tag-violation",
+			refType:       RefTypeTag,
+			actorID:       66,
+			expViolations: 1,
+		},
+		{
+			name:          "tag-bypassed",
+			refType:       RefTypeTag,
+			actorID:       42,
+			expViolations: 1,
+			expBypassed:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := tag.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+				Actor:       &types.Principal{ID: test.actorID},
+				AllowBypass: true,
+				RefAction:   RefActionDelete,
+				RefType:     test.refType,
+				RefNames:    []string{"v1"},
+			})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if want, got := test.expViolations, len(violations); want != got {
+				t.Errorf("violation count: want=%d got=%d", want, got)
+				return
+			}
+
+			for _, v := range violations {
+				if want, got := test.expBypassed, v.Bypassed; want != got {
+					t.Errorf("bypassed: want=%t got=%t", want, got)
+				}
+			}
+		})
+	}
+}
+
+// TestRuleSet_RefChangeVerifyProtectedTarget verifies that a tag can't be pushed onto a commit
+// that exists only on branches that aren't protected by a branch rule.
+func TestRuleSet_RefChangeVerifyProtectedTarget(t *testing.T) {
+	rules := []types.RuleInfoInternal{
+		{
+			RuleInfo:   types.RuleInfo{ID: 1, Identifier: "branches", Type: TypeBranch, State: enum.RuleStateActive},
+			Pattern:    []byte(`{"default":true}`),
+			Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+		},
+		{
+			RuleInfo:   types.RuleInfo{ID: 2, Identifier: "monitored", Type: TypeBranch, State: enum.RuleStateMonitor},
+			Pattern:    []byte(`{"include":["monitored"]}`),
+			Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+		},
+		{
+			RuleInfo:   types.RuleInfo{ID: 3, Identifier: "tags", Type: TypeTag, State: enum.RuleStateActive},
+			Pattern:    []byte(`{"include":["v*"]}`),
+			Definition: []byte(`{"lifecycle":{"protected_target_required":true}}`),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		branchNames   []string
+		expViolations int
+	}{
+		{name: "on-protected-branch", branchNames: []string{"feature", "main"}},
+		{name: "on-unprotected-branch", branchNames: []string{"feature"}, expViolations: 1},
+		{name: "on-monitored-branch", branchNames: []string{"monitored"}, expViolations: 1},
+		{name: "on-no-branch", expViolations: 1},
+	}
+
+	m, err := ProvideManager(nil)
+	if err != nil {
+		t.Fatalf("failed to create manager: %s", err.Error())
+	}
+
+	set := ruleSet{rules: rules, manager: m}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := set.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+				Actor:     &types.Principal{ID: 1},
+				Repo:      &types.RepositoryCore{ID: 1, DefaultBranch: "main"},
+				RefAction: RefActionCreate,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"v1.0.0"},
+				ListBranchesContaining: func(context.Context, string) ([]string, error) {
+					return test.branchNames, nil
+				},
+			})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if want, got := test.expViolations, len(violations); want != got {
+				t.Errorf("violation count: want=%d got=%d", want, got)
+				return
+			}
+
+			for _, v := range violations {
+				if want, got := "tags", v.Rule.Identifier; want != got {
+					t.Errorf("violated rule: want=%s got=%s", want, got)
+				}
+				if want, got := codeTagLifecycleProtectedTarget, v.Violations[0].Code; want != got {
+					t.Errorf("violation code: want=%s got=%s", want, got)
+				}
+			}
+		})
+	}
+}
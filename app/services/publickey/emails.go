@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListVerifiedEmails returns the emails that the principal is allowed to author and commit with.
+// Only the email of the principal is verified, the emails of the identities of its signing keys
+// are chosen by the uploader and aren't verified.
+func (s LocalService) ListVerifiedEmails(_ context.Context, principal *types.PrincipalInfo) ([]string, error) {
+	return []string{principal.Email}, nil
+}
+
+// ServerCommitVerification returns the signature verification of the commits created by the server.
+// It returns nil if the server signing key is not configured, because the server doesn't sign the commits then.
+func (s LocalService) ServerCommitVerification(ctx context.Context) (*types.SignatureVerification, error) {
+	key, err := s.serverKey.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, nil //nolint:nilnil // nil means that the server doesn't sign the commits.
+	}
+
+	info := key.info()
+
+	return &types.SignatureVerification{
+		Result:         enum.GitSignatureVerified,
+		KeyScheme:      info.Scheme,
+		KeyFingerprint: info.Fingerprint,
+	}, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	//nolint:staticcheck // the package is deprecated, but there is no replacement among the dependencies.
+	"golang.org/x/crypto/openpgp"
+	//nolint:staticcheck // the package is deprecated, but there is no replacement among the dependencies.
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// ParsePGP parses an armored OpenPGP public key.
+// The key data must contain exactly one key (with any number of subkeys).
+func ParsePGP(keyData string) (PGPKeyInfo, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return PGPKeyInfo{}, errors.InvalidArgument("failed to read PGP public key: %s", err.Error())
+	}
+
+	if len(entities) != 1 {
+		return PGPKeyInfo{}, errors.InvalidArgument("exactly one PGP public key must be provided")
+	}
+
+	if entities[0].PrivateKey != nil {
+		return PGPKeyInfo{}, errors.InvalidArgument("PGP private keys are not accepted")
+	}
+
+	return PGPKeyInfo{
+		Entity: entities[0],
+	}, nil
+}
+
+type PGPKeyInfo struct {
+	Entity *openpgp.Entity
+}
+
+// Fingerprint returns the fingerprint of the primary key as an upper case hex string.
+func (key PGPKeyInfo) Fingerprint() string {
+	return strings.ToUpper(hex.EncodeToString(key.Entity.PrimaryKey.Fingerprint[:]))
+}
+
+// Type returns the name of the public key algorithm of the primary key.
+func (key PGPKeyInfo) Type() string {
+	switch key.Entity.PrimaryKey.PubKeyAlgo {
+	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSASignOnly, packet.PubKeyAlgoRSAEncryptOnly:
+		return "pgp-rsa"
+	case packet.PubKeyAlgoDSA:
+		return "pgp-dsa"
+	case packet.PubKeyAlgoECDSA:
+		return "pgp-ecdsa"
+	case packet.PubKeyAlgoECDH:
+		return "pgp-ecdh"
+	case packet.PubKeyAlgoElGamal:
+		return "pgp-elgamal"
+	default:
+		return "pgp"
+	}
+}
+
+// Comment returns the primary identity of the key.
+func (key PGPKeyInfo) Comment() string {
+	for name, identity := range key.Entity.Identities {
+		if identity.SelfSignature != nil && identity.SelfSignature.IsPrimaryId != nil &&
+			*identity.SelfSignature.IsPrimaryId {
+			return name
+		}
+	}
+
+	// fallback to any identity in alphabetical order to get a stable result.
+	var comment string
+	for name := range key.Entity.Identities {
+		if comment == "" || name < comment {
+			comment = name
+		}
+	}
+
+	return comment
+}
+
+// HasEmail returns true if any of the identities of the key has the provided email address.
+func (key PGPKeyInfo) HasEmail(email string) bool {
+	for _, identity := range key.Entity.Identities {
+		if identity.UserId != nil && strings.EqualFold(identity.UserId.Email, email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Emails returns the email addresses of the identities of the key.
+func (key PGPKeyInfo) Emails() []string {
+	emails := make([]string, 0, len(key.Entity.Identities))
+	for _, identity := range key.Entity.Identities {
+		if identity.UserId != nil && identity.UserId.Email != "" {
+			emails = append(emails, identity.UserId.Email)
+		}
+	}
+
+	return emails
+}
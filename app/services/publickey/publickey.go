@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -33,21 +34,41 @@ type Service interface {
 		publicKey ssh.PublicKey,
 		usage enum.PublicKeyUsage,
 	) (*types.PrincipalInfo, error)
+
+	VerifySignature(ctx context.Context,
+		signedData *git.SignedData,
+		signerEmail string,
+	) (*types.SignatureVerification, error)
+
+	VerifyCommits(ctx context.Context,
+		gitCommits []git.Commit,
+		commits []types.Commit,
+	) error
+
+	ListVerifiedEmails(ctx context.Context, principal *types.PrincipalInfo) ([]string, error)
+
+	ServerCommitVerification(ctx context.Context) (*types.SignatureVerification, error)
 }
 
 func NewService(
 	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
 	pCache store.PrincipalInfoCache,
+	serverKey *ServerKey,
 ) LocalService {
 	return LocalService{
 		publicKeyStore: publicKeyStore,
+		principalStore: principalStore,
 		pCache:         pCache,
+		serverKey:      serverKey,
 	}
 }
 
 type LocalService struct {
 	publicKeyStore store.PublicKeyStore
+	principalStore store.PrincipalStore
 	pCache         store.PrincipalInfoCache
+	serverKey      *ServerKey
 }
 
 // ValidateKey tries to match the provided key to one of the keys in the database.
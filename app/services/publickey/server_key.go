@@ -0,0 +1,330 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	//nolint:staticcheck // the package is deprecated, but there is no replacement among the dependencies.
+	"golang.org/x/crypto/openpgp"
+	//nolint:staticcheck // the package is deprecated, but there is no replacement among the dependencies.
+	"golang.org/x/crypto/openpgp/armor"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ensures that the ServerKey type implements the git's Signer interface.
+var _ api.Signer = (*ServerKey)(nil)
+
+// ServerKey manages the key that is used by the server to sign the commits it creates.
+// The private key is stored encrypted in the system settings.
+type ServerKey struct {
+	settings  *settings.Service
+	encrypter encrypt.Encrypter
+
+	mx           sync.Mutex
+	cachedStored []byte
+	cachedKey    serverSigningKey
+}
+
+func NewServerKey(
+	settings *settings.Service,
+	encrypter encrypt.Encrypter,
+) *ServerKey {
+	return &ServerKey{
+		settings:  settings,
+		encrypter: encrypter,
+	}
+}
+
+// storedServerKey is the value of the server signing key stored in the system settings.
+type storedServerKey struct {
+	Scheme     enum.PublicKeyScheme `json:"scheme"`
+	PrivateKey []byte               `json:"private_key"` // encrypted
+}
+
+type serverSigningKey interface {
+	sign(payload []byte) ([]byte, error)
+	verify(signedData *git.SignedData) bool
+	info() types.ServerSigningKey
+}
+
+// Set parses the armored private key and stores it as the server signing key.
+func (k *ServerKey) Set(
+	ctx context.Context,
+	scheme enum.PublicKeyScheme,
+	privateKey string,
+) (*types.ServerSigningKey, error) {
+	privateKey = strings.TrimSpace(privateKey)
+	if privateKey == "" {
+		return nil, errors.InvalidArgument("private key not provided")
+	}
+
+	key, err := parseServerSigningKey(scheme, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := k.encrypter.Encrypt(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt server signing key: %w", err)
+	}
+
+	err = k.settings.SystemSet(ctx, settings.KeyServerSigningKey, storedServerKey{
+		Scheme:     scheme,
+		PrivateKey: encrypted,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store server signing key: %w", err)
+	}
+
+	info := key.info()
+
+	return &info, nil
+}
+
+// Delete removes the server signing key. The server doesn't sign commits without it.
+func (k *ServerKey) Delete(ctx context.Context) error {
+	err := k.settings.SystemSet(ctx, settings.KeyServerSigningKey, storedServerKey{})
+	if err != nil {
+		return fmt.Errorf("failed to remove server signing key: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the public part of the server signing key.
+func (k *ServerKey) Get(ctx context.Context) (*types.ServerSigningKey, error) {
+	key, err := k.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, errors.NotFound("Server signing key is not configured")
+	}
+
+	info := key.info()
+
+	return &info, nil
+}
+
+// Sign signs the payload with the server signing key.
+// It returns nil if the server signing key is not configured.
+func (k *ServerKey) Sign(ctx context.Context, payload []byte) ([]byte, error) {
+	key, err := k.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, nil
+	}
+
+	return key.sign(payload)
+}
+
+// verify returns the verification result if the signed data is signed with the server signing key.
+// It returns nil if the data isn't signed by the server.
+func (k *ServerKey) verify(ctx context.Context, signedData *git.SignedData) (*types.SignatureVerification, error) {
+	key, err := k.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil || !key.verify(signedData) {
+		return nil, nil //nolint:nilnil // nil means that the data isn't signed by the server.
+	}
+
+	info := key.info()
+
+	return &types.SignatureVerification{
+		Result:         enum.GitSignatureVerified,
+		KeyScheme:      info.Scheme,
+		KeyFingerprint: info.Fingerprint,
+	}, nil
+}
+
+// load reads the server signing key from the settings.
+// The parsed key is cached as long as the stored key doesn't change.
+func (k *ServerKey) load(ctx context.Context) (serverSigningKey, error) {
+	var stored storedServerKey
+
+	found, err := k.settings.SystemGet(ctx, settings.KeyServerSigningKey, &stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server signing key: %w", err)
+	}
+
+	if !found || len(stored.PrivateKey) == 0 {
+		return nil, nil //nolint:nilnil // nil means that the server signing key is not configured.
+	}
+
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	if k.cachedKey != nil && bytes.Equal(k.cachedStored, stored.PrivateKey) {
+		return k.cachedKey, nil
+	}
+
+	privateKey, err := k.encrypter.Decrypt(stored.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt server signing key: %w", err)
+	}
+
+	key, err := parseServerSigningKey(stored.Scheme, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server signing key: %w", err)
+	}
+
+	k.cachedStored = stored.PrivateKey
+	k.cachedKey = key
+
+	return key, nil
+}
+
+func parseServerSigningKey(scheme enum.PublicKeyScheme, privateKey string) (serverSigningKey, error) {
+	switch scheme {
+	case enum.PublicKeySchemePGP:
+		return parsePGPServerKey(privateKey)
+	case enum.PublicKeySchemeSSH:
+		return parseSSHServerKey(privateKey)
+	default:
+		return nil, errors.InvalidArgument("invalid value for key scheme")
+	}
+}
+
+type pgpServerKey struct {
+	entity *openpgp.Entity
+}
+
+func parsePGPServerKey(privateKey string) (pgpServerKey, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return pgpServerKey{}, errors.InvalidArgument("failed to read PGP private key: %s", err.Error())
+	}
+
+	if len(entities) != 1 {
+		return pgpServerKey{}, errors.InvalidArgument("exactly one PGP private key must be provided")
+	}
+
+	entity := entities[0]
+
+	if entity.PrivateKey == nil {
+		return pgpServerKey{}, errors.InvalidArgument("PGP private key not provided")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		return pgpServerKey{}, errors.InvalidArgument("passphrase protected PGP keys are not supported")
+	}
+
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			return pgpServerKey{}, errors.InvalidArgument("passphrase protected PGP keys are not supported")
+		}
+	}
+
+	return pgpServerKey{entity: entity}, nil
+}
+
+func (key pgpServerKey) sign(payload []byte) ([]byte, error) {
+	signature := bytes.NewBuffer(nil)
+
+	err := openpgp.ArmoredDetachSign(signature, key.entity, bytes.NewReader(payload), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PGP signature: %w", err)
+	}
+
+	return signature.Bytes(), nil
+}
+
+func (key pgpServerKey) verify(signedData *git.SignedData) bool {
+	_, err := openpgp.CheckArmoredDetachedSignature(
+		openpgp.EntityList{key.entity},
+		bytes.NewReader(signedData.Payload),
+		bytes.NewReader(signedData.Signature),
+	)
+
+	return err == nil
+}
+
+func (key pgpServerKey) info() types.ServerSigningKey {
+	keyInfo := PGPKeyInfo{Entity: key.entity}
+
+	publicKey := bytes.NewBuffer(nil)
+	if w, err := armor.Encode(publicKey, openpgp.PublicKeyType, nil); err == nil {
+		_ = key.entity.Serialize(w)
+		_ = w.Close()
+	}
+
+	return types.ServerSigningKey{
+		Scheme:      enum.PublicKeySchemePGP,
+		Type:        keyInfo.Type(),
+		Fingerprint: keyInfo.Fingerprint(),
+		PublicKey:   publicKey.String(),
+	}
+}
+
+type sshServerKey struct {
+	signer gossh.Signer
+}
+
+func parseSSHServerKey(privateKey string) (sshServerKey, error) {
+	signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		var errPassphrase *gossh.PassphraseMissingError
+		if errors.As(err, &errPassphrase) {
+			return sshServerKey{}, errors.InvalidArgument("passphrase protected SSH keys are not supported")
+		}
+
+		return sshServerKey{}, errors.InvalidArgument("failed to read SSH private key: %s", err.Error())
+	}
+
+	return sshServerKey{signer: signer}, nil
+}
+
+func (key sshServerKey) sign(payload []byte) ([]byte, error) {
+	return SignSSH(key.signer, payload)
+}
+
+func (key sshServerKey) verify(signedData *git.SignedData) bool {
+	sig, err := ParseSSHSignature(signedData.Signature)
+	if err != nil {
+		return false
+	}
+
+	return From(key.signer.PublicKey()).MatchesKey(sig.PublicKey) && sig.Verify(signedData.Payload) == nil
+}
+
+func (key sshServerKey) info() types.ServerSigningKey {
+	keyInfo := From(key.signer.PublicKey())
+
+	return types.ServerSigningKey{
+		Scheme:      enum.PublicKeySchemeSSH,
+		Type:        keyInfo.Type(),
+		Fingerprint: keyInfo.Fingerprint(),
+		PublicKey:   strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key.signer.PublicKey()))),
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	//nolint:staticcheck // the package is deprecated, but there is no replacement among the dependencies.
+	"golang.org/x/crypto/openpgp"
+	//nolint:staticcheck // the package is deprecated, but there is no replacement among the dependencies.
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+)
+
+const (
+	pgpSignaturePrefix = "-----BEGIN PGP SIGNATURE-----"
+	sshSignaturePrefix = "-----BEGIN SSH SIGNATURE-----"
+)
+
+// VerifySignature verifies the signature of a git object (commit or tag) against the signing keys of the users.
+// The signerEmail is the email of the identity that claims to have signed the object (committer or tagger).
+// It returns nil if the object isn't signed.
+func (s LocalService) VerifySignature(
+	ctx context.Context,
+	signedData *git.SignedData,
+	signerEmail string,
+) (*types.SignatureVerification, error) {
+	if signedData == nil || len(signedData.Signature) == 0 {
+		return nil, nil //nolint:nilnil // nil means that the object isn't signed.
+	}
+
+	// objects created by the server are signed with the server signing key.
+	serverVerification, err := s.serverKey.verify(ctx, signedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signature with server signing key: %w", err)
+	}
+
+	if serverVerification != nil {
+		return serverVerification, nil
+	}
+
+	switch {
+	case bytes.HasPrefix(signedData.Signature, []byte(pgpSignaturePrefix)):
+		return s.verifyPGPSignature(ctx, signedData, signerEmail)
+	case bytes.HasPrefix(signedData.Signature, []byte(sshSignaturePrefix)):
+		return s.verifySSHSignature(ctx, signedData, signerEmail)
+	default:
+		// signature format is not supported (e.g. x509)
+		return &types.SignatureVerification{Result: enum.GitSignatureUnverified}, nil
+	}
+}
+
+// VerifyCommits verifies the signatures of the git commits and stores the results
+// to the Verification field of the corresponding commits. Both slices must be of the same length.
+func (s LocalService) VerifyCommits(
+	ctx context.Context,
+	gitCommits []git.Commit,
+	commits []types.Commit,
+) error {
+	if len(gitCommits) != len(commits) {
+		return fmt.Errorf("commit count mismatch: %d git commits, %d commits", len(gitCommits), len(commits))
+	}
+
+	for i := range gitCommits {
+		verification, err := s.VerifySignature(ctx, gitCommits[i].SignedData, gitCommits[i].Committer.Identity.Email)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature of commit %s: %w", gitCommits[i].SHA, err)
+		}
+
+		commits[i].Verification = verification
+	}
+
+	return nil
+}
+
+func (s LocalService) verifySSHSignature(
+	ctx context.Context,
+	signedData *git.SignedData,
+	signerEmail string,
+) (*types.SignatureVerification, error) {
+	result := &types.SignatureVerification{
+		KeyScheme: enum.PublicKeySchemeSSH,
+	}
+
+	sig, err := ParseSSHSignature(signedData.Signature)
+	if err != nil {
+		result.Result = enum.GitSignatureBad
+		return result, nil
+	}
+
+	key := From(sig.PublicKey)
+	result.KeyFingerprint = key.Fingerprint()
+
+	existingKeys, err := s.publicKeyStore.ListByFingerprint(ctx, result.KeyFingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys by fingerprint: %w", err)
+	}
+
+	var signingKey *types.PublicKey
+	for i := range existingKeys {
+		if existingKeys[i].Usage == enum.PublicKeyUsageSign &&
+			existingKeys[i].Scheme == enum.PublicKeySchemeSSH &&
+			key.Matches(existingKeys[i].Content) {
+			signingKey = &existingKeys[i]
+			break
+		}
+	}
+
+	if signingKey == nil {
+		result.Result = enum.GitSignatureUnknownKey
+		return result, nil
+	}
+
+	if err = sig.Verify(signedData.Payload); err != nil {
+		result.Result = enum.GitSignatureBad
+		return result, nil
+	}
+
+	signer, err := s.pCache.Get(ctx, signingKey.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal info of the signing key owner: %w", err)
+	}
+
+	result.Signer = signer
+
+	if !strings.EqualFold(signer.Email, signerEmail) {
+		result.Result = enum.GitSignatureUnverified
+		return result, nil
+	}
+
+	result.Result = enum.GitSignatureVerified
+
+	return result, nil
+}
+
+func (s LocalService) verifyPGPSignature(
+	ctx context.Context,
+	signedData *git.SignedData,
+	signerEmail string,
+) (*types.SignatureVerification, error) {
+	result := &types.SignatureVerification{
+		KeyScheme: enum.PublicKeySchemePGP,
+	}
+
+	// PGP signatures only contain the ID of the signing key, so only keys of the principal
+	// with the email of the signer are considered.
+	principal, err := s.principalStore.FindByEmail(ctx, signerEmail)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		result.Result = enum.GitSignatureUnknownKey
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	existingKeys, err := s.publicKeyStore.List(ctx, principal.ID, &types.PublicKeyFilter{
+		Usages:  []enum.PublicKeyUsage{enum.PublicKeyUsageSign},
+		Schemes: []enum.PublicKeyScheme{enum.PublicKeySchemePGP},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys of the principal: %w", err)
+	}
+
+	keyring := make(openpgp.EntityList, 0, len(existingKeys))
+	for i := range existingKeys {
+		key, err := ParsePGP(existingKeys[i].Content)
+		if err != nil {
+			// the keys are validated when added, so this shouldn't happen.
+			continue
+		}
+		keyring = append(keyring, key.Entity)
+	}
+
+	entity, err := openpgp.CheckArmoredDetachedSignature(
+		keyring,
+		bytes.NewReader(signedData.Payload),
+		bytes.NewReader(signedData.Signature),
+	)
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		result.Result = enum.GitSignatureUnknownKey
+		return result, nil
+	}
+	if err != nil {
+		result.Result = enum.GitSignatureBad
+		return result, nil
+	}
+
+	signingKey := PGPKeyInfo{Entity: entity}
+
+	result.KeyFingerprint = signingKey.Fingerprint()
+	result.Signer = principal.ToPrincipalInfo()
+
+	if !signingKey.HasEmail(signerEmail) {
+		result.Result = enum.GitSignatureUnverified
+		return result, nil
+	}
+
+	result.Result = enum.GitSignatureVerified
+
+	return result, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// The SSH signature format is described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+
+const (
+	sshSigMagic        = "SSHSIG"
+	sshSigVersion      = 1
+	sshSigPEMType      = "SSH SIGNATURE"
+	sshSigNamespaceGit = "git"
+)
+
+var (
+	errSSHSigInvalid   = errors.New("invalid ssh signature")
+	errSSHSigNamespace = errors.New("ssh signature namespace is not git")
+)
+
+// SSHSignature is a parsed armored SSH signature.
+type SSHSignature struct {
+	PublicKey     gossh.PublicKey
+	Namespace     string
+	HashAlgorithm string
+	Signature     *gossh.Signature
+}
+
+// ParseSSHSignature parses an armored SSH signature.
+func ParseSSHSignature(armored []byte) (*SSHSignature, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != sshSigPEMType {
+		return nil, errSSHSigInvalid
+	}
+
+	if !bytes.HasPrefix(block.Bytes, []byte(sshSigMagic)) {
+		return nil, errSSHSigInvalid
+	}
+
+	var blob struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := gossh.Unmarshal(block.Bytes[len(sshSigMagic):], &blob); err != nil {
+		return nil, fmt.Errorf("%w: %w", errSSHSigInvalid, err)
+	}
+
+	if blob.Version != sshSigVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", errSSHSigInvalid, blob.Version)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSSHSigInvalid, err)
+	}
+
+	var sig struct {
+		Format string
+		Blob   []byte
+		Rest   []byte `ssh:"rest"`
+	}
+	if err := gossh.Unmarshal(blob.Signature, &sig); err != nil {
+		return nil, fmt.Errorf("%w: %w", errSSHSigInvalid, err)
+	}
+
+	return &SSHSignature{
+		PublicKey:     publicKey,
+		Namespace:     blob.Namespace,
+		HashAlgorithm: blob.HashAlgorithm,
+		Signature: &gossh.Signature{
+			Format: sig.Format,
+			Blob:   sig.Blob,
+			Rest:   sig.Rest,
+		},
+	}, nil
+}
+
+// Verify verifies that the signature is a valid git signature of the provided message.
+func (s *SSHSignature) Verify(message []byte) error {
+	if s.Namespace != sshSigNamespaceGit {
+		return errSSHSigNamespace
+	}
+
+	var h hash.Hash
+	switch s.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("%w: unsupported hash algorithm %q", errSSHSigInvalid, s.HashAlgorithm)
+	}
+
+	h.Write(message)
+
+	return s.PublicKey.Verify(sshSigSignedData(s.Namespace, s.HashAlgorithm, h.Sum(nil)), s.Signature)
+}
+
+// SignSSH creates an armored git SSH signature of the message.
+func SignSSH(signer gossh.Signer, message []byte) ([]byte, error) {
+	const hashAlgorithm = "sha512"
+
+	hash := sha512.Sum512(message)
+	signedData := sshSigSignedData(sshSigNamespaceGit, hashAlgorithm, hash[:])
+
+	var (
+		sig *gossh.Signature
+		err error
+	)
+
+	// the SHA-1 based RSA signatures are rejected by the recent versions of OpenSSH.
+	if algSigner, ok := signer.(gossh.AlgorithmSigner); ok && signer.PublicKey().Type() == gossh.KeyAlgoRSA {
+		sig, err = algSigner.SignWithAlgorithm(rand.Reader, signedData, gossh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssh signature: %w", err)
+	}
+
+	blob := gossh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{
+		Version:       sshSigVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSigNamespaceGit,
+		HashAlgorithm: hashAlgorithm,
+		Signature:     gossh.Marshal(sig),
+	})
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  sshSigPEMType,
+		Bytes: append([]byte(sshSigMagic), blob...),
+	}), nil
+}
+
+// sshSigSignedData returns the data that is signed by an SSH signature.
+func sshSigSignedData(namespace, hashAlgorithm string, hash []byte) []byte {
+	signedData := gossh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          hash,
+	})
+
+	return append([]byte(sshSigMagic), signedData...)
+}
@@ -15,18 +15,37 @@
 package publickey
 
 import (
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git/api"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvidePublicKey,
+	ProvideServerKey,
+	ProvideGitSigner,
 )
 
 func ProvidePublicKey(
 	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
 	pCache store.PrincipalInfoCache,
+	serverKey *ServerKey,
 ) Service {
-	return NewService(publicKeyStore, pCache)
+	return NewService(publicKeyStore, principalStore, pCache, serverKey)
+}
+
+func ProvideServerKey(
+	settings *settings.Service,
+	encrypter encrypt.Encrypter,
+) *ServerKey {
+	return NewServerKey(settings, encrypter)
+}
+
+// ProvideGitSigner provides the signer of the commits created by the git service.
+func ProvideGitSigner(serverKey *ServerKey) api.Signer {
+	return serverKey
 }
@@ -24,6 +24,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -82,7 +83,6 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 
 	// TODO: This function is currently executed directly on branch update event.
 	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
 		// First check if the merge base has changed
 
@@ -91,6 +91,13 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			return fmt.Errorf("failed to get target repo git info: %w", err)
 		}
 
+		// commits of a pull request from a fork must be fetched into the target repository.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			if err = s.fetchSourceCommits(ctx, targetRepo, pr.SourceRepoID, event.Payload.NewSHA); err != nil {
+				return err
+			}
+		}
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -298,3 +305,32 @@ func getBranchFromRef(ref string) (string, error) {
 	}
 	return branch, nil
 }
+
+// fetchSourceCommits fetches the commit from the source repository of a pull request into the target repository.
+func (s *Service) fetchSourceCommits(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	sourceRepoID int64,
+	commitSHA string,
+) error {
+	sourceRepo, err := s.repoFinder.FindByID(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{sha.Must(commitSHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit %s into the target repository: %w", commitSHA, err)
+	}
+
+	return nil
+}
@@ -41,8 +41,7 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// commits of pull requests from forks are fetched into the target repository on the source branch update.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -16,9 +16,11 @@ package rules
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/harness/gitness/app/services/protection"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -79,6 +81,10 @@ func (s *Service) getRuleUserGroups(
 	}
 
 	groupMap, err := s.userGroupStore.Map(ctx, groupIDs)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the usergroups referenced by the rule have been deleted in the meantime
+		return userGroupInfoMap, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get userGroup infos: %w", err)
 	}
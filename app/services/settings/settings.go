@@ -26,4 +26,8 @@ var (
 	DefaultInstallID                   = string("")
 	KeyPrincipalCommitterMatch     Key = "principal_committer_match"
 	DefaultPrincipalCommitterMatch     = false
+	// KeyServerSigningKey [json] contains the encrypted private key used to sign commits created by the server.
+	KeyServerSigningKey Key = "server_signing_key"
+	// KeyChatWebhooks [json] contains the chat webhooks of a space with encrypted URLs.
+	KeyChatWebhooks Key = "chat_webhooks"
 )
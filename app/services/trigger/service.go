@@ -133,6 +133,7 @@ func New(
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterMergeQueueMergeCreated(service.handleEventPullReqMergeQueueMergeCreated)
 
 			return nil
 		})
@@ -149,6 +150,16 @@ func New(
 // and fire an execution.
 func (s *Service) trigger(ctx context.Context, repoID int64,
 	action enum.TriggerAction, hook *triggerer.Hook) error {
+	repo, err := s.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	// Pipelines of archived repositories are disabled.
+	if repo.State == enum.RepoStateArchived {
+		return nil
+	}
+
 	// Get all enabled triggers for a repo.
 	ret, err := s.triggerStore.ListAllEnabled(ctx, repoID)
 	if err != nil {
@@ -16,19 +16,31 @@ package usergroup
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 )
 
+// ListUsers returns the UIDs of all members of the usergroup.
 func (s *searchService) ListUsers(
-	_ context.Context,
+	ctx context.Context,
 	_ *auth.Session,
-	_ *types.UserGroup,
+	userGroup *types.UserGroup,
 ) ([]string, error) {
-	return nil, nil
+	return listUserUIDs(ctx, s.userGroupMemberStore, s.principalInfoCache, userGroup.ID)
 }
 
-func (s *searchService) ListUserIDsByGroupIDs(_ context.Context, _ []int64) ([]int64, error) {
-	return nil, nil
+// ListUserIDsByGroupIDs returns the IDs of all members of the provided usergroups.
+func (s *searchService) ListUserIDsByGroupIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	if len(userGroupIDs) == 0 {
+		return nil, nil
+	}
+
+	userIDs, err := s.userGroupMemberStore.ListPrincipalIDs(ctx, userGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroup member IDs: %w", err)
+	}
+
+	return userIDs, nil
 }
@@ -16,20 +16,99 @@ package usergroup
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 )
 
 var _ Resolver = (*GitnessResolver)(nil)
 
+// GitnessResolver resolves usergroups by their scoped ID,
+// which is the path of the space the usergroup is defined in followed by the usergroup identifier,
+// e.g. "space1/space2/developers".
 type GitnessResolver struct {
+	spaceFinder          refcache.SpaceFinder
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	principalInfoCache   store.PrincipalInfoCache
 }
 
-func NewGitnessResolver() *GitnessResolver {
-	return &GitnessResolver{}
+func NewGitnessResolver(
+	spaceFinder refcache.SpaceFinder,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalInfoCache store.PrincipalInfoCache,
+) *GitnessResolver {
+	return &GitnessResolver{
+		spaceFinder:          spaceFinder,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		principalInfoCache:   principalInfoCache,
+	}
 }
 
-func (s *GitnessResolver) Resolve(context.Context, string) (*types.UserGroup, error) {
-	// todo: implement once usergroup is supported
-	return nil, ErrNotFound
+func (s *GitnessResolver) Resolve(ctx context.Context, scopedID string) (*types.UserGroup, error) {
+	spacePath, identifier, err := paths.DisectLeaf(scopedID)
+	if err != nil || spacePath == "" || identifier == "" {
+		return nil, ErrNotFound
+	}
+
+	space, err := s.spaceFinder.FindByRef(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space %q: %w", spacePath, err)
+	}
+
+	userGroup, err := s.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usergroup %q: %w", scopedID, err)
+	}
+
+	userGroup.Users, err = listUserUIDs(ctx, s.userGroupMemberStore, s.principalInfoCache, userGroup.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return userGroup, nil
+}
+
+// listUserUIDs returns the UIDs of all members of the usergroup.
+func listUserUIDs(
+	ctx context.Context,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalInfoCache store.PrincipalInfoCache,
+	userGroupID int64,
+) ([]string, error) {
+	userIDs, err := userGroupMemberStore.ListPrincipalIDs(ctx, []int64{userGroupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroup member IDs: %w", err)
+	}
+
+	if len(userIDs) == 0 {
+		return []string{}, nil
+	}
+
+	infoMap, err := principalInfoCache.Map(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usergroup member principal infos: %w", err)
+	}
+
+	uids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if info, ok := infoMap[id]; ok {
+			uids = append(uids, info.UID)
+		}
+	}
+
+	return uids, nil
 }
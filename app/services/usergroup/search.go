@@ -18,20 +18,61 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type searchService struct {
+	spaceFinder          refcache.SpaceFinder
+	spaceStore           store.SpaceStore
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	principalInfoCache   store.PrincipalInfoCache
 }
 
-func NewSearchService() SearchService {
-	return &searchService{}
+func NewSearchService(
+	spaceFinder refcache.SpaceFinder,
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalInfoCache store.PrincipalInfoCache,
+) SearchService {
+	return &searchService{
+		spaceFinder:          spaceFinder,
+		spaceStore:           spaceStore,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		principalInfoCache:   principalInfoCache,
+	}
 }
 
+// Search returns usergroups that are available in the space,
+// i.e. those defined in the space itself or in any of its ancestors.
 func (s *searchService) Search(
-	_ context.Context,
-	_ *types.ListQueryFilter,
-	_ string,
+	ctx context.Context,
+	filter *types.ListQueryFilter,
+	spacePath string,
 ) ([]*types.UserGroupInfo, error) {
-	return nil, fmt.Errorf("not implemented")
+	space, err := s.spaceFinder.FindByRef(ctx, spacePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space ancestor IDs: %w", err)
+	}
+
+	userGroups, err := s.userGroupStore.List(ctx, spaceIDs, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroups: %w", err)
+	}
+
+	userGroupInfos := make([]*types.UserGroupInfo, len(userGroups))
+	for i, userGroup := range userGroups {
+		userGroupInfos[i] = userGroup.ToUserGroupInfo()
+	}
+
+	return userGroupInfos, nil
 }
@@ -15,6 +15,9 @@
 package usergroup
 
 import (
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+
 	"github.com/google/wire"
 )
 
@@ -24,10 +27,21 @@ var WireSet = wire.NewSet(
 	ProvideSearchService,
 )
 
-func ProvideUserGroupResolver() Resolver {
-	return NewGitnessResolver()
+func ProvideUserGroupResolver(
+	spaceFinder refcache.SpaceFinder,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalInfoCache store.PrincipalInfoCache,
+) Resolver {
+	return NewGitnessResolver(spaceFinder, userGroupStore, userGroupMemberStore, principalInfoCache)
 }
 
-func ProvideSearchService() SearchService {
-	return NewSearchService()
+func ProvideSearchService(
+	spaceFinder refcache.SpaceFinder,
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalInfoCache store.PrincipalInfoCache,
+) SearchService {
+	return NewSearchService(spaceFinder, spaceStore, userGroupStore, userGroupMemberStore, principalInfoCache)
 }
@@ -54,6 +54,11 @@ func (s *Service) triggerForEventWithRepo(
 		return err
 	}
 
+	if repo.State == enum.RepoStateArchived {
+		log.Ctx(ctx).Debug().Msgf("skipping webhooks for archived repo %d", repo.ID)
+		return nil
+	}
+
 	// create body
 	body, err := createBodyFn(principal, repo)
 	if err != nil {
@@ -96,6 +101,11 @@ func (s *Service) triggerForEventWithPullReq(
 		return fmt.Errorf("failed to get pr target repo: %w", err)
 	}
 
+	if targetRepo.State == enum.RepoStateArchived {
+		log.Ctx(ctx).Debug().Msgf("skipping webhooks for archived repo %d", targetRepo.ID)
+		return nil
+	}
+
 	sourceRepo := targetRepo
 	if pr.SourceRepoID != pr.TargetRepoID {
 		sourceRepo, err = s.findRepositoryForEvent(ctx, pr.SourceRepoID)
@@ -669,6 +669,11 @@ func (s *Service) handleEventPullReqReviewSubmitted(
 				return nil, fmt.Errorf("failed to get reviewer by id for reviewer id %d: %w", event.Payload.ReviewerID, err)
 			}
 
+			comments, err := s.reviewCommentSegments(ctx, event.Payload.CommentIDs)
+			if err != nil {
+				return nil, err
+			}
+
 			return &PullReqReviewSubmittedPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
@@ -693,7 +698,41 @@ func (s *Service) handleEventPullReqReviewSubmitted(
 				PullReqReviewSegment: PullReqReviewSegment{
 					ReviewDecision: event.Payload.Decision,
 					ReviewerInfo:   principalInfoFrom(reviewer.ToPrincipalInfo()),
+					Comments:       comments,
 				},
 			}, nil
 		})
 }
+
+// reviewCommentSegments returns the comment segments of the comments published with a review.
+func (s *Service) reviewCommentSegments(ctx context.Context, commentIDs []int64) ([]PullReqCommentSegment, error) {
+	if len(commentIDs) == 0 {
+		return nil, nil
+	}
+
+	comments := make([]PullReqCommentSegment, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		activity, err := s.activityStore.Find(ctx, commentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w", commentID, err)
+		}
+
+		if activity.Deleted != nil {
+			continue
+		}
+
+		comments = append(comments, PullReqCommentSegment{
+			CommentInfo: CommentInfo{
+				Text:     activity.Text,
+				ID:       activity.ID,
+				ParentID: activity.ParentID,
+				Kind:     activity.Kind,
+				Created:  activity.Created,
+				Updated:  activity.Updated,
+			},
+			CodeCommentInfo: extractCodeCommentInfoIfAvailable(activity),
+		})
+	}
+
+	return comments, nil
+}
@@ -30,7 +30,9 @@ var (
 	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
 )
 
-func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
+// NewHTTPClient returns an http client that blocks requests to loopback and private network addresses
+// unless explicitly allowed. The addresses are checked after DNS resolution.
+func NewHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
 	// no customizations? use default client
 	if allowLoopback && allowPrivateNetwork && !disableSSLVerification {
 		return http.DefaultClient
@@ -133,10 +133,10 @@ func NewWebhookExecutor(
 ) *WebhookExecutor {
 	return &WebhookExecutor{
 		webhookExecutorStore:       webhookExecutorStore,
-		secureHTTPClient:           newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient:         newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClient:           NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+		insecureHTTPClient:         NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClientInternal:   NewHTTPClient(config.AllowLoopback, true, false),
+		insecureHTTPClientInternal: NewHTTPClient(config.AllowLoopback, true, true),
 		config:                     config,
 		webhookURLProvider:         webhookURLProvider,
 		encrypter:                  encrypter,
@@ -103,6 +103,8 @@ type PullReqUpdateSegment struct {
 type PullReqReviewSegment struct {
 	ReviewDecision enum.PullReqReviewDecision `json:"review_decision"`
 	ReviewerInfo   PrincipalInfo              `json:"reviewer"`
+	// Comments contains the pending comments published with the review.
+	Comments []PullReqCommentSegment `json:"comments,omitempty"`
 }
 
 // RepositoryInfo describes the repo related info for a webhook payload.
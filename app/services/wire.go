@@ -15,6 +15,7 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspacedeleteevent"
@@ -24,13 +25,17 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mailreply"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
+	registrycleanuppolicy "github.com/harness/gitness/registry/services/cleanuppolicy"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 
 	"github.com/google/wire"
@@ -50,12 +55,17 @@ type Services struct {
 	Repo                    *repo.Service
 	Cleanup                 *cleanup.Service
 	Notification            *notification.Service
+	MailReply               *mailreply.Service
+	AutoMerge               *automerge.Service
+	MergeQueue              *mergequeue.Service
+	Mirror                  *mirror.Service
 	Keywordsearch           *keywordsearch.Service
 	GitspaceService         *GitspaceServices
 	Instrumentation         instrument.Service
 	instrumentConsumer      instrument.Consumer
 	instrumentRepoCounter   *instrument.RepositoryCount
 	registryWebhooksService *registrywebhooks.Service
+	RegistryCleanupPolicy   *registrycleanuppolicy.Service
 }
 
 type GitspaceServices struct {
@@ -95,12 +105,17 @@ func ProvideServices(
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
+	mailReplySvc *mailreply.Service,
+	autoMergeSvc *automerge.Service,
+	mergeQueueSvc *mergequeue.Service,
+	mirrorSvc *mirror.Service,
 	keywordsearchSvc *keywordsearch.Service,
 	gitspaceSvc *GitspaceServices,
 	instrumentation instrument.Service,
 	instrumentConsumer instrument.Consumer,
 	instrumentRepoCounter *instrument.RepositoryCount,
 	registryWebhooksService *registrywebhooks.Service,
+	registryCleanupPolicySvc *registrycleanuppolicy.Service,
 ) Services {
 	return Services{
 		Webhook:                 webhooksSvc,
@@ -112,11 +127,16 @@ func ProvideServices(
 		Repo:                    repo,
 		Cleanup:                 cleanupSvc,
 		Notification:            notificationSvc,
+		MailReply:               mailReplySvc,
+		AutoMerge:               autoMergeSvc,
+		MergeQueue:              mergeQueueSvc,
+		Mirror:                  mirrorSvc,
 		Keywordsearch:           keywordsearchSvc,
 		GitspaceService:         gitspaceSvc,
 		Instrumentation:         instrumentation,
 		instrumentConsumer:      instrumentConsumer,
 		instrumentRepoCounter:   instrumentRepoCounter,
 		registryWebhooksService: registryWebhooksService,
+		RegistryCleanupPolicy:   registryCleanupPolicySvc,
 	}
 }
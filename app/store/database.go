@@ -309,6 +309,12 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// ListForks returns a list of active forks of a repo.
+		ListForks(ctx context.Context, repoID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+
+		// ListAllForks returns all forks of a repo, including the deleted ones.
+		ListAllForks(ctx context.Context, repoID int64) ([]*types.Repository, error)
 	}
 
 	// SettingsStore defines the settings storage.
@@ -479,6 +485,9 @@ type (
 
 		// ListAuthorIDs returns a list of pull request activity author ids in a thread (order).
 		ListAuthorIDs(ctx context.Context, prID int64, order int64) ([]int64, error)
+
+		// ListPending returns the pending (not deleted) comments of the principal in a pull request.
+		ListPending(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqActivity, error)
 	}
 
 	// CodeCommentView is to manipulate only code-comment subset of PullReqActivity.
@@ -1116,6 +1125,37 @@ type (
 			spaceID int64,
 			userGroup *types.UserGroup,
 		) error
+
+		// Update updates the name and the description of an existing usergroup.
+		Update(ctx context.Context, userGroup *types.UserGroup) error
+
+		// Delete deletes the usergroup with the given ID.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns a list of usergroups defined in any of the provided spaces.
+		List(ctx context.Context, spaceIDs []int64, filter *types.ListQueryFilter) ([]*types.UserGroup, error)
+	}
+
+	// UserGroupMemberStore defines the usergroup membership storage.
+	UserGroupMemberStore interface {
+		// Create adds a principal to a usergroup.
+		Create(ctx context.Context, member *types.UserGroupMember) error
+
+		// Delete removes a principal from a usergroup.
+		Delete(ctx context.Context, userGroupID, principalID int64) error
+
+		// List returns the members of a usergroup.
+		List(
+			ctx context.Context,
+			userGroupID int64,
+			filter *types.ListQueryFilter,
+		) ([]*types.UserGroupMemberInfo, error)
+
+		// Count returns the number of members of a usergroup.
+		Count(ctx context.Context, userGroupID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// ListPrincipalIDs returns the IDs of all principals that are members of any of the provided usergroups.
+		ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error)
 	}
 
 	PublicKeyStore interface {
@@ -1346,4 +1386,199 @@ type (
 			end int64,
 		) ([]types.UsageMetric, error)
 	}
+
+	// AuditEventStore defines the audit event data storage.
+	AuditEventStore interface {
+		// Create creates a new audit event.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// ListForSpaces lists the audit events recorded in the provided spaces.
+		ListForSpaces(
+			ctx context.Context,
+			spaceIDs []int64,
+			filter *types.AuditEventFilter,
+		) ([]*types.AuditEvent, error)
+
+		// CountForSpaces counts the audit events recorded in the provided spaces.
+		CountForSpaces(ctx context.Context, spaceIDs []int64, filter *types.AuditEventFilter) (int64, error)
+
+		// ListForRepo lists the audit events recorded for a repository.
+		ListForRepo(
+			ctx context.Context,
+			repoID int64,
+			filter *types.AuditEventFilter,
+		) ([]*types.AuditEvent, error)
+
+		// CountForRepo counts the audit events recorded for a repository.
+		CountForRepo(ctx context.Context, repoID int64, filter *types.AuditEventFilter) (int64, error)
+
+		// DeleteOld removes all audit events that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// NotificationPreferenceStore defines the notification preference data storage.
+	NotificationPreferenceStore interface {
+		// List returns all notification preferences of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationPreference, error)
+
+		// Upsert creates or updates the notification preference of the principal for the scope of the preference.
+		Upsert(ctx context.Context, pref *types.NotificationPreference) error
+
+		// Delete deletes the notification preference of the principal for the provided scope.
+		Delete(ctx context.Context, principalID int64, spaceID, repoID *int64) error
+	}
+
+	// NotificationDigestStore defines the storage of notifications that are waiting to be sent in a digest.
+	NotificationDigestStore interface {
+		// Create stores a notification for a digest.
+		// A notification of the same event is stored only once for a principal.
+		Create(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPrincipalIDs returns IDs of all principals with pending notifications for the delivery.
+		ListPrincipalIDs(ctx context.Context, delivery enum.NotificationDelivery) ([]int64, error)
+
+		// List returns the oldest pending notifications of the principal for the delivery.
+		List(
+			ctx context.Context,
+			principalID int64,
+			delivery enum.NotificationDelivery,
+			limit int,
+		) ([]*types.NotificationDigestItem, error)
+
+		// DeleteUpTo deletes the pending notifications of the principal for the delivery
+		// with IDs up to and including the provided ID.
+		DeleteUpTo(ctx context.Context, principalID int64, delivery enum.NotificationDelivery, maxID int64) error
+	}
+
+	// PullReqAutoMergeStore defines the storage of pull request auto-merge settings.
+	PullReqAutoMergeStore interface {
+		// Find returns the auto-merge settings of the pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, error)
+
+		// ListByRepo returns the auto-merge settings of all pull requests targeting the repository.
+		ListByRepo(ctx context.Context, repoID int64) ([]*types.PullReqAutoMerge, error)
+
+		// Upsert creates or replaces the auto-merge settings of the pull request.
+		Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error
+
+		// Delete deletes the auto-merge settings of the pull request.
+		Delete(ctx context.Context, pullReqID int64) error
+	}
+
+	MergeQueueStore interface {
+		// Find returns the merge queue entry of the pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error)
+
+		// FindByMergeSHA returns the merge queue entry whose speculative merge commit is the provided commit.
+		FindByMergeSHA(ctx context.Context, repoID int64, mergeSHA string) (*types.MergeQueueEntry, error)
+
+		// List returns the merge queue of the branch in the order the pull requests have been added to it.
+		List(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error)
+
+		// Create adds the pull request to the end of the merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the speculative merge information of the merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the pull request from the merge queue.
+		Delete(ctx context.Context, pullReqID int64) error
+	}
+
+	PullMirrorStore interface {
+		// Find returns the pull mirror configuration of the repository.
+		Find(ctx context.Context, repoID int64) (*types.PullMirror, error)
+
+		// ListDue returns enabled pull mirrors that should be synchronized. Mirrors with a synchronization
+		// in progress are skipped, unless they haven't been updated since staleBefore.
+		ListDue(ctx context.Context, now int64, staleBefore int64, limit int) ([]*types.PullMirror, error)
+
+		// Create creates a new pull mirror configuration.
+		Create(ctx context.Context, mirror *types.PullMirror) error
+
+		// Update updates the pull mirror configuration.
+		Update(ctx context.Context, mirror *types.PullMirror) error
+
+		// UpdateOptLock updates the pull mirror configuration using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			mirror *types.PullMirror,
+			mutateFn func(mirror *types.PullMirror) error,
+		) (*types.PullMirror, error)
+	}
+
+	PushMirrorStore interface {
+		// Find returns the push mirror by id.
+		Find(ctx context.Context, id int64) (*types.PushMirror, error)
+
+		// FindByIdentifier returns the push mirror of the repository by its identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.PushMirror, error)
+
+		// Create creates a new push mirror.
+		Create(ctx context.Context, mirror *types.PushMirror) error
+
+		// Update updates the push mirror.
+		Update(ctx context.Context, mirror *types.PushMirror) error
+
+		// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			mirror *types.PushMirror,
+			mutateFn func(mirror *types.PushMirror) error,
+		) (*types.PushMirror, error)
+
+		// Delete deletes the push mirror.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the push mirrors of the repository.
+		List(ctx context.Context, repoID int64, filter *types.PushMirrorFilter) ([]*types.PushMirror, error)
+
+		// Count counts the push mirrors of the repository.
+		Count(ctx context.Context, repoID int64, filter *types.PushMirrorFilter) (int64, error)
+
+		// ListEnabled lists the enabled push mirrors of the repository.
+		ListEnabled(ctx context.Context, repoID int64) ([]*types.PushMirror, error)
+
+		// ListAllEnabled lists the enabled push mirrors of all repositories with IDs greater than afterID.
+		ListAllEnabled(ctx context.Context, afterID int64, limit int) ([]*types.PushMirror, error)
+	}
+
+	PushMirrorExecutionStore interface {
+		// Create creates a new push mirror execution entry.
+		Create(ctx context.Context, execution *types.PushMirrorExecution) error
+
+		// ListForPushMirror lists the executions of the push mirror, newest first.
+		ListForPushMirror(
+			ctx context.Context,
+			pushMirrorID int64,
+			filter *types.PushMirrorExecutionFilter,
+		) ([]*types.PushMirrorExecution, error)
+
+		// CountForPushMirror counts the executions of the push mirror.
+		CountForPushMirror(ctx context.Context, pushMirrorID int64) (int64, error)
+
+		// DeleteOld removes all executions that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// PullReqReactionStore defines the storage of emoji reactions to pull request descriptions and comments.
+	PullReqReactionStore interface {
+		// Create adds the reaction. Adding an already existing reaction does nothing.
+		Create(ctx context.Context, reaction *types.PullReqReaction) error
+
+		// Delete removes the reaction of the principal. Removing a non-existing reaction does nothing.
+		Delete(
+			ctx context.Context,
+			pullReqID int64,
+			activityID *int64,
+			principalID int64,
+			reaction enum.Reaction,
+		) error
+
+		// List returns all reactions to the pull request description and to all comments of the pull request.
+		List(ctx context.Context, pullReqID int64) ([]*types.PullReqReaction, error)
+
+		// ListForTarget returns the reactions to the pull request description (if activityID is nil) or to the comment.
+		ListForTarget(ctx context.Context, pullReqID int64, activityID *int64) ([]*types.PullReqReaction, error)
+	}
 )
@@ -0,0 +1,397 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.AuditEventStore = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *AuditEventStore {
+	return &AuditEventStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// AuditEventStore implements store.AuditEventStore backed by a relational database.
+type AuditEventStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type auditEvent struct {
+	ID                 int64                  `db:"audit_event_id"`
+	Identifier         string                 `db:"audit_event_uid"`
+	Created            int64                  `db:"audit_event_created"`
+	Action             string                 `db:"audit_event_action"`
+	ActorID            int64                  `db:"audit_event_actor_id"`
+	SpaceID            null.Int               `db:"audit_event_space_id"`
+	SpacePath          string                 `db:"audit_event_space_path"`
+	RepoID             null.Int               `db:"audit_event_repo_id"`
+	RepoPath           string                 `db:"audit_event_repo_path"`
+	ResourceType       string                 `db:"audit_event_resource_type"`
+	ResourceIdentifier string                 `db:"audit_event_resource_identifier"`
+	ResourceData       sqlxtypes.JSONText     `db:"audit_event_resource_data"`
+	OldObject          sqlxtypes.NullJSONText `db:"audit_event_old_object"`
+	NewObject          sqlxtypes.NullJSONText `db:"audit_event_new_object"`
+	ClientIP           string                 `db:"audit_event_client_ip"`
+	RequestMethod      string                 `db:"audit_event_request_method"`
+	Data               sqlxtypes.JSONText     `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_uid
+		,audit_event_created
+		,audit_event_action
+		,audit_event_actor_id
+		,audit_event_space_id
+		,audit_event_space_path
+		,audit_event_repo_id
+		,audit_event_repo_path
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_data`
+)
+
+// Create creates a new audit event.
+func (s *AuditEventStore) Create(ctx context.Context, event *types.AuditEvent) error {
+	const sqlQuery = `
+		INSERT INTO audit_events (
+			 audit_event_uid
+			,audit_event_created
+			,audit_event_action
+			,audit_event_actor_id
+			,audit_event_space_id
+			,audit_event_space_path
+			,audit_event_repo_id
+			,audit_event_repo_path
+			,audit_event_resource_type
+			,audit_event_resource_identifier
+			,audit_event_resource_data
+			,audit_event_old_object
+			,audit_event_new_object
+			,audit_event_client_ip
+			,audit_event_request_method
+			,audit_event_data
+		) values (
+			 :audit_event_uid
+			,:audit_event_created
+			,:audit_event_action
+			,:audit_event_actor_id
+			,:audit_event_space_id
+			,:audit_event_space_path
+			,:audit_event_repo_id
+			,:audit_event_repo_path
+			,:audit_event_resource_type
+			,:audit_event_resource_identifier
+			,:audit_event_resource_data
+			,:audit_event_old_object
+			,:audit_event_new_object
+			,:audit_event_client_ip
+			,:audit_event_request_method
+			,:audit_event_data
+		) RETURNING audit_event_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAuditEvent(event))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert audit event")
+	}
+
+	return nil
+}
+
+// ListForSpaces lists the audit events recorded in the provided spaces.
+func (s *AuditEventStore) ListForSpaces(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events").
+		Where(squirrel.Eq{"audit_event_space_id": spaceIDs})
+
+	return s.list(ctx, stmt, filter)
+}
+
+// CountForSpaces counts the audit events recorded in the provided spaces.
+func (s *AuditEventStore) CountForSpaces(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.AuditEventFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events").
+		Where(squirrel.Eq{"audit_event_space_id": spaceIDs})
+
+	return s.count(ctx, stmt, filter)
+}
+
+// ListForRepo lists the audit events recorded for a repository.
+func (s *AuditEventStore) ListForRepo(
+	ctx context.Context,
+	repoID int64,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events").
+		Where("audit_event_repo_id = ?", repoID)
+
+	return s.list(ctx, stmt, filter)
+}
+
+// CountForRepo counts the audit events recorded for a repository.
+func (s *AuditEventStore) CountForRepo(
+	ctx context.Context,
+	repoID int64,
+	filter *types.AuditEventFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events").
+		Where("audit_event_repo_id = ?", repoID)
+
+	return s.count(ctx, stmt, filter)
+}
+
+// DeleteOld removes all audit events that are older than the provided time.
+func (s *AuditEventStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_events").
+		Where("audit_event_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete audit events query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted audit events")
+	}
+
+	return n, nil
+}
+
+func (s *AuditEventStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, error) {
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	// fixed ordering by desc id (new ones first)
+	stmt = stmt.OrderBy("audit_event_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*auditEvent{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return s.mapToAuditEvents(ctx, dst)
+}
+
+func (s *AuditEventStore) count(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditEventFilter,
+) (int64, error) {
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+func applyAuditEventFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditEventFilter,
+) squirrel.SelectBuilder {
+	if len(filter.ActorIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_actor_id": filter.ActorIDs})
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_event_created < ?", filter.CreatedLt)
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_event_created > ?", filter.CreatedGt)
+	}
+
+	return stmt
+}
+
+func mapToInternalAuditEvent(in *types.AuditEvent) *auditEvent {
+	return &auditEvent{
+		ID:                 in.ID,
+		Identifier:         in.Identifier,
+		Created:            in.Created,
+		Action:             in.Action,
+		ActorID:            in.ActorID,
+		SpaceID:            null.NewInt(in.SpaceID, in.SpaceID != 0),
+		SpacePath:          in.SpacePath,
+		RepoID:             null.NewInt(in.RepoID, in.RepoID != 0),
+		RepoPath:           in.RepoPath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourceData:       EncodeToSQLXJSON(in.ResourceData),
+		OldObject:          toNullJSONText(in.OldObject),
+		NewObject:          toNullJSONText(in.NewObject),
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+		Data:               EncodeToSQLXJSON(in.Data),
+	}
+}
+
+func mapToAuditEvent(ctx context.Context, in *auditEvent) *types.AuditEvent {
+	event := &types.AuditEvent{
+		ID:                 in.ID,
+		Identifier:         in.Identifier,
+		Created:            in.Created,
+		Action:             in.Action,
+		ActorID:            in.ActorID,
+		SpaceID:            in.SpaceID.Int64,
+		SpacePath:          in.SpacePath,
+		RepoID:             in.RepoID.Int64,
+		RepoPath:           in.RepoPath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		OldObject:          fromNullJSONText(in.OldObject),
+		NewObject:          fromNullJSONText(in.NewObject),
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+	}
+
+	if err := in.ResourceData.Unmarshal(&event.ResourceData); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to unmarshal resource data of audit event %d", in.ID)
+	}
+
+	if err := in.Data.Unmarshal(&event.Data); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to unmarshal data of audit event %d", in.ID)
+	}
+
+	return event
+}
+
+func toNullJSONText(obj json.RawMessage) sqlxtypes.NullJSONText {
+	return sqlxtypes.NullJSONText{JSONText: sqlxtypes.JSONText(obj), Valid: obj != nil}
+}
+
+func fromNullJSONText(obj sqlxtypes.NullJSONText) json.RawMessage {
+	if !obj.Valid {
+		return nil
+	}
+
+	return json.RawMessage(obj.JSONText)
+}
+
+func (s *AuditEventStore) mapToAuditEvents(
+	ctx context.Context,
+	events []*auditEvent,
+) ([]*types.AuditEvent, error) {
+	// collect all actor IDs
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ActorID)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit event actors: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	res := make([]*types.AuditEvent, len(events))
+	for i, e := range events {
+		res[i] = mapToAuditEvent(ctx, e)
+		if actor, ok := infoMap[e.ActorID]; ok {
+			res[i].ActorInfo = *actor
+		}
+	}
+
+	return res, nil
+}
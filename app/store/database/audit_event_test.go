@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	auditStore := database.NewAuditEventStore(db, pCache)
+
+	now := time.Now()
+	events := []*types.AuditEvent{
+		{
+			Identifier:         "e1",
+			Created:            now.Add(-48 * time.Hour).UnixMilli(),
+			Action:             "created",
+			ActorID:            userID,
+			SpaceID:            1,
+			SpacePath:          "root",
+			RepoID:             1,
+			RepoPath:           "root/repo",
+			ResourceType:       "repository",
+			ResourceIdentifier: "repo",
+		},
+		{
+			Identifier:         "e2",
+			Created:            now.UnixMilli(),
+			Action:             "bypassed",
+			ActorID:            userID,
+			SpaceID:            1,
+			SpacePath:          "root",
+			RepoID:             1,
+			RepoPath:           "root/repo",
+			ResourceType:       "repository",
+			ResourceIdentifier: "repo",
+			ResourceData:       map[string]string{"bypassedResourceName": "main"},
+		},
+		{
+			Identifier:         "e3",
+			Created:            now.UnixMilli(),
+			Action:             "created",
+			ActorID:            userID,
+			SpaceID:            2,
+			SpacePath:          "root/sub",
+			ResourceType:       "branch_rule",
+			ResourceIdentifier: "rule",
+			NewObject:          []byte(`{"identifier":"rule"}`),
+		},
+		{
+			Identifier:         "e4",
+			Created:            now.UnixMilli(),
+			Action:             "created",
+			ActorID:            userID,
+			SpaceID:            3,
+			SpacePath:          "root_other",
+			ResourceType:       "branch_rule",
+			ResourceIdentifier: "rule",
+		},
+	}
+
+	for _, e := range events {
+		require.NoError(t, auditStore.Create(ctx, e))
+		require.NotZero(t, e.ID)
+	}
+
+	// space listing includes only the provided spaces
+	list, err := auditStore.ListForSpaces(ctx, []int64{1, 2}, &types.AuditEventFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	require.Equal(t, "e3", list[0].Identifier)
+	require.Equal(t, int64(2), list[0].SpaceID)
+	require.JSONEq(t, `{"identifier":"rule"}`, string(list[0].NewObject))
+	require.Equal(t, "user_1", list[0].ActorInfo.UID)
+
+	count, err := auditStore.CountForSpaces(ctx, []int64{1, 2}, &types.AuditEventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	// events without an old or a new object
+	require.Nil(t, list[1].OldObject)
+	require.Nil(t, list[1].NewObject)
+
+	// repo listing with action filter
+	list, err = auditStore.ListForRepo(ctx, 1, &types.AuditEventFilter{Actions: []string{"bypassed"}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "main", list[0].ResourceData["bypassedResourceName"])
+	require.Equal(t, int64(1), list[0].RepoID)
+
+	// the repo listing doesn't depend on the path of the repo
+	count, err = auditStore.CountForRepo(ctx, 2, &types.AuditEventFilter{})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// time range filter
+	count, err = auditStore.CountForRepo(ctx, 1, &types.AuditEventFilter{
+		CreatedFilter: types.CreatedFilter{CreatedLt: now.Add(-time.Hour).UnixMilli()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	n, err := auditStore.DeleteOld(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	count, err = auditStore.CountForRepo(ctx, 1, &types.AuditEventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = MergeQueueStore{}
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) MergeQueueStore {
+	return MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID            int64  `db:"merge_queue_entry_id"`
+	RepoID        int64  `db:"merge_queue_entry_repo_id"`
+	Branch        string `db:"merge_queue_entry_branch"`
+	PullReqID     int64  `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber int64  `db:"pullreq_number"`
+	CreatedBy     int64  `db:"merge_queue_entry_created_by"`
+	Created       int64  `db:"merge_queue_entry_created"`
+	Updated       int64  `db:"merge_queue_entry_updated"`
+	Method        string `db:"merge_queue_entry_method"`
+	Title         string `db:"merge_queue_entry_title"`
+	Message       string `db:"merge_queue_entry_message"`
+	State         string `db:"merge_queue_entry_state"`
+	HeadSHA       string `db:"merge_queue_entry_head_sha"`
+	BaseSHA       string `db:"merge_queue_entry_base_sha"`
+	MergeBaseSHA  string `db:"merge_queue_entry_merge_base_sha"`
+	MergeSHA      string `db:"merge_queue_entry_merge_sha"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_branch
+		,merge_queue_entry_pullreq_id
+		,pullreq_number
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_state
+		,merge_queue_entry_head_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_base_sha
+		,merge_queue_entry_merge_sha`
+
+	mergeQueueEntrySelectBase = `
+		SELECT` + mergeQueueEntryColumns + `
+		FROM merge_queue_entries
+		INNER JOIN pullreqs ON pullreq_id = merge_queue_entry_pullreq_id`
+)
+
+// Find returns the merge queue entry of the pull request.
+func (s MergeQueueStore) Find(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// FindByMergeSHA returns the merge queue entry whose speculative merge commit is the provided commit.
+func (s MergeQueueStore) FindByMergeSHA(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_repo_id = $1 AND merge_queue_entry_merge_sha = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, mergeSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry by merge SHA")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// List returns the merge queue of the branch in the order the pull requests have been added to it.
+func (s MergeQueueStore) List(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_repo_id = $1 AND merge_queue_entry_branch = $2
+		ORDER BY merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]mergeQueueEntry, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, branch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	entries := make([]*types.MergeQueueEntry, len(dst))
+	for i := range dst {
+		entries[i] = mapToMergeQueueEntry(&dst[i])
+	}
+
+	return entries, nil
+}
+
+// Create adds the pull request to the end of the merge queue.
+func (s MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_repo_id
+			,merge_queue_entry_branch
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_created_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_state
+			,merge_queue_entry_head_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha
+			,merge_queue_entry_merge_sha
+		) values (
+			 :merge_queue_entry_repo_id
+			,:merge_queue_entry_branch
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_state
+			,:merge_queue_entry_head_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_base_sha
+			,:merge_queue_entry_merge_sha
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the speculative merge information of the merge queue entry.
+func (s MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_updated = :merge_queue_entry_updated
+			,merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_head_sha = :merge_queue_entry_head_sha
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha = :merge_queue_entry_merge_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+		WHERE merge_queue_entry_id = :merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the pull request from the merge queue.
+func (s MergeQueueStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullReqID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete merge queue entry query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalMergeQueueEntry(in *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		Branch:        in.Branch,
+		PullReqID:     in.PullReqID,
+		PullReqNumber: in.PullReqNumber,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		Method:        string(in.Method),
+		Title:         in.Title,
+		Message:       in.Message,
+		State:         string(in.State),
+		HeadSHA:       in.HeadSHA,
+		BaseSHA:       in.BaseSHA,
+		MergeBaseSHA:  in.MergeBaseSHA,
+		MergeSHA:      in.MergeSHA,
+	}
+}
+
+func mapToMergeQueueEntry(in *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		Branch:        in.Branch,
+		PullReqID:     in.PullReqID,
+		PullReqNumber: in.PullReqNumber,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		Method:        enum.MergeMethod(in.Method),
+		Title:         in.Title,
+		Message:       in.Message,
+		State:         enum.MergeQueueEntryState(in.State),
+		HeadSHA:       in.HeadSHA,
+		BaseSHA:       in.BaseSHA,
+		MergeBaseSHA:  in.MergeBaseSHA,
+		MergeSHA:      in.MergeSHA,
+	}
+}
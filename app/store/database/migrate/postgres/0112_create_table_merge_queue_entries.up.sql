@@ -0,0 +1,39 @@
+CREATE TABLE merge_queue_entries (
+ merge_queue_entry_id SERIAL PRIMARY KEY
+,merge_queue_entry_repo_id INTEGER NOT NULL
+,merge_queue_entry_branch TEXT NOT NULL
+,merge_queue_entry_pullreq_id INTEGER NOT NULL
+,merge_queue_entry_created_by INTEGER NOT NULL
+,merge_queue_entry_created BIGINT NOT NULL
+,merge_queue_entry_updated BIGINT NOT NULL
+,merge_queue_entry_method TEXT NOT NULL
+,merge_queue_entry_title TEXT NOT NULL
+,merge_queue_entry_message TEXT NOT NULL
+,merge_queue_entry_state TEXT NOT NULL
+,merge_queue_entry_head_sha TEXT NOT NULL
+,merge_queue_entry_base_sha TEXT NOT NULL
+,merge_queue_entry_merge_base_sha TEXT NOT NULL
+,merge_queue_entry_merge_sha TEXT NOT NULL
+
+,CONSTRAINT fk_merge_queue_entry_repo_id FOREIGN KEY (merge_queue_entry_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_merge_queue_entry_pullreq_id FOREIGN KEY (merge_queue_entry_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_merge_queue_entry_created_by FOREIGN KEY (merge_queue_entry_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX merge_queue_entries_pullreq_id
+	ON merge_queue_entries(merge_queue_entry_pullreq_id);
+
+CREATE INDEX merge_queue_entries_repo_id_branch
+	ON merge_queue_entries(merge_queue_entry_repo_id, merge_queue_entry_branch);
+
+CREATE INDEX merge_queue_entries_repo_id_merge_sha
+	ON merge_queue_entries(merge_queue_entry_repo_id, merge_queue_entry_merge_sha);
@@ -0,0 +1,39 @@
+CREATE TABLE pull_mirrors (
+ pull_mirror_id SERIAL PRIMARY KEY
+,pull_mirror_version INTEGER NOT NULL
+,pull_mirror_repo_id INTEGER NOT NULL
+,pull_mirror_created_by INTEGER NOT NULL
+,pull_mirror_created BIGINT NOT NULL
+,pull_mirror_updated BIGINT NOT NULL
+,pull_mirror_remote_url TEXT NOT NULL
+,pull_mirror_username TEXT NOT NULL
+,pull_mirror_password TEXT NOT NULL
+,pull_mirror_secret_space_id INTEGER
+,pull_mirror_secret_identifier TEXT NOT NULL
+,pull_mirror_cron TEXT NOT NULL
+,pull_mirror_enabled BOOLEAN NOT NULL
+,pull_mirror_next_sync BIGINT NOT NULL
+,pull_mirror_last_sync_status TEXT NOT NULL
+,pull_mirror_last_sync_started BIGINT NOT NULL
+,pull_mirror_last_sync_finished BIGINT NOT NULL
+,pull_mirror_last_sync_error TEXT NOT NULL
+
+,CONSTRAINT fk_pull_mirror_repo_id FOREIGN KEY (pull_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pull_mirror_created_by FOREIGN KEY (pull_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pull_mirror_secret_space_id FOREIGN KEY (pull_mirror_secret_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX pull_mirrors_repo_id
+	ON pull_mirrors(pull_mirror_repo_id);
+
+CREATE INDEX pull_mirrors_enabled_next_sync
+	ON pull_mirrors(pull_mirror_enabled, pull_mirror_next_sync);
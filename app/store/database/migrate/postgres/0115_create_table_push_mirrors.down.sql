@@ -0,0 +1,2 @@
+DROP TABLE push_mirror_executions;
+DROP TABLE push_mirrors;
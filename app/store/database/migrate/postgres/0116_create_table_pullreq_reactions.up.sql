@@ -0,0 +1,35 @@
+CREATE TABLE pullreq_reactions (
+ pullreq_reaction_id SERIAL PRIMARY KEY
+,pullreq_reaction_pullreq_id INTEGER NOT NULL
+,pullreq_reaction_activity_id INTEGER
+,pullreq_reaction_created_by INTEGER NOT NULL
+,pullreq_reaction_created BIGINT NOT NULL
+,pullreq_reaction_reaction TEXT NOT NULL
+
+,CONSTRAINT fk_pullreq_reaction_pullreq_id FOREIGN KEY (pullreq_reaction_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reaction_activity_id FOREIGN KEY (pullreq_reaction_activity_id)
+    REFERENCES pullreq_activities (pullreq_activity_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reaction_created_by FOREIGN KEY (pullreq_reaction_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+-- a user can react with the same reaction only once to the pull request description
+CREATE UNIQUE INDEX pullreq_reactions_pullreq_id_created_by_reaction
+    ON pullreq_reactions(pullreq_reaction_pullreq_id, pullreq_reaction_created_by, pullreq_reaction_reaction)
+    WHERE pullreq_reaction_activity_id IS NULL;
+
+-- a user can react with the same reaction only once to a comment
+CREATE UNIQUE INDEX pullreq_reactions_activity_id_created_by_reaction
+    ON pullreq_reactions(pullreq_reaction_activity_id, pullreq_reaction_created_by, pullreq_reaction_reaction)
+    WHERE pullreq_reaction_activity_id IS NOT NULL;
+
+-- this index is used to list all reactions of a pull request
+CREATE INDEX pullreq_reactions_pullreq_id
+    ON pullreq_reactions(pullreq_reaction_pullreq_id);
@@ -0,0 +1,2 @@
+ALTER TABLE cleanup_policies
+ADD COLUMN cp_keep_last_versions INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX audit_events_repo_id_created;
+DROP INDEX audit_events_space_id_created;
+DROP INDEX audit_events_created;
+DROP INDEX audit_events_uid;
+DROP TABLE audit_events;
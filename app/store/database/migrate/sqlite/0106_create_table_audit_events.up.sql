@@ -0,0 +1,33 @@
+CREATE TABLE audit_events (
+     audit_event_id INTEGER PRIMARY KEY AUTOINCREMENT
+    ,audit_event_uid TEXT NOT NULL
+    ,audit_event_created BIGINT NOT NULL
+    ,audit_event_action TEXT NOT NULL
+    ,audit_event_actor_id INTEGER NOT NULL
+    ,audit_event_space_id INTEGER
+    ,audit_event_space_path TEXT NOT NULL
+    ,audit_event_repo_id INTEGER
+    ,audit_event_repo_path TEXT NOT NULL DEFAULT ''
+    ,audit_event_resource_type TEXT NOT NULL
+    ,audit_event_resource_identifier TEXT NOT NULL
+    ,audit_event_resource_data TEXT NOT NULL DEFAULT '{}'
+    ,audit_event_old_object TEXT
+    ,audit_event_new_object TEXT
+    ,audit_event_client_ip TEXT NOT NULL DEFAULT ''
+    ,audit_event_request_method TEXT NOT NULL DEFAULT ''
+    ,audit_event_data TEXT NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX audit_events_uid
+    ON audit_events(audit_event_uid);
+
+CREATE INDEX audit_events_created
+    ON audit_events(audit_event_created);
+
+CREATE INDEX audit_events_space_id_created
+    ON audit_events(audit_event_space_id, audit_event_created)
+    WHERE audit_event_space_id IS NOT NULL;
+
+CREATE INDEX audit_events_repo_id_created
+    ON audit_events(audit_event_repo_id, audit_event_created)
+    WHERE audit_event_repo_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX usergroup_members_principal_id;
+DROP TABLE usergroup_members;
@@ -0,0 +1,21 @@
+CREATE TABLE usergroup_members (
+    usergroup_member_usergroup_id INTEGER NOT NULL,
+    usergroup_member_principal_id INTEGER NOT NULL,
+    usergroup_member_created_by INTEGER NOT NULL,
+    usergroup_member_created BIGINT NOT NULL,
+    CONSTRAINT pk_usergroup_members PRIMARY KEY (usergroup_member_usergroup_id, usergroup_member_principal_id),
+    CONSTRAINT fk_usergroup_member_usergroup_id FOREIGN KEY (usergroup_member_usergroup_id)
+        REFERENCES usergroups (usergroup_id)
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_usergroup_member_principal_id FOREIGN KEY (usergroup_member_principal_id)
+        REFERENCES principals (principal_id)
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_usergroup_member_created_by FOREIGN KEY (usergroup_member_created_by)
+        REFERENCES principals (principal_id)
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE INDEX usergroup_members_principal_id ON usergroup_members (usergroup_member_principal_id);
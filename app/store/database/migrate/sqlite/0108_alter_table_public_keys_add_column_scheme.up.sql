@@ -0,0 +1,2 @@
+ALTER TABLE public_keys
+ADD COLUMN public_key_scheme TEXT NOT NULL DEFAULT 'ssh';
@@ -0,0 +1,2 @@
+ALTER TABLE principals
+DROP COLUMN principal_user_chat_handles;
@@ -0,0 +1,2 @@
+ALTER TABLE principals
+ADD COLUMN principal_user_chat_handles TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,67 @@
+CREATE TABLE notification_preferences (
+ notification_preference_id INTEGER PRIMARY KEY AUTOINCREMENT
+,notification_preference_principal_id INTEGER NOT NULL
+,notification_preference_space_id INTEGER
+,notification_preference_repo_id INTEGER
+,notification_preference_disabled_events TEXT NOT NULL
+,notification_preference_delivery TEXT NOT NULL
+,notification_preference_created BIGINT NOT NULL
+,notification_preference_updated BIGINT NOT NULL
+
+,CONSTRAINT fk_notification_preference_principal_id FOREIGN KEY (notification_preference_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_preference_space_id FOREIGN KEY (notification_preference_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_preference_repo_id FOREIGN KEY (notification_preference_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX notification_preferences_principal_id
+	ON notification_preferences(notification_preference_principal_id)
+	WHERE notification_preference_space_id IS NULL AND notification_preference_repo_id IS NULL;
+
+CREATE UNIQUE INDEX notification_preferences_principal_id_space_id
+	ON notification_preferences(notification_preference_principal_id, notification_preference_space_id)
+	WHERE notification_preference_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX notification_preferences_principal_id_repo_id
+	ON notification_preferences(notification_preference_principal_id, notification_preference_repo_id)
+	WHERE notification_preference_repo_id IS NOT NULL;
+
+CREATE TABLE notification_digest_items (
+ notification_digest_item_id INTEGER PRIMARY KEY AUTOINCREMENT
+,notification_digest_item_principal_id INTEGER NOT NULL
+,notification_digest_item_delivery TEXT NOT NULL
+,notification_digest_item_event_id TEXT NOT NULL
+,notification_digest_item_event TEXT NOT NULL
+,notification_digest_item_repo_id INTEGER NOT NULL
+,notification_digest_item_subject TEXT NOT NULL
+,notification_digest_item_text TEXT NOT NULL
+,notification_digest_item_url TEXT NOT NULL
+,notification_digest_item_created BIGINT NOT NULL
+
+,CONSTRAINT fk_notification_digest_item_principal_id FOREIGN KEY (notification_digest_item_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_digest_item_repo_id FOREIGN KEY (notification_digest_item_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_items_delivery_principal_id
+	ON notification_digest_items(notification_digest_item_delivery, notification_digest_item_principal_id);
+
+CREATE UNIQUE INDEX notification_digest_items_principal_id_event_id_event
+	ON notification_digest_items(
+		notification_digest_item_principal_id,
+		notification_digest_item_event_id,
+		notification_digest_item_event
+	);
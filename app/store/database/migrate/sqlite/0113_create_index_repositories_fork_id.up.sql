@@ -0,0 +1,3 @@
+CREATE INDEX repositories_fork_id
+	ON repositories(repo_fork_id)
+	WHERE repo_fork_id > 0;
@@ -0,0 +1,55 @@
+CREATE TABLE push_mirrors (
+ push_mirror_id INTEGER PRIMARY KEY AUTOINCREMENT
+,push_mirror_version INTEGER NOT NULL
+,push_mirror_repo_id INTEGER NOT NULL
+,push_mirror_identifier TEXT NOT NULL
+,push_mirror_created_by INTEGER NOT NULL
+,push_mirror_created BIGINT NOT NULL
+,push_mirror_updated BIGINT NOT NULL
+,push_mirror_remote_url TEXT NOT NULL
+,push_mirror_username TEXT NOT NULL
+,push_mirror_secret_space_id INTEGER
+,push_mirror_secret_identifier TEXT NOT NULL
+,push_mirror_branch_patterns TEXT NOT NULL
+,push_mirror_tag_patterns TEXT NOT NULL
+,push_mirror_force BOOLEAN NOT NULL
+,push_mirror_enabled BOOLEAN NOT NULL
+,push_mirror_latest_execution_result TEXT
+
+,CONSTRAINT fk_push_mirror_repo_id FOREIGN KEY (push_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_push_mirror_created_by FOREIGN KEY (push_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_push_mirror_secret_space_id FOREIGN KEY (push_mirror_secret_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX push_mirrors_repo_id_identifier
+	ON push_mirrors(push_mirror_repo_id, LOWER(push_mirror_identifier));
+
+CREATE TABLE push_mirror_executions (
+ push_mirror_execution_id INTEGER PRIMARY KEY AUTOINCREMENT
+,push_mirror_execution_push_mirror_id INTEGER NOT NULL
+,push_mirror_execution_created BIGINT NOT NULL
+,push_mirror_execution_trigger TEXT NOT NULL
+,push_mirror_execution_result TEXT NOT NULL
+,push_mirror_execution_duration BIGINT NOT NULL
+,push_mirror_execution_error TEXT NOT NULL
+
+,CONSTRAINT fk_push_mirror_execution_push_mirror_id FOREIGN KEY (push_mirror_execution_push_mirror_id)
+    REFERENCES push_mirrors (push_mirror_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX push_mirror_executions_push_mirror_id
+	ON push_mirror_executions(push_mirror_execution_push_mirror_id);
+
+CREATE INDEX push_mirror_executions_created
+	ON push_mirror_executions(push_mirror_execution_created);
@@ -0,0 +1,2 @@
+ALTER TABLE pullreq_activities
+DROP COLUMN pullreq_activity_pending;
@@ -0,0 +1,2 @@
+ALTER TABLE pullreq_activities
+ADD COLUMN pullreq_activity_pending BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE repositories
+ADD COLUMN repo_is_template BOOLEAN NOT NULL DEFAULT FALSE;
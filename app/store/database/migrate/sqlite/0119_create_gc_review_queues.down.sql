@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS gc_track_switched_tag_trigger;
+DROP TRIGGER IF EXISTS gc_track_deleted_tag_trigger;
+DROP TRIGGER IF EXISTS gc_track_deleted_manifest_lists_trigger;
+DROP TRIGGER IF EXISTS gc_track_deleted_layers_trigger;
+DROP TRIGGER IF EXISTS gc_track_deleted_manifests_trigger;
+DROP TRIGGER IF EXISTS gc_track_manifest_uploads_trigger;
+DROP TRIGGER IF EXISTS gc_track_blob_uploads_trigger;
+DROP TABLE IF EXISTS gc_manifest_review_queue;
+DROP TABLE IF EXISTS gc_blob_review_queue;
+DROP TABLE IF EXISTS gc_review_after_defaults;
@@ -0,0 +1,142 @@
+CREATE TABLE gc_review_after_defaults
+(
+    event TEXT    NOT NULL PRIMARY KEY,
+    -- value is the review delay in seconds.
+    value INTEGER NOT NULL
+);
+
+INSERT INTO gc_review_after_defaults (event, value)
+VALUES ('blob_upload', 86400),
+       ('manifest_upload', 86400),
+       ('manifest_delete', 86400),
+       ('layer_delete', 86400),
+       ('manifest_list_delete', 86400),
+       ('tag_delete', 86400),
+       ('tag_switch', 86400);
+
+CREATE TABLE gc_blob_review_queue
+(
+    blob_id      INTEGER NOT NULL PRIMARY KEY,
+    review_after INTEGER NOT NULL,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    created_at   INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+    event        TEXT    NOT NULL
+);
+
+CREATE INDEX index_gc_blob_review_queue_on_review_after
+    ON gc_blob_review_queue (review_after);
+
+CREATE TABLE gc_manifest_review_queue
+(
+    registry_id  INTEGER NOT NULL,
+    manifest_id  INTEGER NOT NULL,
+    review_after INTEGER NOT NULL,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    created_at   INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+    event        TEXT    NOT NULL,
+    CONSTRAINT pk_gc_manifest_review_queue PRIMARY KEY (registry_id, manifest_id),
+    CONSTRAINT fk_gc_manifest_review_queue_mfst_id_mnfsts FOREIGN KEY (manifest_id)
+        REFERENCES manifests (manifest_id) ON DELETE CASCADE
+);
+
+CREATE INDEX index_gc_manifest_review_queue_on_review_after
+    ON gc_manifest_review_queue (review_after);
+
+CREATE TRIGGER gc_track_blob_uploads_trigger
+    AFTER INSERT
+    ON blobs
+    FOR EACH ROW
+BEGIN
+    INSERT INTO gc_blob_review_queue (blob_id, review_after, event)
+    VALUES (NEW.blob_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'blob_upload'), 86400),
+            'blob_upload')
+    ON CONFLICT (blob_id)
+        DO UPDATE SET review_after = excluded.review_after,
+                      event        = excluded.event;
+END;
+
+CREATE TRIGGER gc_track_manifest_uploads_trigger
+    AFTER INSERT
+    ON manifests
+    FOR EACH ROW
+BEGIN
+    INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+    VALUES (NEW.manifest_registry_id, NEW.manifest_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'manifest_upload'), 86400),
+            'manifest_upload');
+END;
+
+CREATE TRIGGER gc_track_deleted_manifests_trigger
+    AFTER DELETE
+    ON manifests
+    FOR EACH ROW
+    WHEN OLD.manifest_configuration_blob_id IS NOT NULL
+BEGIN
+    INSERT INTO gc_blob_review_queue (blob_id, review_after, event)
+    VALUES (OLD.manifest_configuration_blob_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'manifest_delete'), 86400),
+            'manifest_delete')
+    ON CONFLICT (blob_id)
+        DO UPDATE SET review_after = excluded.review_after,
+                      event        = excluded.event;
+END;
+
+CREATE TRIGGER gc_track_deleted_layers_trigger
+    AFTER DELETE
+    ON layers
+    FOR EACH ROW
+BEGIN
+    INSERT INTO gc_blob_review_queue (blob_id, review_after, event)
+    VALUES (OLD.layer_blob_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'layer_delete'), 86400),
+            'layer_delete')
+    ON CONFLICT (blob_id)
+        DO UPDATE SET review_after = excluded.review_after,
+                      event        = excluded.event;
+END;
+
+CREATE TRIGGER gc_track_deleted_manifest_lists_trigger
+    AFTER DELETE
+    ON manifest_references
+    FOR EACH ROW
+    WHEN EXISTS (SELECT 1 FROM manifests WHERE manifest_id = OLD.manifest_ref_child_id)
+BEGIN
+    INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+    VALUES (OLD.manifest_ref_registry_id, OLD.manifest_ref_child_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'manifest_list_delete'), 86400),
+            'manifest_list_delete')
+    ON CONFLICT (registry_id, manifest_id)
+        DO UPDATE SET review_after = excluded.review_after,
+                      event        = excluded.event;
+END;
+
+CREATE TRIGGER gc_track_deleted_tag_trigger
+    AFTER DELETE
+    ON tags
+    FOR EACH ROW
+    WHEN EXISTS (SELECT 1 FROM manifests WHERE manifest_id = OLD.tag_manifest_id)
+BEGIN
+    INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+    VALUES (OLD.tag_registry_id, OLD.tag_manifest_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'tag_delete'), 86400),
+            'tag_delete')
+    ON CONFLICT (registry_id, manifest_id)
+        DO UPDATE SET review_after = excluded.review_after,
+                      event        = excluded.event;
+END;
+
+CREATE TRIGGER gc_track_switched_tag_trigger
+    AFTER UPDATE OF tag_manifest_id
+    ON tags
+    FOR EACH ROW
+    WHEN OLD.tag_manifest_id <> NEW.tag_manifest_id
+BEGIN
+    INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+    VALUES (OLD.tag_registry_id, OLD.tag_manifest_id,
+            strftime('%s', 'now') + COALESCE((SELECT value FROM gc_review_after_defaults WHERE event = 'tag_switch'), 86400),
+            'tag_switch')
+    ON CONFLICT (registry_id, manifest_id)
+        DO UPDATE SET review_after = excluded.review_after,
+                      event        = excluded.event;
+END;
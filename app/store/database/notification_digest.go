@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = NotificationDigestStore{}
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) NotificationDigestStore {
+	return NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements a store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID          int64  `db:"notification_digest_item_id"`
+	PrincipalID int64  `db:"notification_digest_item_principal_id"`
+	Delivery    string `db:"notification_digest_item_delivery"`
+	EventID     string `db:"notification_digest_item_event_id"`
+	Event       string `db:"notification_digest_item_event"`
+	RepoID      int64  `db:"notification_digest_item_repo_id"`
+	Subject     string `db:"notification_digest_item_subject"`
+	Text        string `db:"notification_digest_item_text"`
+	URL         string `db:"notification_digest_item_url"`
+	Created     int64  `db:"notification_digest_item_created"`
+}
+
+const (
+	notificationDigestItemColumns = `
+		 notification_digest_item_id
+		,notification_digest_item_principal_id
+		,notification_digest_item_delivery
+		,notification_digest_item_event_id
+		,notification_digest_item_event
+		,notification_digest_item_repo_id
+		,notification_digest_item_subject
+		,notification_digest_item_text
+		,notification_digest_item_url
+		,notification_digest_item_created`
+)
+
+// Create stores a notification for a digest.
+// A notification of the same event is stored only once for a principal, so retries of the event are no-op.
+func (s NotificationDigestStore) Create(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+		INSERT INTO notification_digest_items (
+			 notification_digest_item_principal_id
+			,notification_digest_item_delivery
+			,notification_digest_item_event_id
+			,notification_digest_item_event
+			,notification_digest_item_repo_id
+			,notification_digest_item_subject
+			,notification_digest_item_text
+			,notification_digest_item_url
+			,notification_digest_item_created
+		) values (
+			 :notification_digest_item_principal_id
+			,:notification_digest_item_delivery
+			,:notification_digest_item_event_id
+			,:notification_digest_item_event
+			,:notification_digest_item_repo_id
+			,:notification_digest_item_subject
+			,:notification_digest_item_text
+			,:notification_digest_item_url
+			,:notification_digest_item_created
+		) ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbItem := mapToInternalNotificationDigestItem(item)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbItem)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest item object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert notification digest item query failed")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns IDs of all principals with pending notifications for the delivery.
+func (s NotificationDigestStore) ListPrincipalIDs(
+	ctx context.Context,
+	delivery enum.NotificationDelivery,
+) ([]int64, error) {
+	const sqlQuery = `
+		SELECT DISTINCT notification_digest_item_principal_id
+		FROM notification_digest_items
+		WHERE notification_digest_item_delivery = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	principalIDs := make([]int64, 0)
+	if err := db.SelectContext(ctx, &principalIDs, sqlQuery, delivery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principals with pending notifications")
+	}
+
+	return principalIDs, nil
+}
+
+// List returns the oldest pending notifications of the principal for the delivery.
+func (s NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+	limit int,
+) ([]*types.NotificationDigestItem, error) {
+	const sqlQuery = `
+		SELECT` + notificationDigestItemColumns + `
+		FROM notification_digest_items
+		WHERE notification_digest_item_principal_id = $1 AND notification_digest_item_delivery = $2
+		ORDER BY notification_digest_item_id
+		LIMIT $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]notificationDigestItem, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID, delivery, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pending notifications")
+	}
+
+	items := make([]*types.NotificationDigestItem, len(dst))
+	for i := range dst {
+		items[i] = mapToNotificationDigestItem(&dst[i])
+	}
+
+	return items, nil
+}
+
+// DeleteUpTo deletes the pending notifications of the principal for the delivery
+// with IDs up to and including the provided ID.
+func (s NotificationDigestStore) DeleteUpTo(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+	maxID int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM notification_digest_items
+		WHERE notification_digest_item_principal_id = $1
+			AND notification_digest_item_delivery = $2
+			AND notification_digest_item_id <= $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, delivery, maxID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pending notifications")
+	}
+
+	return nil
+}
+
+func mapToInternalNotificationDigestItem(in *types.NotificationDigestItem) *notificationDigestItem {
+	return &notificationDigestItem{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Delivery:    string(in.Delivery),
+		EventID:     in.EventID,
+		Event:       string(in.Event),
+		RepoID:      in.RepoID,
+		Subject:     in.Subject,
+		Text:        in.Text,
+		URL:         in.URL,
+		Created:     in.Created,
+	}
+}
+
+func mapToNotificationDigestItem(in *notificationDigestItem) *types.NotificationDigestItem {
+	return &types.NotificationDigestItem{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Delivery:    enum.NotificationDelivery(in.Delivery),
+		EventID:     in.EventID,
+		Event:       enum.NotificationEvent(in.Event),
+		RepoID:      in.RepoID,
+		Subject:     in.Subject,
+		Text:        in.Text,
+		URL:         in.URL,
+		Created:     in.Created,
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDigestStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	require.NoError(t, principalStore.CreateUser(ctx, &types.User{ID: 2, UID: "user_2", Email: "user_2@example.com"}))
+
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	digestStore := database.NewNotificationDigestStore(db)
+
+	newItem := func(principalID int64, delivery enum.NotificationDelivery, eventID string) *types.NotificationDigestItem {
+		return &types.NotificationDigestItem{
+			PrincipalID: principalID,
+			Delivery:    delivery,
+			EventID:     eventID,
+			Event:       enum.NotificationEventComment,
+			RepoID:      1,
+			Subject:     "PR #1",
+			Text:        "comment " + eventID,
+			URL:         "https://example.com/pulls/1",
+		}
+	}
+
+	for _, item := range []*types.NotificationDigestItem{
+		newItem(userID, enum.NotificationDeliveryHourly, "1-0"),
+		newItem(userID, enum.NotificationDeliveryHourly, "2-0"),
+		newItem(userID, enum.NotificationDeliveryHourly, "3-0"),
+		newItem(2, enum.NotificationDeliveryHourly, "1-0"),
+		newItem(2, enum.NotificationDeliveryDaily, "4-0"),
+	} {
+		require.NoError(t, digestStore.Create(ctx, item))
+	}
+
+	// a retried event is stored only once
+	require.NoError(t, digestStore.Create(ctx, newItem(userID, enum.NotificationDeliveryHourly, "2-0")))
+
+	// the same event of another kind is stored separately
+	mention := newItem(userID, enum.NotificationDeliveryHourly, "2-0")
+	mention.Event = enum.NotificationEventMention
+	require.NoError(t, digestStore.Create(ctx, mention))
+
+	principalIDs, err := digestStore.ListPrincipalIDs(ctx, enum.NotificationDeliveryHourly)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{userID, 2}, principalIDs)
+
+	principalIDs, err = digestStore.ListPrincipalIDs(ctx, enum.NotificationDeliveryDaily)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, principalIDs)
+
+	items, err := digestStore.List(ctx, userID, enum.NotificationDeliveryHourly, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	require.Equal(t, "1-0", items[0].EventID)
+	require.Equal(t, "2-0", items[1].EventID)
+	require.Equal(t, enum.NotificationEventComment, items[1].Event)
+	require.Equal(t, "3-0", items[2].EventID)
+	require.Equal(t, enum.NotificationEventMention, items[3].Event)
+
+	items, err = digestStore.List(ctx, userID, enum.NotificationDeliveryHourly, 2)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	require.NoError(t, digestStore.DeleteUpTo(ctx, userID, enum.NotificationDeliveryHourly, items[1].ID))
+
+	items, err = digestStore.List(ctx, userID, enum.NotificationDeliveryHourly, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, "3-0", items[0].EventID)
+
+	// the notifications of other principals are kept
+	items, err = digestStore.List(ctx, 2, enum.NotificationDeliveryHourly, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationPreferenceStore = NotificationPreferenceStore{}
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) NotificationPreferenceStore {
+	return NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements a store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreference struct {
+	ID             int64    `db:"notification_preference_id"`
+	PrincipalID    int64    `db:"notification_preference_principal_id"`
+	SpaceID        null.Int `db:"notification_preference_space_id"`
+	RepoID         null.Int `db:"notification_preference_repo_id"`
+	DisabledEvents string   `db:"notification_preference_disabled_events"`
+	Delivery       string   `db:"notification_preference_delivery"`
+	Created        int64    `db:"notification_preference_created"`
+	Updated        int64    `db:"notification_preference_updated"`
+}
+
+const (
+	notificationPreferenceColumns = `
+		 notification_preference_id
+		,notification_preference_principal_id
+		,notification_preference_space_id
+		,notification_preference_repo_id
+		,notification_preference_disabled_events
+		,notification_preference_delivery
+		,notification_preference_created
+		,notification_preference_updated`
+)
+
+// List returns all notification preferences of the principal.
+func (s NotificationPreferenceStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationPreference, error) {
+	const sqlQuery = `
+		SELECT` + notificationPreferenceColumns + `
+		FROM notification_preferences
+		WHERE notification_preference_principal_id = $1
+		ORDER BY notification_preference_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]notificationPreference, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences")
+	}
+
+	prefs := make([]*types.NotificationPreference, len(dst))
+	for i := range dst {
+		prefs[i] = mapToNotificationPreference(&dst[i])
+	}
+
+	return prefs, nil
+}
+
+// Upsert creates or updates the notification preference of the principal for the scope of the preference.
+func (s NotificationPreferenceStore) Upsert(ctx context.Context, pref *types.NotificationPreference) error {
+	var conflict string
+	switch {
+	case pref.RepoID != nil:
+		conflict = `(notification_preference_principal_id, notification_preference_repo_id)
+			WHERE notification_preference_repo_id IS NOT NULL`
+	case pref.SpaceID != nil:
+		conflict = `(notification_preference_principal_id, notification_preference_space_id)
+			WHERE notification_preference_space_id IS NOT NULL`
+	default:
+		conflict = `(notification_preference_principal_id)
+			WHERE notification_preference_space_id IS NULL AND notification_preference_repo_id IS NULL`
+	}
+
+	sqlQuery := `
+		INSERT INTO notification_preferences (
+			 notification_preference_principal_id
+			,notification_preference_space_id
+			,notification_preference_repo_id
+			,notification_preference_disabled_events
+			,notification_preference_delivery
+			,notification_preference_created
+			,notification_preference_updated
+		) values (
+			 :notification_preference_principal_id
+			,:notification_preference_space_id
+			,:notification_preference_repo_id
+			,:notification_preference_disabled_events
+			,:notification_preference_delivery
+			,:notification_preference_created
+			,:notification_preference_updated
+		) ON CONFLICT ` + conflict + ` DO UPDATE SET
+			 notification_preference_disabled_events = EXCLUDED.notification_preference_disabled_events
+			,notification_preference_delivery = EXCLUDED.notification_preference_delivery
+			,notification_preference_updated = EXCLUDED.notification_preference_updated
+		RETURNING notification_preference_id, notification_preference_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbPref, err := mapToInternalNotificationPreference(pref)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbPref)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preference object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pref.ID, &pref.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert notification preference query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the notification preference of the principal for the provided scope.
+func (s NotificationPreferenceStore) Delete(ctx context.Context, principalID int64, spaceID, repoID *int64) error {
+	stmt := database.Builder.
+		Delete("notification_preferences").
+		Where("notification_preference_principal_id = ?", principalID)
+
+	if spaceID != nil {
+		stmt = stmt.Where("notification_preference_space_id = ?", *spaceID)
+	} else {
+		stmt = stmt.Where("notification_preference_space_id IS NULL")
+	}
+
+	if repoID != nil {
+		stmt = stmt.Where("notification_preference_repo_id = ?", *repoID)
+	} else {
+		stmt = stmt.Where("notification_preference_repo_id IS NULL")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete notification preference query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after delete of notification preference failed")
+	}
+
+	if count == 0 {
+		return errors.NotFound("Notification preference not found")
+	}
+
+	return nil
+}
+
+func mapToInternalNotificationPreference(in *types.NotificationPreference) (*notificationPreference, error) {
+	disabledEvents := in.DisabledEvents
+	if disabledEvents == nil {
+		disabledEvents = []enum.NotificationEvent{}
+	}
+
+	disabledEventsJSON, err := json.Marshal(disabledEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal disabled notification events: %w", err)
+	}
+
+	return &notificationPreference{
+		ID:             in.ID,
+		PrincipalID:    in.PrincipalID,
+		SpaceID:        null.IntFromPtr(in.SpaceID),
+		RepoID:         null.IntFromPtr(in.RepoID),
+		DisabledEvents: string(disabledEventsJSON),
+		Delivery:       string(in.Delivery),
+		Created:        in.Created,
+		Updated:        in.Updated,
+	}, nil
+}
+
+func mapToNotificationPreference(in *notificationPreference) *types.NotificationPreference {
+	var disabledEvents []enum.NotificationEvent
+	// the value is always written by the store, so ignore the error and treat all events as enabled.
+	_ = json.Unmarshal([]byte(in.DisabledEvents), &disabledEvents)
+
+	return &types.NotificationPreference{
+		ID:             in.ID,
+		PrincipalID:    in.PrincipalID,
+		SpaceID:        in.SpaceID.Ptr(),
+		RepoID:         in.RepoID.Ptr(),
+		DisabledEvents: disabledEvents,
+		Delivery:       enum.NotificationDelivery(in.Delivery),
+		Created:        in.Created,
+		Updated:        in.Updated,
+	}
+}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -33,11 +34,13 @@ import (
 // It is required to allow storing transformed UIDs used for uniquness constraints and searching.
 type user struct {
 	types.User
-	UIDUnique string `db:"principal_uid_unique"`
+	UIDUnique   string `db:"principal_uid_unique"`
+	ChatHandles string `db:"principal_user_chat_handles"`
 }
 
 const userColumns = principalCommonColumns + `
-	,principal_user_password`
+	,principal_user_password
+	,principal_user_chat_handles`
 
 const userSelectBase = `
 	SELECT` + userColumns + `
@@ -111,6 +114,7 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,principal_created
 			,principal_updated
 			,principal_user_password
+			,principal_user_chat_handles
 		) values (
 			'user'
 			,:principal_uid
@@ -123,6 +127,7 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,:principal_created
 			,:principal_updated
 			,:principal_user_password
+			,:principal_user_chat_handles
 		) RETURNING principal_id`
 
 	dbUser, err := s.mapToDBUser(user)
@@ -158,6 +163,7 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 			,principal_salt           = :principal_salt
 			,principal_updated        = :principal_updated
 			,principal_user_password  = :principal_user_password
+			,principal_user_chat_handles = :principal_user_chat_handles
 		WHERE principal_type = 'user' AND principal_id = :principal_id`
 
 	dbUser, err := s.mapToDBUser(user)
@@ -269,6 +275,11 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 }
 
 func (s *PrincipalStore) mapDBUser(dbUser *user) *types.User {
+	if dbUser.ChatHandles != "" {
+		// chat handles are optional, so a malformed value is ignored.
+		_ = json.Unmarshal([]byte(dbUser.ChatHandles), &dbUser.User.ChatHandles)
+	}
+
 	return &dbUser.User
 }
 
@@ -290,9 +301,15 @@ func (s *PrincipalStore) mapToDBUser(usr *types.User) (*user, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to transform user UID: %w", err)
 	}
+	chatHandles, err := json.Marshal(usr.ChatHandles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user chat handles: %w", err)
+	}
+
 	dbUser := &user{
-		User:      *usr,
-		UIDUnique: uidUnique,
+		User:        *usr,
+		UIDUnique:   uidUnique,
+		ChatHandles: string(chatHandles),
 	}
 
 	return dbUser, nil
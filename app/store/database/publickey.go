@@ -60,6 +60,7 @@ type publicKey struct {
 	Content     string `db:"public_key_content"`
 	Comment     string `db:"public_key_comment"`
 	Type        string `db:"public_key_type"`
+	Scheme      string `db:"public_key_scheme"`
 }
 
 const (
@@ -73,7 +74,8 @@ const (
 		,public_key_fingerprint
 		,public_key_content
 		,public_key_comment
-		,public_key_type`
+		,public_key_type
+		,public_key_scheme`
 
 	publicKeySelectBase = `
 		SELECT` + publicKeyColumns + `
@@ -131,6 +133,7 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			,public_key_content
 			,public_key_comment
 			,public_key_type
+			,public_key_scheme
 		) values (
 			 :public_key_principal_id
 			,:public_key_created
@@ -141,6 +144,7 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			,:public_key_content
 			,:public_key_comment
 			,:public_key_type
+			,:public_key_scheme
 		) RETURNING public_key_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -289,6 +293,14 @@ func (PublicKeyStore) applyQueryFilter(
 		stmt = stmt.Where(PartialMatch("public_key_identifier", filter.Query))
 	}
 
+	if len(filter.Usages) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"public_key_usage": filter.Usages})
+	}
+
+	if len(filter.Schemes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"public_key_scheme": filter.Schemes})
+	}
+
 	return stmt
 }
 
@@ -326,6 +338,7 @@ func mapToInternalPublicKey(in *types.PublicKey) publicKey {
 		Content:     in.Content,
 		Comment:     in.Comment,
 		Type:        in.Type,
+		Scheme:      string(in.Scheme),
 	}
 }
 
@@ -341,6 +354,7 @@ func mapToPublicKey(in *publicKey) types.PublicKey {
 		Content:     in.Content,
 		Comment:     in.Comment,
 		Type:        in.Type,
+		Scheme:      enum.PublicKeyScheme(in.Scheme),
 	}
 }
 
@@ -0,0 +1,320 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullMirrorStore = PullMirrorStore{}
+
+// NewPullMirrorStore returns a new PullMirrorStore.
+func NewPullMirrorStore(db *sqlx.DB) PullMirrorStore {
+	return PullMirrorStore{
+		db: db,
+	}
+}
+
+// PullMirrorStore implements a store.PullMirrorStore backed by a relational database.
+type PullMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pullMirror struct {
+	ID               int64    `db:"pull_mirror_id"`
+	Version          int64    `db:"pull_mirror_version"`
+	RepoID           int64    `db:"pull_mirror_repo_id"`
+	CreatedBy        int64    `db:"pull_mirror_created_by"`
+	Created          int64    `db:"pull_mirror_created"`
+	Updated          int64    `db:"pull_mirror_updated"`
+	RemoteURL        string   `db:"pull_mirror_remote_url"`
+	Username         string   `db:"pull_mirror_username"`
+	Password         string   `db:"pull_mirror_password"`
+	SecretSpaceID    null.Int `db:"pull_mirror_secret_space_id"`
+	SecretIdentifier string   `db:"pull_mirror_secret_identifier"`
+	Cron             string   `db:"pull_mirror_cron"`
+	Enabled          bool     `db:"pull_mirror_enabled"`
+	NextSync         int64    `db:"pull_mirror_next_sync"`
+	LastSyncStatus   string   `db:"pull_mirror_last_sync_status"`
+	LastSyncStarted  int64    `db:"pull_mirror_last_sync_started"`
+	LastSyncFinished int64    `db:"pull_mirror_last_sync_finished"`
+	LastSyncError    string   `db:"pull_mirror_last_sync_error"`
+}
+
+const (
+	pullMirrorColumns = `
+		 pull_mirror_id
+		,pull_mirror_version
+		,pull_mirror_repo_id
+		,pull_mirror_created_by
+		,pull_mirror_created
+		,pull_mirror_updated
+		,pull_mirror_remote_url
+		,pull_mirror_username
+		,pull_mirror_password
+		,pull_mirror_secret_space_id
+		,pull_mirror_secret_identifier
+		,pull_mirror_cron
+		,pull_mirror_enabled
+		,pull_mirror_next_sync
+		,pull_mirror_last_sync_status
+		,pull_mirror_last_sync_started
+		,pull_mirror_last_sync_finished
+		,pull_mirror_last_sync_error`
+
+	pullMirrorSelectBase = `
+		SELECT` + pullMirrorColumns + `
+		FROM pull_mirrors`
+)
+
+// Find returns the pull mirror configuration of the repository.
+func (s PullMirrorStore) Find(ctx context.Context, repoID int64) (*types.PullMirror, error) {
+	const sqlQuery = pullMirrorSelectBase + `
+		WHERE pull_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull mirror")
+	}
+
+	return mapToPullMirror(dst), nil
+}
+
+// ListDue returns enabled pull mirrors that should be synchronized. Mirrors with a synchronization
+// in progress are skipped, unless they haven't been updated since staleBefore.
+func (s PullMirrorStore) ListDue(
+	ctx context.Context,
+	now int64,
+	staleBefore int64,
+	limit int,
+) ([]*types.PullMirror, error) {
+	const sqlQuery = pullMirrorSelectBase + `
+		WHERE pull_mirror_enabled = TRUE AND pull_mirror_next_sync <= $1 AND (
+			pull_mirror_last_sync_status NOT IN ($2, $3) OR pull_mirror_updated < $4)
+		ORDER BY pull_mirror_next_sync
+		LIMIT $5`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]pullMirror, 0)
+	err := db.SelectContext(ctx, &dst, sqlQuery, now,
+		enum.MirrorSyncStatusQueued, enum.MirrorSyncStatusRunning, staleBefore, limit)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list due pull mirrors")
+	}
+
+	mirrors := make([]*types.PullMirror, len(dst))
+	for i := range dst {
+		mirrors[i] = mapToPullMirror(&dst[i])
+	}
+
+	return mirrors, nil
+}
+
+// Create creates a new pull mirror configuration.
+func (s PullMirrorStore) Create(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		INSERT INTO pull_mirrors (
+			 pull_mirror_version
+			,pull_mirror_repo_id
+			,pull_mirror_created_by
+			,pull_mirror_created
+			,pull_mirror_updated
+			,pull_mirror_remote_url
+			,pull_mirror_username
+			,pull_mirror_password
+			,pull_mirror_secret_space_id
+			,pull_mirror_secret_identifier
+			,pull_mirror_cron
+			,pull_mirror_enabled
+			,pull_mirror_next_sync
+			,pull_mirror_last_sync_status
+			,pull_mirror_last_sync_started
+			,pull_mirror_last_sync_finished
+			,pull_mirror_last_sync_error
+		) values (
+			 :pull_mirror_version
+			,:pull_mirror_repo_id
+			,:pull_mirror_created_by
+			,:pull_mirror_created
+			,:pull_mirror_updated
+			,:pull_mirror_remote_url
+			,:pull_mirror_username
+			,:pull_mirror_password
+			,:pull_mirror_secret_space_id
+			,:pull_mirror_secret_identifier
+			,:pull_mirror_cron
+			,:pull_mirror_enabled
+			,:pull_mirror_next_sync
+			,:pull_mirror_last_sync_status
+			,:pull_mirror_last_sync_started
+			,:pull_mirror_last_sync_finished
+			,:pull_mirror_last_sync_error
+		) RETURNING pull_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert pull mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the pull mirror configuration.
+func (s PullMirrorStore) Update(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		UPDATE pull_mirrors
+		SET
+			 pull_mirror_version = :pull_mirror_version
+			,pull_mirror_updated = :pull_mirror_updated
+			,pull_mirror_remote_url = :pull_mirror_remote_url
+			,pull_mirror_username = :pull_mirror_username
+			,pull_mirror_password = :pull_mirror_password
+			,pull_mirror_secret_space_id = :pull_mirror_secret_space_id
+			,pull_mirror_secret_identifier = :pull_mirror_secret_identifier
+			,pull_mirror_cron = :pull_mirror_cron
+			,pull_mirror_enabled = :pull_mirror_enabled
+			,pull_mirror_next_sync = :pull_mirror_next_sync
+			,pull_mirror_last_sync_status = :pull_mirror_last_sync_status
+			,pull_mirror_last_sync_started = :pull_mirror_last_sync_started
+			,pull_mirror_last_sync_finished = :pull_mirror_last_sync_finished
+			,pull_mirror_last_sync_error = :pull_mirror_last_sync_error
+		WHERE pull_mirror_id = :pull_mirror_id AND pull_mirror_version = :pull_mirror_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror := mapToInternalPullMirror(mirror)
+	dbMirror.Version++
+	dbMirror.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update pull mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	*mirror = *mapToPullMirror(dbMirror)
+
+	return nil
+}
+
+// UpdateOptLock updates the pull mirror configuration using the optimistic locking mechanism.
+func (s PullMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.PullMirror,
+	mutateFn func(mirror *types.PullMirror) error,
+) (*types.PullMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		mirror, err = s.Find(ctx, mirror.RepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reload pull mirror: %w", err)
+		}
+	}
+}
+
+func mapToInternalPullMirror(in *types.PullMirror) *pullMirror {
+	return &pullMirror{
+		ID:               in.ID,
+		Version:          in.Version,
+		RepoID:           in.RepoID,
+		CreatedBy:        in.CreatedBy,
+		Created:          in.Created,
+		Updated:          in.Updated,
+		RemoteURL:        in.RemoteURL,
+		Username:         in.Username,
+		Password:         in.Password,
+		SecretSpaceID:    null.NewInt(in.SecretSpaceID, in.SecretSpaceID != 0),
+		SecretIdentifier: in.SecretIdentifier,
+		Cron:             in.Cron,
+		Enabled:          in.Enabled,
+		NextSync:         in.NextSync,
+		LastSyncStatus:   string(in.LastSyncStatus),
+		LastSyncStarted:  in.LastSyncStarted,
+		LastSyncFinished: in.LastSyncFinished,
+		LastSyncError:    in.LastSyncError,
+	}
+}
+
+func mapToPullMirror(in *pullMirror) *types.PullMirror {
+	return &types.PullMirror{
+		ID:               in.ID,
+		Version:          in.Version,
+		RepoID:           in.RepoID,
+		CreatedBy:        in.CreatedBy,
+		Created:          in.Created,
+		Updated:          in.Updated,
+		RemoteURL:        in.RemoteURL,
+		Username:         in.Username,
+		Password:         in.Password,
+		SecretSpaceID:    in.SecretSpaceID.ValueOrZero(),
+		SecretIdentifier: in.SecretIdentifier,
+		Cron:             in.Cron,
+		Enabled:          in.Enabled,
+		NextSync:         in.NextSync,
+		LastSyncStatus:   enum.MirrorSyncStatus(in.LastSyncStatus),
+		LastSyncStarted:  in.LastSyncStarted,
+		LastSyncFinished: in.LastSyncFinished,
+		LastSyncError:    in.LastSyncError,
+	}
+}
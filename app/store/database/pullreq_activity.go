@@ -83,6 +83,8 @@ type pullReqActivity struct {
 	ResolvedBy null.Int `db:"pullreq_activity_resolved_by"`
 	Resolved   null.Int `db:"pullreq_activity_resolved"`
 
+	Pending bool `db:"pullreq_activity_pending"`
+
 	Outdated                null.Bool   `db:"pullreq_activity_outdated"`
 	CodeCommentMergeBaseSHA null.String `db:"pullreq_activity_code_comment_merge_base_sha"`
 	CodeCommentSourceSHA    null.String `db:"pullreq_activity_code_comment_source_sha"`
@@ -115,6 +117,7 @@ const (
 		,pullreq_activity_metadata
 		,pullreq_activity_resolved_by
 		,pullreq_activity_resolved
+		,pullreq_activity_pending
 		,pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha
@@ -172,6 +175,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_metadata
 		,pullreq_activity_resolved_by
 		,pullreq_activity_resolved
+		,pullreq_activity_pending
 		,pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha
@@ -200,6 +204,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_metadata
 		,:pullreq_activity_resolved_by
 		,:pullreq_activity_resolved
+		,:pullreq_activity_pending
 		,:pullreq_activity_outdated
 		,:pullreq_activity_code_comment_merge_base_sha
 		,:pullreq_activity_code_comment_source_sha
@@ -279,6 +284,7 @@ func (s *PullReqActivityStore) Update(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_metadata = :pullreq_activity_metadata
 		,pullreq_activity_resolved_by = :pullreq_activity_resolved_by
 		,pullreq_activity_resolved = :pullreq_activity_resolved
+		,pullreq_activity_pending = :pullreq_activity_pending
 		,pullreq_activity_outdated = :pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha = :pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha = :pullreq_activity_code_comment_source_sha
@@ -364,7 +370,8 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 	stmt := database.Builder.
 		Select("count(*)").
 		From("pullreq_activities").
-		Where("pullreq_activity_pullreq_id = ?", prID)
+		Where("pullreq_activity_pullreq_id = ?", prID).
+		Where("pullreq_activity_pending = ?", false)
 
 	if len(opts.Types) == 1 {
 		stmt = stmt.Where("pullreq_activity_type = ?", opts.Types[0])
@@ -443,7 +450,8 @@ func (s *PullReqActivityStore) ListAuthorIDs(ctx context.Context, prID int64, or
 		Select("DISTINCT pullreq_activity_created_by").
 		From("pullreq_activities").
 		Where("pullreq_activity_pullreq_id = ?", prID).
-		Where("pullreq_activity_order = ?", order)
+		Where("pullreq_activity_order = ?", order).
+		Where("pullreq_activity_pending = ?", false)
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -461,6 +469,37 @@ func (s *PullReqActivityStore) ListAuthorIDs(ctx context.Context, prID int64, or
 	return dst, nil
 }
 
+// ListPending returns the pending (not deleted) comments of the principal in a PR.
+func (s *PullReqActivityStore) ListPending(
+	ctx context.Context,
+	prID int64,
+	principalID int64,
+) ([]*types.PullReqActivity, error) {
+	stmt := database.Builder.
+		Select(pullreqActivityColumns).
+		From("pullreq_activities").
+		Where("pullreq_activity_pullreq_id = ?", prID).
+		Where("pullreq_activity_created_by = ?", principalID).
+		Where("pullreq_activity_pending = ?", true).
+		Where("pullreq_activity_deleted IS NULL").
+		OrderBy("pullreq_activity_order asc", "pullreq_activity_sub_order asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pull request activity query to sql")
+	}
+
+	dst := make([]*pullReqActivity, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pending pull request activity list query")
+	}
+
+	return s.mapSlicePullReqActivity(ctx, dst)
+}
+
 func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64) (int, error) {
 	stmt := database.Builder.
 		Select("count(*)").
@@ -469,6 +508,7 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 		Where("pullreq_activity_sub_order = 0").
 		Where("pullreq_activity_resolved IS NULL").
 		Where("pullreq_activity_deleted IS NULL").
+		Where("pullreq_activity_pending = ?", false).
 		Where("pullreq_activity_kind <> ?", enum.PullReqActivityKindSystem)
 
 	sql, args, err := stmt.ToSql()
@@ -515,6 +555,7 @@ func mapPullReqActivity(act *pullReqActivity) (*types.PullReqActivity, error) {
 		Metadata:   metadata,
 		ResolvedBy: act.ResolvedBy.Ptr(),
 		Resolved:   act.Resolved.Ptr(),
+		Pending:    act.Pending,
 		Author:     types.PrincipalInfo{},
 		Resolver:   nil,
 	}
@@ -556,6 +597,7 @@ func mapInternalPullReqActivity(act *types.PullReqActivity) (*pullReqActivity, e
 		Metadata:   nil,
 		ResolvedBy: null.IntFromPtr(act.ResolvedBy),
 		Resolved:   null.IntFromPtr(act.Resolved),
+		Pending:    act.Pending,
 	}
 	if act.IsValidCodeComment() {
 		m.Outdated = null.BoolFrom(act.CodeComment.Outdated)
@@ -670,6 +712,14 @@ func applyFilter(
 		stmt = stmt.Where("pullreq_activity_created < ?", filter.Before)
 	}
 
+	// pending comments are visible only to their authors.
+	if filter.PendingAuthorID > 0 {
+		stmt = stmt.Where("(pullreq_activity_pending = ? OR pullreq_activity_created_by = ?)",
+			false, filter.PendingAuthorID)
+	} else {
+		stmt = stmt.Where("pullreq_activity_pending = ?", false)
+	}
+
 	if filter.Limit > 0 {
 		stmt = stmt.Limit(database.Limit(filter.Limit))
 	}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqAutoMergeStore = PullReqAutoMergeStore{}
+
+// NewPullReqAutoMergeStore returns a new PullReqAutoMergeStore.
+func NewPullReqAutoMergeStore(db *sqlx.DB) PullReqAutoMergeStore {
+	return PullReqAutoMergeStore{
+		db: db,
+	}
+}
+
+// PullReqAutoMergeStore implements a store.PullReqAutoMergeStore backed by a relational database.
+type PullReqAutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type pullReqAutoMerge struct {
+	PullReqID int64  `db:"pullreq_auto_merge_pullreq_id"`
+	RepoID    int64  `db:"pullreq_auto_merge_repo_id"`
+	CreatedBy int64  `db:"pullreq_auto_merge_created_by"`
+	Created   int64  `db:"pullreq_auto_merge_created"`
+	Method    string `db:"pullreq_auto_merge_method"`
+	Title     string `db:"pullreq_auto_merge_title"`
+	Message   string `db:"pullreq_auto_merge_message"`
+}
+
+const (
+	pullReqAutoMergeColumns = `
+		 pullreq_auto_merge_pullreq_id
+		,pullreq_auto_merge_repo_id
+		,pullreq_auto_merge_created_by
+		,pullreq_auto_merge_created
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message`
+)
+
+// Find returns the auto-merge settings of the pull request.
+func (s PullReqAutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, error) {
+	const sqlQuery = `
+		SELECT` + pullReqAutoMergeColumns + `
+		FROM pullreq_auto_merges
+		WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqAutoMerge{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request auto-merge")
+	}
+
+	return mapToPullReqAutoMerge(dst), nil
+}
+
+// ListByRepo returns the auto-merge settings of all pull requests targeting the repository.
+func (s PullReqAutoMergeStore) ListByRepo(ctx context.Context, repoID int64) ([]*types.PullReqAutoMerge, error) {
+	const sqlQuery = `
+		SELECT` + pullReqAutoMergeColumns + `
+		FROM pullreq_auto_merges
+		WHERE pullreq_auto_merge_repo_id = $1
+		ORDER BY pullreq_auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]pullReqAutoMerge, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request auto-merges")
+	}
+
+	autoMerges := make([]*types.PullReqAutoMerge, len(dst))
+	for i := range dst {
+		autoMerges[i] = mapToPullReqAutoMerge(&dst[i])
+	}
+
+	return autoMerges, nil
+}
+
+// Upsert creates or replaces the auto-merge settings of the pull request.
+func (s PullReqAutoMergeStore) Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_auto_merges (
+			 pullreq_auto_merge_pullreq_id
+			,pullreq_auto_merge_repo_id
+			,pullreq_auto_merge_created_by
+			,pullreq_auto_merge_created
+			,pullreq_auto_merge_method
+			,pullreq_auto_merge_title
+			,pullreq_auto_merge_message
+		) values (
+			 :pullreq_auto_merge_pullreq_id
+			,:pullreq_auto_merge_repo_id
+			,:pullreq_auto_merge_created_by
+			,:pullreq_auto_merge_created
+			,:pullreq_auto_merge_method
+			,:pullreq_auto_merge_title
+			,:pullreq_auto_merge_message
+		) ON CONFLICT (pullreq_auto_merge_pullreq_id) DO UPDATE SET
+			 pullreq_auto_merge_created_by = EXCLUDED.pullreq_auto_merge_created_by
+			,pullreq_auto_merge_created = EXCLUDED.pullreq_auto_merge_created
+			,pullreq_auto_merge_method = EXCLUDED.pullreq_auto_merge_method
+			,pullreq_auto_merge_title = EXCLUDED.pullreq_auto_merge_title
+			,pullreq_auto_merge_message = EXCLUDED.pullreq_auto_merge_message`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqAutoMerge(autoMerge))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request auto-merge object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert pull request auto-merge query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the auto-merge settings of the pull request.
+func (s PullReqAutoMergeStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_auto_merges
+		WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullReqID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete pull request auto-merge query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalPullReqAutoMerge(in *types.PullReqAutoMerge) *pullReqAutoMerge {
+	return &pullReqAutoMerge{
+		PullReqID: in.PullReqID,
+		RepoID:    in.RepoID,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Method:    string(in.Method),
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+}
+
+func mapToPullReqAutoMerge(in *pullReqAutoMerge) *types.PullReqAutoMerge {
+	return &types.PullReqAutoMerge{
+		PullReqID: in.PullReqID,
+		RepoID:    in.RepoID,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Method:    enum.MergeMethod(in.Method),
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullReqReactionStore = (*PullReqReactionStore)(nil)
+
+// NewPullReqReactionStore returns a new PullReqReactionStore.
+func NewPullReqReactionStore(db *sqlx.DB) *PullReqReactionStore {
+	return &PullReqReactionStore{
+		db: db,
+	}
+}
+
+// PullReqReactionStore implements store.PullReqReactionStore backed by a relational database.
+type PullReqReactionStore struct {
+	db *sqlx.DB
+}
+
+type pullReqReaction struct {
+	ID         int64         `db:"pullreq_reaction_id"`
+	PullReqID  int64         `db:"pullreq_reaction_pullreq_id"`
+	ActivityID null.Int      `db:"pullreq_reaction_activity_id"`
+	CreatedBy  int64         `db:"pullreq_reaction_created_by"`
+	Created    int64         `db:"pullreq_reaction_created"`
+	Reaction   enum.Reaction `db:"pullreq_reaction_reaction"`
+}
+
+const (
+	pullReqReactionColumns = `
+		 pullreq_reaction_id
+		,pullreq_reaction_pullreq_id
+		,pullreq_reaction_activity_id
+		,pullreq_reaction_created_by
+		,pullreq_reaction_created
+		,pullreq_reaction_reaction`
+)
+
+// Create adds the reaction. Adding an already existing reaction does nothing.
+func (s *PullReqReactionStore) Create(ctx context.Context, reaction *types.PullReqReaction) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_reactions (
+		 pullreq_reaction_pullreq_id
+		,pullreq_reaction_activity_id
+		,pullreq_reaction_created_by
+		,pullreq_reaction_created
+		,pullreq_reaction_reaction
+	) VALUES (
+		 :pullreq_reaction_pullreq_id
+		,:pullreq_reaction_activity_id
+		,:pullreq_reaction_created_by
+		,:pullreq_reaction_created
+		,:pullreq_reaction_reaction
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqReaction(reaction))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pullreq reaction object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the reaction of the principal. Removing a non-existing reaction does nothing.
+func (s *PullReqReactionStore) Delete(
+	ctx context.Context,
+	pullReqID int64,
+	activityID *int64,
+	principalID int64,
+	reaction enum.Reaction,
+) error {
+	stmt := database.Builder.
+		Delete("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", pullReqID).
+		Where(squirrel.Eq{"pullreq_reaction_activity_id": activityID}).
+		Where("pullreq_reaction_created_by = ?", principalID).
+		Where("pullreq_reaction_reaction = ?", reaction)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete query failed")
+	}
+
+	return nil
+}
+
+// List returns all reactions to the pull request description and to all comments of the pull request.
+func (s *PullReqReactionStore) List(ctx context.Context, pullReqID int64) ([]*types.PullReqReaction, error) {
+	stmt := database.Builder.
+		Select(pullReqReactionColumns).
+		From("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", pullReqID).
+		OrderBy("pullreq_reaction_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ListForTarget returns the reactions to the pull request description (if activityID is nil) or to the comment.
+func (s *PullReqReactionStore) ListForTarget(
+	ctx context.Context,
+	pullReqID int64,
+	activityID *int64,
+) ([]*types.PullReqReaction, error) {
+	stmt := database.Builder.
+		Select(pullReqReactionColumns).
+		From("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", pullReqID).
+		Where(squirrel.Eq{"pullreq_reaction_activity_id": activityID}).
+		OrderBy("pullreq_reaction_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PullReqReactionStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.PullReqReaction, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqReaction
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list query")
+	}
+
+	return mapToPullReqReactions(dst), nil
+}
+
+func mapToInternalPullReqReaction(reaction *types.PullReqReaction) *pullReqReaction {
+	return &pullReqReaction{
+		PullReqID:  reaction.PullReqID,
+		ActivityID: null.IntFromPtr(reaction.ActivityID),
+		CreatedBy:  reaction.CreatedBy,
+		Created:    reaction.Created,
+		Reaction:   reaction.Reaction,
+	}
+}
+
+func mapToPullReqReaction(reaction *pullReqReaction) *types.PullReqReaction {
+	return &types.PullReqReaction{
+		PullReqID:  reaction.PullReqID,
+		ActivityID: reaction.ActivityID.Ptr(),
+		CreatedBy:  reaction.CreatedBy,
+		Created:    reaction.Created,
+		Reaction:   reaction.Reaction,
+	}
+}
+
+func mapToPullReqReactions(reactions []*pullReqReaction) []*types.PullReqReaction {
+	res := make([]*types.PullReqReaction, len(reactions))
+	for i := range reactions {
+		res[i] = mapToPullReqReaction(reactions[i])
+	}
+	return res
+}
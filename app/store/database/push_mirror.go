@@ -0,0 +1,463 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PushMirrorStore = PushMirrorStore{}
+
+// NewPushMirrorStore returns a new PushMirrorStore.
+func NewPushMirrorStore(db *sqlx.DB) PushMirrorStore {
+	return PushMirrorStore{
+		db: db,
+	}
+}
+
+// PushMirrorStore implements a store.PushMirrorStore backed by a relational database.
+type PushMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pushMirror struct {
+	ID                    int64       `db:"push_mirror_id"`
+	Version               int64       `db:"push_mirror_version"`
+	RepoID                int64       `db:"push_mirror_repo_id"`
+	Identifier            string      `db:"push_mirror_identifier"`
+	CreatedBy             int64       `db:"push_mirror_created_by"`
+	Created               int64       `db:"push_mirror_created"`
+	Updated               int64       `db:"push_mirror_updated"`
+	RemoteURL             string      `db:"push_mirror_remote_url"`
+	Username              string      `db:"push_mirror_username"`
+	SecretSpaceID         null.Int    `db:"push_mirror_secret_space_id"`
+	SecretIdentifier      string      `db:"push_mirror_secret_identifier"`
+	BranchPatterns        string      `db:"push_mirror_branch_patterns"`
+	TagPatterns           string      `db:"push_mirror_tag_patterns"`
+	Force                 bool        `db:"push_mirror_force"`
+	Enabled               bool        `db:"push_mirror_enabled"`
+	LatestExecutionResult null.String `db:"push_mirror_latest_execution_result"`
+}
+
+const (
+	pushMirrorColumns = `
+		 push_mirror_id
+		,push_mirror_version
+		,push_mirror_repo_id
+		,push_mirror_identifier
+		,push_mirror_created_by
+		,push_mirror_created
+		,push_mirror_updated
+		,push_mirror_remote_url
+		,push_mirror_username
+		,push_mirror_secret_space_id
+		,push_mirror_secret_identifier
+		,push_mirror_branch_patterns
+		,push_mirror_tag_patterns
+		,push_mirror_force
+		,push_mirror_enabled
+		,push_mirror_latest_execution_result`
+
+	pushMirrorSelectBase = `
+		SELECT` + pushMirrorColumns + `
+		FROM push_mirrors`
+)
+
+// Find returns the push mirror by id.
+func (s PushMirrorStore) Find(ctx context.Context, id int64) (*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror")
+	}
+
+	return mapToPushMirror(dst)
+}
+
+// FindByIdentifier returns the push mirror of the repository by its identifier.
+func (s PushMirrorStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+		WHERE push_mirror_repo_id = $1 AND LOWER(push_mirror_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror by identifier")
+	}
+
+	return mapToPushMirror(dst)
+}
+
+// Create creates a new push mirror.
+func (s PushMirrorStore) Create(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		INSERT INTO push_mirrors (
+			 push_mirror_version
+			,push_mirror_repo_id
+			,push_mirror_identifier
+			,push_mirror_created_by
+			,push_mirror_created
+			,push_mirror_updated
+			,push_mirror_remote_url
+			,push_mirror_username
+			,push_mirror_secret_space_id
+			,push_mirror_secret_identifier
+			,push_mirror_branch_patterns
+			,push_mirror_tag_patterns
+			,push_mirror_force
+			,push_mirror_enabled
+			,push_mirror_latest_execution_result
+		) values (
+			 :push_mirror_version
+			,:push_mirror_repo_id
+			,:push_mirror_identifier
+			,:push_mirror_created_by
+			,:push_mirror_created
+			,:push_mirror_updated
+			,:push_mirror_remote_url
+			,:push_mirror_username
+			,:push_mirror_secret_space_id
+			,:push_mirror_secret_identifier
+			,:push_mirror_branch_patterns
+			,:push_mirror_tag_patterns
+			,:push_mirror_force
+			,:push_mirror_enabled
+			,:push_mirror_latest_execution_result
+		) RETURNING push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror, err := mapToInternalPushMirror(mirror)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert push mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the push mirror.
+func (s PushMirrorStore) Update(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_version = :push_mirror_version
+			,push_mirror_identifier = :push_mirror_identifier
+			,push_mirror_updated = :push_mirror_updated
+			,push_mirror_remote_url = :push_mirror_remote_url
+			,push_mirror_username = :push_mirror_username
+			,push_mirror_secret_space_id = :push_mirror_secret_space_id
+			,push_mirror_secret_identifier = :push_mirror_secret_identifier
+			,push_mirror_branch_patterns = :push_mirror_branch_patterns
+			,push_mirror_tag_patterns = :push_mirror_tag_patterns
+			,push_mirror_force = :push_mirror_force
+			,push_mirror_enabled = :push_mirror_enabled
+			,push_mirror_latest_execution_result = :push_mirror_latest_execution_result
+		WHERE push_mirror_id = :push_mirror_id AND push_mirror_version = :push_mirror_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror, err := mapToInternalPushMirror(mirror)
+	if err != nil {
+		return err
+	}
+
+	dbMirror.Version++
+	dbMirror.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+	mirror.Updated = dbMirror.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+func (s PushMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.PushMirror,
+	mutateFn func(mirror *types.PushMirror) error,
+) (*types.PushMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		mirror, err = s.Find(ctx, mirror.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reload push mirror: %w", err)
+		}
+	}
+}
+
+// Delete deletes the push mirror.
+func (s PushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM push_mirrors
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the push mirrors of the repository.
+func (s PushMirrorStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.PushMirrorFilter,
+) ([]*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		Where("push_mirror_repo_id = ?", repoID)
+
+	stmt = applyPushMirrorFilter(filter, stmt)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("LOWER(push_mirror_identifier)")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pushMirror, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list push mirrors")
+	}
+
+	return mapToPushMirrors(dst)
+}
+
+// Count counts the push mirrors of the repository.
+func (s PushMirrorStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.PushMirrorFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("push_mirrors").
+		Where("push_mirror_repo_id = ?", repoID)
+
+	stmt = applyPushMirrorFilter(filter, stmt)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count push mirrors")
+	}
+
+	return count, nil
+}
+
+// ListEnabled lists the enabled push mirrors of the repository.
+func (s PushMirrorStore) ListEnabled(ctx context.Context, repoID int64) ([]*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+		WHERE push_mirror_repo_id = $1 AND push_mirror_enabled = TRUE
+		ORDER BY push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pushMirror, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list enabled push mirrors")
+	}
+
+	return mapToPushMirrors(dst)
+}
+
+// ListAllEnabled lists the enabled push mirrors of all repositories with IDs greater than afterID.
+func (s PushMirrorStore) ListAllEnabled(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+		WHERE push_mirror_id > $1 AND push_mirror_enabled = TRUE
+		ORDER BY push_mirror_id
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pushMirror, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, afterID, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list all enabled push mirrors")
+	}
+
+	return mapToPushMirrors(dst)
+}
+
+func applyPushMirrorFilter(
+	filter *types.PushMirrorFilter,
+	stmt squirrel.SelectBuilder,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("push_mirror_identifier", filter.Query))
+	}
+
+	return stmt
+}
+
+func mapToInternalPushMirror(in *types.PushMirror) (*pushMirror, error) {
+	branchPatterns, err := json.Marshal(in.BranchPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal push mirror branch patterns: %w", err)
+	}
+
+	tagPatterns, err := json.Marshal(in.TagPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal push mirror tag patterns: %w", err)
+	}
+
+	return &pushMirror{
+		ID:                    in.ID,
+		Version:               in.Version,
+		RepoID:                in.RepoID,
+		Identifier:            in.Identifier,
+		CreatedBy:             in.CreatedBy,
+		Created:               in.Created,
+		Updated:               in.Updated,
+		RemoteURL:             in.RemoteURL,
+		Username:              in.Username,
+		SecretSpaceID:         null.NewInt(in.SecretSpaceID, in.SecretSpaceID != 0),
+		SecretIdentifier:      in.SecretIdentifier,
+		BranchPatterns:        string(branchPatterns),
+		TagPatterns:           string(tagPatterns),
+		Force:                 in.Force,
+		Enabled:               in.Enabled,
+		LatestExecutionResult: null.StringFromPtr((*string)(in.LatestExecutionResult)),
+	}, nil
+}
+
+func mapToPushMirror(in *pushMirror) (*types.PushMirror, error) {
+	var branchPatterns, tagPatterns []string
+
+	if err := json.Unmarshal([]byte(in.BranchPatterns), &branchPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push mirror branch patterns: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(in.TagPatterns), &tagPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push mirror tag patterns: %w", err)
+	}
+
+	return &types.PushMirror{
+		ID:                    in.ID,
+		Version:               in.Version,
+		RepoID:                in.RepoID,
+		Identifier:            in.Identifier,
+		CreatedBy:             in.CreatedBy,
+		Created:               in.Created,
+		Updated:               in.Updated,
+		RemoteURL:             in.RemoteURL,
+		Username:              in.Username,
+		SecretSpaceID:         in.SecretSpaceID.ValueOrZero(),
+		SecretIdentifier:      in.SecretIdentifier,
+		BranchPatterns:        branchPatterns,
+		TagPatterns:           tagPatterns,
+		Force:                 in.Force,
+		Enabled:               in.Enabled,
+		LatestExecutionResult: (*enum.PushMirrorExecutionResult)(in.LatestExecutionResult.Ptr()),
+	}, nil
+}
+
+func mapToPushMirrors(in []*pushMirror) ([]*types.PushMirror, error) {
+	mirrors := make([]*types.PushMirror, len(in))
+	for i := range in {
+		var err error
+		mirrors[i], err = mapToPushMirror(in[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mirrors, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PushMirrorExecutionStore = PushMirrorExecutionStore{}
+
+// NewPushMirrorExecutionStore returns a new PushMirrorExecutionStore.
+func NewPushMirrorExecutionStore(db *sqlx.DB) PushMirrorExecutionStore {
+	return PushMirrorExecutionStore{
+		db: db,
+	}
+}
+
+// PushMirrorExecutionStore implements a store.PushMirrorExecutionStore backed by a relational database.
+type PushMirrorExecutionStore struct {
+	db *sqlx.DB
+}
+
+type pushMirrorExecution struct {
+	ID           int64  `db:"push_mirror_execution_id"`
+	PushMirrorID int64  `db:"push_mirror_execution_push_mirror_id"`
+	Created      int64  `db:"push_mirror_execution_created"`
+	Trigger      string `db:"push_mirror_execution_trigger"`
+	Result       string `db:"push_mirror_execution_result"`
+	Duration     int64  `db:"push_mirror_execution_duration"`
+	Error        string `db:"push_mirror_execution_error"`
+}
+
+const pushMirrorExecutionColumns = `
+	 push_mirror_execution_id
+	,push_mirror_execution_push_mirror_id
+	,push_mirror_execution_created
+	,push_mirror_execution_trigger
+	,push_mirror_execution_result
+	,push_mirror_execution_duration
+	,push_mirror_execution_error`
+
+// Create creates a new push mirror execution entry.
+func (s PushMirrorExecutionStore) Create(ctx context.Context, execution *types.PushMirrorExecution) error {
+	const sqlQuery = `
+		INSERT INTO push_mirror_executions (
+			 push_mirror_execution_push_mirror_id
+			,push_mirror_execution_created
+			,push_mirror_execution_trigger
+			,push_mirror_execution_result
+			,push_mirror_execution_duration
+			,push_mirror_execution_error
+		) values (
+			 :push_mirror_execution_push_mirror_id
+			,:push_mirror_execution_created
+			,:push_mirror_execution_trigger
+			,:push_mirror_execution_result
+			,:push_mirror_execution_duration
+			,:push_mirror_execution_error
+		) RETURNING push_mirror_execution_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPushMirrorExecution(execution))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror execution object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&execution.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert push mirror execution query failed")
+	}
+
+	return nil
+}
+
+// ListForPushMirror lists the executions of the push mirror, newest first.
+func (s PushMirrorExecutionStore) ListForPushMirror(
+	ctx context.Context,
+	pushMirrorID int64,
+	filter *types.PushMirrorExecutionFilter,
+) ([]*types.PushMirrorExecution, error) {
+	stmt := database.Builder.
+		Select(pushMirrorExecutionColumns).
+		From("push_mirror_executions").
+		Where("push_mirror_execution_push_mirror_id = ?", pushMirrorID)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("push_mirror_execution_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pushMirrorExecution, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list push mirror executions")
+	}
+
+	executions := make([]*types.PushMirrorExecution, len(dst))
+	for i := range dst {
+		executions[i] = mapToPushMirrorExecution(dst[i])
+	}
+
+	return executions, nil
+}
+
+// CountForPushMirror counts the executions of the push mirror.
+func (s PushMirrorExecutionStore) CountForPushMirror(ctx context.Context, pushMirrorID int64) (int64, error) {
+	const sqlQuery = `
+		SELECT COUNT(*)
+		FROM push_mirror_executions
+		WHERE push_mirror_execution_push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, pushMirrorID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count push mirror executions")
+	}
+
+	return count, nil
+}
+
+// DeleteOld removes all executions that are older than the provided time.
+func (s PushMirrorExecutionStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM push_mirror_executions
+		WHERE push_mirror_execution_created < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, olderThan.UnixMilli())
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete old push mirror executions")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted push mirror executions")
+	}
+
+	return n, nil
+}
+
+func mapToInternalPushMirrorExecution(in *types.PushMirrorExecution) *pushMirrorExecution {
+	return &pushMirrorExecution{
+		ID:           in.ID,
+		PushMirrorID: in.PushMirrorID,
+		Created:      in.Created,
+		Trigger:      string(in.Trigger),
+		Result:       string(in.Result),
+		Duration:     in.Duration,
+		Error:        in.Error,
+	}
+}
+
+func mapToPushMirrorExecution(in *pushMirrorExecution) *types.PushMirrorExecution {
+	return &types.PushMirrorExecution{
+		ID:           in.ID,
+		PushMirrorID: in.PushMirrorID,
+		Created:      in.Created,
+		Trigger:      enum.PushMirrorTrigger(in.Trigger),
+		Result:       enum.PushMirrorExecutionResult(in.Result),
+		Duration:     in.Duration,
+		Error:        in.Error,
+	}
+}
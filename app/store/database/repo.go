@@ -87,6 +87,8 @@ type repository struct {
 
 	State   enum.RepoState `db:"repo_state"`
 	IsEmpty bool           `db:"repo_is_empty"`
+
+	IsTemplate bool `db:"repo_is_template"`
 }
 
 const (
@@ -113,7 +115,8 @@ const (
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
 		,repo_state
-		,repo_is_empty`
+		,repo_is_empty
+		,repo_is_template`
 )
 
 // Find finds the repo by id.
@@ -232,6 +235,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_num_merged_pulls
 			,repo_state
 			,repo_is_empty
+			,repo_is_template
 		) values (
 			:repo_version
 			,:repo_parent_id
@@ -255,6 +259,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_num_merged_pulls
 			,:repo_state
 			,:repo_is_empty
+			,:repo_is_template
 		) RETURNING repo_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -299,6 +304,7 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_num_merged_pulls = :repo_num_merged_pulls
 			,repo_state = :repo_state
 			,repo_is_empty = :repo_is_empty
+			,repo_is_template = :repo_is_template
 		WHERE repo_id = :repo_id AND repo_version = :repo_version - 1`
 
 	dbRepo := mapToInternalRepo(repo)
@@ -716,6 +722,58 @@ func (s *RepoStore) listRecursive(
 	return s.mapToRepos(ctx, repos)
 }
 
+// ListForks returns a list of active forks of a repo.
+func (s *RepoStore) ListForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, filter)
+	stmt = applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListAllForks returns all forks of a repo, including the deleted ones.
+func (s *RepoStore) ListAllForks(ctx context.Context, repoID int64) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID).
+		OrderBy("repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list all forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 type repoSize struct {
 	ID          int64  `db:"repo_id"`
 	GitUID      string `db:"repo_git_uid"`
@@ -800,6 +858,7 @@ func (s *RepoStore) mapToRepo(
 		NumMergedPulls: in.NumMergedPulls,
 		State:          in.State,
 		IsEmpty:        in.IsEmpty,
+		IsTemplate:     in.IsTemplate,
 		// Path: is set below
 	}
 
@@ -888,6 +947,7 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		NumMergedPulls: in.NumMergedPulls,
 		State:          in.State,
 		IsEmpty:        in.IsEmpty,
+		IsTemplate:     in.IsTemplate,
 	}
 }
 
@@ -904,6 +964,14 @@ func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squ
 		stmt = stmt.Where("repo_deleted IS NULL")
 	}
 
+	if filter.Archived != nil {
+		if *filter.Archived {
+			stmt = stmt.Where("repo_state = ?", enum.RepoStateArchived)
+		} else {
+			stmt = stmt.Where("repo_state <> ?", enum.RepoStateArchived)
+		}
+	}
+
 	return stmt
 }
 
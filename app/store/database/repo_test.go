@@ -22,6 +22,9 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
 )
 
 const (
@@ -126,6 +129,53 @@ func TestDatabase_CountAll(t *testing.T) {
 	}
 }
 
+func TestDatabase_CountArchived(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	numRepos := createRepos(ctx, t, repoStore, 0, numTestRepos, 1)
+
+	archived := types.Repository{
+		Identifier: "repo_archived",
+		ID:         numRepos,
+		ParentID:   1,
+		GitUID:     "repo_archived",
+		State:      enum.RepoStateArchived,
+	}
+	if err := repoStore.Create(ctx, &archived); err != nil {
+		t.Fatalf("failed to create repo %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		archived *bool
+		want     int64
+	}{
+		{name: "all", archived: nil, want: numRepos + 1},
+		{name: "archived", archived: ptr.Bool(true), want: 1},
+		{name: "not-archived", archived: ptr.Bool(false), want: numRepos},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := repoStore.Count(ctx, 1, &types.RepoFilter{Archived: test.archived})
+			if err != nil {
+				t.Fatalf("failed to count repos %v", err)
+			}
+			if count != test.want {
+				t.Errorf("count = %v, want %v", count, test.want)
+			}
+		})
+	}
+}
+
 func TestDatabase_List(t *testing.T) {
 	db, teardown := setupDB(t)
 	defer teardown()
@@ -239,6 +239,97 @@ func (s *UserGroupStore) CreateOrUpdate(
 	return nil
 }
 
+// Update updates the name and the description of an existing usergroup.
+func (s *UserGroupStore) Update(ctx context.Context, userGroup *types.UserGroup) error {
+	const sqlQuery = `
+	UPDATE usergroups
+	SET
+		 usergroup_name = :usergroup_name
+		,usergroup_description = :usergroup_description
+		,usergroup_updated = :usergroup_updated
+	WHERE usergroup_id = :usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUserGroup(userGroup, userGroup.SpaceID))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update usergroup")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the usergroup with the given ID.
+func (s *UserGroupStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroups
+	WHERE usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete usergroup")
+	}
+
+	return nil
+}
+
+// List returns a list of usergroups defined in any of the provided spaces.
+func (s *UserGroupStore) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("usergroups").
+		Where(squirrel.Eq{"usergroup_space_id": spaceIDs})
+
+	if filter.Query != "" {
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Expr(PartialMatch("usergroup_identifier", filter.Query)),
+			squirrel.Expr(PartialMatch("usergroup_name", filter.Query)),
+		})
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("LOWER(usergroup_identifier) ASC", "usergroup_id ASC")
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to generate list usergroups query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*UserGroup{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "list usergroups query failed")
+	}
+
+	result := make([]*types.UserGroup, len(dst))
+	for i, u := range dst {
+		result[i] = mapUserGroup(u)
+	}
+
+	return result, nil
+}
+
 func mapUserGroup(ug *UserGroup) *types.UserGroup {
 	return &types.UserGroup{
 		ID:          ug.ID,
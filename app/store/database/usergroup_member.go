@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMemberStore = (*UserGroupMemberStore)(nil)
+
+// NewUserGroupMemberStore returns a new UserGroupMemberStore.
+func NewUserGroupMemberStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *UserGroupMemberStore {
+	return &UserGroupMemberStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMemberStore implements store.UserGroupMemberStore backed by a relational database.
+type UserGroupMemberStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMember struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	PrincipalID int64 `db:"usergroup_member_principal_id"`
+	CreatedBy   int64 `db:"usergroup_member_created_by"`
+	Created     int64 `db:"usergroup_member_created"`
+}
+
+type userGroupMemberPrincipal struct {
+	userGroupMember
+	principalInfo
+}
+
+const (
+	userGroupMemberColumns = `
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created`
+)
+
+// Create adds a principal to a usergroup.
+func (s *UserGroupMemberStore) Create(ctx context.Context, member *types.UserGroupMember) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_members (
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created
+	) values (
+		 :usergroup_member_usergroup_id
+		,:usergroup_member_principal_id
+		,:usergroup_member_created_by
+		,:usergroup_member_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroupMember(member))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup member object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert usergroup member")
+	}
+
+	return nil
+}
+
+// Delete removes a principal from a usergroup.
+func (s *UserGroupMemberStore) Delete(ctx context.Context, userGroupID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_members
+	WHERE usergroup_member_usergroup_id = $1 AND
+	      usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "delete usergroup member query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Count returns the number of members of a usergroup.
+func (s *UserGroupMemberStore) Count(
+	ctx context.Context,
+	userGroupID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID)
+
+	stmt = applyUserGroupMemberFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert usergroup members count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup members count query")
+	}
+
+	return count, nil
+}
+
+// List returns the members of a usergroup.
+func (s *UserGroupMemberStore) List(
+	ctx context.Context,
+	userGroupID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroupMemberInfo, error) {
+	const columns = userGroupMemberColumns + "," + principalInfoCommonColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID)
+
+	stmt = applyUserGroupMemberFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("principal_display_name ASC", "principal_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup members list query to sql: %w", err)
+	}
+
+	dst := make([]*userGroupMemberPrincipal, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup members list query")
+	}
+
+	result, err := s.mapToUserGroupMemberInfos(ctx, dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map usergroup members to external type: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListPrincipalIDs returns the IDs of all principals that are members of any of the provided usergroups.
+func (s *UserGroupMemberStore) ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	if len(userGroupIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("DISTINCT usergroup_member_principal_id").
+		From("usergroup_members").
+		Where(squirrel.Eq{"usergroup_member_usergroup_id": userGroupIDs}).
+		OrderBy("usergroup_member_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert usergroup member principal IDs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := make([]int64, 0)
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing usergroup member principal IDs query")
+	}
+
+	return result, nil
+}
+
+func applyUserGroupMemberFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ListQueryFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Expr(PartialMatch("principal_uid", filter.Query)),
+			squirrel.Expr(PartialMatch("principal_display_name", filter.Query)),
+		})
+	}
+
+	return stmt
+}
+
+func mapToUserGroupMember(m *userGroupMember) types.UserGroupMember {
+	return types.UserGroupMember{
+		UserGroupID: m.UserGroupID,
+		PrincipalID: m.PrincipalID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+	}
+}
+
+func mapToInternalUserGroupMember(m *types.UserGroupMember) *userGroupMember {
+	return &userGroupMember{
+		UserGroupID: m.UserGroupID,
+		PrincipalID: m.PrincipalID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+	}
+}
+
+func (s *UserGroupMemberStore) mapToUserGroupMemberInfos(
+	ctx context.Context,
+	ms []*userGroupMemberPrincipal,
+) ([]*types.UserGroupMemberInfo, error) {
+	// collect all principal IDs
+	ids := make([]int64, 0, len(ms))
+	for _, m := range ms {
+		ids = append(ids, m.userGroupMember.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usergroup member principal infos: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	res := make([]*types.UserGroupMemberInfo, len(ms))
+	for i, m := range ms {
+		res[i] = &types.UserGroupMemberInfo{
+			UserGroupMember: mapToUserGroupMember(&m.userGroupMember),
+			Principal:       mapToPrincipalInfo(&m.principalInfo),
+		}
+		if addedBy, ok := infoMap[m.userGroupMember.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserGroupMemberStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	require.NoError(t, principalStore.CreateUser(ctx,
+		&types.User{ID: 2, UID: "user_2", Email: "user_2@example.com", DisplayName: "Second"}))
+
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	userGroupStore := database.NewUserGroupStore(db)
+	memberStore := database.NewUserGroupMemberStore(db, pCache)
+
+	devs := &types.UserGroup{Identifier: "devs", Name: "Developers"}
+	require.NoError(t, userGroupStore.Create(ctx, 1, devs))
+	ops := &types.UserGroup{Identifier: "ops", Name: "Operations"}
+	require.NoError(t, userGroupStore.Create(ctx, 2, ops))
+
+	groups, err := userGroupStore.List(ctx, []int64{1, 2}, &types.ListQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	groups, err = userGroupStore.List(ctx, []int64{1, 2}, &types.ListQueryFilter{Query: "operat"})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, ops.ID, groups[0].ID)
+
+	for _, m := range []types.UserGroupMember{
+		{UserGroupID: devs.ID, PrincipalID: 1, CreatedBy: userID},
+		{UserGroupID: devs.ID, PrincipalID: 2, CreatedBy: userID},
+		{UserGroupID: ops.ID, PrincipalID: 2, CreatedBy: userID},
+	} {
+		require.NoError(t, memberStore.Create(ctx, &m))
+	}
+
+	err = memberStore.Create(ctx, &types.UserGroupMember{UserGroupID: ops.ID, PrincipalID: 2, CreatedBy: userID})
+	require.ErrorIs(t, err, gitness_store.ErrDuplicate)
+
+	ids, err := memberStore.ListPrincipalIDs(ctx, []int64{devs.ID, ops.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, ids)
+
+	members, err := memberStore.List(ctx, devs.ID, &types.ListQueryFilter{Query: "second"})
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, "user_2", members[0].Principal.UID)
+	require.Equal(t, "user_1", members[0].AddedBy.UID)
+
+	count, err := memberStore.Count(ctx, devs.ID, &types.ListQueryFilter{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	require.NoError(t, memberStore.Delete(ctx, devs.ID, 1))
+	require.ErrorIs(t, memberStore.Delete(ctx, devs.ID, 1), gitness_store.ErrResourceNotFound)
+
+	// deleting the usergroup removes all of its members
+	require.NoError(t, userGroupStore.Delete(ctx, ops.ID))
+
+	ids, err = memberStore.ListPrincipalIDs(ctx, []int64{devs.ID, ops.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, ids)
+}
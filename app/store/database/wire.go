@@ -32,6 +32,7 @@ var WireSet = wire.NewSet(
 	ProvidePrincipalStore,
 	ProvideUserGroupStore,
 	ProvideUserGroupReviewerStore,
+	ProvideUserGroupMemberStore,
 	ProvidePrincipalInfoView,
 	ProvideInfraProviderResourceView,
 	ProvideSpacePathStore,
@@ -74,6 +75,15 @@ var WireSet = wire.NewSet(
 	ProvideInfraProviderTemplateStore,
 	ProvideInfraProvisionedStore,
 	ProvideUsageMetricStore,
+	ProvideAuditEventStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationDigestStore,
+	ProvidePullReqAutoMergeStore,
+	ProvideMergeQueueStore,
+	ProvidePullMirrorStore,
+	ProvidePushMirrorStore,
+	ProvidePushMirrorExecutionStore,
+	ProvidePullReqReactionStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -111,6 +121,14 @@ func ProvideUserGroupReviewerStore(
 	return NewUsergroupReviewerStore(db, pInfoCache, userGroupStore)
 }
 
+// ProvideUserGroupMemberStore provides a usergroup member store.
+func ProvideUserGroupMemberStore(
+	db *sqlx.DB,
+	pInfoCache store.PrincipalInfoCache,
+) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db, pInfoCache)
+}
+
 // ProvidePrincipalInfoView provides a principal info store.
 func ProvidePrincipalInfoView(db *sqlx.DB) store.PrincipalInfoView {
 	return NewPrincipalInfoView(db)
@@ -355,3 +373,51 @@ func ProvideInfraProvisionedStore(db *sqlx.DB) store.InfraProvisionedStore {
 func ProvideUsageMetricStore(db *sqlx.DB) store.UsageMetricStore {
 	return NewUsageMetricsStore(db)
 }
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.AuditEventStore {
+	return NewAuditEventStore(db, principalInfoCache)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
+// ProvidePullReqAutoMergeStore provides a pull request auto-merge store.
+func ProvidePullReqAutoMergeStore(db *sqlx.DB) store.PullReqAutoMergeStore {
+	return NewPullReqAutoMergeStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvidePullMirrorStore provides a pull mirror store.
+func ProvidePullMirrorStore(db *sqlx.DB) store.PullMirrorStore {
+	return NewPullMirrorStore(db)
+}
+
+// ProvidePushMirrorStore provides a push mirror store.
+func ProvidePushMirrorStore(db *sqlx.DB) store.PushMirrorStore {
+	return NewPushMirrorStore(db)
+}
+
+// ProvidePushMirrorExecutionStore provides a push mirror execution store.
+func ProvidePushMirrorExecutionStore(db *sqlx.DB) store.PushMirrorExecutionStore {
+	return NewPushMirrorExecutionStore(db)
+}
+
+// ProvidePullReqReactionStore provides a pull request reaction store.
+func ProvidePullReqReactionStore(db *sqlx.DB) store.PullReqReactionStore {
+	return NewPullReqReactionStore(db)
+}
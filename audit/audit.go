@@ -56,11 +56,14 @@ const (
 	ActionUpdated  Action = "updated" // update default branch, switching default branch, updating description
 	ActionDeleted  Action = "deleted"
 	ActionBypassed Action = "bypassed"
+
+	ActionArchived   Action = "archived"
+	ActionUnarchived Action = "unarchived"
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed:
+	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed, ActionArchived, ActionUnarchived:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -145,6 +148,7 @@ type Event struct {
 	Action        Action          // example: ActionCreated
 	User          types.Principal // example: Admin
 	SpacePath     string          // example: /root/projects
+	RepoID        int64           // ID of the repository the event belongs to, if any
 	Resource      Resource
 	DiffObject    DiffObject
 	ClientIP      string
@@ -168,6 +172,21 @@ func (e *Event) Validate() error {
 	return nil
 }
 
+// ValidateFilter checks that the actions and resource types of the provided audit event filter are defined.
+func ValidateFilter(filter *types.AuditEventFilter) error {
+	for _, action := range filter.Actions {
+		if err := Action(action).Validate(); err != nil {
+			return fmt.Errorf("invalid action %q: %w", action, err)
+		}
+	}
+	for _, resourceType := range filter.ResourceTypes {
+		if err := ResourceType(resourceType).Validate(); err != nil {
+			return fmt.Errorf("invalid resource type %q: %w", resourceType, err)
+		}
+	}
+	return nil
+}
+
 type Noop struct{}
 
 func New() *Noop {
@@ -202,6 +221,13 @@ func WithID(value string) FuncOption {
 	}
 }
 
+// WithRepoID sets the ID of the repository the event belongs to.
+func WithRepoID(value int64) FuncOption {
+	return func(e *Event) {
+		e.RepoID = value
+	}
+}
+
 func WithNewObject(value any) FuncOption {
 	return func(e *Event) {
 		e.DiffObject.NewObject = value
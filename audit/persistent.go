@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	dataKeyRequestID = "requestID"
+	dataKeyPath      = "path"
+)
+
+var _ Service = (*Persistent)(nil)
+
+// Persistent is an audit service that stores all audit events in the database.
+type Persistent struct {
+	auditEventStore store.AuditEventStore
+	spaceFinder     refcache.SpaceFinder
+	repoFinder      refcache.RepoFinder
+}
+
+func NewPersistent(
+	auditEventStore store.AuditEventStore,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+) *Persistent {
+	return &Persistent{
+		auditEventStore: auditEventStore,
+		spaceFinder:     spaceFinder,
+		repoFinder:      repoFinder,
+	}
+}
+
+func (s *Persistent) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource Resource,
+	action Action,
+	spacePath string,
+	options ...Option,
+) error {
+	event := Event{
+		ID:            uuid.NewString(),
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      GetRealIP(ctx),
+		RequestMethod: GetRequestMethod(ctx),
+	}
+
+	if requestID := GetRequestID(ctx); requestID != "" {
+		WithData(dataKeyRequestID, requestID).Apply(&event)
+	}
+	if path := GetPath(ctx); path != "" {
+		WithData(dataKeyPath, path).Apply(&event)
+	}
+
+	for _, opt := range options {
+		opt.Apply(&event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal old object: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new object: %w", err)
+	}
+
+	repoPath := repoPathOf(event.SpacePath, event.Resource)
+
+	err = s.auditEventStore.Create(ctx, &types.AuditEvent{
+		Identifier:         event.ID,
+		Created:            event.Timestamp,
+		Action:             string(event.Action),
+		ActorID:            event.User.ID,
+		SpaceID:            s.spaceIDOf(ctx, event.SpacePath),
+		SpacePath:          event.SpacePath,
+		RepoID:             s.repoIDOf(ctx, event.RepoID, repoPath),
+		RepoPath:           repoPath,
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		ResourceData:       event.Resource.Data,
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		Data:               event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store audit event: %w", err)
+	}
+
+	return nil
+}
+
+// repoPathOf returns the path of the repository the resource belongs to,
+// or an empty string if the resource isn't related to a repository.
+func repoPathOf(spacePath string, resource Resource) string {
+	if repoPath := resource.Data[RepoPath]; repoPath != "" {
+		return repoPath
+	}
+
+	if repoName := resource.Data[RepoName]; repoName != "" {
+		return paths.Concatenate(spacePath, repoName)
+	}
+
+	if resource.Type == ResourceTypeRepository || resource.Type == ResourceTypeRepositorySettings {
+		return paths.Concatenate(spacePath, resource.Identifier)
+	}
+
+	return ""
+}
+
+// spaceIDOf returns the ID of the space the event was recorded in. The events are listed by the space IDs,
+// so the history of a space is kept after it's moved or renamed.
+func (s *Persistent) spaceIDOf(ctx context.Context, spacePath string) int64 {
+	space, err := s.spaceFinder.FindByRef(ctx, spacePath)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find space %q of audit event", spacePath)
+		return 0
+	}
+
+	return space.ID
+}
+
+// repoIDOf returns the ID of the repository the event belongs to. The events are listed by the repository ID,
+// so the history of a repository is kept after it's moved or renamed.
+// If the ID isn't provided with the event, the repository is looked up by its path.
+func (s *Persistent) repoIDOf(ctx context.Context, repoID int64, repoPath string) int64 {
+	if repoID != 0 || repoPath == "" {
+		return repoID
+	}
+
+	repo, err := s.repoFinder.FindByRef(ctx, repoPath)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find repository %q of audit event", repoPath)
+		return 0
+	}
+
+	return repo.ID
+}
+
+func marshalObject(obj any) (json.RawMessage, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(obj)
+}
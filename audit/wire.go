@@ -14,12 +14,21 @@
 
 package audit
 
-import "github.com/google/wire"
+import (
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/google/wire"
+)
 
 var WireSet = wire.NewSet(
 	ProvideAuditService,
 )
 
-func ProvideAuditService() Service {
-	return New()
+func ProvideAuditService(
+	auditEventStore store.AuditEventStore,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+) Service {
+	return NewPersistent(auditEventStore, spaceFinder, repoFinder)
 }
@@ -338,9 +338,18 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 
 func ProvideNotificationConfig(config *types.Config) notification.Config {
 	return notification.Config{
-		EventReaderName: config.InstanceID,
-		Concurrency:     config.Notification.Concurrency,
-		MaxRetries:      config.Notification.MaxRetries,
+		EventReaderName:  config.InstanceID,
+		Concurrency:      config.Notification.Concurrency,
+		MaxRetries:       config.Notification.MaxRetries,
+		DigestHourlyCron: config.Notification.DigestHourlyCron,
+		DigestDailyCron:  config.Notification.DigestDailyCron,
+		Reply: notification.ReplyConfig{
+			Address:  config.Notification.Reply.Address,
+			Maildir:  config.Notification.Reply.Maildir,
+			PollCron: config.Notification.Reply.PollCron,
+			TokenTTL: config.Notification.Reply.TokenTTL,
+			Secret:   config.Notification.Reply.Secret,
+		},
 	}
 }
 
@@ -382,8 +391,10 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
-		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
-		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		WebhookExecutionsRetentionTime:    config.Webhook.RetentionTime,
+		DeletedRepositoriesRetentionTime:  config.Repos.DeletedRetentionTime,
+		AuditEventsRetentionTime:          config.Audit.RetentionTime,
+		PushMirrorExecutionsRetentionTime: config.Mirror.PushExecutionsRetentionTime,
 	}
 }
 
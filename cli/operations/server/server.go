@@ -105,6 +105,26 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.Notification.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register notification digest jobs")
+			return err
+		}
+
+		if err := system.services.MailReply.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register mail reply service")
+			return err
+		}
+
+		if err := system.services.Mirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register mirror service")
+			return err
+		}
+
+		if err := system.services.RegistryCleanupPolicy.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register registry cleanup policy service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
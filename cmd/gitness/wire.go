@@ -53,6 +53,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	connectorservice "github.com/harness/gitness/app/connector"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	gitspaceevents "github.com/harness/gitness/app/events/gitspace"
 	gitspacedeleteevents "github.com/harness/gitness/app/events/gitspacedelete"
@@ -82,6 +83,7 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -94,8 +96,11 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mailreply"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
@@ -133,6 +138,7 @@ import (
 	"github.com/harness/gitness/pubsub"
 	registryevents "github.com/harness/gitness/registry/app/events"
 	"github.com/harness/gitness/registry/app/pkg/docker"
+	registrycleanuppolicy "github.com/harness/gitness/registry/services/cleanuppolicy"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -152,6 +158,10 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideBlobStoreConfig,
 		mailer.WireSet,
 		notification.WireSet,
+		mailreply.WireSet,
+		automerge.WireSet,
+		mergequeue.WireSet,
+		mirror.WireSet,
 		blob.WireSet,
 		dbtx.WireSet,
 		cache.WireSetSpace,
@@ -190,6 +200,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		checkevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -286,6 +297,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		usage.WireSet,
 		registryevents.WireSet,
 		registrywebhooks.WireSet,
+		registrycleanuppolicy.WireSet,
 		gitspacedeleteevents.WireSet,
 		gitspacedeleteeventservice.WireSet,
 	)
@@ -42,6 +42,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/connector"
+	events11 "github.com/harness/gitness/app/events/check"
 	events9 "github.com/harness/gitness/app/events/git"
 	events3 "github.com/harness/gitness/app/events/gitspace"
 	events6 "github.com/harness/gitness/app/events/gitspacedelete"
@@ -71,6 +72,7 @@ import (
 	router2 "github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -86,8 +88,11 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mailreply"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/migrate"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
@@ -124,6 +129,9 @@ import (
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	api2 "github.com/harness/gitness/registry/app/api"
+	gopackage2 "github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
+	helm2 "github.com/harness/gitness/registry/app/api/controller/pkg/helm"
+	npm2 "github.com/harness/gitness/registry/app/api/controller/pkg/npm"
 	python2 "github.com/harness/gitness/registry/app/api/controller/pkg/python"
 	"github.com/harness/gitness/registry/app/api/router"
 	events10 "github.com/harness/gitness/registry/app/events"
@@ -132,10 +140,14 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	"github.com/harness/gitness/registry/app/pkg/generic"
+	"github.com/harness/gitness/registry/app/pkg/gopackage"
+	"github.com/harness/gitness/registry/app/pkg/helm"
 	"github.com/harness/gitness/registry/app/pkg/maven"
+	"github.com/harness/gitness/registry/app/pkg/npm"
 	"github.com/harness/gitness/registry/app/pkg/python"
 	database2 "github.com/harness/gitness/registry/app/store/database"
 	"github.com/harness/gitness/registry/gc"
+	"github.com/harness/gitness/registry/services/cleanuppolicy"
 	webhook3 "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -187,7 +199,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
 	publicKeyStore := database.ProvidePublicKeyStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore)
+	notificationPreferenceStore := database.ProvideNotificationPreferenceStore(db)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, spaceFinder, repoFinder, notificationPreferenceStore)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
@@ -202,6 +215,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
 	settingsStore := database.ProvideSettingsStore(db)
 	settingsService := settings.ProvideService(settingsStore)
+	encrypter, err := encrypt.ProvideEncrypter(config)
+	if err != nil {
+		return nil, err
+	}
+	serverKey := publickey.ProvideServerKey(settingsService, encrypter)
+	webhookConfig := server.ProvideWebhookConfig(config)
+	chatWebhooks := notification.ProvideChatWebhooks(settingsService, encrypter, spaceStore, webhookConfig)
+	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalStore, principalInfoCache, serverKey)
 	protectionManager, err := protection.ProvideManager(ruleStore)
 	if err != nil {
 		return nil, err
@@ -217,15 +238,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	storageStore := storage.ProvideLocalStore()
-	gitInterface, err := git.ProvideService(typesConfig, apiGit, clientFactory, storageStore)
+	signer := publickey.ProvideGitSigner(serverKey)
+	gitInterface, err := git.ProvideService(typesConfig, apiGit, clientFactory, storageStore, signer)
 	if err != nil {
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
-	encrypter, err := encrypt.ProvideEncrypter(config)
-	if err != nil {
-		return nil, err
-	}
 	jobStore := database.ProvideJobStore(db)
 	executor := job.ProvideExecutor(jobStore, pubSub)
 	lockConfig := server.ProvideLockConfig(config)
@@ -247,13 +265,16 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	auditService := audit.ProvideAuditService()
+	auditEventStore := database.ProvideAuditEventStore(db, principalInfoCache)
+	auditService := audit.ProvideAuditService(auditEventStore, spaceFinder, repoFinder)
 	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, repoFinder, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, reporter, auditService)
 	if err != nil {
 		return nil, err
 	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
-	usergroupResolver := usergroup.ProvideUserGroupResolver()
+	userGroupStore := database.ProvideUserGroupStore(db)
+	userGroupMemberStore := database.ProvideUserGroupMemberStore(db, principalInfoCache)
+	usergroupResolver := usergroup.ProvideUserGroupResolver(spaceFinder, userGroupStore, userGroupMemberStore, principalInfoCache)
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
 	resourceLimiter, err := limiter.ProvideLimiter()
 	if err != nil {
@@ -267,10 +288,19 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqLabelAssignmentStore := database.ProvidePullReqLabelStore(db)
 	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, spaceFinder)
 	instrumentService := instrument.ProvideService()
-	userGroupStore := database.ProvideUserGroupStore(db)
-	searchService := usergroup.ProvideSearchService()
+	searchService := usergroup.ProvideSearchService(spaceFinder, spaceStore, userGroupStore, userGroupMemberStore, principalInfoCache)
 	rulesService := rules.ProvideService(transactor, ruleStore, repoStore, spaceStore, protectionManager, auditService, instrumentService, principalInfoCache, userGroupStore, searchService, streamer)
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, searchService, rulesService, streamer)
+	pullMirrorStore := database.ProvidePullMirrorStore(db)
+	pushMirrorStore := database.ProvidePushMirrorStore(db)
+	pushMirrorExecutionStore := database.ProvidePushMirrorExecutionStore(db)
+	secretStore := database.ProvideSecretStore(db)
+	reporter7, err := events9.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mirrorService := mirror.ProvideService(config, jobScheduler, executor, pullMirrorStore, pushMirrorStore, pushMirrorExecutionStore, repoStore, repoFinder, secretStore, encrypter, gitInterface, provider, reporter7, streamer)
+	webhookStore := database.ProvideWebhookStore(db)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, searchService, rulesService, streamer, auditEventStore, publickeyService, pullMirrorStore, secretStore, encrypter, mirrorService, pushMirrorStore, pushMirrorExecutionStore, webhookStore)
 	reposettingsController := reposettings.ProvideController(authorizer, repoFinder, settingsService, auditService)
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
@@ -290,7 +320,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, pipelineStore, stageStore, stepStore, logStore, logStream, repoFinder)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
-	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db, secretStore)
 	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, pullReqStore, checkStore, repoFinder, labelService, protectionManager)
 	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
@@ -361,7 +390,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, eventsReporter, gitspaceEventStore, spaceFinder, infraproviderService, orchestratorOrchestrator, scmSCM, config, reporter4)
 	usageMetricStore := database.ProvideUsageMetricStore(db)
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, auditEventStore, chatWebhooks)
 	reporter5, err := events7.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -398,9 +427,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, repoFinder, transactor, mutexManager)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, repoFinder, reporter6, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService)
-	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
+	pullReqAutoMergeStore := database.ProvidePullReqAutoMergeStore(db)
+	mergeQueueStore := database.ProvideMergeQueueStore(db)
+	pullReqReactionStore := database.ProvidePullReqReactionStore(db)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, repoFinder, reporter6, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService, publickeyService, pullReqAutoMergeStore, mergeQueueStore, pullReqReactionStore)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
 	urlProvider := webhook.ProvideURLProvider(ctx)
 	secretService := secret3.ProvideSecretService(secretStore, encrypter, spaceFinder)
@@ -410,10 +440,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	preprocessor := webhook2.ProvidePreprocessor()
 	webhookController := webhook2.ProvideController(authorizer, spaceFinder, repoFinder, webhookService, encrypter, preprocessor)
-	reporter7, err := events9.ProvideReporter(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
 	preReceiveExtender, err := githook.ProvidePreReceiveExtender()
 	if err != nil {
 		return nil, err
@@ -427,13 +453,17 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	lfsObjectStore := database.ProvideLFSObjectStore(db)
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter7, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter7, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, publickeyService, mirrorService, searchService)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore, authorizer)
-	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, authorizer, searchService)
+	usergroupController := usergroup2.ProvideController(userGroupStore, userGroupMemberStore, spaceStore, principalStore, spaceFinder, authorizer, searchService)
 	v2 := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, spaceStore, checkStore, spaceFinder, repoFinder, gitInterface, v2, streamer)
-	systemController := system.NewController(principalStore, config)
+	reporter9, err := events11.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	checkController := check2.ProvideController(transactor, authorizer, spaceStore, checkStore, spaceFinder, repoFinder, gitInterface, v2, streamer, reporter9)
+	systemController := system.NewController(principalStore, config, serverKey)
 	blobConfig, err := server.ProvideBlobStoreConfig(config)
 	if err != nil {
 		return nil, err
@@ -461,7 +491,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	mediaTypesRepository := database2.ProvideMediaTypeDao(db)
 	blobRepository := database2.ProvideBlobDao(db, mediaTypesRepository)
 	storageService := docker.StorageServiceProvider(config, storageDriver)
-	gcService := gc.ServiceProvider()
+	gcBlobTaskRepository := database2.ProvideGCBlobTaskDao(db)
+	gcManifestTaskRepository := database2.ProvideGCManifestTaskDao(db)
+	gcReviewAfterDefaultRepository := database2.ProvideGCReviewAfterDefaultDao(db)
+	gcService := gc.ServiceProvider(transactor, gcBlobTaskRepository, gcManifestTaskRepository, gcReviewAfterDefaultRepository)
 	app := docker.NewApp(ctx, storageDeleter, blobRepository, spaceStore, config, storageService, gcService)
 	registryRepository := database2.ProvideRepoDao(db, mediaTypesRepository)
 	manifestRepository := database2.ProvideManifestDao(db, mediaTypesRepository)
@@ -504,7 +537,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceFinder, transactor, authenticator, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service2, spacePathStore, reporter8)
+	cleanuppolicyService := cleanuppolicy.ProvideService(jobScheduler, executor, registryRepository, cleanupPolicyRepository, tagRepository, manifestRepository, spaceFinder, auditService, reporter8, provider)
+	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceFinder, transactor, authenticator, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service2, spacePathStore, reporter8, cleanuppolicyService)
 	mavenDBStore := maven.DBStoreProvider(registryRepository, imageRepository, artifactRepository, spaceStore, bandwidthStatRepository, downloadStatRepository, nodesRepository, upstreamProxyConfigRepository)
 	mavenLocalRegistry := maven.LocalRegistryProvider(mavenDBStore, transactor, fileManager)
 	mavenController := maven.ProvideProxyController(mavenLocalRegistry, secretService, spaceFinder)
@@ -523,14 +557,26 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	proxy := python.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, localRegistryHelper)
 	pythonController := python2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, pythonLocalRegistry, proxy)
 	pythonHandler := api2.NewPythonHandlerProvider(pythonController, packagesHandler)
-	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler)
+	npmLocalRegistry := npm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
+	npmLocalRegistryHelper := npm.LocalRegistryHelperProvider(npmLocalRegistry, localBase)
+	npmProxy := npm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, npmLocalRegistryHelper)
+	npmController := npm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, npmLocalRegistry, npmProxy)
+	npmHandler := api2.NewNpmHandlerProvider(npmController, packagesHandler)
+	helmLocalRegistry := helm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
+	helmController := helm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, downloadStatRepository, fileManager, transactor, provider, helmLocalRegistry)
+	helmHandler := api2.NewHelmHandlerProvider(helmController, packagesHandler)
+	gopackageLocalRegistry := gopackage.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, gitInterface)
+	gopackageLocalRegistryHelper := gopackage.LocalRegistryHelperProvider(gopackageLocalRegistry, localBase)
+	gopackageProxy := gopackage.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, gopackageLocalRegistryHelper)
+	gopackageController := gopackage2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, authorizer, spaceFinder, repoFinder, gopackageLocalRegistry, gopackageProxy)
+	goHandler := api2.NewGoHandlerProvider(gopackageController, packagesHandler)
+	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, npmHandler, helmHandler, goHandler)
 	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler, handler2, handler3, handler4)
 	sender := usage.ProvideMediator(ctx, config, spaceFinder, usageMetricStore)
 	remoteauthService := remoteauth.ProvideRemoteAuth(tokenStore, principalStore)
 	lfsController := lfs.ProvideController(authorizer, repoFinder, principalStore, lfsObjectStore, blobStore, remoteauthService, provider)
 	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, provider, openapiService, appRouter, sender, lfsController)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, publickeyService, repoController, lfsController)
 	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter5)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
@@ -570,14 +616,23 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, auditEventStore, pushMirrorExecutionStore)
 	if err != nil {
 		return nil, err
 	}
 	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	replyAddresses, err := notification.ProvideReplyAddresses(notificationConfig, principalStore)
+	if err != nil {
+		return nil, err
+	}
+	mailClient := notification.ProvideMailClient(mailerMailer, replyAddresses)
+	chatClient := notification.ProvideChatClient(chatWebhooks, principalStore)
+	preferences := notification.ProvidePreferences(notificationPreferenceStore, spaceStore)
+	notificationDigestStore := database.ProvideNotificationDigestStore(db)
+	notificationClient := notification.ProvideClient(mailClient, chatClient, preferences, notificationDigestStore)
+	digest := notification.ProvideDigest(notificationConfig, jobScheduler, executor, notificationDigestStore, principalInfoCache, mailerMailer)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider, digest)
 	if err != nil {
 		return nil, err
 	}
@@ -629,7 +684,20 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service2)
+	readerFactory8, err := events11.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	automergeService, err := automerge.ProvideService(ctx, config, eventsReaderFactory, readerFactory8, pullReqStore, pullReqAutoMergeStore, principalStore, pullreqController)
+	if err != nil {
+		return nil, err
+	}
+	mergequeueService, err := mergequeue.ProvideService(ctx, config, eventsReaderFactory, readerFactory, readerFactory8, pullReqStore, mergeQueueStore, checkStore, repoFinder, gitInterface, protectionManager, lockerLocker, pullreqController)
+	if err != nil {
+		return nil, err
+	}
+	mailreplyService := mailreply.ProvideService(notificationConfig, jobScheduler, executor, replyAddresses, pullReqStore, pullreqController)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, mailreplyService, automergeService, mergequeueService, mirrorService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service2, cleanuppolicyService)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
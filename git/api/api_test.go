@@ -81,6 +81,39 @@ func TestParseTagDataFromCatFile(t *testing.T) {
 		"some message")
 }
 
+func TestParseTagDataFromCatFile_Signature(t *testing.T) {
+	const header = "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype commit\ntag v1\ntagger max <max@mail.com> 1666401234 -0700\n"
+
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{
+			name:      "pgp",
+			signature: "-----BEGIN PGP SIGNATURE-----\n\nw...B\n-----END PGP SIGNATURE-----\n",
+		},
+		{
+			name:      "ssh",
+			signature: "-----BEGIN SSH SIGNATURE-----\nU1NI...\n-----END SSH SIGNATURE-----\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := header + "\nsome title\n\nsome body\n"
+
+			res, err := parseTagDataFromCatFile([]byte(payload + test.signature))
+			require.NoError(t, err)
+
+			require.Equal(t, "some title\n\nsome body", res.Message)
+			require.Equal(t, "some title", res.Title)
+			require.NotNil(t, res.Signature)
+			require.Equal(t, test.signature, res.Signature.Signature)
+			require.Equal(t, payload, res.Signature.Payload)
+		})
+	}
+}
+
 func testParseTagDataFromCatFileFor(t *testing.T, object string, typ GitObjectType, name string,
 	tagger Signature, remainder string, expectedMessage string) {
 	data := fmt.Sprintf(
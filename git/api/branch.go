@@ -115,6 +115,38 @@ func (g *Git) IsBranchExist(ctx context.Context, repoPath, name string) (bool, e
 	return true, nil
 }
 
+// ListBranchesContaining returns names of all branches that contain the commit the provided revision points to.
+func (g *Git) ListBranchesContaining(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	rev string,
+) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("for-each-ref",
+		command.WithFlag("--format=%(refname:lstrip=2)"),
+		command.WithFlag("--contains="+rev),
+		command.WithArg(BranchPrefix),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return nil, processGitErrorf(err, "failed to list branches containing %q", rev)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(output.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			branches = append(branches, line)
+		}
+	}
+
+	return branches, nil
+}
+
 func (g *Git) GetBranchCount(
 	ctx context.Context,
 	repoPath string,
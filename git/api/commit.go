@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
@@ -215,6 +216,223 @@ func (g *Git) ListCommitSHAs(
 	return g.listCommitSHAs(ctx, repoPath, alternateObjectDirs, ref, page, limit, filter)
 }
 
+// ListNewCommits lists the commits reachable from ref that aren't reachable from any existing reference,
+// or from the after ref if it's provided.
+// Intended to be used from within git hooks, where the new objects are still in the alternate object dirs.
+func (g *Git) ListNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	ref string,
+	after string,
+	limit int,
+) ([]*Commit, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("rev-list",
+		command.WithArg(ref),
+		command.WithArg("--not"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	if after != "" {
+		cmd.Add(command.WithArg(after))
+	} else {
+		cmd.Add(command.WithArg("--all"))
+	}
+	if limit > 0 {
+		cmd.Add(command.WithFlag("--max-count", strconv.Itoa(limit)))
+	}
+
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to list new commits")
+	}
+
+	commitSHAs := parseLinesToSlice(output.Bytes())
+	if len(commitSHAs) == 0 {
+		return nil, nil
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer cancel()
+	defer writer.Close()
+
+	commits := make([]*Commit, 0, len(commitSHAs))
+	for _, commitSHA := range commitSHAs {
+		if _, err = writer.Write([]byte(commitSHA + "\n")); err != nil {
+			return nil, fmt.Errorf("failed to write to cat-file batch: %w", err)
+		}
+
+		commit, err := getCommitFromBatchReader(ctx, repoPath, reader, commitSHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", commitSHA, err)
+		}
+
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// NewCommitFile is a file changed by one of the new commits.
+type NewCommitFile struct {
+	Path    string
+	Deleted bool
+	SHA     sha.SHA
+	Size    int64
+}
+
+// ListNewCommitFiles returns the files changed by the commits reachable from the ref
+// that aren't reachable from any existing reference, or from the after ref if it's provided.
+// Merge commits are compared to their first parent, so the changes they bring to the branch are included.
+// The same path is returned more than once if more than one version of the file has been committed.
+func (g *Git) ListNewCommitFiles(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	ref string,
+	after string,
+	limit int,
+) ([]NewCommitFile, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("log",
+		command.WithFlag("--raw"),
+		command.WithFlag("-z"),
+		command.WithFlag("--no-renames"),
+		command.WithFlag("--no-abbrev"),
+		command.WithFlag("--diff-merges=first-parent"),
+		command.WithFlag("--format="),
+		command.WithArg(ref),
+		command.WithArg("--not"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	if after != "" {
+		cmd.Add(command.WithArg(after))
+	} else {
+		cmd.Add(command.WithArg("--all"))
+	}
+	if limit > 0 {
+		cmd.Add(command.WithFlag("--max-count", strconv.Itoa(limit)))
+	}
+
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to list files of new commits")
+	}
+
+	diffEntries, err := parser.DiffRaw(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse files of new commits: %w", err)
+	}
+
+	type fileVersion struct {
+		path string
+		sha  string
+	}
+
+	files := make([]NewCommitFile, 0, len(diffEntries))
+	processed := make(map[fileVersion]struct{}, len(diffEntries))
+	blobSHAs := make([]string, 0, len(diffEntries))
+
+	for _, entry := range diffEntries {
+		deleted := entry.Status == parser.DiffStatusDeleted
+
+		blobSHA := entry.NewBlobSHA
+		fileMode := entry.NewFileMode
+		if deleted {
+			blobSHA = entry.OldBlobSHA
+			fileMode = entry.OldFileMode
+		}
+
+		// submodules point to commits of other repositories
+		if fileMode == TreeNodeModeCommit.String() {
+			continue
+		}
+
+		key := fileVersion{path: entry.Path, sha: blobSHA}
+		if _, ok := processed[key]; ok {
+			continue
+		}
+		processed[key] = struct{}{}
+
+		fileSHA, err := sha.New(blobSHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse blob SHA of file %q: %w", entry.Path, err)
+		}
+
+		files = append(files, NewCommitFile{
+			Path:    entry.Path,
+			Deleted: deleted,
+			SHA:     fileSHA,
+		})
+
+		if !deleted {
+			blobSHAs = append(blobSHAs, blobSHA)
+		}
+	}
+
+	if len(blobSHAs) == 0 {
+		return files, nil
+	}
+
+	sizes, err := g.getObjectSizes(ctx, repoPath, alternateObjectDirs, blobSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range files {
+		if !files[i].Deleted {
+			files[i].Size = sizes[files[i].SHA.String()]
+		}
+	}
+
+	return files, nil
+}
+
+// getObjectSizes returns the sizes of the git objects mapped by the object SHA.
+func (g *Git) getObjectSizes(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	objectSHAs []string,
+) (map[string]int64, error) {
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-check"),
+		command.WithFlag("-Z"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	input := strings.NewReader(strings.Join(objectSHAs, "\x00") + "\x00")
+	output := &bytes.Buffer{}
+
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(input),
+		command.WithStdout(output))
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to get object sizes")
+	}
+
+	objects, err := parser.CatFileBatchCheckAllObjects(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse object sizes: %w", err)
+	}
+
+	sizes := make(map[string]int64, len(objects))
+	for _, object := range objects {
+		sizes[object.SHA.String()] = object.Size
+	}
+
+	return sizes, nil
+}
+
 // ListCommits lists the commits reachable from ref.
 // Note: ref & afterRef can be Branch / Tag / CommitSHA.
 // Note: commits returned are [ref->...->afterRef).
@@ -589,6 +807,43 @@ func (g *Git) GetCommits(
 	return getCommits(ctx, repoPath, refs)
 }
 
+// GetCommitSignatures returns the signatures of the provided commits.
+// The result has the same length as the provided list of commits - nil is returned for unsigned commits.
+func (g *Git) GetCommitSignatures(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commitSHAs []string,
+) ([]*CommitGPGSignature, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	if len(commitSHAs) == 0 {
+		return nil, nil
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer cancel()
+	defer writer.Close()
+
+	signatures := make([]*CommitGPGSignature, len(commitSHAs))
+	for i, commitSHA := range commitSHAs {
+		if _, err := writer.Write([]byte(commitSHA + "\n")); err != nil {
+			return nil, fmt.Errorf("failed to write to cat-file batch: %w", err)
+		}
+
+		commit, err := getCommitFromBatchReader(ctx, repoPath, reader, commitSHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", commitSHA, err)
+		}
+
+		signatures[i] = commit.Signature
+	}
+
+	return signatures, nil
+}
+
 // GetCommitDivergences returns the count of the diverging commits for all branch pairs.
 // IMPORTANT: If a maxCount is provided it limits the overal count of diverging commits
 // (maxCount 10 could lead to (0, 10) while it's actually (2, 12)).
@@ -940,6 +1195,7 @@ readLoop:
 		}
 	}
 	commit.Message = messageSB.String()
+	commit.Title, _, _ = strings.Cut(commit.Message, "\n")
 	commit.Signature = &CommitGPGSignature{
 		Signature: signatureSB.String(),
 		Payload:   payloadSB.String(),
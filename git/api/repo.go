@@ -26,6 +26,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -51,6 +52,9 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	Prune          bool
+	// RefSpecs are pushed in addition to the Branch, if any.
+	RefSpecs []string
 }
 
 // ObjectCount represents the parsed information from the `git count-objects -v` command.
@@ -285,6 +289,68 @@ func (g *Git) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided objects (and all objects reachable from them)
+// from the source repository without updating any references.
+func (g *Git) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []sha.SHA,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("advice.fetchShowForcedUpdates", "false"),
+		command.WithConfig("credential.helper", ""),
+		// the objects aren't necessarily pointed to by a reference of the source repository.
+		command.WithConfig("uploadpack.allowAnySHA1InWant", "true"),
+		command.WithFlag(
+			"--quiet",
+			"--no-tags",
+			"--no-write-fetch-head",
+			"--no-show-forced-updates",
+		),
+		command.WithArg(source),
+	)
+	for _, objectSHA := range objectSHAs {
+		cmd.Add(command.WithArg(objectSHA.String()))
+	}
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
+// RepackAll packs all objects of the repository into a single pack, including
+// the objects that are only available through the alternate object directories.
+func (g *Git) RepackAll(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "-q"),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to repack repo")
+	}
+
+	return nil
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -362,12 +428,19 @@ func (g *Git) Push(
 	if opts.Mirror {
 		cmd.Add(command.WithFlag("--mirror"))
 	}
+	if opts.Prune {
+		cmd.Add(command.WithFlag("--prune"))
+	}
 	cmd.Add(command.WithPostSepArg(opts.Remote))
 
 	if len(opts.Branch) > 0 {
 		cmd.Add(command.WithPostSepArg(opts.Branch))
 	}
 
+	if len(opts.RefSpecs) > 0 {
+		cmd.Add(command.WithPostSepArg(opts.RefSpecs...))
+	}
+
 	if g.traceGit {
 		cmd.Add(command.WithEnv(command.GitTrace, "true"))
 	}
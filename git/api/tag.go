@@ -31,6 +31,7 @@ import (
 const (
 	pgpSignatureBeginToken = "\n-----BEGIN PGP SIGNATURE-----\n" //#nosec G101
 	pgpSignatureEndToken   = "\n-----END PGP SIGNATURE-----"     //#nosec G101
+	sshSignatureBeginToken = "\n-----BEGIN SSH SIGNATURE-----\n" //#nosec G101
 )
 
 type Tag struct {
@@ -211,18 +212,20 @@ func parseTagDataFromCatFile(data []byte) (tag Tag, err error) {
 		return tag, err
 	}
 
-	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
-
-	// handle gpg signature
-	pgpEnd := strings.Index(message, pgpSignatureEndToken)
-	if pgpEnd > -1 {
-		messageStart := pgpEnd + len(pgpSignatureEndToken)
-		// for now we just remove the signature (and trim any separating new lines)
-		// TODO: add support for GPG signature of tags
-		message = strings.TrimLeft(message[messageStart:], "\n")
+	// remainder is message and an optional signature
+	body := data[p:]
+	if bytes.HasPrefix(body, []byte("gpgsig ")) {
+		// for now we just remove the signature stored as header
+		if pgpEnd := bytes.Index(body, []byte(pgpSignatureEndToken)); pgpEnd > -1 {
+			body = body[pgpEnd+len(pgpSignatureEndToken):]
+		}
+	} else {
+		body = parseTagSignature(&tag, data, p)
 	}
 
+	// remove leading and tailing new lines
+	message := string(bytes.Trim(body, "\n"))
+
 	tag.Message = message
 
 	// get title from message
@@ -235,6 +238,28 @@ func parseTagDataFromCatFile(data []byte) (tag Tag, err error) {
 	return tag, nil
 }
 
+// parseTagSignature extracts the signature (PGP or SSH) appended to the end of the tag message.
+// It returns the remainder of the tag data that starts at the provided position, without the signature.
+func parseTagSignature(tag *Tag, data []byte, start int) []byte {
+	body := data[start:]
+	for _, token := range []string{pgpSignatureBeginToken, sshSignatureBeginToken} {
+		sigStart := bytes.LastIndex(body, []byte(token))
+		if sigStart == -1 {
+			continue
+		}
+
+		// the signed payload is the whole tag object up to the signature
+		tag.Signature = &CommitGPGSignature{
+			Signature: string(body[sigStart+1:]),
+			Payload:   string(data[:start+sigStart+1]),
+		}
+
+		return body[:sigStart+1]
+	}
+
+	return body
+}
+
 func parseCatFileLine(data []byte, start int, header string) (string, int, error) {
 	// for simplicity only look at data from start onwards
 	data = data[start:]
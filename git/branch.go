@@ -90,6 +90,16 @@ type ListBranchesOutput struct {
 	Branches []Branch
 }
 
+type ListBranchesContainingParams struct {
+	ReadParams
+	// Rev is a git revision (branch / tag / commit SHA) that points to the commit.
+	Rev string
+}
+
+type ListBranchesContainingOutput struct {
+	BranchNames []string
+}
+
 func (s *Service) CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
@@ -233,6 +243,31 @@ func (s *Service) ListBranches(ctx context.Context, params *ListBranchesParams)
 	}, nil
 }
 
+// ListBranchesContaining returns names of all branches that contain the commit the provided revision points to.
+func (s *Service) ListBranchesContaining(
+	ctx context.Context,
+	params *ListBranchesContainingParams,
+) (*ListBranchesContainingOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	if params.Rev == "" {
+		return nil, errors.InvalidArgument("revision must be provided")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	branchNames, err := s.git.ListBranchesContaining(ctx, repoPath, params.AlternateObjectDirs, params.Rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListBranchesContainingOutput{
+		BranchNames: branchNames,
+	}, nil
+}
+
 func (s *Service) listBranchesLoadReferenceData(
 	ctx context.Context,
 	repoPath string,
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// BaseSHA is the commit on top of which the commits are applied.
+	BaseSHA sha.SHA
+
+	// FromCommitSHA and ToCommitSHA define the range of the commits that are applied.
+	// The FromCommitSHA is excluded from the range. If it's not provided, only the ToCommitSHA is applied.
+	FromCommitSHA sha.SHA
+	ToCommitSHA   sha.SHA
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+
+	// Refs are updated to the last applied commit. If a ref's New value is empty, it's set to the last commit.
+	Refs []RefUpdate
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseSHA.IsEmpty() {
+		return errors.InvalidArgument("base commit SHA is mandatory")
+	}
+
+	if p.ToCommitSHA.IsEmpty() {
+		return errors.InvalidArgument("commit SHA is mandatory")
+	}
+
+	for _, ref := range p.Refs {
+		if ref.Name == "" {
+			return errors.InvalidArgument("ref name has to be provided")
+		}
+	}
+
+	return nil
+}
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// CommitSHA is the last applied commit. It's empty if there are conflicts.
+	CommitSHA     sha.SHA
+	ConflictFiles []string
+}
+
+// CherryPick applies a commit or a range of commits on top of the base commit.
+// The author and the message of every commit are preserved, the committer is changed.
+// Commits that would become empty are dropped.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	fromCommitSHA := params.FromCommitSHA
+	if fromCommitSHA.IsEmpty() {
+		commit, err := s.git.GetCommit(ctx, repoPath, params.ToCommitSHA.String())
+		if err != nil {
+			return CherryPickOutput{}, fmt.Errorf("failed to get commit to cherry-pick: %w", err)
+		}
+
+		if len(commit.ParentSHAs) == 0 {
+			return CherryPickOutput{}, errors.InvalidArgument("The commit %s has no parent and can't be cherry-picked.",
+				params.ToCommitSHA)
+		}
+
+		fromCommitSHA = commit.ParentSHAs[0]
+	}
+
+	_, committer := commitSignatures(params.Actor, nil, nil, params.Committer, params.CommitterDate)
+
+	commitSHA, conflicts, err := s.commitOnTop(ctx, params.WriteParams, params.Refs,
+		func(ctx context.Context, s *sharedrepo.SharedRepo) (sha.SHA, []string, error) {
+			// applying a range of commits on top of another commit is exactly what the rebase does.
+			commitSHA, conflicts, err := merge.Rebase(ctx, s, merge.Params{
+				Committer:    &committer,
+				MergeBaseSHA: fromCommitSHA,
+				TargetSHA:    params.BaseSHA,
+				SourceSHA:    params.ToCommitSHA,
+			})
+			if err != nil {
+				return sha.None, nil, err
+			}
+
+			if len(conflicts) == 0 && commitSHA.Equal(params.BaseSHA) {
+				return sha.None, nil, errors.InvalidArgument(
+					"The changes are already present on the target, nothing to cherry-pick.")
+			}
+
+			return commitSHA, conflicts, nil
+		})
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to cherry-pick commits: %w", err)
+	}
+
+	return CherryPickOutput{
+		CommitSHA:     commitSHA,
+		ConflictFiles: conflicts,
+	}, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCherryPick(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	const repoUID = "cherry0001"
+
+	repoPath := initTestRepo(t, s, repoUID)
+
+	baseCommit := commitTestTree(t, repoPath, "", "", []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "a\n"},
+	})
+	mainCommit := commitTestTree(t, repoPath, "main", baseCommit, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "a\n"},
+		{mode: "100644", path: "main.txt", content: "main\n"},
+	})
+	otherCommit := commitTestTree(t, repoPath, "other", mainCommit, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "other\n"},
+		{mode: "100644", path: "main.txt", content: "main\n"},
+	})
+
+	feature1 := commitTestTree(t, repoPath, "", baseCommit, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "a\n"},
+		{mode: "100644", path: "f1.txt", content: "f1\n"},
+	})
+	feature2 := commitTestTree(t, repoPath, "", feature1, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "a\n"},
+		{mode: "100644", path: "f1.txt", content: "f1\n"},
+		{mode: "100644", path: "f2.txt", content: "f2\n"},
+	})
+	feature3 := commitTestTree(t, repoPath, "feature", feature2, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "feature\n"},
+		{mode: "100644", path: "f1.txt", content: "f1\n"},
+		{mode: "100644", path: "f2.txt", content: "f2\n"},
+	})
+
+	skipWithoutMergeTreeBase(t, repoPath, baseCommit, mainCommit)
+
+	cherryPick := func(baseSHA, fromCommitSHA, toCommitSHA string, refs []RefUpdate) (CherryPickOutput, error) {
+		params := &CherryPickParams{
+			WriteParams: WriteParams{
+				Actor:   Identity{Name: "actor", Email: "actor@example.com"},
+				RepoUID: repoUID,
+			},
+			BaseSHA:     sha.Must(baseSHA),
+			ToCommitSHA: sha.Must(toCommitSHA),
+			Committer:   &Identity{Name: "system", Email: "system@example.com"},
+			Refs:        refs,
+		}
+		if fromCommitSHA != "" {
+			params.FromCommitSHA = sha.Must(fromCommitSHA)
+		}
+
+		return s.CherryPick(ctx, params)
+	}
+
+	listFiles := func(rev string) []string {
+		return strings.Split(runTestGit(t, repoPath, "", "ls-tree", "--name-only", rev), "\n")
+	}
+
+	// a single commit is applied on top of the base commit, the author is preserved
+	out, err := cherryPick(mainCommit, "", feature2, []RefUpdate{{Name: "refs/heads/main", Old: sha.Must(mainCommit)}})
+	require.NoError(t, err)
+	require.Empty(t, out.ConflictFiles)
+
+	singleSHA := out.CommitSHA.String()
+	require.Equal(t, singleSHA, runTestGit(t, repoPath, "", "rev-parse", "refs/heads/main"))
+	require.Equal(t, mainCommit, runTestGit(t, repoPath, "", "log", "--format=%P", "-1", singleSHA))
+	require.Equal(t, []string{"a.txt", "f2.txt", "main.txt"}, listFiles(singleSHA))
+	require.Equal(t, "test@example.com system@example.com",
+		runTestGit(t, repoPath, "", "log", "--format=%ae %ce", "-1", singleSHA))
+
+	// the changes that are already present on the base commit can't be cherry-picked again
+	_, err = cherryPick(singleSHA, "", feature2, nil)
+	require.True(t, errors.IsInvalidArgument(err), "expected an invalid argument error, got: %v", err)
+
+	// a range of commits is applied on top of the base commit, excluding the from commit
+	out, err = cherryPick(mainCommit, feature1, feature3, nil)
+	require.NoError(t, err)
+	require.Empty(t, out.ConflictFiles)
+
+	rangeSHA := out.CommitSHA.String()
+	require.Equal(t, []string{"a.txt", "f2.txt", "main.txt"}, listFiles(rangeSHA))
+	require.Equal(t, "feature", runTestGit(t, repoPath, "", "cat-file", "blob", rangeSHA+":a.txt"))
+	require.Equal(t, "2", runTestGit(t, repoPath, "", "rev-list", "--count", mainCommit+".."+rangeSHA))
+	// the refs are updated only if provided
+	require.Equal(t, singleSHA, runTestGit(t, repoPath, "", "rev-parse", "refs/heads/main"))
+
+	// the changes that conflict with the cherry-picked commits are reported and no branch is updated
+	out, err = cherryPick(otherCommit, "", feature3, []RefUpdate{{Name: "refs/heads/other", Old: sha.Must(otherCommit)}})
+	require.NoError(t, err)
+	require.True(t, out.CommitSHA.IsEmpty())
+	require.Equal(t, []string{"a.txt"}, out.ConflictFiles)
+	require.Equal(t, otherCommit, runTestGit(t, repoPath, "", "rev-parse", "refs/heads/other"))
+}
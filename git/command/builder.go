@@ -140,7 +140,9 @@ var descriptions = map[string]builder{
 		flags: NoRefUpdates,
 	},
 	"log": {
-		flags: NoRefUpdates,
+		// Same as for git-rev-list(1), pseudo revisions like `--all` and `--not` count as options.
+		flags:                  NoRefUpdates | NoEndOfOptions,
+		validatePositionalArgs: validateRevisionArgs,
 	},
 	"ls-files": {
 		flags: NoRefUpdates,
@@ -214,25 +216,8 @@ var descriptions = map[string]builder{
 	"rev-list": {
 		// We cannot use --end-of-options here because pseudo revisions like `--all`
 		// and `--not` count as options.
-		flags: NoRefUpdates | NoEndOfOptions,
-		validatePositionalArgs: func(args []string) error {
-			for _, arg := range args {
-				// git-rev-list(1) supports pseudo-revision arguments which can be
-				// intermingled with normal positional arguments. Given that these
-				// pseudo-revisions have leading dashes, normal validation would
-				// refuse them as positional arguments. We thus override validation
-				// for two of these which we are using in our codebase. There are
-				// more, but we can add them at a later point if they're ever
-				// required.
-				if arg == "--all" || arg == "--not" {
-					continue
-				}
-				if err := validatePositionalArg(arg); err != nil {
-					return fmt.Errorf("rev-list: %w", err)
-				}
-			}
-			return nil
-		},
+		flags:                  NoRefUpdates | NoEndOfOptions,
+		validatePositionalArgs: validateRevisionArgs,
 	},
 	"rev-parse": {
 		// --end-of-options is echoed by git-rev-parse(1) if used without
@@ -316,6 +301,26 @@ func (b builder) args(
 	return cmdArgs, nil
 }
 
+// validateRevisionArgs validates the revision arguments of commands that walk the commit history.
+func validateRevisionArgs(args []string) error {
+	for _, arg := range args {
+		// git-rev-list(1) supports pseudo-revision arguments which can be
+		// intermingled with normal positional arguments. Given that these
+		// pseudo-revisions have leading dashes, normal validation would
+		// refuse them as positional arguments. We thus override validation
+		// for two of these which we are using in our codebase. There are
+		// more, but we can add them at a later point if they're ever
+		// required.
+		if arg == "--all" || arg == "--not" {
+			continue
+		}
+		if err := validatePositionalArg(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validatePositionalArg(arg string) error {
 	if strings.HasPrefix(arg, "-") {
 		return fmt.Errorf("positional arg %q cannot start with dash '-': %w", arg, ErrInvalidArg)
@@ -51,6 +51,13 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+	SignedData *SignedData       `json:"-"`
+}
+
+// SignedData contains the signature of a git object (commit or tag) and the payload that was signed.
+type SignedData struct {
+	Signature []byte
+	Payload   []byte
 }
 
 type GetCommitOutput struct {
@@ -127,6 +134,9 @@ type ListCommitsParams struct {
 
 	// Regex allows to use regular expression in the Committer and Author fields
 	Regex bool
+
+	// IncludeSignatures allows to include the signed data of the commits (used for signature verification).
+	IncludeSignatures bool
 }
 
 type RenameDetails struct {
@@ -204,6 +214,22 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 		commits[i] = *commit
 	}
 
+	if params.IncludeSignatures && len(commits) > 0 {
+		commitSHAs := make([]string, len(commits))
+		for i := range commits {
+			commitSHAs[i] = commits[i].SHA.String()
+		}
+
+		signatures, err := s.git.GetCommitSignatures(ctx, repoPath, params.AlternateObjectDirs, commitSHAs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit signatures: %w", err)
+		}
+
+		for i := range commits {
+			commits[i].SignedData = mapSignedData(signatures[i])
+		}
+	}
+
 	return &ListCommitsOutput{
 		Commits:       commits,
 		RenameDetails: mapRenameDetails(renameDetails),
@@ -211,6 +237,118 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListNewCommitsParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA) from which the new commits are listed.
+	GitREF string
+	// After is a git reference, if provided only the commits that aren't reachable from it are listed,
+	// regardless of the existing references - Optional.
+	After string
+	// Limit is the maximum number of returned commits - Optional, ignored if value is 0.
+	Limit int32
+}
+
+type ListNewCommitsOutput struct {
+	Commits []Commit
+}
+
+// ListNewCommits returns the commits reachable from GitREF that aren't reachable from any existing reference,
+// or from After if it's provided.
+// It's intended to be used in git hooks to get commits that are being pushed.
+func (s *Service) ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	gitCommits, err := s.git.ListNewCommits(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.GitREF,
+		params.After,
+		int(params.Limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]Commit, len(gitCommits))
+	for i := range gitCommits {
+		commit, err := mapCommit(gitCommits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+
+		commits[i] = *commit
+	}
+
+	return &ListNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
+
+type ListNewCommitFilesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA) from which the new commits are listed.
+	GitREF string
+	// After is a git reference, if provided only the commits that aren't reachable from it are listed,
+	// regardless of the existing references - Optional.
+	After string
+	// Limit is the maximum number of processed commits - Optional, ignored if value is 0.
+	Limit int32
+}
+
+type ListNewCommitFilesOutput struct {
+	Files []NewCommitFile
+}
+
+// NewCommitFile is a file that is added, modified or deleted by a new commit.
+type NewCommitFile struct {
+	Path    string
+	Deleted bool
+	SHA     sha.SHA
+	// Size is the size of the file in bytes. It's zero for deleted files.
+	Size int64
+}
+
+// ListNewCommitFiles returns the files changed by the commits reachable from GitREF
+// that aren't reachable from any existing reference, or from After if it's provided.
+// It's intended to be used in git hooks to get files that are being pushed,
+// and to get files changed by the commits of a pull request.
+func (s *Service) ListNewCommitFiles(
+	ctx context.Context,
+	params *ListNewCommitFilesParams,
+) (*ListNewCommitFilesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	gitFiles, err := s.git.ListNewCommitFiles(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.GitREF,
+		params.After,
+		int(params.Limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]NewCommitFile, len(gitFiles))
+	for i := range gitFiles {
+		files[i] = NewCommitFile(gitFiles[i])
+	}
+
+	return &ListNewCommitFilesOutput{
+		Files: files,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
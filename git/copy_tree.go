@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/rs/zerolog/log"
+)
+
+// copyTreeReplaceMaxSize is the maximum size of a file in which the strings are replaced.
+// Larger files are copied unchanged.
+const copyTreeReplaceMaxSize = 1 << 20
+
+// CopyTreeParams holds the data for committing the files of another repository.
+type CopyTreeParams struct {
+	WriteParams
+
+	// SourceRepoUID is the UID of the repository from which the files are copied.
+	SourceRepoUID string
+
+	// SourceBranch is the branch of the source repository whose files are copied.
+	SourceBranch string
+
+	// Branch is the name of the new branch that points to the created commit.
+	Branch string
+
+	// ParentBranch (optional) is the branch whose head commit becomes the parent of the created commit.
+	// If empty, the created commit has no parent.
+	ParentBranch string
+
+	Message string
+
+	// Replacements (optional) maps the strings to their replacements in the copied text files.
+	// Files larger than copyTreeReplaceMaxSize, symbolic links and submodules are copied unchanged.
+	Replacements map[string]string
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the files
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the files
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *CopyTreeParams) Validate() error {
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository id cannot be empty")
+	}
+
+	if p.SourceBranch == "" {
+		return errors.InvalidArgument("source branch name cannot be empty")
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch name cannot be empty")
+	}
+
+	return p.WriteParams.Validate()
+}
+
+type CopyTreeOutput struct {
+	CommitID sha.SHA
+}
+
+// CopyTree creates a new branch with a single commit containing all files of the source repository's branch.
+// If the files are the same as in the parent branch, the new branch points to the parent branch commit instead.
+// The git objects of the tree are fetched from the source repository, so the file modes, symbolic links
+// and submodules are preserved and the file contents aren't loaded into memory.
+func (s *Service) CopyTree(ctx context.Context, params *CopyTreeParams) (*CopyTreeOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	committer := params.Actor
+	if params.Committer != nil {
+		committer = *params.Committer
+	}
+	committerDate := time.Now().UTC()
+	if params.CommitterDate != nil {
+		committerDate = *params.CommitterDate
+	}
+
+	author := committer
+	if params.Author != nil {
+		author = *params.Author
+	}
+	authorDate := committerDate
+	if params.AuthorDate != nil {
+		authorDate = *params.AuthorDate
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	sourceRoot, err := s.git.GetTreeNode(ctx, sourcePath, api.GetReferenceFromBranchName(params.SourceBranch), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of the source branch: %w", err)
+	}
+
+	treeSHA := sourceRoot.SHA
+
+	err = s.git.FetchObjects(ctx, repoPath, sourcePath, []sha.SHA{treeSHA})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tree objects from the source repository: %w", err)
+	}
+
+	var parentCommits []sha.SHA
+	var parentTreeSHA sha.SHA
+	if params.ParentBranch != "" {
+		parent, err := s.git.GetCommit(ctx, repoPath, api.GetReferenceFromBranchName(params.ParentBranch))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent branch commit: %w", err)
+		}
+
+		parentRoot, err := s.git.GetTreeNode(ctx, repoPath, parent.SHA.String(), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree of the parent branch commit: %w", err)
+		}
+
+		parentCommits = append(parentCommits, parent.SHA)
+		parentTreeSHA = parentRoot.SHA
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ref updater: %w", err)
+	}
+
+	var commitSHA sha.SHA
+
+	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(r *sharedrepo.SharedRepo) error {
+		r.SetSigner(s.signer)
+
+		if err := r.SetIndex(ctx, treeSHA); err != nil {
+			return fmt.Errorf("failed to set index in shared repository: %w", err)
+		}
+
+		if err := s.replaceInFiles(ctx, r, repoPath, treeSHA, params.Replacements); err != nil {
+			return fmt.Errorf("failed to replace strings in files: %w", err)
+		}
+
+		newTreeSHA, err := r.WriteTree(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to write tree object: %w", err)
+		}
+
+		if len(parentCommits) > 0 && parentTreeSHA.Equal(newTreeSHA) {
+			// no changes, the new branch points to the parent commit
+			commitSHA = parentCommits[0]
+			return initCopyTreeRefUpdater(ctx, refUpdater, params.Branch, commitSHA)
+		}
+
+		authorSig := &api.Signature{
+			Identity: api.Identity{
+				Name:  author.Name,
+				Email: author.Email,
+			},
+			When: authorDate,
+		}
+
+		committerSig := &api.Signature{
+			Identity: api.Identity{
+				Name:  committer.Name,
+				Email: committer.Email,
+			},
+			When: committerDate,
+		}
+
+		message := parser.CleanUpWhitespace(params.Message)
+
+		commitSHA, err = r.CommitTree(ctx, authorSig, committerSig, newTreeSHA, message, false, parentCommits...)
+		if err != nil {
+			return fmt.Errorf("failed to commit the tree: %w", err)
+		}
+
+		return initCopyTreeRefUpdater(ctx, refUpdater, params.Branch, commitSHA)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CopyTree: failed to create commit in shared repository: %w", err)
+	}
+
+	return &CopyTreeOutput{
+		CommitID: commitSHA,
+	}, nil
+}
+
+// initCopyTreeRefUpdater initializes the ref updater to create the branch pointing to the commit.
+func initCopyTreeRefUpdater(
+	ctx context.Context,
+	refUpdater *hook.RefUpdater,
+	branch string,
+	commitSHA sha.SHA,
+) error {
+	ref := hook.ReferenceUpdate{
+		Ref: api.GetReferenceFromBranchName(branch),
+		Old: sha.Nil,
+		New: commitSHA,
+	}
+
+	if err := refUpdater.Init(ctx, []hook.ReferenceUpdate{ref}); err != nil {
+		return fmt.Errorf("failed to init ref updater new=%s: %w", commitSHA, err)
+	}
+
+	return nil
+}
+
+// replaceInFiles replaces the strings in the regular text files of the index.
+// Only the files containing any of the strings are read.
+func (s *Service) replaceInFiles(
+	ctx context.Context,
+	r *sharedrepo.SharedRepo,
+	repoPath string,
+	treeSHA sha.SHA,
+	replacements map[string]string,
+) error {
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	olds := make([]string, 0, len(replacements))
+	for old := range replacements {
+		olds = append(olds, old)
+	}
+
+	sort.Strings(olds)
+
+	oldNew := make([]string, 0, 2*len(olds))
+	for _, old := range olds {
+		oldNew = append(oldNew, old, replacements[old])
+	}
+
+	replacer := strings.NewReplacer(oldNew...)
+
+	filePaths, err := r.GrepFiles(ctx, olds...)
+	if err != nil {
+		return err
+	}
+
+	for _, filePath := range filePaths {
+		node, err := api.GetTreeNode(ctx, repoPath, treeSHA.String(), filePath, true)
+		if err != nil {
+			return fmt.Errorf("failed to get file %q: %w", filePath, err)
+		}
+
+		if node.Mode != api.TreeNodeModeFile && node.Mode != api.TreeNodeModeExec {
+			continue
+		}
+
+		if node.Size > copyTreeReplaceMaxSize {
+			log.Ctx(ctx).Debug().Msgf("file %q is too large for replacements, copied unchanged", filePath)
+			continue
+		}
+
+		content, err := readBlob(ctx, repoPath, node.SHA, copyTreeReplaceMaxSize)
+		if err != nil {
+			return fmt.Errorf("failed to read file %q: %w", filePath, err)
+		}
+
+		if !utf8.Valid(content) {
+			continue
+		}
+
+		blobSHA, err := r.WriteGitObject(ctx, strings.NewReader(replacer.Replace(string(content))))
+		if err != nil {
+			return fmt.Errorf("failed to write file %q: %w", filePath, err)
+		}
+
+		err = r.AddObjectToIndex(ctx, node.Mode.String(), blobSHA, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to add file %q to index: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+func readBlob(ctx context.Context, repoPath string, blobSHA sha.SHA, sizeLimit int64) ([]byte, error) {
+	blob, err := api.GetBlob(ctx, repoPath, nil, blobSHA, sizeLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyTree(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	const (
+		sourceUID = "source0001"
+		targetUID = "target0001"
+	)
+
+	sourcePath := initTestRepo(t, s, sourceUID)
+	initTestRepo(t, s, targetUID)
+
+	largeFile := strings.Repeat("{{repo_name}}\n", copyTreeReplaceMaxSize/10)
+
+	sourceFiles := []testTreeEntry{
+		{mode: "100644", path: "README.md", content: "# {{repo_name}}\n"},
+		{mode: "100755", path: "gradlew", content: "#!/bin/sh\necho {{repo_name}}\n"},
+		{mode: "100644", path: "docs/large.txt", content: largeFile},
+		{mode: "100644", path: "docs/image.bin", content: "\x00{{repo_name}}"},
+		{mode: "120000", path: "link-{{repo_name}}", content: "{{repo_name}}"},
+	}
+	mainCommit := commitTestTree(t, sourcePath, "main", "", sourceFiles)
+
+	// the submodule commit doesn't need to exist in the repository.
+	runTestGit(t, sourcePath, "", "update-ref", "refs/heads/with-submodule",
+		commitTestTree(t, sourcePath, "", mainCommit, append(sourceFiles, testTreeEntry{
+			mode: "160000", path: "sub", content: mainCommit,
+		})))
+
+	commitTestTree(t, sourcePath, "feature", mainCommit, append(sourceFiles, testTreeEntry{
+		mode: "100644", path: "feature.txt", content: "feature of {{repo_name}}\n",
+	}))
+
+	copyTree := func(sourceBranch, branch, parentBranch string) *CopyTreeOutput {
+		out, err := s.CopyTree(ctx, &CopyTreeParams{
+			WriteParams: WriteParams{
+				Actor:   Identity{Name: "test", Email: "test@example.com"},
+				RepoUID: targetUID,
+			},
+			SourceRepoUID: sourceUID,
+			SourceBranch:  sourceBranch,
+			Branch:        branch,
+			ParentBranch:  parentBranch,
+			Message:       "Initial commit",
+			Replacements:  map[string]string{"{{repo_name}}": "demo"},
+		})
+		require.NoError(t, err)
+		return out
+	}
+
+	targetPath := getFullPathForRepo(s.reposRoot, targetUID)
+
+	mainOut := copyTree("main", "main", "")
+
+	require.Equal(t, mainOut.CommitID.String(), runTestGit(t, targetPath, "", "rev-parse", "refs/heads/main"))
+	require.Empty(t, runTestGit(t, targetPath, "", "log", "--format=%P", "-1", "refs/heads/main"))
+
+	require.Equal(t, []string{
+		"100644 README.md",
+		"100644 docs/image.bin",
+		"100644 docs/large.txt",
+		"100755 gradlew",
+		"120000 link-{{repo_name}}",
+	}, listTestTree(t, targetPath, "refs/heads/main"))
+
+	showFile := func(rev, path string) string {
+		return runTestGit(t, targetPath, "", "cat-file", "blob", rev+":"+path)
+	}
+
+	require.Equal(t, "# demo", showFile("main", "README.md"))
+	require.Equal(t, "#!/bin/sh\necho demo", showFile("main", "gradlew"))
+	// binary and large files and symbolic links are copied unchanged
+	require.Equal(t, "\x00{{repo_name}}", showFile("main", "docs/image.bin"))
+	require.Equal(t, strings.TrimSpace(largeFile), showFile("main", "docs/large.txt"))
+	require.Equal(t, "{{repo_name}}", showFile("main", "link-{{repo_name}}"))
+
+	// the branch with the differences gets a commit on top of the parent branch
+	featureOut := copyTree("feature", "feature", "main")
+
+	require.Equal(t, mainOut.CommitID.String(),
+		runTestGit(t, targetPath, "", "log", "--format=%P", "-1", featureOut.CommitID.String()))
+	require.Equal(t, "feature of demo", showFile("feature", "feature.txt"))
+	require.Equal(t, "create mode 100644 feature.txt",
+		runTestGit(t, targetPath, "", "diff", "--summary", "main", "feature"))
+
+	// the branch without differences points to the parent commit
+	sameOut := copyTree("main", "same", "main")
+	require.Equal(t, mainOut.CommitID, sameOut.CommitID)
+
+	// submodules are copied as well
+	copyTree("with-submodule", "with-submodule", "main")
+	require.Contains(t, listTestTree(t, targetPath, "refs/heads/with-submodule"), "160000 sub")
+}
+
+type testTreeEntry struct {
+	mode    string
+	path    string
+	content string
+}
+
+type testHookClientFactory struct{}
+
+func (testHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+func newTestService(t *testing.T) *Service {
+	// the hooks aren't executed by the tested operations, so the hook binary doesn't need to exist.
+	root := t.TempDir()
+	config := types.Config{Root: root, HookPath: filepath.Join(root, "hook")}
+
+	adapter, err := api.New(config, nil, testHookClientFactory{})
+	require.NoError(t, err)
+
+	s, err := New(config, adapter, testHookClientFactory{}, storage.NewLocalStore(), nil)
+	require.NoError(t, err)
+
+	return s
+}
+
+func initTestRepo(t *testing.T, s *Service, uid string) string {
+	repoPath := getFullPathForRepo(s.reposRoot, uid)
+	require.NoError(t, os.MkdirAll(repoPath, fileMode700))
+
+	runTestGit(t, repoPath, "", "init", "--bare", "--quiet")
+
+	return repoPath
+}
+
+// commitTestTree creates a commit with the entries and updates the branch to point to it (if not empty).
+// Entries of submodules contain the commit SHA, all other entries contain the blob content.
+func commitTestTree(t *testing.T, repoPath, branch, parent string, entries []testTreeEntry) string {
+	indexFile := filepath.Join(t.TempDir(), "index")
+
+	for _, entry := range entries {
+		objectSHA := entry.content
+		if entry.mode != "160000" {
+			objectSHA = runTestGit(t, repoPath, entry.content, "hash-object", "-w", "--stdin")
+		}
+
+		runTestGitWithEnv(t, repoPath, []string{"GIT_INDEX_FILE=" + indexFile}, "",
+			"update-index", "--add", "--cacheinfo", fmt.Sprintf("%s,%s,%s", entry.mode, objectSHA, entry.path))
+	}
+
+	treeSHA := runTestGitWithEnv(t, repoPath, []string{"GIT_INDEX_FILE=" + indexFile}, "", "write-tree")
+
+	args := []string{"commit-tree", treeSHA, "-m", "test"}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+
+	commitSHA := runTestGit(t, repoPath, "", args...)
+
+	if branch != "" {
+		runTestGit(t, repoPath, "", "update-ref", "refs/heads/"+branch, commitSHA)
+	}
+
+	return commitSHA
+}
+
+// listTestTree returns the mode and the path of all entries of the tree.
+func listTestTree(t *testing.T, repoPath, rev string) []string {
+	var entries []string
+	for _, line := range strings.Split(runTestGit(t, repoPath, "", "ls-tree", "-r", rev), "\n") {
+		// format: <mode> SP <type> SP <object> TAB <file>
+		mode, _, _ := strings.Cut(line, " ")
+		_, path, _ := strings.Cut(line, "\t")
+		entries = append(entries, mode+" "+path)
+	}
+
+	return entries
+}
+
+func runTestGit(t *testing.T, dir, stdin string, args ...string) string {
+	return runTestGitWithEnv(t, dir, nil, stdin, args...)
+}
+
+func runTestGitWithEnv(t *testing.T, dir string, env []string, stdin string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	cmd.Env = append(cmd.Env, env...)
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, output)
+
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	gitObjectsDir        = "objects"
+	gitAlternatesFile    = "objects/info/alternates"
+	fileModeAlternates   = 0o600
+	gitRefSpecAllBranch  = "+refs/heads/*:refs/heads/*"
+	gitRefSpecAllTags    = "+refs/tags/*:refs/tags/*"
+	gitRefSpecBranchTmpl = "+refs/heads/%[1]s:refs/heads/%[1]s"
+
+	gitConfigGCPruneExpire = "gc.pruneExpire"
+)
+
+type ForkRepositoryParams struct {
+	// Fork operation is similar to the create operation, as UID doesn't exist yet.
+	// Only take actor and envars as input and create WriteParams manually
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that is being forked.
+	UpstreamRepoUID string
+
+	// DefaultBranch is the default branch of the fork. It must exist in the upstream repository.
+	DefaultBranch string
+
+	// DefaultBranchOnly specifies that only the default branch is copied to the fork.
+	// Otherwise, all branches and tags of the upstream repository are copied.
+	DefaultBranchOnly bool
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository id cannot be empty")
+	}
+
+	if p.DefaultBranch == "" {
+		return errors.InvalidArgument("default branch cannot be empty")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID string
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+
+	// SourceRepoUID is the UID of the repository from which the objects are fetched.
+	SourceRepoUID string
+
+	// ObjectSHAs are the SHAs of the objects that should be fetched.
+	// All objects reachable from them are fetched as well.
+	ObjectSHAs []sha.SHA
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository id cannot be empty")
+	}
+
+	return p.WriteParams.Validate()
+}
+
+type DissociateRepositoryParams struct {
+	WriteParams
+}
+
+// ForkRepository creates a new repository as a fork of the upstream repository.
+// The fork borrows the git objects of the upstream repository using the git alternates mechanism,
+// so only the references are copied and the objects are shared between the repositories.
+// Pruning of unreachable objects is disabled in the upstream repository, because the objects that are
+// no longer reachable from the upstream references can still be reachable from the references of the fork.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	upstreamPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.NotFound("upstream repository not found")
+		}
+		return nil, errors.Internal(err, "failed to find upstream repository")
+	}
+
+	uid, err := NewRepositoryUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new uid: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("Fork git repository with uid '%s' to new repository with uid '%s'",
+		params.UpstreamRepoUID, uid)
+
+	writeParams := WriteParams{
+		RepoUID: uid,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err = s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		params.DefaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, uid)
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			cleanuperr := s.DeleteRepositoryBestEffort(ctx, uid)
+			if cleanuperr != nil && !errors.IsNotFound(cleanuperr) {
+				log.Ctx(ctx).Warn().Err(cleanuperr).Msg("failed to cleanup fork repo dir")
+			}
+		}
+	}()
+
+	// git gc (including the automatic one) must not delete the objects borrowed by the fork.
+	// The setting isn't reverted when a fork is deleted, as other forks might still exist.
+	err = s.git.Config(ctx, upstreamPath, gitConfigGCPruneExpire, "never")
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable pruning of objects in upstream repo: %w", err)
+	}
+
+	if err = writeAlternates(repoPath, upstreamPath); err != nil {
+		return nil, err
+	}
+
+	refSpecs := []string{gitRefSpecAllBranch, gitRefSpecAllTags}
+	if params.DefaultBranchOnly {
+		refSpecs = []string{fmt.Sprintf(gitRefSpecBranchTmpl, params.DefaultBranch)}
+	}
+
+	// all objects are available through alternates, so the fetch only copies the references.
+	err = s.git.Sync(ctx, repoPath, upstreamPath, refSpecs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy references from upstream repo: %w", err)
+	}
+
+	err = s.git.SetDefaultBranch(ctx, repoPath, params.DefaultBranch, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set default branch of the fork: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		UID: uid,
+	}, nil
+}
+
+// FetchObjects copies the objects from the source repository to the repository.
+// It's used to make commits of a fork available in its upstream repository, e.g. for cross-repo pull requests.
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	err := s.git.FetchObjects(ctx, repoPath, sourcePath, params.ObjectSHAs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch objects from the source repository: %w", err)
+	}
+
+	return nil
+}
+
+// DissociateRepository copies all objects borrowed through the git alternates mechanism to the repository
+// and removes the alternates. It must be called for every fork of a repository before the repository is deleted.
+func (s *Service) DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	alternatesPath := filepath.Join(repoPath, gitAlternatesFile)
+
+	if _, err := os.Stat(alternatesPath); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	err := s.git.RepackAll(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to repack objects of the repository: %w", err)
+	}
+
+	err = os.Remove(alternatesPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Internal(err, "failed to remove alternates of the repository")
+	}
+
+	return nil
+}
+
+// writeAlternates configures the repository to borrow the objects of the upstream repository.
+// The path is stored relative to the objects directory to survive relocation of the repositories root.
+func writeAlternates(repoPath, upstreamPath string) error {
+	upstreamObjects, err := filepath.Rel(
+		filepath.Join(repoPath, gitObjectsDir),
+		filepath.Join(upstreamPath, gitObjectsDir),
+	)
+	if err != nil {
+		return errors.Internal(err, "failed to get relative path of the upstream objects")
+	}
+
+	alternatesPath := filepath.Join(repoPath, gitAlternatesFile)
+
+	err = os.MkdirAll(filepath.Dir(alternatesPath), fileMode700)
+	if err != nil {
+		return errors.Internal(err, "failed to create objects info directory")
+	}
+
+	err = os.WriteFile(alternatesPath, []byte(upstreamObjects+"\n"), fileModeAlternates)
+	if err != nil {
+		return errors.Internal(err, "failed to write alternates file")
+	}
+
+	return nil
+}
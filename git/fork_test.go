@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkRepositoryDisablesUpstreamPrune(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	const upstreamUID = "upstream01"
+
+	upstreamPath := initTestRepo(t, s, upstreamUID)
+
+	// setting the default branch of the fork uses "git show-ref --exists", added in git 2.43.
+	if err := exec.Command("git", "-C", upstreamPath, "show-ref", "--exists", "HEAD").Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 129 {
+			t.Skip("git 2.43 or newer is required")
+		}
+	}
+
+	mainCommit := commitTestTree(t, upstreamPath, "main", "", []testTreeEntry{
+		{mode: "100644", path: "README.md", content: "main\n"},
+	})
+	featureCommit := commitTestTree(t, upstreamPath, "feature", mainCommit, []testTreeEntry{
+		{mode: "100644", path: "README.md", content: "feature\n"},
+	})
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:           Identity{Name: "test", Email: "test@example.com"},
+		UpstreamRepoUID: upstreamUID,
+		DefaultBranch:   "main",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "never", runTestGit(t, upstreamPath, "", "config", "--get", gitConfigGCPruneExpire))
+
+	// the feature branch is deleted in the upstream, but it's still needed by the fork
+	runTestGit(t, upstreamPath, "", "update-ref", "-d", "refs/heads/feature")
+	runTestGit(t, upstreamPath, "", "gc", "--quiet")
+
+	forkPath := getFullPathForRepo(s.reposRoot, out.UID)
+	require.Equal(t, featureCommit, runTestGit(t, forkPath, "", "rev-parse", "refs/heads/feature"))
+	runTestGit(t, forkPath, "", "fsck", "--connectivity-only", "--no-dangling")
+}
@@ -35,6 +35,10 @@ type Interface interface {
 	GetBranch(ctx context.Context, params *GetBranchParams) (*GetBranchOutput, error)
 	DeleteBranch(ctx context.Context, params *DeleteBranchParams) error
 	ListBranches(ctx context.Context, params *ListBranchesParams) (*ListBranchesOutput, error)
+	ListBranchesContaining(
+		ctx context.Context,
+		params *ListBranchesContainingParams,
+	) (*ListBranchesContainingOutput, error)
 	UpdateDefaultBranch(ctx context.Context, params *UpdateDefaultBranchParams) error
 	GetRef(ctx context.Context, params GetRefParams) (GetRefResponse, error)
 	PathsDetails(ctx context.Context, params PathsDetailsParams) (PathsDetailsOutput, error)
@@ -50,6 +54,13 @@ type Interface interface {
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	/*
+	 * Fork service
+	 */
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
+	DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
 	/*
@@ -57,9 +68,12 @@ type Interface interface {
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error)
+	ListNewCommitFiles(ctx context.Context, params *ListNewCommitFilesParams) (*ListNewCommitFilesOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
+	CopyTree(ctx context.Context, params *CopyTreeParams) (*CopyTreeOutput, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
 	IsAncestor(ctx context.Context, params IsAncestorParams) (IsAncestorOutput, error)
 
@@ -101,6 +115,8 @@ type Interface interface {
 	 * Merge services
 	 */
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
+	Revert(ctx context.Context, params *RevertParams) (RevertOutput, error)
+	CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error)
 
 	/*
 	 * Blame services
@@ -65,9 +65,21 @@ func mapCommit(c *api.Commit) (*Commit, error) {
 		Author:     *author,
 		Committer:  *comitter,
 		FileStats:  mapFileStats(c.FileStats),
+		SignedData: mapSignedData(c.Signature),
 	}, nil
 }
 
+func mapSignedData(s *api.CommitGPGSignature) *SignedData {
+	if s == nil {
+		return nil
+	}
+
+	return &SignedData{
+		Signature: []byte(s.Signature),
+		Payload:   []byte(s.Payload),
+	}
+}
+
 func mapFileStats(typeStats []api.CommitFileStats) []CommitFileStats {
 	var stats = make([]CommitFileStats, len(typeStats))
 
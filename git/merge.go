@@ -37,7 +37,7 @@ type MergeParams struct {
 	BaseBranch string
 
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If it's different from the RepoUID, the head branch commits are fetched to the repository first.
 	HeadRepoUID string
 	HeadBranch  string
 
@@ -179,11 +179,23 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		}
 	}
 
-	headCommitSHA, err := s.git.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.git.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get head branch commit SHA: %w", err)
 	}
 
+	if headRepoPath != repoPath {
+		err = s.git.FetchObjects(ctx, repoPath, headRepoPath, []sha.SHA{headCommitSHA})
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head branch commits from the head repository: %w", err)
+		}
+	}
+
 	if !params.HeadExpectedSHA.IsEmpty() && !params.HeadExpectedSHA.Equal(headCommitSHA) {
 		return MergeOutput{}, errors.PreconditionFailed(
 			"head branch '%s' is on SHA '%s' which doesn't match expected SHA '%s'.",
@@ -224,25 +236,8 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 
 	// author and committer
 
-	now := time.Now().UTC()
-
-	committer := api.Signature{Identity: api.Identity(params.Actor), When: now}
-
-	if params.Committer != nil {
-		committer.Identity = api.Identity(*params.Committer)
-	}
-	if params.CommitterDate != nil {
-		committer.When = *params.CommitterDate
-	}
-
-	author := committer
-
-	if params.Author != nil {
-		author.Identity = api.Identity(*params.Author)
-	}
-	if params.AuthorDate != nil {
-		author.When = *params.AuthorDate
-	}
+	author, committer := commitSignatures(params.Actor,
+		params.Author, params.AuthorDate, params.Committer, params.CommitterDate)
 
 	// merge message
 
@@ -264,7 +259,15 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	var mergeCommitSHA sha.SHA
 	var conflicts []string
 
+	// sign the commits only if a reference is going to be updated with them.
+	var signer api.Signer
+	if len(params.Refs) > 0 {
+		signer = s.signer
+	}
+
 	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(s *sharedrepo.SharedRepo) error {
+		s.SetSigner(signer)
+
 		mergeCommitSHA, conflicts, err = mergeFunc(
 			ctx,
 			s,
@@ -421,3 +424,33 @@ func (s *Service) IsAncestor(
 		Ancestor: result,
 	}, nil
 }
+
+// commitSignatures returns the author and the committer of a new commit.
+// The committer defaults to the actor and the author defaults to the committer.
+func commitSignatures(
+	actor Identity,
+	authorIdentity *Identity,
+	authorDate *time.Time,
+	committerIdentity *Identity,
+	committerDate *time.Time,
+) (api.Signature, api.Signature) {
+	committer := api.Signature{Identity: api.Identity(actor), When: time.Now().UTC()}
+
+	if committerIdentity != nil {
+		committer.Identity = api.Identity(*committerIdentity)
+	}
+	if committerDate != nil {
+		committer.When = *committerDate
+	}
+
+	author := committer
+
+	if authorIdentity != nil {
+		author.Identity = api.Identity(*authorIdentity)
+	}
+	if authorDate != nil {
+		author.When = *authorDate
+	}
+
+	return author, committer
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// Revert creates a commit on top of the target commit (TargetSHA) that reverts the changes
+// between the source commit (SourceSHA) and its parent commit (MergeBaseSHA).
+// The parent doesn't have to be a direct parent of the source, so a range of commits can be reverted at once.
+func Revert(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	params Params,
+) (revertSHA sha.SHA, conflicts []string, err error) {
+	// the changes are reverted by applying the diff from the source commit to its parent on top of the target.
+	treeSHA, conflicts, err := s.MergeTree(ctx, params.SourceSHA, params.TargetSHA, params.MergeBaseSHA)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("merge tree failed: %w", err)
+	}
+
+	if len(conflicts) > 0 {
+		return sha.None, conflicts, nil
+	}
+
+	targetTreeSHA, err := s.GetTreeSHA(ctx, params.TargetSHA.String())
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to get tree sha for target: %w", err)
+	}
+
+	if treeSHA.Equal(targetTreeSHA) {
+		return sha.None, nil, errors.InvalidArgument("The changes aren't present on the target, nothing to revert.")
+	}
+
+	revertSHA, err = s.CommitTree(ctx, params.Author, params.Committer, treeSHA, params.Message, false,
+		params.TargetSHA)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("commit tree failed: %w", err)
+	}
+
+	return revertSHA, nil, nil
+}
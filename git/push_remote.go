@@ -25,6 +25,12 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefSpecs, if provided, are pushed instead of mirroring all references.
+	RefSpecs []string
+
+	// Prune specifies that the remote references matching the RefSpecs that don't exist locally are deleted.
+	Prune bool
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -57,10 +63,12 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 	}
 
 	err = s.git.Push(ctx, repoPath, api.PushOptions{
-		Remote: params.RemoteURL,
-		Force:  false,
-		Env:    nil,
-		Mirror: true,
+		Remote:   params.RemoteURL,
+		Force:    false,
+		Env:      nil,
+		Mirror:   len(params.RefSpecs) == 0,
+		Prune:    len(params.RefSpecs) > 0 && params.Prune,
+		RefSpecs: params.RefSpecs,
 	})
 	if err != nil {
 		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
@@ -107,6 +107,7 @@ func (s *Service) UpdateRef(ctx context.Context, params UpdateRefParams) error {
 func GetRefPath(refName string, refType enum.RefType) (string, error) {
 	const (
 		refPullReqPrefix      = "refs/pullreq/"
+		refMergeQueuePrefix   = "refs/queue/"
 		refPullReqHeadSuffix  = "/head"
 		refPullReqMergeSuffix = "/merge"
 	)
@@ -122,6 +123,8 @@ func GetRefPath(refName string, refType enum.RefType) (string, error) {
 		return refPullReqPrefix + refName + refPullReqHeadSuffix, nil
 	case enum.RefTypePullReqMerge:
 		return refPullReqPrefix + refName + refPullReqMergeSuffix, nil
+	case enum.RefTypeMergeQueue:
+		return refMergeQueuePrefix + refName, nil
 	default:
 		return "", errors.InvalidArgument("provided reference type '%s' is invalid", refType)
 	}
@@ -22,12 +22,14 @@ import (
 	"os"
 	"path"
 	"runtime/debug"
+	"sort"
 	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/sha"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rs/zerolog/log"
@@ -118,6 +120,10 @@ type SyncRepositoryParams struct {
 
 type SyncRepositoryOutput struct {
 	DefaultBranch string
+
+	// RefUpdates contains the branches and tags that have been created, updated or deleted by the sync.
+	// A created reference has an empty Old value, and a deleted reference has an empty New value.
+	RefUpdates []RefUpdate
 }
 
 type HashRepositoryParams struct {
@@ -287,12 +293,22 @@ func (s *Service) SyncRepository(
 		}
 	}
 
+	refsBefore, err := s.listBranchesAndTags(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
 	// sync repo content
 	err = s.git.Sync(ctx, repoPath, params.Source, params.RefSpecs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync from source repo: %w", err)
 	}
 
+	refsAfter, err := s.listBranchesAndTags(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
 	defaultBranch := params.DefaultBranch
 	if defaultBranch == "" {
 		// get default branch from remote repo (returns api.ErrNoDefaultBranch if repo is empty!)
@@ -310,9 +326,59 @@ func (s *Service) SyncRepository(
 
 	return &SyncRepositoryOutput{
 		DefaultBranch: defaultBranch,
+		RefUpdates:    diffRefs(refsBefore, refsAfter),
 	}, nil
 }
 
+// listBranchesAndTags returns the values of all branches and tags of the repository.
+func (s *Service) listBranchesAndTags(ctx context.Context, repoPath string) (map[string]sha.SHA, error) {
+	refs := make(map[string]sha.SHA)
+
+	err := s.git.WalkReferences(ctx, repoPath, func(e api.WalkReferencesEntry) error {
+		value, err := sha.New(e[api.GitReferenceFieldObjectName])
+		if err != nil {
+			return fmt.Errorf("failed to parse value of reference %q: %w", e[api.GitReferenceFieldRefName], err)
+		}
+
+		refs[e[api.GitReferenceFieldRefName]] = value
+
+		return nil
+	}, &api.WalkReferencesOptions{
+		Patterns: []string{"refs/heads/", "refs/tags/"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches and tags: %w", err)
+	}
+
+	return refs, nil
+}
+
+// diffRefs returns the reference updates that transform the references before to the references after.
+func diffRefs(before, after map[string]sha.SHA) []RefUpdate {
+	var updates []RefUpdate
+
+	for name, newValue := range after {
+		oldValue, ok := before[name]
+		if ok && oldValue.Equal(newValue) {
+			continue
+		}
+
+		updates = append(updates, RefUpdate{Name: name, Old: oldValue, New: newValue})
+	}
+
+	for name, oldValue := range before {
+		if _, ok := after[name]; !ok {
+			updates = append(updates, RefUpdate{Name: name, Old: oldValue})
+		}
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Name < updates[j].Name
+	})
+
+	return updates
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
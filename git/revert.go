@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// RevertParams is input structure object for the revert operation.
+type RevertParams struct {
+	WriteParams
+
+	// BaseSHA is the commit on top of which the revert commit is created.
+	BaseSHA sha.SHA
+
+	// CommitSHA is the commit whose changes are reverted.
+	CommitSHA sha.SHA
+	// ParentCommitSHA is the commit to which the changes of the CommitSHA are reverted
+	// (optional, default: the first parent of the CommitSHA).
+	ParentCommitSHA sha.SHA
+
+	Message string
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the files
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the files
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+
+	// Refs are updated to the revert commit. If a ref's New value is empty, it's set to the revert commit.
+	Refs []RefUpdate
+}
+
+func (p *RevertParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseSHA.IsEmpty() {
+		return errors.InvalidArgument("base commit SHA is mandatory")
+	}
+
+	if p.CommitSHA.IsEmpty() {
+		return errors.InvalidArgument("commit SHA is mandatory")
+	}
+
+	for _, ref := range p.Refs {
+		if ref.Name == "" {
+			return errors.InvalidArgument("ref name has to be provided")
+		}
+	}
+
+	return nil
+}
+
+// RevertOutput is result object of the revert operation.
+type RevertOutput struct {
+	// CommitSHA is the revert commit. It's empty if there are conflicts.
+	CommitSHA     sha.SHA
+	ConflictFiles []string
+}
+
+// Revert creates a new commit on top of the base commit that reverts the changes of a commit.
+func (s *Service) Revert(ctx context.Context, params *RevertParams) (RevertOutput, error) {
+	if err := params.Validate(); err != nil {
+		return RevertOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	parentCommitSHA := params.ParentCommitSHA
+	if parentCommitSHA.IsEmpty() {
+		commit, err := s.git.GetCommit(ctx, repoPath, params.CommitSHA.String())
+		if err != nil {
+			return RevertOutput{}, fmt.Errorf("failed to get commit to revert: %w", err)
+		}
+
+		if len(commit.ParentSHAs) == 0 {
+			return RevertOutput{}, errors.InvalidArgument("The commit %s has no parent and can't be reverted.",
+				params.CommitSHA)
+		}
+
+		parentCommitSHA = commit.ParentSHAs[0]
+	}
+
+	author, committer := commitSignatures(params.Actor,
+		params.Author, params.AuthorDate, params.Committer, params.CommitterDate)
+
+	revertSHA, conflicts, err := s.commitOnTop(ctx, params.WriteParams, params.Refs,
+		func(ctx context.Context, s *sharedrepo.SharedRepo) (sha.SHA, []string, error) {
+			return merge.Revert(ctx, s, merge.Params{
+				Author:       &author,
+				Committer:    &committer,
+				Message:      parser.CleanUpWhitespace(params.Message),
+				MergeBaseSHA: parentCommitSHA,
+				TargetSHA:    params.BaseSHA,
+				SourceSHA:    params.CommitSHA,
+			})
+		})
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to revert commit %s: %w", params.CommitSHA, err)
+	}
+
+	return RevertOutput{
+		CommitSHA:     revertSHA,
+		ConflictFiles: conflicts,
+	}, nil
+}
+
+// commitOnTop runs the function that creates a new commit in a shared repository
+// and updates the references to the new commit, unless there are conflicts.
+func (s *Service) commitOnTop(
+	ctx context.Context,
+	writeParams WriteParams,
+	refs []RefUpdate,
+	fn func(ctx context.Context, s *sharedrepo.SharedRepo) (sha.SHA, []string, error),
+) (sha.SHA, []string, error) {
+	repoPath := getFullPathForRepo(s.reposRoot, writeParams.RepoUID)
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, writeParams.EnvVars, repoPath)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to create reference updater: %w", err)
+	}
+
+	var (
+		commitSHA sha.SHA
+		conflicts []string
+	)
+
+	// sign the commits only if a reference is going to be updated with them.
+	var signer api.Signer
+	if len(refs) > 0 {
+		signer = s.signer
+	}
+
+	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(s *sharedrepo.SharedRepo) error {
+		s.SetSigner(signer)
+
+		var err error
+
+		commitSHA, conflicts, err = fn(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		if commitSHA.IsEmpty() || len(conflicts) > 0 {
+			return refUpdater.Init(ctx, nil) // update nothing
+		}
+
+		refUpdates := make([]hook.ReferenceUpdate, len(refs))
+		for i, ref := range refs {
+			newValue := ref.New
+			if newValue.IsEmpty() {
+				newValue = commitSHA
+			}
+
+			refUpdates[i] = hook.ReferenceUpdate{
+				Ref: ref.Name,
+				Old: ref.Old,
+				New: newValue,
+			}
+		}
+
+		if err := refUpdater.Init(ctx, refUpdates); err != nil {
+			return fmt.Errorf("failed to init values of references (%v): %w", refUpdates, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return sha.None, nil, err
+	}
+
+	if len(conflicts) > 0 {
+		return sha.None, conflicts, nil
+	}
+
+	return commitSHA, nil, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevert(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	const repoUID = "revert0001"
+
+	repoPath := initTestRepo(t, s, repoUID)
+
+	baseCommit := commitTestTree(t, repoPath, "", "", []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "one\n"},
+	})
+	changeCommit := commitTestTree(t, repoPath, "", baseCommit, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "two\n"},
+	})
+	mainCommit := commitTestTree(t, repoPath, "main", changeCommit, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "two\n"},
+		{mode: "100644", path: "b.txt", content: "b\n"},
+	})
+	conflictCommit := commitTestTree(t, repoPath, "conflict", mainCommit, []testTreeEntry{
+		{mode: "100644", path: "a.txt", content: "three\n"},
+		{mode: "100644", path: "b.txt", content: "b\n"},
+	})
+
+	skipWithoutMergeTreeBase(t, repoPath, baseCommit, mainCommit)
+
+	revert := func(baseSHA string, refs []RefUpdate) RevertOutput {
+		out, err := s.Revert(ctx, &RevertParams{
+			WriteParams: WriteParams{
+				Actor:   Identity{Name: "actor", Email: "actor@example.com"},
+				RepoUID: repoUID,
+			},
+			BaseSHA:   sha.Must(baseSHA),
+			CommitSHA: sha.Must(changeCommit),
+			Message:   "Revert change",
+			Committer: &Identity{Name: "system", Email: "system@example.com"},
+			Refs:      refs,
+		})
+		require.NoError(t, err)
+		return out
+	}
+
+	// the revert commit is created on top of the base commit and the branch is updated to it
+	out := revert(mainCommit, []RefUpdate{{Name: "refs/heads/main", Old: sha.Must(mainCommit)}})
+	require.Empty(t, out.ConflictFiles)
+
+	revertSHA := out.CommitSHA.String()
+	require.Equal(t, revertSHA, runTestGit(t, repoPath, "", "rev-parse", "refs/heads/main"))
+	require.Equal(t, mainCommit, runTestGit(t, repoPath, "", "log", "--format=%P", "-1", revertSHA))
+	require.Equal(t, "one", runTestGit(t, repoPath, "", "cat-file", "blob", revertSHA+":a.txt"))
+	require.Equal(t, "b", runTestGit(t, repoPath, "", "cat-file", "blob", revertSHA+":b.txt"))
+	require.Equal(t, "Revert change", runTestGit(t, repoPath, "", "log", "--format=%B", "-1", revertSHA))
+	require.Equal(t, "actor@example.com system@example.com",
+		runTestGit(t, repoPath, "", "log", "--format=%ae %ce", "-1", revertSHA))
+
+	// the changes that conflict with the revert are reported and no branch is updated
+	out = revert(conflictCommit, []RefUpdate{{Name: "refs/heads/conflict", Old: sha.Must(conflictCommit)}})
+	require.True(t, out.CommitSHA.IsEmpty())
+	require.Equal(t, []string{"a.txt"}, out.ConflictFiles)
+	require.Equal(t, conflictCommit, runTestGit(t, repoPath, "", "rev-parse", "refs/heads/conflict"))
+}
+
+// skipWithoutMergeTreeBase skips the test if git doesn't support "git merge-tree --merge-base", added in git 2.40.
+func skipWithoutMergeTreeBase(t *testing.T, repoPath, baseSHA, commitSHA string) {
+	err := exec.Command("git", "-C", repoPath, "merge-tree", "--write-tree",
+		"--merge-base="+baseSHA, commitSHA, commitSHA).Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 129 {
+		t.Skip("git 2.40 or newer is required")
+	}
+}
@@ -39,6 +39,7 @@ type Service struct {
 	store             storage.Store
 	gitHookPath       string
 	reposGraveyard    string
+	signer            api.Signer
 }
 
 func New(
@@ -46,6 +47,7 @@ func New(
 	adapter *api.Git,
 	hookClientFactory hook.ClientFactory,
 	storage storage.Store,
+	signer api.Signer,
 ) (*Service, error) {
 	// Create repos folder
 	reposRoot, err := createSubdir(config.Root, repoSubdirName)
@@ -73,6 +75,7 @@ func New(
 		hookClientFactory: hookClientFactory,
 		store:             storage,
 		gitHookPath:       config.HookPath,
+		signer:            signer,
 	}, nil
 }
 
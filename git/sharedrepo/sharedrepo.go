@@ -44,6 +44,7 @@ import (
 type SharedRepo struct {
 	repoPath       string
 	sourceRepoPath string
+	signer         api.Signer
 }
 
 // NewSharedRepo creates a new temporary bare repository.
@@ -119,6 +120,11 @@ func (r *SharedRepo) Directory() string {
 	return r.repoPath
 }
 
+// SetSigner sets the signer that is used to sign all commits created in the shared repository.
+func (r *SharedRepo) SetSigner(signer api.Signer) {
+	r.signer = signer
+}
+
 // SetDefaultIndex sets the git index to our HEAD.
 func (r *SharedRepo) SetDefaultIndex(ctx context.Context) error {
 	cmd := command.New("read-tree", command.WithArg("HEAD"))
@@ -177,6 +183,43 @@ func (r *SharedRepo) LsFiles(
 	return files, nil
 }
 
+// GrepFiles returns the paths of the text files in the index that contain any of the provided strings.
+func (r *SharedRepo) GrepFiles(
+	ctx context.Context,
+	needles ...string,
+) ([]string, error) {
+	cmd := command.New("grep",
+		command.WithFlag("--cached"),
+		command.WithFlag("--files-with-matches"),
+		command.WithFlag("--null"),
+		command.WithFlag("-I"), // skip binary files
+		command.WithFlag("--fixed-strings"),
+	)
+	for _, needle := range needles {
+		cmd.Add(command.WithFlag("-e", needle))
+	}
+
+	stdout := bytes.NewBuffer(nil)
+
+	err := cmd.Run(ctx, command.WithDir(r.repoPath), command.WithStdout(stdout))
+	if err != nil {
+		if command.AsError(err).IsExitCode(1) && stdout.Len() == 0 {
+			// git grep exits with 1 if nothing is found
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to grep files in shared repository's git index: %w", err)
+	}
+
+	var files []string
+	for _, line := range bytes.Split(stdout.Bytes(), []byte{'\000'}) {
+		if len(line) > 0 {
+			files = append(files, string(line))
+		}
+	}
+
+	return files, nil
+}
+
 // RemoveFilesFromIndex removes the given files from the index.
 func (r *SharedRepo) RemoveFilesFromIndex(
 	ctx context.Context,
@@ -386,7 +429,7 @@ func (r *SharedRepo) CommitTree(
 		cmd.Add(command.WithFlag("-p", parentCommit.String()))
 	}
 
-	// temporary no signing
+	// the commit is signed by the server (if configured), not by the git's signing program.
 	cmd.Add(command.WithFlag("--no-gpg-sign"))
 
 	messageBytes := new(bytes.Buffer)
@@ -410,9 +453,85 @@ func (r *SharedRepo) CommitTree(
 		return sha.None, fmt.Errorf("failed to commit-tree in shared repo: %w", err)
 	}
 
+	commitSHA, err := sha.New(stdout.String())
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to parse commit SHA: %w", err)
+	}
+
+	if r.signer == nil {
+		return commitSHA, nil
+	}
+
+	return r.signCommit(ctx, commitSHA)
+}
+
+// signCommit signs the commit with the signer of the shared repository and writes the signed commit object.
+// It returns the SHA of the signed commit. The unsigned commit object is left to be garbage collected.
+func (r *SharedRepo) signCommit(ctx context.Context, commitSHA sha.SHA) (sha.SHA, error) {
+	payload := bytes.NewBuffer(nil)
+
+	cmd := command.New("cat-file",
+		command.WithArg("commit"),
+		command.WithArg(commitSHA.String()))
+
+	err := cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdout(payload))
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to read commit object in shared repo: %w", err)
+	}
+
+	signature, err := r.signer.Sign(ctx, payload.Bytes())
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to sign commit: %w", err)
+	}
+
+	if len(signature) == 0 {
+		return commitSHA, nil
+	}
+
+	signedCommit, err := addCommitSignature(payload.Bytes(), signature)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to add signature to commit %s: %w", commitSHA, err)
+	}
+
+	cmd = command.New("hash-object",
+		command.WithFlag("-t", "commit"),
+		command.WithFlag("-w"),
+		command.WithFlag("--stdin"))
+
+	stdout := bytes.NewBuffer(nil)
+
+	err = cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdin(bytes.NewReader(signedCommit)),
+		command.WithStdout(stdout))
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to write signed commit object in shared repo: %w", err)
+	}
+
 	return sha.New(stdout.String())
 }
 
+// addCommitSignature adds the signature to the commit object as the "gpgsig" header.
+// The header is placed after all other headers and each line of the signature,
+// except the first one, is prefixed with a space.
+func addCommitSignature(commitObject, signature []byte) ([]byte, error) {
+	headers, message, found := bytes.Cut(commitObject, []byte("\n\n"))
+	if !found {
+		return nil, errors.New("invalid commit object: headers not terminated")
+	}
+
+	signedCommit := bytes.NewBuffer(make([]byte, 0, len(commitObject)+len(signature)+32))
+	_, _ = signedCommit.Write(headers)
+	_, _ = signedCommit.WriteString("\ngpgsig ")
+	_, _ = signedCommit.Write(bytes.ReplaceAll(bytes.TrimRight(signature, "\n"), []byte("\n"), []byte("\n ")))
+	_, _ = signedCommit.WriteString("\n\n")
+	_, _ = signedCommit.Write(message)
+
+	return signedCommit.Bytes(), nil
+}
+
 // CommitSHAsForRebase returns list of SHAs of the commits between the two git revisions
 // for a rebase operation - in the order they should be rebased in.
 func (r *SharedRepo) CommitSHAsForRebase(
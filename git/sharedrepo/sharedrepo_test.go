@@ -774,3 +774,57 @@ func Test_patchTextFileWritePatchedFile(t *testing.T) {
 		})
 	}
 }
+
+func Test_addCommitSignature(t *testing.T) {
+	const commitObject = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author John <john@example.com> 1700000000 +0000\n" +
+		"committer John <john@example.com> 1700000000 +0000\n" +
+		"\n" +
+		"title\n" +
+		"\n" +
+		"body\n"
+
+	const signature = "-----BEGIN SSH SIGNATURE-----\n" +
+		"U1NIU0lH\n" +
+		"-----END SSH SIGNATURE-----\n"
+
+	tests := []struct {
+		name    string
+		object  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "signature added",
+			object: commitObject,
+			want: "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+				"author John <john@example.com> 1700000000 +0000\n" +
+				"committer John <john@example.com> 1700000000 +0000\n" +
+				"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+				" U1NIU0lH\n" +
+				" -----END SSH SIGNATURE-----\n" +
+				"\n" +
+				"title\n" +
+				"\n" +
+				"body\n",
+		},
+		{
+			name:    "invalid object",
+			object:  "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := addCommitSignature([]byte(tt.object), []byte(signature))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -70,6 +70,7 @@ type CommitTag struct {
 	Message     string
 	Tagger      *Signature
 	Commit      *Commit
+	SignedData  *SignedData
 }
 
 type CreateCommitTagParams struct {
@@ -194,6 +195,7 @@ func (s *Service) ListCommitTags(
 				return nil, fmt.Errorf("signature mapping error: %w", err)
 			}
 			tags[wi].Tagger = tagger
+			tags[wi].SignedData = mapSignedData(aTags[ai].Signature)
 
 			ai++
 			wi++
@@ -47,11 +47,13 @@ func ProvideService(
 	adapter *api.Git,
 	hookClientFactory hook.ClientFactory,
 	storage storage.Store,
+	signer api.Signer,
 ) (Interface, error) {
 	return New(
 		config,
 		adapter,
 		hookClientFactory,
 		storage,
+		signer,
 	)
 }
@@ -123,6 +123,10 @@ func toPackageType(packageTypeStr string) (artifactapi.PackageType, error) {
 		return artifactapi.PackageTypeMAVEN, nil
 	case string(artifactapi.PackageTypePYTHON):
 		return artifactapi.PackageTypePYTHON, nil
+	case string(artifactapi.PackageTypeNPM):
+		return artifactapi.PackageTypeNPM, nil
+	case string(artifactapi.PackageTypeGO):
+		return artifactapi.PackageTypeGO, nil
 	default:
 		return "", errors.New("invalid package type")
 	}
@@ -237,6 +241,12 @@ func GetArtifactFilesMetadata(
 			filePathPrefix = "/" + artifactName + "/" + version + "/"
 			filename = strings.Replace(file.Path, filePathPrefix, "", 1)
 			downloadCommand = GetMavenArtifactFileDownloadCommand(registryURL, artifactName, version, filename)
+		} else if artifactapi.PackageTypeNPM == packageType {
+			downloadCommand = GetNpmArtifactFileDownloadCommand(registryURL, artifactName, filename)
+		} else if artifactapi.PackageTypeGO == packageType {
+			downloadCommand = GetGoArtifactFileDownloadCommand(registryURL, artifactName, filename)
+		} else if artifactapi.PackageTypeHELM == packageType {
+			downloadCommand = GetHelmArtifactFileDownloadCommand(registryURL, filename)
 		}
 		files = append(files, artifactapi.FileDetail{
 			Checksums:       getCheckSums(file),
@@ -497,6 +507,69 @@ func GetPythonArtifactDetail(
 	return *artifactDetail
 }
 
+func GetNpmArtifactDetail(
+	image *types.Image, artifact *types.Artifact,
+	metadata map[string]interface{},
+) artifactapi.ArtifactDetail {
+	createdAt := GetTimeInMs(artifact.CreatedAt)
+	modifiedAt := GetTimeInMs(artifact.UpdatedAt)
+	artifactDetail := &artifactapi.ArtifactDetail{
+		CreatedAt:  &createdAt,
+		ModifiedAt: &modifiedAt,
+		Name:       &image.Name,
+		Version:    artifact.Version,
+	}
+	err := artifactDetail.FromNpmArtifactDetailConfig(artifactapi.NpmArtifactDetailConfig{
+		Metadata: &metadata,
+	})
+	if err != nil {
+		return artifactapi.ArtifactDetail{}
+	}
+	return *artifactDetail
+}
+
+func GetGoArtifactDetail(
+	image *types.Image, artifact *types.Artifact,
+	metadata map[string]interface{},
+) artifactapi.ArtifactDetail {
+	createdAt := GetTimeInMs(artifact.CreatedAt)
+	modifiedAt := GetTimeInMs(artifact.UpdatedAt)
+	artifactDetail := &artifactapi.ArtifactDetail{
+		CreatedAt:  &createdAt,
+		ModifiedAt: &modifiedAt,
+		Name:       &image.Name,
+		Version:    artifact.Version,
+	}
+	err := artifactDetail.FromGoArtifactDetailConfig(artifactapi.GoArtifactDetailConfig{
+		Metadata: &metadata,
+	})
+	if err != nil {
+		return artifactapi.ArtifactDetail{}
+	}
+	return *artifactDetail
+}
+
+func GetHelmArtifactDetail(
+	image *types.Image, artifact *types.Artifact,
+	pullCommand string,
+) artifactapi.ArtifactDetail {
+	createdAt := GetTimeInMs(artifact.CreatedAt)
+	modifiedAt := GetTimeInMs(artifact.UpdatedAt)
+	artifactDetail := &artifactapi.ArtifactDetail{
+		CreatedAt:  &createdAt,
+		ModifiedAt: &modifiedAt,
+		Name:       &image.Name,
+		Version:    artifact.Version,
+	}
+	err := artifactDetail.FromHelmArtifactDetailConfig(artifactapi.HelmArtifactDetailConfig{
+		PullCommand: &pullCommand,
+	})
+	if err != nil {
+		return artifactapi.ArtifactDetail{}
+	}
+	return *artifactDetail
+}
+
 func GetArtifactSummary(artifact types.ArtifactMetadata) *artifactapi.ArtifactSummaryResponseJSONResponse {
 	createdAt := GetTimeInMs(artifact.CreatedAt)
 	modifiedAt := GetTimeInMs(artifact.ModifiedAt)
@@ -234,6 +234,36 @@ func (c *APIController) getUpstreamProxyKeys(ctx context.Context, ids []int64) [
 	return repoKeys
 }
 
+// isOCIRegistry tells whether the artifacts of the registry are listed from the OCI tags. Helm registries are
+// either used with OCI or as chart repositories, whose charts have no tags.
+func (c *APIController) isOCIRegistry(
+	ctx context.Context,
+	packageType api.PackageType,
+	parentID int64,
+	registryIdentifier string,
+) bool {
+	if packageType != api.PackageTypeHELM {
+		return packageType == api.PackageTypeDOCKER
+	}
+	count, err := c.TagStore.CountAllArtifactsByRepo(ctx, parentID, registryIdentifier, "", nil)
+	return err != nil || count > 0
+}
+
+// isOCIArtifact tells whether the versions of the artifact are listed from the OCI tags, see isOCIRegistry.
+func (c *APIController) isOCIArtifact(
+	ctx context.Context,
+	packageType api.PackageType,
+	parentID int64,
+	registryIdentifier string,
+	image string,
+) bool {
+	if packageType != api.PackageTypeHELM {
+		return packageType == api.PackageTypeDOCKER
+	}
+	count, err := c.TagStore.CountAllTagsByRepoAndImage(ctx, parentID, registryIdentifier, image, "")
+	return err != nil || count > 0
+}
+
 type manifestConfig struct {
 	CreatedAt  *string        `json:"created,omitempty"`
 	Digest     string         `json:"digest,omitempty"`
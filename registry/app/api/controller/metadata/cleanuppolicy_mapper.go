@@ -55,13 +55,21 @@ func getCleanupPolicyEntity(
 	cleanupPolicy artifact.CleanupPolicy,
 	repoID int64,
 ) *types.CleanupPolicy {
-	expireTime := time.Duration(*cleanupPolicy.ExpireDays) * 24 * time.Hour
+	var expireTime time.Duration
+	if cleanupPolicy.ExpireDays != nil {
+		expireTime = time.Duration(*cleanupPolicy.ExpireDays) * 24 * time.Hour
+	}
+	var keepLastVersions int
+	if cleanupPolicy.KeepLastVersions != nil {
+		keepLastVersions = *cleanupPolicy.KeepLastVersions
+	}
 	return &types.CleanupPolicy{
-		Name:          *cleanupPolicy.Name,
-		VersionPrefix: *cleanupPolicy.VersionPrefix,
-		PackagePrefix: *cleanupPolicy.PackagePrefix,
-		ExpiryTime:    expireTime.Milliseconds(),
-		RegistryID:    repoID,
+		Name:             *cleanupPolicy.Name,
+		VersionPrefix:    *cleanupPolicy.VersionPrefix,
+		PackagePrefix:    *cleanupPolicy.PackagePrefix,
+		ExpiryTime:       expireTime.Milliseconds(),
+		KeepLastVersions: keepLastVersions,
+		RegistryID:       repoID,
 	}
 }
 
@@ -70,12 +78,14 @@ func getCleanupPolicyDto(
 ) *artifact.CleanupPolicy {
 	packagePrefix := cleanupPolicy.PackagePrefix
 	versionPrefix := cleanupPolicy.VersionPrefix
-	expiryDays := int(time.Duration(cleanupPolicy.ExpiryTime).Hours() / 24)
+	expiryDays := int((time.Duration(cleanupPolicy.ExpiryTime) * time.Millisecond).Hours() / 24)
+	keepLastVersions := cleanupPolicy.KeepLastVersions
 
 	return &artifact.CleanupPolicy{
-		Name:          &cleanupPolicy.Name,
-		VersionPrefix: &versionPrefix,
-		PackagePrefix: &packagePrefix,
-		ExpireDays:    &expiryDays,
+		Name:             &cleanupPolicy.Name,
+		VersionPrefix:    &versionPrefix,
+		PackagePrefix:    &packagePrefix,
+		ExpireDays:       &expiryDays,
+		KeepLastVersions: &keepLastVersions,
 	}
 }
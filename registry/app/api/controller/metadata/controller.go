@@ -23,6 +23,7 @@ import (
 	registryevents "github.com/harness/gitness/registry/app/events"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/services/tag"
 	"github.com/harness/gitness/store/database/dbtx"
 )
 
@@ -49,6 +50,8 @@ type APIController struct {
 	RegistryMetadataHelper      RegistryMetadataHelper
 	WebhookService              WebhookService
 	ArtifactEventReporter       registryevents.Reporter
+	CleanupPolicyService        CleanupPolicyService
+	tagDeleter                  *tag.Deleter
 }
 
 func NewAPIController(
@@ -73,6 +76,7 @@ func NewAPIController(
 	registryMetadataHelper RegistryMetadataHelper,
 	webhookService WebhookService,
 	artifactEventReporter registryevents.Reporter,
+	cleanupPolicyService CleanupPolicyService,
 ) *APIController {
 	return &APIController{
 		fileManager:                 fileManager,
@@ -96,5 +100,8 @@ func NewAPIController(
 		RegistryMetadataHelper:      registryMetadataHelper,
 		WebhookService:              webhookService,
 		ArtifactEventReporter:       artifactEventReporter,
+		CleanupPolicyService:        cleanupPolicyService,
+		tagDeleter: tag.NewDeleter(tagStore, manifestStore, auditService, artifactEventReporter,
+			urlProvider),
 	}
 }
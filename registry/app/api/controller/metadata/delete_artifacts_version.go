@@ -20,14 +20,10 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/request"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
-	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/services/tag"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
-
-	"github.com/opencontainers/go-digest"
-	"github.com/rs/zerolog/log"
 )
 
 func (c *APIController) DeleteArtifactVersion(ctx context.Context, r artifact.DeleteArtifactVersionRequestObject) (
@@ -92,33 +88,15 @@ func (c *APIController) deleteTagWithAudit(
 	ctx context.Context, regInfo *RegistryRequestBaseInfo,
 	registryName string, principal types.Principal, artifactName string, versionName string,
 ) error {
-	existingDigest := c.getTagDigest(ctx, regInfo.RegistryID, artifactName, versionName)
-
-	err := c.TagStore.DeleteTag(ctx, regInfo.RegistryID, artifactName, versionName)
-	if err != nil {
-		return err
-	}
-
-	if existingDigest != "" {
-		payload := webhook.GetArtifactDeletedPayload(ctx, principal.ID, regInfo.RegistryID,
-			registryName, versionName, existingDigest.String(), regInfo.RootIdentifier,
-			regInfo.PackageType, artifactName, c.URLProvider)
-		c.ArtifactEventReporter.ArtifactDeleted(ctx, &payload)
-	}
-	auditErr := c.AuditService.Log(
-		ctx,
-		principal,
-		audit.NewResource(audit.ResourceTypeRegistry, artifactName),
-		audit.ActionDeleted,
-		regInfo.ParentRef,
-		audit.WithData("registry name", registryName),
-		audit.WithData("artifact name", artifactName),
-		audit.WithData("version name", versionName),
-	)
-	if auditErr != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete tag operation: %s", auditErr)
-	}
-	return err
+	return c.tagDeleter.DeleteWithAudit(ctx, principal, tag.DeleteInput{
+		RegistryID:     regInfo.RegistryID,
+		RegistryName:   registryName,
+		PackageType:    regInfo.PackageType,
+		ParentRef:      regInfo.ParentRef,
+		RootIdentifier: regInfo.RootIdentifier,
+		ArtifactName:   artifactName,
+		VersionName:    versionName,
+	})
 }
 
 func throwDeleteArtifactVersion500Error(err error) artifact.DeleteArtifactVersion500JSONResponse {
@@ -128,19 +106,3 @@ func throwDeleteArtifactVersion500Error(err error) artifact.DeleteArtifactVersio
 		),
 	}
 }
-
-func (c *APIController) getTagDigest(
-	ctx context.Context,
-	registryID int64,
-	imageName string,
-	tag string,
-) digest.Digest {
-	existingTag, findTagErr := c.TagStore.FindTag(ctx, registryID, imageName, tag)
-	if findTagErr == nil && existingTag != nil {
-		existingTaggedManifest, getManifestErr := c.ManifestStore.Get(ctx, existingTag.ManifestID)
-		if getManifestErr == nil {
-			return existingTaggedManifest.Digest
-		}
-	}
-	return ""
-}
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
 	"github.com/harness/gitness/registry/app/metadata"
+	helmmetadata "github.com/harness/gitness/registry/app/metadata/helm"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -132,8 +133,36 @@ func (c *APIController) GetArtifactDetails(
 			}, nil
 		}
 		artifactDetails = GetPythonArtifactDetail(img, art, result)
+	case artifact.PackageTypeNPM:
+		var result map[string]interface{}
+		err := json.Unmarshal(art.Metadata, &result)
+		if err != nil {
+			return artifact.GetArtifactDetails500JSONResponse{
+				InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+				),
+			}, nil
+		}
+		artifactDetails = GetNpmArtifactDetail(img, art, result)
+	case artifact.PackageTypeGO:
+		var result map[string]interface{}
+		err := json.Unmarshal(art.Metadata, &result)
+		if err != nil {
+			return artifact.GetArtifactDetails500JSONResponse{
+				InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+				),
+			}, nil
+		}
+		artifactDetails = GetGoArtifactDetail(img, art, result)
 	case artifact.PackageTypeDOCKER:
 	case artifact.PackageTypeHELM:
+		// the versions of the charts pushed with OCI have no chart metadata, their details are served apart.
+		var metadata helmmetadata.HelmMetadata
+		if err := json.Unmarshal(art.Metadata, &metadata); err == nil && metadata.Name != "" {
+			repoURL := c.URLProvider.PackageURL(ctx, regInfo.RootIdentifier, regInfo.RegistryIdentifier, "helm")
+			artifactDetails = GetHelmArtifactDetail(img, art, GetHelmChartPullCommand(repoURL, img.Name, art.Version))
+		}
 	default:
 		return artifact.GetArtifactDetails400JSONResponse{
 			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
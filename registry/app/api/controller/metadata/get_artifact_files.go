@@ -99,6 +99,15 @@ func (c *APIController) GetArtifactFiles(
 
 	registryURL := c.URLProvider.RegistryURL(ctx,
 		reqInfo.RootIdentifier, strings.ToLower(string(registry.PackageType)), reqInfo.RegistryIdentifier)
+	if artifact.PackageTypeNPM == registry.PackageType {
+		registryURL = c.URLProvider.PackageURL(ctx, reqInfo.RootIdentifier, reqInfo.RegistryIdentifier, "npm")
+	}
+	if artifact.PackageTypeHELM == registry.PackageType {
+		registryURL = c.URLProvider.PackageURL(ctx, reqInfo.RootIdentifier, reqInfo.RegistryIdentifier, "helm")
+	}
+	if artifact.PackageTypeGO == registry.PackageType {
+		registryURL = c.URLProvider.PackageURL(ctx, reqInfo.RootIdentifier, reqInfo.RegistryIdentifier, "go")
+	}
 	filePathPrefix := "/" + img.Name + "/" + art.Version + "%"
 
 	if artifact.PackageTypeMAVEN == registry.PackageType {
@@ -133,7 +142,8 @@ func (c *APIController) GetArtifactFiles(
 
 	//nolint:exhaustive
 	switch registry.PackageType {
-	case artifact.PackageTypeGENERIC, artifact.PackageTypeMAVEN, artifact.PackageTypePYTHON:
+	case artifact.PackageTypeGENERIC, artifact.PackageTypeMAVEN, artifact.PackageTypePYTHON,
+		artifact.PackageTypeNPM, artifact.PackageTypeHELM, artifact.PackageTypeGO:
 		return artifact.GetArtifactFiles200JSONResponse{
 			FileDetailResponseJSONResponse: *GetAllArtifactFilesResponse(
 				fileMetadataList, count, reqInfo.pageNumber, reqInfo.limit, registryURL, img.Name, art.Version,
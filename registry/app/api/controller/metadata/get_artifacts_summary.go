@@ -75,7 +75,7 @@ func (c *APIController) GetArtifactSummary(
 	}
 
 	var metadata *types.ArtifactMetadata
-	if registry.PackageType == artifact.PackageTypeDOCKER || registry.PackageType == artifact.PackageTypeHELM {
+	if c.isOCIArtifact(ctx, registry.PackageType, regInfo.parentID, regInfo.RegistryIdentifier, image) {
 		metadata, err = c.TagStore.GetLatestTagMetadata(ctx, regInfo.parentID, regInfo.RegistryIdentifier, image)
 
 		if err != nil {
@@ -79,7 +79,7 @@ func (c *APIController) FetchArtifactSummary(
 		return "", "", "", err
 	}
 
-	if registry.PackageType == artifact.PackageTypeDOCKER || registry.PackageType == artifact.PackageTypeHELM {
+	if c.isOCIArtifact(ctx, registry.PackageType, regInfo.parentID, regInfo.RegistryIdentifier, image) {
 		tag, err := c.TagStore.GetTagMetadata(ctx, regInfo.parentID, regInfo.RegistryIdentifier, image, version)
 		if err != nil {
 			return "", "", "", err
@@ -83,7 +83,7 @@ func (c *APIController) GetAllArtifactVersions(
 		return throw500Error(err)
 	}
 
-	if registry.PackageType == artifact.PackageTypeDOCKER || registry.PackageType == artifact.PackageTypeHELM {
+	if c.isOCIArtifact(ctx, registry.PackageType, regInfo.parentID, regInfo.RegistryIdentifier, image) {
 		tags, err := c.TagStore.GetAllTagsByRepoAndImage(
 			ctx, regInfo.parentID, regInfo.RegistryIdentifier,
 			image, regInfo.sortByField, regInfo.sortByOrder, regInfo.limit, regInfo.offset, regInfo.searchTerm,
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/cleanuppolicy"
+	"github.com/harness/gitness/types/enum"
+)
+
+// GetCleanupPolicyDryRun returns the artifact versions which would be deleted
+// by the next execution of the cleanup policies of the registry.
+func (c *APIController) GetCleanupPolicyDryRun(
+	ctx context.Context,
+	r artifact.GetCleanupPolicyDryRunRequestObject,
+) (artifact.GetCleanupPolicyDryRunResponseObject, error) {
+	regInfo, err := c.RegistryMetadataHelper.GetRegistryRequestBaseInfo(ctx, "", string(r.RegistryRef))
+	if err != nil {
+		return artifact.GetCleanupPolicyDryRun400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, err.Error()),
+			),
+		}, nil
+	}
+	space, err := c.SpaceFinder.FindByRef(ctx, regInfo.ParentRef)
+	if err != nil {
+		return artifact.GetCleanupPolicyDryRun400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, err.Error()),
+			),
+		}, nil
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	permissionChecks := c.RegistryMetadataHelper.GetPermissionChecks(space, regInfo.RegistryIdentifier,
+		enum.PermissionRegistryView)
+	if err = apiauth.CheckRegistry(
+		ctx,
+		c.Authorizer,
+		session,
+		permissionChecks...,
+	); err != nil {
+		return artifact.GetCleanupPolicyDryRun403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(
+				*GetErrorResponse(http.StatusForbidden, err.Error()),
+			),
+		}, nil
+	}
+
+	candidates, err := c.CleanupPolicyService.Evaluate(ctx, regInfo.RegistryID)
+	if errors.Is(err, cleanuppolicy.ErrPackageTypeNotSupported) {
+		return artifact.GetCleanupPolicyDryRun400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, err.Error()),
+			),
+		}, nil
+	}
+	if err != nil {
+		return artifact.GetCleanupPolicyDryRun500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+			),
+		}, nil
+	}
+
+	return artifact.GetCleanupPolicyDryRun200JSONResponse{
+		CleanupPolicyDryRunResponseJSONResponse: artifact.CleanupPolicyDryRunResponseJSONResponse{
+			Data:   mapToCleanupPolicyDryRun(candidates),
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
+}
+
+func mapToCleanupPolicyDryRun(candidates []cleanuppolicy.Candidate) artifact.CleanupPolicyDryRun {
+	items := make([]artifact.CleanupPolicyDryRunItem, len(candidates))
+	for i, candidate := range candidates {
+		items[i] = artifact.CleanupPolicyDryRunItem{
+			PolicyName:   candidate.PolicyName,
+			Package:      candidate.Tag.ImageName,
+			Version:      candidate.Tag.Name,
+			LastModified: GetTimeInMs(candidate.Tag.UpdatedAt),
+			Reason:       candidate.Reason,
+		}
+		if candidate.Digest != "" {
+			digest := candidate.Digest
+			items[i].Digest = &digest
+		}
+	}
+
+	return artifact.CleanupPolicyDryRun{
+		Items: items,
+	}
+}
@@ -117,6 +117,10 @@ func (c *APIController) GenerateClientSetupDetails(
 		return c.generateGenericClientSetupDetail(ctx, blankString, registryRef, image, tag, registryType)
 	case string(artifact.PackageTypePYTHON):
 		return c.generatePythonClientSetupDetail(ctx, registryRef, username, image, tag, registryType)
+	case string(artifact.PackageTypeNPM):
+		return c.generateNpmClientSetupDetail(ctx, registryRef, username, image, tag, registryType)
+	case string(artifact.PackageTypeGO):
+		return c.generateGoClientSetupDetail(ctx, registryRef, username, image, tag, registryType)
 	case string(artifact.PackageTypeDOCKER):
 		return c.generateDockerClientSetupDetail(ctx, blankString, loginUsernameLabel, loginUsernameValue,
 			loginPasswordLabel, registryType,
@@ -886,6 +890,236 @@ func (c *APIController) generatePythonClientSetupDetail(
 	}
 }
 
+func (c *APIController) generateNpmClientSetupDetail(
+	ctx context.Context,
+	registryRef string,
+	username string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) *artifact.ClientSetupDetailsResponseJSONResponse {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.URLProvider.PackageURL(ctx, registryRef, "npm")
+
+	// Authentication section
+	section1 := artifact.ClientSetupSection{
+		Header: stringPtr("Configure Authentication"),
+	}
+	_ = section1.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: stringPtr("Generate an identity token for authentication"),
+				Type:   &generateTokenType,
+			},
+			{
+				Header: stringPtr("Create or update the .npmrc file of your project with the following content:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("registry=<REGISTRY_URL>/\n" +
+							"//" + common.TrimURLScheme(registryURL) + "/:_authToken=*see step 1*"),
+					},
+				},
+			},
+		},
+	})
+
+	// Publish section
+	section2 := artifact.ClientSetupSection{
+		Header: stringPtr("Publish Package"),
+	}
+	_ = section2.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: stringPtr("Publish your package:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("npm publish"),
+					},
+				},
+			},
+		},
+	})
+
+	// Install section
+	section3 := artifact.ClientSetupSection{
+		Header: stringPtr("Install Package"),
+	}
+	_ = section3.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: stringPtr("Install a package using npm:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("npm install <ARTIFACT_NAME>@<VERSION>"),
+					},
+				},
+			},
+		},
+	})
+
+	sections := []artifact.ClientSetupSection{
+		section1,
+		section2,
+		section3,
+	}
+
+	if registryType == artifact.RegistryTypeUPSTREAM {
+		sections = []artifact.ClientSetupSection{
+			section1,
+			section3,
+		}
+	}
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "npm Client Setup",
+		SecHeader:  "Follow these instructions to install/use npm packages from this registry.",
+		Sections:   sections,
+	}
+
+	c.replacePlaceholders(ctx, &clientSetupDetails.Sections, username, registryRef, image, tag, registryURL, "",
+		string(artifact.PackageTypeNPM))
+
+	return &artifact.ClientSetupDetailsResponseJSONResponse{
+		Data:   clientSetupDetails,
+		Status: artifact.StatusSUCCESS,
+	}
+}
+
+func (c *APIController) generateGoClientSetupDetail(
+	ctx context.Context,
+	registryRef string,
+	username string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) *artifact.ClientSetupDetailsResponseJSONResponse {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.URLProvider.PackageURL(ctx, registryRef, "go")
+	// the go command looks up the credentials of the host of the proxy in the .netrc file.
+	host := common.TrimURLScheme(registryURL)
+	if u, err := url.Parse(registryURL); err == nil {
+		host = u.Hostname()
+	}
+
+	// Authentication section
+	section1 := artifact.ClientSetupSection{
+		Header: stringPtr("Configure Authentication"),
+	}
+	_ = section1.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: stringPtr("Generate an identity token for authentication"),
+				Type:   &generateTokenType,
+			},
+			{
+				Header: stringPtr("Create or update the ~/.netrc file with the following content:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("machine " + host + "\n" +
+							"login <USERNAME>\n" +
+							"password *see step 1*"),
+					},
+				},
+			},
+			{
+				Header: stringPtr("Use the registry as the module proxy and skip the public checksum database " +
+					"for its modules:"),
+				Type: &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("go env -w GOPROXY=<REGISTRY_URL>,direct\n" +
+							"go env -w GONOSUMDB=<ARTIFACT_NAME>"),
+					},
+				},
+			},
+		},
+	})
+
+	// Publish section
+	section2 := artifact.ClientSetupSection{
+		Header: stringPtr("Publish Package"),
+	}
+	_ = section2.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: stringPtr("Upload the zip of a module version:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("curl --request PUT --location '<REGISTRY_URL>/<ARTIFACT_NAME>/@v/<VERSION>.zip' \\\n" +
+							"--header 'Authorization: Bearer *see step 1*' \\\n" +
+							"--data-binary '@<VERSION>.zip'"),
+					},
+				},
+			},
+			{
+				Header: stringPtr("Or build the module version from a tag of a repository:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("curl --request POST --location '<REGISTRY_URL>/-/build' \\\n" +
+							"--header 'Authorization: Bearer *see step 1*' \\\n" +
+							"--data '{\"repo\": \"<REPOSITORY>\", \"tag\": \"<VERSION>\"}'"),
+					},
+				},
+			},
+		},
+	})
+
+	// Install section
+	section3 := artifact.ClientSetupSection{
+		Header: stringPtr("Install Package"),
+	}
+	_ = section3.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: stringPtr("Add a module to your project:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: stringPtr("go get <ARTIFACT_NAME>@<VERSION>"),
+					},
+				},
+			},
+		},
+	})
+
+	sections := []artifact.ClientSetupSection{
+		section1,
+		section2,
+		section3,
+	}
+
+	if registryType == artifact.RegistryTypeUPSTREAM {
+		sections = []artifact.ClientSetupSection{
+			section1,
+			section3,
+		}
+	}
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Go Client Setup",
+		SecHeader:  "Follow these instructions to install/use Go modules from this registry.",
+		Sections:   sections,
+	}
+
+	c.replacePlaceholders(ctx, &clientSetupDetails.Sections, username, registryRef, image, tag, registryURL, "",
+		string(artifact.PackageTypeGO))
+
+	return &artifact.ClientSetupDetailsResponseJSONResponse{
+		Data:   clientSetupDetails,
+		Status: artifact.StatusSUCCESS,
+	}
+}
+
 func (c *APIController) replacePlaceholders(
 	ctx context.Context,
 	clientSetupSections *[]artifact.ClientSetupSection,
@@ -83,7 +83,7 @@ func (c *APIController) GetAllArtifactsByRegistry(
 
 	var artifacts *[]types.ArtifactMetadata
 	var count int64
-	if registry.PackageType == artifact.PackageTypeDOCKER || registry.PackageType == artifact.PackageTypeHELM {
+	if c.isOCIRegistry(ctx, registry.PackageType, regInfo.parentID, regInfo.RegistryIdentifier) {
 		artifacts, err = c.TagStore.GetAllArtifactsByRepo(
 			ctx, regInfo.parentID, regInfo.RegistryIdentifier,
 			regInfo.sortByField, regInfo.sortByOrder, regInfo.limit, regInfo.offset, regInfo.searchTerm, regInfo.labels,
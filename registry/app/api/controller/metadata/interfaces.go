@@ -19,6 +19,7 @@ import (
 
 	gitnesswebhook "github.com/harness/gitness/app/services/webhook"
 	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/cleanuppolicy"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -55,6 +56,10 @@ type RegistryMetadataHelper interface {
 	) (*api.Webhook, error)
 }
 
+type CleanupPolicyService interface {
+	Evaluate(ctx context.Context, registryID int64) ([]cleanuppolicy.Candidate, error)
+}
+
 type WebhookService interface {
 	ReTriggerWebhookExecution(ctx context.Context, webhookExecutionID int64) (*gitnesswebhook.TriggerResult, error)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/cleanuppolicy"
 	"github.com/harness/gitness/registry/types"
 	types2 "github.com/harness/gitness/types"
 	gitnessenum "github.com/harness/gitness/types/enum"
@@ -145,6 +146,14 @@ func (c *APIController) updateVirtualRegistry(
 	if err != nil {
 		return throwModifyRegistry500Error(err), err
 	}
+	if r.Body.CleanupPolicy != nil && len(*r.Body.CleanupPolicy) > 0 &&
+		!cleanuppolicy.IsPackageTypeSupported(repoEntity.PackageType) {
+		return artifact.ModifyRegistry400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, cleanuppolicy.ErrPackageTypeNotSupported.Error()),
+			),
+		}, nil
+	}
 	registry, err := UpdateRepoEntity(
 		artifact.RegistryRequest(*r.Body),
 		repoEntity.ParentID,
@@ -26,6 +26,7 @@ import (
 
 	a "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
 	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/types/gopackage"
 
 	"github.com/inhies/go-bytesize"
 	"github.com/rs/zerolog/log"
@@ -116,6 +117,8 @@ var validPackageTypes = []string{
 	string(a.PackageTypeGENERIC),
 	string(a.PackageTypeMAVEN),
 	string(a.PackageTypePYTHON),
+	string(a.PackageTypeNPM),
+	string(a.PackageTypeGO),
 }
 
 var validUpstreamSources = []string{
@@ -124,6 +127,8 @@ var validUpstreamSources = []string{
 	string(a.UpstreamConfigSourceAwsEcr),
 	string(a.UpstreamConfigSourceMavenCentral),
 	string(a.UpstreamConfigSourcePyPi),
+	string(a.UpstreamConfigSourceNpmJs),
+	string(a.UpstreamConfigSourceGoProxy),
 }
 
 func ValidatePackageTypes(packageTypes []string) error {
@@ -181,7 +186,9 @@ func ValidateUpstream(config *a.RegistryConfig) error {
 	if !commons.IsEmpty(config.Type) && config.Type == a.RegistryTypeUPSTREAM &&
 		*upstreamConfig.Source != a.UpstreamConfigSourceDockerhub &&
 		*upstreamConfig.Source != a.UpstreamConfigSourceMavenCentral &&
-		*upstreamConfig.Source != a.UpstreamConfigSourcePyPi {
+		*upstreamConfig.Source != a.UpstreamConfigSourcePyPi &&
+		*upstreamConfig.Source != a.UpstreamConfigSourceNpmJs &&
+		*upstreamConfig.Source != a.UpstreamConfigSourceGoProxy {
 		if commons.IsEmpty(upstreamConfig.Url) {
 			return errors.New("URL is required for upstream repository")
 		}
@@ -404,6 +411,78 @@ func GetGenericArtifactFileDownloadCommand(regURL, artifact, version, filename s
 	return downloadCommand
 }
 
+func GetNpmArtifactFileDownloadCommand(regURL, artifact, filename string) string {
+	downloadCommand := "curl --location '<HOSTNAME>/<ARTIFACT>/-/<FILENAME>'" +
+		" --header 'Authorization: Bearer <IDENTITY_TOKEN>' -O"
+
+	// Replace the placeholders with the actual values
+	replacements := map[string]string{
+		"<HOSTNAME>": regURL,
+		"<ARTIFACT>": artifact,
+		"<FILENAME>": filename,
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func GetGoArtifactFileDownloadCommand(regURL, artifact, filename string) string {
+	downloadCommand := "curl --location '<HOSTNAME>/<ARTIFACT>/@v/<FILENAME>'" +
+		" --header 'Authorization: Bearer <IDENTITY_TOKEN>' -O"
+
+	// Replace the placeholders with the actual values, the module path is case-encoded in the URLs.
+	replacements := map[string]string{
+		"<HOSTNAME>": regURL,
+		"<ARTIFACT>": gopackage.EscapePath(artifact),
+		"<FILENAME>": filename,
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func GetHelmArtifactFileDownloadCommand(regURL, filename string) string {
+	downloadCommand := "curl --location '<HOSTNAME>/charts/<FILENAME>'" +
+		" --header 'Authorization: Bearer <IDENTITY_TOKEN>' -O"
+
+	// Replace the placeholders with the actual values
+	replacements := map[string]string{
+		"<HOSTNAME>": regURL,
+		"<FILENAME>": filename,
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+// GetHelmChartPullCommand returns the command to pull a chart version from the chart repository of a registry.
+func GetHelmChartPullCommand(repoURL, chart, version string) string {
+	pullCommand := "helm pull <CHART> --version <VERSION> --repo <REPO_URL>" +
+		" --username <USERNAME> --password <IDENTITY_TOKEN>"
+
+	// Replace the placeholders with the actual values
+	replacements := map[string]string{
+		"<REPO_URL>": repoURL,
+		"<CHART>":    chart,
+		"<VERSION>":  version,
+	}
+
+	for placeholder, value := range replacements {
+		pullCommand = strings.ReplaceAll(pullCommand, placeholder, value)
+	}
+
+	return pullCommand
+}
+
 func GetMavenArtifactFileDownloadCommand(regURL, artifact, version, filename string) string {
 	downloadCommand := "curl --location '<HOSTNAME>/<ARTIFACT>/<VERSION>/<FILENAME>'" +
 		" --header 'x-api-key: <IDENTITY_TOKEN>' -O"
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/gopackage"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+type Controller interface {
+	ListVersions(ctx context.Context, info gotype.ArtifactInfo) *ListVersionsResponse
+
+	GetVersionInfo(ctx context.Context, info gotype.ArtifactInfo) *GetVersionInfoResponse
+
+	GetLatestVersion(ctx context.Context, info gotype.ArtifactInfo) *GetVersionInfoResponse
+
+	DownloadPackageFile(ctx context.Context, info gotype.ArtifactInfo) *GetArtifactResponse
+
+	UploadPackage(ctx context.Context, info gotype.ArtifactInfo, file io.Reader) *PutArtifactResponse
+
+	BuildFromRepository(
+		ctx context.Context,
+		session *auth.Session,
+		info gotype.ArtifactInfo,
+		in gotype.BuildRequest,
+	) *BuildResponse
+}
+
+// Controller handles go module operations.
+type controller struct {
+	fileManager filemanager.FileManager
+	proxyStore  store.UpstreamProxyConfigRepository
+	tx          dbtx.Transactor
+	registryDao store.RegistryRepository
+	imageDao    store.ImageRepository
+	artifactDao store.ArtifactRepository
+	urlProvider urlprovider.Provider
+	authorizer  authz.Authorizer
+	spaceFinder refcache.SpaceFinder
+	repoFinder  refcache.RepoFinder
+	local       gopackage.LocalRegistry
+	proxy       gopackage.Proxy
+}
+
+// NewController creates a new go module controller.
+func NewController(
+	proxyStore store.UpstreamProxyConfigRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	fileManager filemanager.FileManager,
+	tx dbtx.Transactor,
+	urlProvider urlprovider.Provider,
+	authorizer authz.Authorizer,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	local gopackage.LocalRegistry,
+	proxy gopackage.Proxy,
+) Controller {
+	return &controller{
+		proxyStore:  proxyStore,
+		registryDao: registryDao,
+		imageDao:    imageDao,
+		artifactDao: artifactDao,
+		fileManager: fileManager,
+		tx:          tx,
+		urlProvider: urlProvider,
+		authorizer:  authorizer,
+		spaceFinder: spaceFinder,
+		repoFinder:  repoFinder,
+		local:       local,
+		proxy:       proxy,
+	}
+}
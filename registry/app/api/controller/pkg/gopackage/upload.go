@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	gopkg "github.com/harness/gitness/registry/app/pkg/gopackage"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	registrytypes "github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UploadPackage stores the module zip of a new version of the module in the registry of the request.
+func (c *controller) UploadPackage(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+	file io.Reader,
+) *PutArtifactResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		goRegistry, ok := a.(gopkg.Registry)
+		if !ok {
+			return &PutArtifactResponse{
+				"",
+				[]error{fmt.Errorf("invalid registry type: expected gopackage.Registry")},
+				nil,
+			}
+		}
+		headers, sha256, err := goRegistry.UploadPackage(ctx, info, file)
+		if commons.IsEmptyError(err) {
+			return &PutArtifactResponse{
+				sha256, []error{}, headers,
+			}
+		}
+		return &PutArtifactResponse{
+			sha256, []error{err}, headers,
+		}
+	}
+
+	result := base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	response, ok := result.(*PutArtifactResponse)
+	if !ok {
+		return &PutArtifactResponse{
+			"",
+			[]error{fmt.Errorf("invalid response type: expected PutArtifactResponse")},
+			nil,
+		}
+	}
+	return response
+}
+
+// BuildFromRepository stores a new version of a module built from a tag of a repository in the space of
+// the registry of the request. The repository must be readable by the session.
+func (c *controller) BuildFromRepository(
+	ctx context.Context,
+	session *auth.Session,
+	info gotype.ArtifactInfo,
+	in gotype.BuildRequest,
+) *BuildResponse {
+	if in.Tag == "" {
+		return &BuildResponse{[]error{errcode.ErrCodeInvalidRequest.WithMessage("tag is required")}, nil}
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, info, in.Repo)
+	if err != nil {
+		return &BuildResponse{[]error{err}, nil}
+	}
+
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		goRegistry, ok := a.(gopkg.Registry)
+		if !ok {
+			return &BuildResponse{
+				[]error{fmt.Errorf("invalid registry type: expected gopackage.Registry")}, nil,
+			}
+		}
+		result, errc := goRegistry.BuildFromRepository(ctx, info, repo, in.Tag)
+		if !commons.IsEmptyError(errc) {
+			return &BuildResponse{[]error{errc}, nil}
+		}
+		return &BuildResponse{nil, result}
+	}
+
+	result := base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	buildResponse, ok := result.(*BuildResponse)
+	if !ok {
+		return &BuildResponse{
+			[]error{fmt.Errorf("invalid response type: expected BuildResponse")}, nil,
+		}
+	}
+	return buildResponse
+}
+
+// getRepoCheckAccess returns the repository of the space of the registry, if the session can read it.
+func (c *controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	info gotype.ArtifactInfo,
+	repoIdentifier string,
+) (*types.RepositoryCore, error) {
+	if repoIdentifier == "" || strings.Contains(repoIdentifier, "/") {
+		return nil, errcode.ErrCodeInvalidRequest.WithMessage(
+			fmt.Sprintf("invalid repository %q, the identifier of a repository in the space is expected",
+				repoIdentifier))
+	}
+
+	space, err := c.spaceFinder.FindByID(ctx, info.ParentID)
+	if err != nil {
+		return nil, errcode.ErrCodeParentNotFound.WithDetail(err)
+	}
+
+	repo, err := c.repoFinder.FindByRef(ctx, paths.Concatenate(space.Path, repoIdentifier))
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, errcode.ErrCodeNameUnknown.WithMessage(
+			fmt.Sprintf("repository %s not found in space %s", repoIdentifier, space.Path))
+	}
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to find repository: %w", err))
+	}
+
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return nil, errcode.ErrCodeDenied.WithMessage(
+			fmt.Sprintf("permission %s is required on repository %s", enum.PermissionRepoView, repo.Path))
+	}
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to check access to repository: %w", err))
+	}
+	return repo, nil
+}
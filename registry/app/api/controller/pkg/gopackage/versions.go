@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	gopkg "github.com/harness/gitness/registry/app/pkg/gopackage"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// ListVersions returns the versions of the module from the registry of the request or its upstream proxies.
+func (c *controller) ListVersions(ctx context.Context, info gotype.ArtifactInfo) *ListVersionsResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		goRegistry, ok := a.(gopkg.Registry)
+		if !ok {
+			return &ListVersionsResponse{
+				[]error{fmt.Errorf("invalid registry type: expected gopackage.Registry")}, nil,
+			}
+		}
+		versions, err := goRegistry.ListVersions(ctx, info)
+		if err != nil {
+			return &ListVersionsResponse{[]error{err}, nil}
+		}
+		return &ListVersionsResponse{nil, versions}
+	}
+
+	result := base.ProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	listResponse, ok := result.(*ListVersionsResponse)
+	if !ok {
+		return &ListVersionsResponse{
+			[]error{fmt.Errorf("invalid response type: expected ListVersionsResponse")}, nil,
+		}
+	}
+	return listResponse
+}
+
+func (c *controller) GetVersionInfo(ctx context.Context, info gotype.ArtifactInfo) *GetVersionInfoResponse {
+	return c.getVersionInfo(ctx, info, func(
+		registry gopkg.Registry,
+		info gotype.ArtifactInfo,
+	) (*gopackage.VersionInfo, error) {
+		return registry.GetVersionInfo(ctx, info)
+	})
+}
+
+func (c *controller) GetLatestVersion(ctx context.Context, info gotype.ArtifactInfo) *GetVersionInfoResponse {
+	return c.getVersionInfo(ctx, info, func(
+		registry gopkg.Registry,
+		info gotype.ArtifactInfo,
+	) (*gopackage.VersionInfo, error) {
+		return registry.GetLatestVersion(ctx, info)
+	})
+}
+
+func (c *controller) getVersionInfo(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+	get func(registry gopkg.Registry, info gotype.ArtifactInfo) (*gopackage.VersionInfo, error),
+) *GetVersionInfoResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		goRegistry, ok := a.(gopkg.Registry)
+		if !ok {
+			return &GetVersionInfoResponse{
+				[]error{fmt.Errorf("invalid registry type: expected gopackage.Registry")}, nil,
+			}
+		}
+		versionInfo, err := get(goRegistry, info)
+		if err != nil {
+			return &GetVersionInfoResponse{[]error{err}, nil}
+		}
+		return &GetVersionInfoResponse{nil, versionInfo}
+	}
+
+	result := base.ProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	infoResponse, ok := result.(*GetVersionInfoResponse)
+	if !ok {
+		return &GetVersionInfoResponse{
+			[]error{fmt.Errorf("invalid response type: expected GetVersionInfoResponse")}, nil,
+		}
+	}
+	return infoResponse
+}
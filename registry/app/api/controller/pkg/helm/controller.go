@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"io"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/helm"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+type Controller interface {
+	GetIndex(ctx context.Context, info helmtype.ArtifactInfo) *GetIndexResponse
+
+	UploadChart(ctx context.Context, info helmtype.ArtifactInfo, file io.ReadCloser) *PutArtifactResponse
+
+	UploadProvenance(ctx context.Context, info helmtype.ArtifactInfo, file io.ReadCloser) *PutArtifactResponse
+
+	DownloadPackageFile(ctx context.Context, info helmtype.ArtifactInfo) *GetArtifactResponse
+
+	// TrackDownloadStat records a download of the chart version.
+	TrackDownloadStat(ctx context.Context, info helmtype.ArtifactInfo) error
+}
+
+// Controller handles Helm chart repository operations.
+type controller struct {
+	fileManager     filemanager.FileManager
+	proxyStore      store.UpstreamProxyConfigRepository
+	tx              dbtx.Transactor
+	registryDao     store.RegistryRepository
+	imageDao        store.ImageRepository
+	artifactDao     store.ArtifactRepository
+	downloadStatDao store.DownloadStatRepository
+	urlProvider     urlprovider.Provider
+	local           helm.LocalRegistry
+}
+
+// NewController creates a new Helm controller.
+func NewController(
+	proxyStore store.UpstreamProxyConfigRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	downloadStatDao store.DownloadStatRepository,
+	fileManager filemanager.FileManager,
+	tx dbtx.Transactor,
+	urlProvider urlprovider.Provider,
+	local helm.LocalRegistry,
+) Controller {
+	return &controller{
+		proxyStore:      proxyStore,
+		registryDao:     registryDao,
+		imageDao:        imageDao,
+		artifactDao:     artifactDao,
+		downloadStatDao: downloadStatDao,
+		fileManager:     fileManager,
+		tx:              tx,
+		urlProvider:     urlProvider,
+		local:           local,
+	}
+}
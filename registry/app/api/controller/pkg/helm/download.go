@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/helm"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	"github.com/harness/gitness/registry/types"
+)
+
+func (c *controller) DownloadPackageFile(
+	ctx context.Context,
+	info helmtype.ArtifactInfo,
+) *GetArtifactResponse {
+	f := func(registry types.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		helmRegistry, ok := a.(helm.Registry)
+		if !ok {
+			return &GetArtifactResponse{
+				[]error{fmt.Errorf("invalid registry type: expected helm.Registry")},
+				nil, "", nil, nil,
+			}
+		}
+		headers, fileReader, readCloser, redirectURL, errs := helmRegistry.DownloadPackageFile(ctx, info)
+		return &GetArtifactResponse{
+			errs, headers, redirectURL,
+			fileReader, readCloser,
+		}
+	}
+
+	result := base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	getResponse, ok := result.(*GetArtifactResponse)
+	if !ok {
+		return &GetArtifactResponse{
+			[]error{fmt.Errorf("invalid response type: expected GetArtifactResponse")},
+			nil, "", nil, nil,
+		}
+	}
+	return getResponse
+}
+
+func (c *controller) TrackDownloadStat(ctx context.Context, info helmtype.ArtifactInfo) error {
+	image, err := c.imageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return err
+	}
+	artifact, err := c.artifactDao.GetByName(ctx, image.ID, info.Version)
+	if err != nil {
+		return err
+	}
+	return c.downloadStatDao.Create(ctx, &types.DownloadStat{ArtifactID: artifact.ID})
+}
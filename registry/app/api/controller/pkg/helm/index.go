@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/helm"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// GetIndex returns the index.yaml of the chart repository of the request.
+func (c *controller) GetIndex(ctx context.Context, info helmtype.ArtifactInfo) *GetIndexResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		helmRegistry, ok := a.(helm.Registry)
+		if !ok {
+			return &GetIndexResponse{
+				[]error{fmt.Errorf("invalid registry type: expected helm.Registry")}, nil,
+			}
+		}
+		index, err := helmRegistry.GetIndex(ctx, info)
+		if err != nil {
+			return &GetIndexResponse{[]error{err}, nil}
+		}
+		return &GetIndexResponse{nil, index}
+	}
+
+	result := base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	indexResponse, ok := result.(*GetIndexResponse)
+	if !ok {
+		return &GetIndexResponse{
+			[]error{fmt.Errorf("invalid response type: expected GetIndexResponse")}, nil,
+		}
+	}
+	return indexResponse
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/helm"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+type uploadFunc func(
+	registry helm.Registry,
+	info helmtype.ArtifactInfo,
+) (*commons.ResponseHeaders, string, errcode.Error)
+
+// UploadChart stores the chart archive in the registry of the request.
+func (c *controller) UploadChart(
+	ctx context.Context,
+	info helmtype.ArtifactInfo,
+	file io.ReadCloser,
+) *PutArtifactResponse {
+	return c.upload(ctx, info, func(
+		registry helm.Registry,
+		info helmtype.ArtifactInfo,
+	) (*commons.ResponseHeaders, string, errcode.Error) {
+		return registry.UploadChart(ctx, info, file)
+	})
+}
+
+// UploadProvenance stores the provenance file of a chart version in the registry of the request.
+func (c *controller) UploadProvenance(
+	ctx context.Context,
+	info helmtype.ArtifactInfo,
+	file io.ReadCloser,
+) *PutArtifactResponse {
+	return c.upload(ctx, info, func(
+		registry helm.Registry,
+		info helmtype.ArtifactInfo,
+	) (*commons.ResponseHeaders, string, errcode.Error) {
+		return registry.UploadProvenance(ctx, info, file)
+	})
+}
+
+func (c *controller) upload(ctx context.Context, info helmtype.ArtifactInfo, upload uploadFunc) *PutArtifactResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		helmRegistry, ok := a.(helm.Registry)
+		if !ok {
+			return &PutArtifactResponse{
+				"",
+				[]error{fmt.Errorf("invalid registry type: expected helm.Registry")},
+				nil,
+			}
+		}
+		headers, sha256, err := upload(helmRegistry, info)
+		if commons.IsEmptyError(err) {
+			return &PutArtifactResponse{
+				sha256, []error{}, headers,
+			}
+		}
+		return &PutArtifactResponse{
+			sha256, []error{err}, headers,
+		}
+	}
+
+	result := base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	response, ok := result.(*PutArtifactResponse)
+	if !ok {
+		return &PutArtifactResponse{
+			"",
+			[]error{fmt.Errorf("invalid response type: expected PutArtifactResponse")},
+			nil,
+		}
+	}
+	return response
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// GetPackageMetadata returns the package document from the first registry in the upstream chain having the package.
+func (c *controller) GetPackageMetadata(ctx context.Context, info npmtype.ArtifactInfo) *GetMetadataResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+
+		npmRegistry, ok := a.(npm.Registry)
+		if !ok {
+			return &GetMetadataResponse{
+				[]error{fmt.Errorf("invalid registry type: expected npm.Registry")},
+				npmtype.PackageMetadata{},
+			}
+		}
+
+		metadata, err := npmRegistry.GetPackageMetadata(ctx, info)
+		return &GetMetadataResponse{toErrors(err), metadata}
+	}
+
+	result := base.ProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	metadataResponse, ok := result.(*GetMetadataResponse)
+	if !ok {
+		return &GetMetadataResponse{
+			[]error{fmt.Errorf("invalid response type: expected GetMetadataResponse, got %T", result)},
+			npmtype.PackageMetadata{},
+		}
+	}
+	return metadataResponse
+}
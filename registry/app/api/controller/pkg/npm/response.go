@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+var _ response.Response = (*BaseResponse)(nil)
+var _ response.Response = (*GetMetadataResponse)(nil)
+var _ response.Response = (*GetArtifactResponse)(nil)
+var _ response.Response = (*PutArtifactResponse)(nil)
+var _ response.Response = (*TagsResponse)(nil)
+
+type BaseResponse struct {
+	Errors []error
+}
+
+func (r *BaseResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *BaseResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type GetMetadataResponse struct {
+	Errors          []error
+	PackageMetadata npmtype.PackageMetadata
+}
+
+func (r *GetMetadataResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *GetMetadataResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type GetArtifactResponse struct {
+	Errors          []error
+	ResponseHeaders *commons.ResponseHeaders
+	RedirectURL     string
+	Body            *storage.FileReader
+	ReadCloser      io.ReadCloser
+}
+
+func (r *GetArtifactResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *GetArtifactResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type PutArtifactResponse struct {
+	Sha256          string
+	Errors          []error
+	ResponseHeaders *commons.ResponseHeaders
+}
+
+func (r *PutArtifactResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *PutArtifactResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+// TagsResponse holds the dist-tags of a package, mapped to the versions they point to.
+type TagsResponse struct {
+	Errors   []error
+	DistTags map[string]string
+}
+
+func (r *TagsResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *TagsResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+func toErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// ListTags returns the dist-tags from the first registry in the upstream chain having the package.
+func (c *controller) ListTags(ctx context.Context, info npmtype.ArtifactInfo) *TagsResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		npmRegistry, ok := a.(npm.Registry)
+		if !ok {
+			return &TagsResponse{[]error{fmt.Errorf("invalid registry type: expected npm.Registry")}, nil}
+		}
+		tags, err := npmRegistry.ListTags(ctx, info)
+		return &TagsResponse{toErrors(err), tags}
+	}
+
+	return toTagsResponse(base.ProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo()))
+}
+
+func (c *controller) AddTag(ctx context.Context, info npmtype.ArtifactInfo) *TagsResponse {
+	return c.modifyTags(ctx, info, func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) (map[string]string, error) {
+		return npmRegistry.AddTag(ctx, info)
+	})
+}
+
+func (c *controller) DeleteTag(ctx context.Context, info npmtype.ArtifactInfo) *TagsResponse {
+	return c.modifyTags(ctx, info, func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) (map[string]string, error) {
+		return npmRegistry.DeleteTag(ctx, info)
+	})
+}
+
+func (c *controller) modifyTags(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+	op func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) (map[string]string, error),
+) *TagsResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		npmRegistry, ok := a.(npm.Registry)
+		if !ok {
+			return &TagsResponse{[]error{fmt.Errorf("invalid registry type: expected npm.Registry")}, nil}
+		}
+		tags, err := op(npmRegistry, info)
+		return &TagsResponse{toErrors(err), tags}
+	}
+
+	return toTagsResponse(base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo()))
+}
+
+func toTagsResponse(result response.Response) *TagsResponse {
+	tagsResponse, ok := result.(*TagsResponse)
+	if !ok {
+		return &TagsResponse{[]error{fmt.Errorf("invalid response type: expected TagsResponse")}, nil}
+	}
+	return tagsResponse
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// UpdatePackage applies the package document sent by the npm client on deprecate and unpublish.
+func (c *controller) UpdatePackage(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+	metadata npmtype.PackageMetadata,
+) *BaseResponse {
+	return c.modify(ctx, info, func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) error {
+		return npmRegistry.UpdatePackage(ctx, info, metadata)
+	})
+}
+
+func (c *controller) DeletePackage(ctx context.Context, info npmtype.ArtifactInfo) *BaseResponse {
+	return c.modify(ctx, info, func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) error {
+		return npmRegistry.DeletePackage(ctx, info)
+	})
+}
+
+func (c *controller) DeleteVersion(ctx context.Context, info npmtype.ArtifactInfo) *BaseResponse {
+	return c.modify(ctx, info, func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) error {
+		return npmRegistry.DeleteVersion(ctx, info)
+	})
+}
+
+// modify runs the operation against the registry of the request only.
+func (c *controller) modify(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+	op func(npmRegistry npm.Registry, info npmtype.ArtifactInfo) error,
+) *BaseResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		npmRegistry, ok := a.(npm.Registry)
+		if !ok {
+			return &BaseResponse{[]error{fmt.Errorf("invalid registry type: expected npm.Registry")}}
+		}
+		return &BaseResponse{toErrors(op(npmRegistry, info))}
+	}
+
+	result := base.NoProxyWrapper(ctx, c.registryDao, f, info.BaseArtifactInfo())
+	baseResponse, ok := result.(*BaseResponse)
+	if !ok {
+		return &BaseResponse{[]error{fmt.Errorf("invalid response type: expected BaseResponse")}}
+	}
+	return baseResponse
+}
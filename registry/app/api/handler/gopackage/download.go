@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	"github.com/harness/gitness/registry/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetModuleFile serves the files of the GOPROXY protocol: the list of versions, the latest version and the
+// .info, .mod and .zip files of a version.
+func (h *handler) GetModuleFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*gotype.ArtifactInfo)
+	if !ok || info.Filename == "" {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	switch {
+	case info.Filename == gotype.ListFile:
+		h.listVersions(w, r, *info)
+	case info.Filename == gotype.LatestFile:
+		response := h.controller.GetLatestVersion(ctx, *info)
+		if len(response.GetErrors()) > 0 {
+			h.HandleErrors(ctx, response.GetErrors(), w)
+			return
+		}
+		render.JSON(w, http.StatusOK, response.VersionInfo)
+	case strings.HasSuffix(info.Filename, gotype.InfoExtension):
+		response := h.controller.GetVersionInfo(ctx, *info)
+		if len(response.GetErrors()) > 0 {
+			h.HandleErrors(ctx, response.GetErrors(), w)
+			return
+		}
+		render.JSON(w, http.StatusOK, response.VersionInfo)
+	default:
+		h.downloadPackageFile(w, r, *info)
+	}
+}
+
+func (h *handler) listVersions(w http.ResponseWriter, r *http.Request, info gotype.ArtifactInfo) {
+	ctx := r.Context()
+	response := h.controller.ListVersions(ctx, info)
+	if len(response.GetErrors()) > 0 {
+		h.HandleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	for _, version := range response.Versions {
+		if _, err := fmt.Fprintln(w, version); err != nil {
+			log.Ctx(ctx).Error().Msgf("Failed to write versions: %v", err)
+			return
+		}
+	}
+}
+
+func (h *handler) downloadPackageFile(w http.ResponseWriter, r *http.Request, info gotype.ArtifactInfo) {
+	ctx := r.Context()
+	response := h.controller.DownloadPackageFile(ctx, info)
+	if response == nil {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to get response from controller")}, w)
+		return
+	}
+
+	defer func() {
+		if response.Body != nil {
+			err := response.Body.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+			}
+		}
+
+		if response.ReadCloser != nil {
+			err := response.ReadCloser.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close read closer: %v", err)
+			}
+		}
+	}()
+
+	if !commons.IsEmpty(response.GetErrors()) {
+		h.HandleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	if response.RedirectURL != "" {
+		http.Redirect(w, r, response.RedirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+
+	if strings.HasSuffix(info.Filename, gotype.ModExtension) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/zip")
+	}
+	response.ResponseHeaders.WriteToResponse(w)
+	err := commons.ServeContent(w, r, response.Body, info.Filename, response.ReadCloser)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Failed to serve content: %v", err)
+		h.HandleErrors(ctx, []error{err}, w)
+		return
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	gopkg "github.com/harness/gitness/registry/app/pkg/gopackage"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	GetModuleFile(writer http.ResponseWriter, request *http.Request)
+	UploadPackage(writer http.ResponseWriter, request *http.Request)
+	BuildFromRepository(writer http.ResponseWriter, request *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller gopackage.Controller
+}
+
+func NewHandler(
+	controller gopackage.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo reads the module path, the version and the requested file from the path, e.g.
+// <module>/@v/list, <module>/@v/<version>.info and <module>/@latest. The module paths and the versions are
+// escaped by the go command, see gotype.EscapePath.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if !commons.IsEmptyError(err) {
+		return nil, err
+	}
+
+	filePath := chi.URLParam(r, "*")
+	if filePath == "" {
+		return &gotype.ArtifactInfo{ArtifactInfo: info}, nil
+	}
+
+	module, version, filename, err2 := parseModuleFilePath(filePath)
+	if err2 != nil {
+		log.Info().Msgf("Invalid module path: %s", filePath)
+		return nil, err2
+	}
+	info.Image = module
+
+	return &gotype.ArtifactInfo{
+		ArtifactInfo: info,
+		Version:      version,
+		Filename:     filename,
+	}, nil
+}
+
+func parseModuleFilePath(filePath string) (module string, version string, filename string, err error) {
+	escapedModule, file, ok := strings.Cut(filePath, "/@v/")
+	if !ok {
+		escapedModule, ok = strings.CutSuffix(filePath, "/"+gotype.LatestFile)
+		if !ok {
+			return "", "", "", fmt.Errorf("invalid module file path: %s", filePath)
+		}
+		file = gotype.LatestFile
+	}
+
+	module, err = gotype.UnescapePath(escapedModule)
+	if err != nil {
+		return "", "", "", err
+	}
+	if err = gopkg.ValidateModulePath(module); err != nil {
+		return "", "", "", err
+	}
+
+	if file == gotype.ListFile || file == gotype.LatestFile {
+		return module, "", file, nil
+	}
+
+	extension := path.Ext(file)
+	if extension != gotype.InfoExtension && extension != gotype.ModExtension && extension != gotype.ZipExtension {
+		return "", "", "", fmt.Errorf("invalid module file: %s", file)
+	}
+
+	version, err = gotype.UnescapePath(strings.TrimSuffix(file, extension))
+	if err != nil {
+		return "", "", "", err
+	}
+	// versions with build metadata, i.e. +incompatible ones, are allowed to be fetched from upstream proxies.
+	if _, err = semver.StrictNewVersion(strings.TrimPrefix(version, "v")); err != nil ||
+		!strings.HasPrefix(version, "v") {
+		return "", "", "", fmt.Errorf("invalid version: %s", version)
+	}
+	return module, version, version + extension, nil
+}
+
+// handleErrors responds with the status of the upload and build errors, which are reported as errcode.Error.
+func (h *handler) handleErrors(ctx context.Context, errs []error, w http.ResponseWriter) {
+	var err errcode.Error
+	if len(errs) > 0 && errors.As(errs[0], &err) {
+		h.HandleErrors2(ctx, err, w)
+		return
+	}
+	h.HandleErrors(ctx, errs, w)
+}
+
+func invalidRequest(format string, args ...any) errcode.Error {
+	return errcode.ErrCodeInvalidRequest.WithMessage(fmt.Sprintf(format, args...))
+}
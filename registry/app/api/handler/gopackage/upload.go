@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	apirequest "github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	gopkg "github.com/harness/gitness/registry/app/pkg/gopackage"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	"github.com/harness/gitness/registry/request"
+)
+
+// UploadPackage handles PUT of the module zip of a new version, to <module>/@v/<version>.zip.
+func (h *handler) UploadPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*gotype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	if !strings.HasSuffix(info.Filename, gotype.ZipExtension) {
+		h.HandleErrors2(ctx, invalidRequest("only module zips can be uploaded, got %s", info.Filename), w)
+		return
+	}
+	if err := gopkg.ValidateVersion(info.Version); err != nil {
+		h.HandleErrors2(ctx, invalidRequest("%s", err), w)
+		return
+	}
+	if err := gopkg.CheckMajorVersion(info.Image, info.Version); err != nil {
+		h.HandleErrors2(ctx, invalidRequest("%s", err), w)
+		return
+	}
+
+	response := h.controller.UploadPackage(ctx, *info, r.Body)
+	if len(response.GetErrors()) > 0 {
+		h.handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	render.JSON(w, http.StatusCreated, gotype.ModuleVersion{
+		Module:  info.Image,
+		Version: info.Version,
+		Sha256:  response.Sha256,
+	})
+}
+
+// BuildFromRepository handles POST of a build request, to build a version of a module from a tag of a
+// repository in the space of the registry.
+func (h *handler) BuildFromRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*gotype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	var in gotype.BuildRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		h.HandleErrors2(ctx, invalidRequest("invalid build request: %s", err), w)
+		return
+	}
+
+	session, _ := apirequest.AuthSessionFrom(ctx)
+	response := h.controller.BuildFromRepository(ctx, session, *info, in)
+	if len(response.GetErrors()) > 0 {
+		h.handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	render.JSON(w, http.StatusCreated, response.Result)
+}
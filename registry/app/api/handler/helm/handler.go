@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/helm"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	helmpkg "github.com/harness/gitness/registry/app/pkg/helm"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	GetIndex(writer http.ResponseWriter, request *http.Request)
+	UploadChart(writer http.ResponseWriter, request *http.Request)
+	UploadProvenance(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(writer http.ResponseWriter, request *http.Request)
+	TrackDownloadStat(ctx context.Context) error
+}
+
+type handler struct {
+	packages.Handler
+	controller helm.Controller
+}
+
+func NewHandler(
+	controller helm.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo reads the chart name and version from the file name in the path. On upload, they are
+// read from the uploaded chart instead.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if !commons.IsEmptyError(err) {
+		return nil, err
+	}
+
+	var version string
+	filename := chi.URLParam(r, "filename")
+	if filename != "" {
+		var err2 error
+		info.Image, version, err2 = helmpkg.ParseFilename(filename)
+		if err2 != nil {
+			log.Info().Msgf("Invalid chart file name: %s", filename)
+			return nil, err2
+		}
+	}
+
+	return &helmtype.ArtifactInfo{
+		ArtifactInfo: info,
+		Filename:     filename,
+		Version:      version,
+	}, nil
+}
+
+// handleErrors responds with the status of the upload errors, which are reported as errcode.Error.
+func (h *handler) handleErrors(ctx context.Context, errs []error, w http.ResponseWriter) {
+	var err errcode.Error
+	if len(errs) > 0 && errors.As(errs[0], &err) {
+		h.HandleErrors2(ctx, err, w)
+		return
+	}
+	h.HandleErrors(ctx, errs, w)
+}
+
+func invalidRequest(format string, args ...any) errcode.Error {
+	return errcode.ErrCodeInvalidRequest.WithMessage(fmt.Sprintf(format, args...))
+}
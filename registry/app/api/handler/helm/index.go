@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	"github.com/harness/gitness/registry/request"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// GetIndex serves the index.yaml of the chart repository, read by "helm repo add" and "helm repo update".
+func (h *handler) GetIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*helmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	response := h.controller.GetIndex(ctx, *info)
+	if !commons.IsEmpty(response.GetErrors()) {
+		h.HandleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	content, err := yaml.Marshal(response.Index)
+	if err != nil {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to encode index: %w", err)}, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(content); err != nil {
+		log.Ctx(ctx).Error().Msgf("Failed to write index: %v", err)
+	}
+}
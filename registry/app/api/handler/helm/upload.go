@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	helmpkg "github.com/harness/gitness/registry/app/pkg/helm"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	"github.com/harness/gitness/registry/request"
+)
+
+const (
+	// maxChartSize limits the size of the uploaded charts, which are read in memory to be parsed.
+	maxChartSize = 20 << 20
+
+	chartField      = "chart"
+	provenanceField = "prov"
+)
+
+type savedResponse struct {
+	Saved bool `json:"saved"`
+}
+
+// UploadChart handles the upload of a chart archive, either as the request body or as the "chart" field of a
+// multipart form with an optional "prov" field for its provenance, like ChartMuseum does.
+func (h *handler) UploadChart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*helmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxChartSize)
+	chart, provenance, err := readFiles(r, chartField)
+	if err != nil {
+		h.HandleErrors2(ctx, invalidRequest("failed to read chart: %s", err), w)
+		return
+	}
+	if chart == nil {
+		h.HandleErrors2(ctx, invalidRequest("chart is missing"), w)
+		return
+	}
+
+	metadata, err := helmpkg.ParseChart(bytes.NewReader(chart))
+	if err != nil {
+		h.HandleErrors2(ctx, invalidRequest("invalid chart: %s", err), w)
+		return
+	}
+	digest := sha256.Sum256(chart)
+
+	info.Image = metadata.Name
+	info.Version = metadata.Version
+	info.Filename = helmtype.ChartFilename(metadata.Name, metadata.Version)
+	info.Metadata.ChartMetadata = *metadata
+	info.Metadata.Digest = hex.EncodeToString(digest[:])
+
+	response := h.controller.UploadChart(ctx, *info, io.NopCloser(bytes.NewReader(chart)))
+	if len(response.Errors) != 0 {
+		h.handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	if provenance != nil {
+		h.uploadProvenance(w, r, *info, provenance)
+		return
+	}
+	render.JSON(w, http.StatusCreated, savedResponse{Saved: true})
+}
+
+// UploadProvenance handles the upload of the provenance file of a chart version, either as the request body
+// or as the "prov" field of a multipart form.
+func (h *handler) UploadProvenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*helmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxChartSize)
+	provenance, _, err := readFiles(r, provenanceField)
+	if err != nil {
+		h.HandleErrors2(ctx, invalidRequest("failed to read provenance: %s", err), w)
+		return
+	}
+	if provenance == nil {
+		h.HandleErrors2(ctx, invalidRequest("provenance is missing"), w)
+		return
+	}
+	h.uploadProvenance(w, r, *info, provenance)
+}
+
+func (h *handler) uploadProvenance(
+	w http.ResponseWriter,
+	r *http.Request,
+	info helmtype.ArtifactInfo,
+	provenance []byte,
+) {
+	ctx := r.Context()
+	metadata, err := helmpkg.ParseProvenance(provenance)
+	if err != nil {
+		h.HandleErrors2(ctx, invalidRequest("invalid provenance: %s", err), w)
+		return
+	}
+	// the provenance uploaded along with a chart must be the one of the chart.
+	if info.Image == "" {
+		info.Image = metadata.Name
+		info.Version = metadata.Version
+	} else if metadata.Name != info.Image || metadata.Version != info.Version {
+		h.HandleErrors2(ctx, invalidRequest("provenance of chart %s version %s doesn't match chart %s version %s",
+			metadata.Name, metadata.Version, info.Image, info.Version), w)
+		return
+	}
+
+	info.Filename = helmtype.ChartFilename(info.Image, info.Version) + ".prov"
+	response := h.controller.UploadProvenance(ctx, info, io.NopCloser(bytes.NewReader(provenance)))
+	if len(response.Errors) != 0 {
+		h.handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	render.JSON(w, http.StatusCreated, savedResponse{Saved: true})
+}
+
+// readFiles reads the file of the given field and the provenance file of a multipart form, or the request body
+// when the request isn't a multipart form.
+func readFiles(r *http.Request, field string) ([]byte, []byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		content, err := io.ReadAll(r.Body)
+		if err != nil || len(content) == 0 {
+			return nil, nil, err
+		}
+		return content, nil, nil
+	}
+
+	if err := r.ParseMultipartForm(maxChartSize); err != nil {
+		return nil, nil, err
+	}
+	content, err := readFormFile(r, field)
+	if err != nil {
+		return nil, nil, err
+	}
+	if field == provenanceField {
+		return content, nil, nil
+	}
+	provenance, err := readFormFile(r, provenanceField)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, provenance, nil
+}
+
+func readFormFile(r *http.Request, field string) ([]byte, error) {
+	file, _, err := r.FormFile(field)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field %s: %w", field, err)
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/npm"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/request"
+)
+
+// DeletePackage unpublishes all the versions of the package, as in "npm unpublish <name> --force".
+func (h *handler) DeletePackage(w http.ResponseWriter, r *http.Request) {
+	h.handleDelete(w, r, func(info npmtype.ArtifactInfo) *npm.BaseResponse {
+		return h.controller.DeletePackage(r.Context(), info)
+	})
+}
+
+// DeleteVersion removes the tarball of the version, sent by the npm client on "npm unpublish <name>@<version>"
+// after the version was removed from the package document.
+func (h *handler) DeleteVersion(w http.ResponseWriter, r *http.Request) {
+	h.handleDelete(w, r, func(info npmtype.ArtifactInfo) *npm.BaseResponse {
+		return h.controller.DeleteVersion(r.Context(), info)
+	})
+}
+
+func (h *handler) handleDelete(
+	w http.ResponseWriter,
+	r *http.Request,
+	op func(info npmtype.ArtifactInfo) *npm.BaseResponse,
+) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*npmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	response := op(*info)
+	if len(response.GetErrors()) > 0 {
+		h.HandleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	render.JSON(w, http.StatusOK, okResponse{OK: true})
+}
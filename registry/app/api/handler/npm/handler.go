@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/npm"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+const maxNameLength = 214
+
+// https://github.com/npm/validate-npm-package-name
+var nameMatcher = regexp.MustCompile(`\A(?:@[a-z0-9\-*~][a-z0-9\-*._~]*/)?[a-z0-9\-~][a-z0-9\-._~]*\z`)
+
+// https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+var versionMatcher = regexp.MustCompile(`\A(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)` +
+	`(?:-(?:(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` + // pre-release
+	`(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?\z`) // build metadata
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	PublishPackage(writer http.ResponseWriter, request *http.Request)
+	UpdatePackage(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(writer http.ResponseWriter, request *http.Request)
+	PackageMetadata(writer http.ResponseWriter, request *http.Request)
+	DeletePackage(writer http.ResponseWriter, request *http.Request)
+	DeleteVersion(writer http.ResponseWriter, request *http.Request)
+	ListTags(writer http.ResponseWriter, request *http.Request)
+	AddTag(writer http.ResponseWriter, request *http.Request)
+	DeleteTag(writer http.ResponseWriter, request *http.Request)
+	Login(writer http.ResponseWriter, request *http.Request)
+	Whoami(writer http.ResponseWriter, request *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller npm.Controller
+}
+
+func NewHandler(
+	controller npm.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo reads the package name, the version and the dist-tag from the path.
+// The npm client escapes the slash of scoped packages (@scope%2fname), some clients don't (@scope/name),
+// so both forms are routed and the name is unescaped here.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if !commons.IsEmptyError(err) {
+		return nil, err
+	}
+
+	name, err2 := url.PathUnescape(chi.URLParam(r, "name"))
+	if err2 != nil {
+		return nil, fmt.Errorf("invalid package name: %w", err2)
+	}
+	if scope := chi.URLParam(r, "scope"); scope != "" {
+		name = "@" + scope + "/" + name
+	}
+
+	if name != "" && !isValidName(name) {
+		log.Info().Msgf("Invalid package name: %s", name)
+		return nil, fmt.Errorf("invalid package name: %s", name)
+	}
+
+	var version string
+	filename := chi.URLParam(r, "filename")
+	if filename != "" {
+		version = getVersion(name, filename)
+		if !versionMatcher.MatchString(version) {
+			log.Info().Msgf("Invalid tarball name: %s", filename)
+			return nil, fmt.Errorf("invalid tarball name: %s", filename)
+		}
+	}
+
+	tag, err2 := url.PathUnescape(chi.URLParam(r, "tag"))
+	if err2 != nil {
+		return nil, fmt.Errorf("invalid dist-tag: %w", err2)
+	}
+
+	info.Image = name
+
+	return &npmtype.ArtifactInfo{
+		ArtifactInfo: info,
+		Filename:     filename,
+		Version:      version,
+		DistTag:      tag,
+	}, nil
+}
+
+func isValidName(name string) bool {
+	return len(name) <= maxNameLength && nameMatcher.MatchString(name)
+}
+
+// getVersion extracts the version from the tarball name, e.g. "1.0.0" from "name-1.0.0.tgz" of "@scope/name".
+func getVersion(name string, filename string) string {
+	prefix := path.Base(name) + "-"
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, ".tgz") {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(filename, prefix), ".tgz")
+}
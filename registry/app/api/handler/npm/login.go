@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Login handles "npm login --auth-type=legacy". The password must be a personal access token,
+// it's validated and returned as the token the npm client sends with the following requests.
+func (h *handler) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var in npmtype.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil || in.Name == "" || in.Password == "" {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("name and password are required"), w)
+		return
+	}
+
+	// the authenticator accepts the token as the password of the basic auth.
+	tokenRequest := r.Clone(ctx)
+	tokenRequest.URL.RawQuery = ""
+	tokenRequest.Header.Del("Cookie")
+	tokenRequest.SetBasicAuth(in.Name, in.Password)
+
+	session, err := h.GetAuthenticator().Authenticate(tokenRequest)
+	if err != nil || session == nil || auth.IsAnonymousSession(session) {
+		log.Ctx(ctx).Info().Err(err).Msgf("npm login failed for user: %s", in.Name)
+		render.Unauthorized(ctx, w)
+		return
+	}
+
+	render.JSON(w, http.StatusCreated, npmtype.LoginResponse{
+		OK:    true,
+		ID:    "org.couchdb.user:" + session.Principal.UID,
+		Token: in.Password,
+	})
+}
+
+func (h *handler) Whoami(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session, ok := request.AuthSessionFrom(ctx)
+	if !ok || auth.IsAnonymousSession(session) {
+		render.Unauthorized(ctx, w)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, npmtype.WhoamiResponse{Username: session.Principal.UID})
+}
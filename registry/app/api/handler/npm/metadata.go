@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/request"
+)
+
+// PackageMetadata returns the package document with all the versions of the package, used by the npm client
+// to resolve the version to install.
+func (h *handler) PackageMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*npmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	response := h.controller.GetPackageMetadata(ctx, *info)
+	if len(response.GetErrors()) > 0 {
+		h.HandleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	render.JSON(w, http.StatusOK, response.PackageMetadata)
+}
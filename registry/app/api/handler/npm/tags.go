@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/npm"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/request"
+)
+
+func (h *handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	h.handleTags(w, r, func(info npmtype.ArtifactInfo) *npm.TagsResponse {
+		return h.controller.ListTags(r.Context(), info)
+	})
+}
+
+// AddTag points the dist-tag to the version sent as a JSON string in the body, as in "npm dist-tag add".
+func (h *handler) AddTag(w http.ResponseWriter, r *http.Request) {
+	var version string
+	if err := json.NewDecoder(r.Body).Decode(&version); err != nil || !versionMatcher.MatchString(version) {
+		h.HandleErrors2(r.Context(), errcode.ErrCodeInvalidRequest.WithMessage(
+			fmt.Sprintf("invalid version for dist-tag: %q", version)), w)
+		return
+	}
+
+	h.handleTags(w, r, func(info npmtype.ArtifactInfo) *npm.TagsResponse {
+		info.Version = version
+		return h.controller.AddTag(r.Context(), info)
+	})
+}
+
+func (h *handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	h.handleTags(w, r, func(info npmtype.ArtifactInfo) *npm.TagsResponse {
+		return h.controller.DeleteTag(r.Context(), info)
+	})
+}
+
+func (h *handler) handleTags(
+	w http.ResponseWriter,
+	r *http.Request,
+	op func(info npmtype.ArtifactInfo) *npm.TagsResponse,
+) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*npmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	response := op(*info)
+	if len(response.GetErrors()) > 0 {
+		h.HandleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	render.JSON(w, http.StatusOK, response.DistTags)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/request"
+	"github.com/harness/gitness/types/enum"
+)
+
+const defaultDistTag = "latest"
+
+type okResponse struct {
+	OK bool `json:"ok"`
+}
+
+// PublishPackage handles PUT of the package document. The npm client sends the document with the tarball
+// attached on publish and without attachments on deprecate.
+func (h *handler) PublishPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*npmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	metadata, err := h.readPackageMetadata(r, *info)
+	if err != nil {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage(err.Error()), w)
+		return
+	}
+
+	if len(metadata.Attachments) == 0 {
+		// the versions missing in the document are removed on update.
+		if err = h.GetRegistryCheckAccess(ctx, r, enum.PermissionArtifactsDelete); err != nil {
+			render.Forbiddenf(ctx, w, "permission %s is required to update the package", enum.PermissionArtifactsDelete)
+			return
+		}
+		h.updatePackage(w, r, *info, metadata)
+		return
+	}
+
+	file, err := getTarball(info, metadata)
+	if err != nil {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage(err.Error()), w)
+		return
+	}
+
+	response := h.controller.UploadPackageFile(ctx, *info, file)
+	if len(response.Errors) == 0 {
+		render.JSON(w, http.StatusCreated, okResponse{OK: true})
+		return
+	}
+	h.HandleErrors(ctx, response.GetErrors(), w)
+}
+
+// UpdatePackage handles PUT of the package document with a revision, sent by the npm client on unpublish
+// of a single version and on deprecate.
+func (h *handler) UpdatePackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*npmtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	metadata, err := h.readPackageMetadata(r, *info)
+	if err != nil {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage(err.Error()), w)
+		return
+	}
+	h.updatePackage(w, r, *info, metadata)
+}
+
+func (h *handler) updatePackage(
+	w http.ResponseWriter,
+	r *http.Request,
+	info npmtype.ArtifactInfo,
+	metadata npmtype.PackageMetadata,
+) {
+	response := h.controller.UpdatePackage(r.Context(), info, metadata)
+	if len(response.Errors) == 0 {
+		render.JSON(w, http.StatusOK, okResponse{OK: true})
+		return
+	}
+	h.HandleErrors(r.Context(), response.GetErrors(), w)
+}
+
+func (h *handler) readPackageMetadata(r *http.Request, info npmtype.ArtifactInfo) (npmtype.PackageMetadata, error) {
+	var metadata npmtype.PackageMetadata
+	if err := json.NewDecoder(r.Body).Decode(&metadata); err != nil {
+		return npmtype.PackageMetadata{}, fmt.Errorf("failed to parse package document: %w", err)
+	}
+
+	if metadata.Name != info.Image {
+		return npmtype.PackageMetadata{}, fmt.Errorf("package name %q doesn't match the path %q",
+			metadata.Name, info.Image)
+	}
+	return metadata, nil
+}
+
+// getTarball fills the info with the version being published and returns its decoded tarball.
+// The npm client publishes a single version at a time.
+func getTarball(info *npmtype.ArtifactInfo, metadata npmtype.PackageMetadata) (io.ReadCloser, error) {
+	if len(metadata.Versions) != 1 {
+		return nil, fmt.Errorf("exactly one version must be published, got %d", len(metadata.Versions))
+	}
+
+	for version, versionMetadata := range metadata.Versions {
+		if !versionMatcher.MatchString(version) || versionMetadata.Version() != version {
+			return nil, fmt.Errorf("invalid version: %s", version)
+		}
+		info.Version = version
+		info.Metadata = versionMetadata
+	}
+
+	info.Filename = npmtype.TarballName(info.Image, info.Version)
+	// the npm client names the attachment after the full name of the package, scope included.
+	attachment, ok := metadata.Attachments[info.Image+"-"+info.Version+".tgz"]
+	if !ok {
+		attachment, ok = metadata.Attachments[info.Filename]
+	}
+	if !ok || attachment == nil {
+		return nil, fmt.Errorf("tarball %s is missing", info.Filename)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(attachment.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tarball %s: %w", info.Filename, err)
+	}
+
+	info.DistTag = defaultDistTag
+	for tag, version := range metadata.DistTags {
+		if version == info.Version {
+			info.DistTag = tag
+			break
+		}
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
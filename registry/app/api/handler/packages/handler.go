@@ -87,12 +87,18 @@ const (
 	PathPackageTypeGeneric PathPackageType = "generic"
 	PathPackageTypeMaven   PathPackageType = "maven"
 	PathPackageTypePython  PathPackageType = "python"
+	PathPackageTypeNpm     PathPackageType = "npm"
+	PathPackageTypeHelm    PathPackageType = "helm"
+	PathPackageTypeGo      PathPackageType = "go"
 )
 
 var packageTypeMap = map[PathPackageType]artifact2.PackageType{
 	PathPackageTypeGeneric: artifact2.PackageTypeGENERIC,
 	PathPackageTypeMaven:   artifact2.PackageTypeMAVEN,
 	PathPackageTypePython:  artifact2.PackageTypePYTHON,
+	PathPackageTypeNpm:     artifact2.PackageTypeNPM,
+	PathPackageTypeHelm:    artifact2.PackageTypeHELM,
+	PathPackageTypeGo:      artifact2.PackageTypeGO,
 }
 
 func (h *handler) GetAuthenticator() authn.Authenticator {
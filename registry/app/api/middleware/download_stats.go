@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/harness/gitness/registry/app/api/handler/generic"
+	"github.com/harness/gitness/registry/app/api/handler/helm"
 	"github.com/harness/gitness/registry/app/api/handler/maven"
 	"github.com/harness/gitness/registry/app/api/handler/oci"
 	"github.com/harness/gitness/registry/app/api/router/utils"
@@ -30,6 +32,7 @@ import (
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
 	maven2 "github.com/harness/gitness/registry/app/pkg/maven"
 	mavenutils "github.com/harness/gitness/registry/app/pkg/maven/utils"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
 	"github.com/harness/gitness/registry/types"
 	"github.com/harness/gitness/store"
 
@@ -200,6 +203,36 @@ func TrackDownloadStatForMavenArtifact(h *maven.Handler) func(http.Handler) http
 	}
 }
 
+// TrackDownloadStatForHelmArtifact records the downloads of chart archives, it must be used after
+// StoreArtifactInfo. The downloads of the provenance files are not recorded.
+func TrackDownloadStatForHelmArtifact(h helm.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				sw := &StatusWriter{ResponseWriter: w}
+
+				if http.MethodGet == r.Method && strings.HasSuffix(r.URL.Path, helmtype.ChartExtension) {
+					next.ServeHTTP(sw, r)
+				} else {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				if sw.StatusCode != http.StatusOK && sw.StatusCode != http.StatusTemporaryRedirect {
+					return
+				}
+
+				if err := h.TrackDownloadStat(ctx); err != nil {
+					log.Ctx(ctx).Error().Stack().Str("middleware",
+						"TrackDownloadStat").Err(err).Msgf("error while putting download stat of artifact, %v",
+						err)
+				}
+			},
+		)
+	}
+}
+
 func dbDownloadStatForGenericArtifact(
 	ctx context.Context,
 	c *generic2.Controller,
@@ -89,6 +89,9 @@ type ServerInterface interface {
 	// List Artifacts for Registry
 	// (GET /registry/{registry_ref}/artifacts)
 	GetAllArtifactsByRegistry(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetAllArtifactsByRegistryParams)
+	// Returns Cleanup Policy Dry Run Report
+	// (GET /registry/{registry_ref}/cleanup-policies/dry-run)
+	GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam)
 	// Returns CLI Client Setup Details
 	// (GET /registry/{registry_ref}/client-setup-details)
 	GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams)
@@ -263,6 +266,12 @@ func (_ Unimplemented) GetAllArtifactsByRegistry(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Returns Cleanup Policy Dry Run Report
+// (GET /registry/{registry_ref}/cleanup-policies/dry-run)
+func (_ Unimplemented) GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Returns CLI Client Setup Details
 // (GET /registry/{registry_ref}/client-setup-details)
 func (_ Unimplemented) GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams) {
@@ -1388,6 +1397,31 @@ func (siw *ServerInterfaceWrapper) GetAllArtifactsByRegistry(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
+// GetCleanupPolicyDryRun operation middleware
+func (siw *ServerInterfaceWrapper) GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "registry_ref", chi.URLParam(r, "registry_ref"), &registryRef, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCleanupPolicyDryRun(w, r, registryRef)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetClientSetupDetails operation middleware
 func (siw *ServerInterfaceWrapper) GetClientSetupDetails(w http.ResponseWriter, r *http.Request) {
 
@@ -2173,6 +2207,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifacts", wrapper.GetAllArtifactsByRegistry)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/registry/{registry_ref}/cleanup-policies/dry-run", wrapper.GetCleanupPolicyDryRun)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/client-setup-details", wrapper.GetClientSetupDetails)
 	})
@@ -2255,6 +2292,14 @@ type ArtifactVersionSummaryResponseJSONResponse struct {
 
 type BadRequestJSONResponse Error
 
+type CleanupPolicyDryRunResponseJSONResponse struct {
+	// Data Cleanup Policy Dry Run Report
+	Data CleanupPolicyDryRun `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 type ClientSetupDetailsResponseJSONResponse struct {
 	// Data Client Setup Details
 	Data ClientSetupDetails `json:"data"`
@@ -3891,6 +3936,72 @@ func (response GetAllArtifactsByRegistry500JSONResponse) VisitGetAllArtifactsByR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetCleanupPolicyDryRunRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+}
+
+type GetCleanupPolicyDryRunResponseObject interface {
+	VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error
+}
+
+type GetCleanupPolicyDryRun200JSONResponse struct {
+	CleanupPolicyDryRunResponseJSONResponse
+}
+
+func (response GetCleanupPolicyDryRun200JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetCleanupPolicyDryRun400JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response GetCleanupPolicyDryRun401JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetCleanupPolicyDryRun403JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetCleanupPolicyDryRun404JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetCleanupPolicyDryRun500JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetClientSetupDetailsRequestObject struct {
 	RegistryRef RegistryRefPathParam `json:"registry_ref"`
 	Params      GetClientSetupDetailsParams
@@ -4696,6 +4807,9 @@ type StrictServerInterface interface {
 	// List Artifacts for Registry
 	// (GET /registry/{registry_ref}/artifacts)
 	GetAllArtifactsByRegistry(ctx context.Context, request GetAllArtifactsByRegistryRequestObject) (GetAllArtifactsByRegistryResponseObject, error)
+	// Returns Cleanup Policy Dry Run Report
+	// (GET /registry/{registry_ref}/cleanup-policies/dry-run)
+	GetCleanupPolicyDryRun(ctx context.Context, request GetCleanupPolicyDryRunRequestObject) (GetCleanupPolicyDryRunResponseObject, error)
 	// Returns CLI Client Setup Details
 	// (GET /registry/{registry_ref}/client-setup-details)
 	GetClientSetupDetails(ctx context.Context, request GetClientSetupDetailsRequestObject) (GetClientSetupDetailsResponseObject, error)
@@ -5391,6 +5505,32 @@ func (sh *strictHandler) GetAllArtifactsByRegistry(w http.ResponseWriter, r *htt
 	}
 }
 
+// GetCleanupPolicyDryRun operation middleware
+func (sh *strictHandler) GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	var request GetCleanupPolicyDryRunRequestObject
+
+	request.RegistryRef = registryRef
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCleanupPolicyDryRun(ctx, request.(GetCleanupPolicyDryRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCleanupPolicyDryRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCleanupPolicyDryRunResponseObject); ok {
+		if err := validResponse.VisitGetCleanupPolicyDryRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetClientSetupDetails operation middleware
 func (sh *strictHandler) GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams) {
 	var request GetClientSetupDetailsRequestObject
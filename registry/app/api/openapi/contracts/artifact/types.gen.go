@@ -28,6 +28,7 @@ const (
 const (
 	PackageTypeDOCKER  PackageType = "DOCKER"
 	PackageTypeGENERIC PackageType = "GENERIC"
+	PackageTypeGO      PackageType = "GO"
 	PackageTypeHELM    PackageType = "HELM"
 	PackageTypeMAVEN   PackageType = "MAVEN"
 	PackageTypeNPM     PackageType = "NPM"
@@ -64,7 +65,9 @@ const (
 	UpstreamConfigSourceAwsEcr       UpstreamConfigSource = "AwsEcr"
 	UpstreamConfigSourceCustom       UpstreamConfigSource = "Custom"
 	UpstreamConfigSourceDockerhub    UpstreamConfigSource = "Dockerhub"
+	UpstreamConfigSourceGoProxy      UpstreamConfigSource = "GoProxy"
 	UpstreamConfigSourceMavenCentral UpstreamConfigSource = "MavenCentral"
+	UpstreamConfigSourceNpmJs        UpstreamConfigSource = "NpmJs"
 	UpstreamConfigSourcePyPi         UpstreamConfigSource = "PyPi"
 )
 
@@ -186,10 +189,26 @@ type AuthType string
 
 // CleanupPolicy Cleanup Policy for Harness Artifact Registries
 type CleanupPolicy struct {
-	ExpireDays    *int      `json:"expireDays,omitempty"`
-	Name          *string   `json:"name,omitempty"`
-	PackagePrefix *[]string `json:"packagePrefix,omitempty"`
-	VersionPrefix *[]string `json:"versionPrefix,omitempty"`
+	ExpireDays       *int      `json:"expireDays,omitempty"`
+	KeepLastVersions *int      `json:"keepLastVersions,omitempty"`
+	Name             *string   `json:"name,omitempty"`
+	PackagePrefix    *[]string `json:"packagePrefix,omitempty"`
+	VersionPrefix    *[]string `json:"versionPrefix,omitempty"`
+}
+
+// CleanupPolicyDryRun Cleanup Policy Dry Run Report
+type CleanupPolicyDryRun struct {
+	Items []CleanupPolicyDryRunItem `json:"items"`
+}
+
+// CleanupPolicyDryRunItem Artifact version which would be deleted by a cleanup policy
+type CleanupPolicyDryRunItem struct {
+	Digest       *string `json:"digest,omitempty"`
+	LastModified string  `json:"lastModified"`
+	Package      string  `json:"package"`
+	PolicyName   string  `json:"policyName"`
+	Reason       string  `json:"reason"`
+	Version      string  `json:"version"`
 }
 
 // ClientSetupDetails Client Setup Details
@@ -319,6 +338,11 @@ type GenericArtifactDetailConfig struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// GoArtifactDetailConfig Config for go module artifact details
+type GoArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // HelmArtifactDetail Helm Artifact Detail
 type HelmArtifactDetail struct {
 	Artifact       *string `json:"artifact,omitempty"`
@@ -817,6 +841,15 @@ type ArtifactVersionSummaryResponse struct {
 // BadRequest defines model for BadRequest.
 type BadRequest Error
 
+// CleanupPolicyDryRunResponse defines model for CleanupPolicyDryRunResponse.
+type CleanupPolicyDryRunResponse struct {
+	// Data Cleanup Policy Dry Run Report
+	Data CleanupPolicyDryRun `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 // ClientSetupDetailsResponse defines model for ClientSetupDetailsResponse.
 type ClientSetupDetailsResponse struct {
 	// Data Client Setup Details
@@ -1423,6 +1456,36 @@ func (t *ArtifactDetail) MergeNpmArtifactDetailConfig(v NpmArtifactDetailConfig)
 	return err
 }
 
+// AsGoArtifactDetailConfig returns the union data inside the ArtifactDetail as a GoArtifactDetailConfig
+func (t ArtifactDetail) AsGoArtifactDetailConfig() (GoArtifactDetailConfig, error) {
+	var body GoArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGoArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided GoArtifactDetailConfig
+func (t *ArtifactDetail) FromGoArtifactDetailConfig(v GoArtifactDetailConfig) error {
+	t.PackageType = "GO"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGoArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided GoArtifactDetailConfig
+func (t *ArtifactDetail) MergeGoArtifactDetailConfig(v GoArtifactDetailConfig) error {
+	t.PackageType = "GO"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t ArtifactDetail) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"packageType"`
@@ -1441,6 +1504,8 @@ func (t ArtifactDetail) ValueByDiscriminator() (interface{}, error) {
 		return t.AsDockerArtifactDetailConfig()
 	case "GENERIC":
 		return t.AsGenericArtifactDetailConfig()
+	case "GO":
+		return t.AsGoArtifactDetailConfig()
 	case "HELM":
 		return t.AsHelmArtifactDetailConfig()
 	case "MAVEN":
@@ -32,6 +32,7 @@ import (
 	registryevents "github.com/harness/gitness/registry/app/events"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/services/cleanuppolicy"
 	registrywebhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -76,6 +77,7 @@ func NewAPIHandler(
 	webhookService registrywebhook.Service,
 	spacePathStore corestore.SpacePathStore,
 	artifactEventReporter registryevents.Reporter,
+	cleanupPolicyService *cleanuppolicy.Service,
 ) APIHandler {
 	r := chi.NewRouter()
 	r.Use(audit.Middleware())
@@ -104,6 +106,7 @@ func NewAPIHandler(
 		registryMetadataHelper,
 		&webhookService,
 		artifactEventReporter,
+		cleanupPolicyService,
 	)
 
 	handler := artifact.NewStrictHandler(apiController, []artifact.StrictMiddlewareFunc{})
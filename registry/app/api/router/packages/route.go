@@ -19,7 +19,10 @@ import (
 
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
+	"github.com/harness/gitness/registry/app/api/handler/gopackage"
+	"github.com/harness/gitness/registry/app/api/handler/helm"
 	"github.com/harness/gitness/registry/app/api/handler/maven"
+	"github.com/harness/gitness/registry/app/api/handler/npm"
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	"github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/middleware"
@@ -43,6 +46,9 @@ func NewRouter(
 	mavenHandler *maven.Handler,
 	genericHandler *generic.Handler,
 	pythonHandler python.Handler,
+	npmHandler npm.Handler,
+	helmHandler helm.Handler,
+	goHandler gopackage.Handler,
 ) Handler {
 	r := chi.NewRouter()
 
@@ -86,6 +92,83 @@ func NewRouter(
 				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
 				Get("/simple/{image}/", pythonHandler.PackageMetadata)
 		})
+
+		r.Route("/npm", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+
+			r.With(middleware.StoreArtifactInfo(npmHandler)).
+				Put("/-/user/org.couchdb.user:{username}", npmHandler.Login)
+			r.With(middleware.StoreArtifactInfo(npmHandler)).
+				Get("/-/whoami", npmHandler.Whoami)
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.StoreArtifactInfo(npmHandler))
+
+				for _, prefix := range []string{"/-/package/{name}", "/-/package/@{scope}/{name}"} {
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+						Get(prefix+"/dist-tags", npmHandler.ListTags)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+						Put(prefix+"/dist-tags/{tag}", npmHandler.AddTag)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+						Delete(prefix+"/dist-tags/{tag}", npmHandler.DeleteTag)
+				}
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.StoreArtifactInfo(npmHandler))
+
+				// scoped packages are requested as /@scope%2fname by the npm client and as /@scope/name by others.
+				for _, prefix := range []string{"/{name}", "/@{scope}/{name}"} {
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+						Get(prefix, npmHandler.PackageMetadata)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+						Get(prefix+"/-/{filename}", npmHandler.DownloadPackageFile)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+						Put(prefix, npmHandler.PublishPackage)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload,
+						enum.PermissionArtifactsDelete)).
+						Put(prefix+"/-rev/{rev}", npmHandler.UpdatePackage)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDelete)).
+						Delete(prefix+"/-rev/{rev}", npmHandler.DeletePackage)
+					r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDelete)).
+						Delete(prefix+"/-/{filename}/-rev/{rev}", npmHandler.DeleteVersion)
+				}
+			})
+		})
+
+		r.Route("/helm", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.StoreArtifactInfo(helmHandler))
+
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/index.yaml", helmHandler.GetIndex)
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					With(middleware.TrackDownloadStatForHelmArtifact(helmHandler)).
+					Get("/charts/{filename}", helmHandler.DownloadPackageFile)
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+					Post("/api/charts", helmHandler.UploadChart)
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+					Post("/api/prov", helmHandler.UploadProvenance)
+			})
+		})
+
+		r.Route("/go", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.StoreArtifactInfo(goHandler))
+
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+					Post("/-/build", goHandler.BuildFromRepository)
+				// the GOPROXY protocol: <module>/@v/list, <module>/@v/<version>.{info,mod,zip} and <module>/@latest.
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/*", goHandler.GetModuleFile)
+				r.With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+					Put("/*", goHandler.UploadPackage)
+			})
+		})
 	})
 
 	return r
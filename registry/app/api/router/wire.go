@@ -23,7 +23,10 @@ import (
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
+	"github.com/harness/gitness/registry/app/api/handler/gopackage"
+	"github.com/harness/gitness/registry/app/api/handler/helm"
 	"github.com/harness/gitness/registry/app/api/handler/maven"
+	"github.com/harness/gitness/registry/app/api/handler/npm"
 	hoci "github.com/harness/gitness/registry/app/api/handler/oci"
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	"github.com/harness/gitness/registry/app/api/handler/python"
@@ -36,6 +39,7 @@ import (
 	registryevents "github.com/harness/gitness/registry/app/events"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/services/cleanuppolicy"
 	registrywebhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -73,6 +77,7 @@ func APIHandlerProvider(
 	webhookService *registrywebhook.Service,
 	spacePathStore corestore.SpacePathStore,
 	artifactEventReporter *registryevents.Reporter,
+	cleanupPolicyService *cleanuppolicy.Service,
 ) harness.APIHandler {
 	return harness.NewAPIHandler(
 		repoDao,
@@ -96,6 +101,7 @@ func APIHandlerProvider(
 		*webhookService,
 		spacePathStore,
 		*artifactEventReporter,
+		cleanupPolicyService,
 	)
 }
 
@@ -116,8 +122,12 @@ func PackageHandlerProvider(
 	mavenHandler *maven.Handler,
 	genericHandler *generic.Handler,
 	pypiHandler python.Handler,
+	npmHandler npm.Handler,
+	helmHandler helm.Handler,
+	goHandler gopackage.Handler,
 ) packagerrouter.Handler {
-	return packagerrouter.NewRouter(handler, mavenHandler, genericHandler, pypiHandler)
+	return packagerrouter.NewRouter(handler, mavenHandler, genericHandler, pypiHandler, npmHandler, helmHandler,
+		goHandler)
 }
 
 var WireSet = wire.NewSet(APIHandlerProvider, OCIHandlerProvider, AppRouterProvider,
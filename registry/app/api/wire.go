@@ -21,9 +21,15 @@ import (
 	"github.com/harness/gitness/app/services/refcache"
 	corestore "github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
+	gopackage2 "github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
+	helm2 "github.com/harness/gitness/registry/app/api/controller/pkg/helm"
+	npm2 "github.com/harness/gitness/registry/app/api/controller/pkg/npm"
 	python2 "github.com/harness/gitness/registry/app/api/controller/pkg/python"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
+	gohandler "github.com/harness/gitness/registry/app/api/handler/gopackage"
+	helmhandler "github.com/harness/gitness/registry/app/api/handler/helm"
 	mavenhandler "github.com/harness/gitness/registry/app/api/handler/maven"
+	npmhandler "github.com/harness/gitness/registry/app/api/handler/npm"
 	ocihandler "github.com/harness/gitness/registry/app/api/handler/oci"
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	pypi2 "github.com/harness/gitness/registry/app/api/handler/python"
@@ -37,7 +43,10 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
+	"github.com/harness/gitness/registry/app/pkg/gopackage"
+	"github.com/harness/gitness/registry/app/pkg/helm"
 	"github.com/harness/gitness/registry/app/pkg/maven"
+	"github.com/harness/gitness/registry/app/pkg/npm"
 	"github.com/harness/gitness/registry/app/pkg/python"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/app/store/database"
@@ -133,6 +142,27 @@ func NewPythonHandlerProvider(
 	return pypi2.NewHandler(controller, packageHandler)
 }
 
+func NewNpmHandlerProvider(
+	controller npm2.Controller,
+	packageHandler packages.Handler,
+) npmhandler.Handler {
+	return npmhandler.NewHandler(controller, packageHandler)
+}
+
+func NewHelmHandlerProvider(
+	controller helm2.Controller,
+	packageHandler packages.Handler,
+) helmhandler.Handler {
+	return helmhandler.NewHandler(controller, packageHandler)
+}
+
+func NewGoHandlerProvider(
+	controller gopackage2.Controller,
+	packageHandler packages.Handler,
+) gohandler.Handler {
+	return gohandler.NewHandler(controller, packageHandler)
+}
+
 func NewGenericHandlerProvider(
 	spaceStore corestore.SpaceStore, controller *generic2.Controller, tokenStore corestore.TokenStore,
 	userCtrl *usercontroller.Controller, authenticator authn.Authenticator, urlProvider urlprovider.Provider,
@@ -156,16 +186,25 @@ var WireSet = wire.NewSet(
 	NewGenericHandlerProvider,
 	NewPackageHandlerProvider,
 	NewPythonHandlerProvider,
+	NewNpmHandlerProvider,
+	NewHelmHandlerProvider,
+	NewGoHandlerProvider,
 	database.WireSet,
 	pkg.WireSet,
 	docker.WireSet,
 	filemanager.WireSet,
 	maven.WireSet,
 	python.WireSet,
+	npm.WireSet,
+	helm.WireSet,
+	gopackage.WireSet,
 	router.WireSet,
 	gc.WireSet,
 	generic2.WireSet,
 	python2.ControllerSet,
+	npm2.ControllerSet,
+	helm2.ControllerSet,
+	gopackage2.ControllerSet,
 	base.WireSet,
 )
 
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"time"
+
+	"github.com/harness/gitness/registry/app/metadata"
+)
+
+var _ metadata.Metadata = (*GoMetadata)(nil)
+
+// VersionInfo is the .info document of a module version served by the GOPROXY protocol.
+// Source: https://go.dev/ref/mod#goproxy-protocol
+type VersionInfo struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+	Origin  *Origin   `json:"Origin,omitempty"`
+}
+
+// Origin describes the source of a module version built from a repository.
+type Origin struct {
+	VCS  string `json:"VCS,omitempty"`
+	URL  string `json:"URL,omitempty"`
+	Ref  string `json:"Ref,omitempty"`
+	Hash string `json:"Hash,omitempty"`
+}
+
+// GoMetadata represents the metadata stored for a version of a module.
+//
+//nolint:revive
+type GoMetadata struct {
+	VersionInfo
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+}
+
+func (p *GoMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *GoMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*HelmMetadata)(nil)
+
+// ChartMetadata is the content of the Chart.yaml file of a chart.
+// Source: https://helm.sh/docs/topics/charts/#the-chartyaml-file
+type ChartMetadata struct {
+	APIVersion   string            `json:"apiVersion" yaml:"apiVersion"`
+	Name         string            `json:"name" yaml:"name"`
+	Version      string            `json:"version" yaml:"version"`
+	KubeVersion  string            `json:"kubeVersion,omitempty" yaml:"kubeVersion,omitempty"`
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Type         string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Keywords     []string          `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	Home         string            `json:"home,omitempty" yaml:"home,omitempty"`
+	Sources      []string          `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Dependencies []*Dependency     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Maintainers  []*Maintainer     `json:"maintainers,omitempty" yaml:"maintainers,omitempty"`
+	Icon         string            `json:"icon,omitempty" yaml:"icon,omitempty"`
+	AppVersion   string            `json:"appVersion,omitempty" yaml:"appVersion,omitempty"`
+	Deprecated   bool              `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+type Dependency struct {
+	Name         string        `json:"name" yaml:"name"`
+	Version      string        `json:"version,omitempty" yaml:"version,omitempty"`
+	Repository   string        `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Condition    string        `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Tags         []string      `json:"tags,omitempty" yaml:"tags,omitempty"`
+	ImportValues []interface{} `json:"import-values,omitempty" yaml:"import-values,omitempty"`
+	Alias        string        `json:"alias,omitempty" yaml:"alias,omitempty"`
+}
+
+type Maintainer struct {
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// HelmMetadata represents the metadata stored for a version of a chart uploaded to a chart repository.
+//
+//nolint:revive
+type HelmMetadata struct {
+	ChartMetadata
+	// Digest is the sha256 of the chart archive, served in the index of the repository.
+	Digest    string          `json:"digest"`
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+}
+
+func (p *HelmMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *HelmMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
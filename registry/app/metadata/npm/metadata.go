@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*NpmMetadata)(nil)
+
+// PackageMetadata is the manifest of a single version of an npm package.
+// It's the package.json of the version extended with the fields added by the npm client (dist, _id, ...),
+// so it's kept as a generic map to preserve all the fields required by the clients on install.
+// Source: https://github.com/npm/registry/blob/main/docs/REGISTRY-API.md#version
+type PackageMetadata map[string]interface{}
+
+func (m PackageMetadata) Name() string {
+	return m.getString("name")
+}
+
+func (m PackageMetadata) Version() string {
+	return m.getString("version")
+}
+
+func (m PackageMetadata) Description() string {
+	return m.getString("description")
+}
+
+// Deprecated returns the deprecation message of the version, it's empty if the version isn't deprecated.
+func (m PackageMetadata) Deprecated() string {
+	return m.getString("deprecated")
+}
+
+// SetDeprecated sets the deprecation message of the version, an empty message un-deprecates the version.
+func (m PackageMetadata) SetDeprecated(message string) {
+	if message == "" {
+		delete(m, "deprecated")
+		return
+	}
+	m["deprecated"] = message
+}
+
+// SetTarball sets the download URL of the version's tarball.
+func (m PackageMetadata) SetTarball(url string) {
+	dist, ok := m["dist"].(map[string]interface{})
+	if !ok {
+		dist = map[string]interface{}{}
+		m["dist"] = dist
+	}
+	dist["tarball"] = url
+}
+
+// Tarball returns the download URL of the version's tarball.
+func (m PackageMetadata) Tarball() string {
+	dist, ok := m["dist"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	tarball, _ := dist["tarball"].(string)
+	return tarball
+}
+
+func (m PackageMetadata) getString(key string) string {
+	value, _ := m[key].(string)
+	return value
+}
+
+// NpmMetadata represents the metadata stored for a version of an npm package.
+//
+//nolint:revive
+type NpmMetadata struct {
+	PackageMetadata PackageMetadata `json:"package_metadata"`
+	// DistTags are the distribution tags (e.g. "latest") that point to the version.
+	DistTags  []string        `json:"dist_tags,omitempty"`
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+}
+
+func (p *NpmMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *NpmMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,422 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	gitnesstypes "github.com/harness/gitness/types"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
+)
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase   base.LocalBase
+	fileManager filemanager.FileManager
+	proxyStore  store.UpstreamProxyConfigRepository
+	tx          dbtx.Transactor
+	registryDao store.RegistryRepository
+	imageDao    store.ImageRepository
+	artifactDao store.ArtifactRepository
+	urlProvider urlprovider.Provider
+	git         git.Interface
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+	git git.Interface,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:   localBase,
+		fileManager: fileManager,
+		proxyStore:  proxyStore,
+		tx:          tx,
+		registryDao: registryDao,
+		imageDao:    imageDao,
+		artifactDao: artifactDao,
+		urlProvider: urlProvider,
+		git:         git,
+	}
+}
+
+// version is a stored version of a module along with its decoded metadata.
+type version struct {
+	artifact types.Artifact
+	metadata *gopackage.GoMetadata
+}
+
+func (v *version) info() *gopackage.VersionInfo {
+	info := v.metadata.VersionInfo
+	info.Version = v.artifact.Version
+	if info.Time.IsZero() {
+		info.Time = v.artifact.CreatedAt.UTC()
+	}
+	return &info
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeGO}
+}
+
+func (c *localRegistry) ListVersions(ctx context.Context, info gotype.ArtifactInfo) ([]string, error) {
+	versions, err := c.getVersions(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if !IsPseudoVersion(v.artifact.Version) {
+			list = append(list, v.artifact.Version)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return compareVersions(list[i], list[j]) < 0
+	})
+	return list, nil
+}
+
+func (c *localRegistry) GetVersionInfo(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+) (*gopackage.VersionInfo, error) {
+	v, err := c.getVersion(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return v.info(), nil
+}
+
+// GetLatestVersion returns the highest release version of the module, or the highest pre-release version
+// if there is no release, or the highest pseudo-version if there is neither.
+func (c *localRegistry) GetLatestVersion(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+) (*gopackage.VersionInfo, error) {
+	versions, err := c.getVersions(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := versions[0]
+	for _, v := range versions[1:] {
+		if versionRank(v.artifact.Version) > versionRank(latest.artifact.Version) ||
+			(versionRank(v.artifact.Version) == versionRank(latest.artifact.Version) &&
+				compareVersions(v.artifact.Version, latest.artifact.Version) > 0) {
+			latest = v
+		}
+	}
+	return latest.info(), nil
+}
+
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, []error) {
+	if _, err := c.getVersion(ctx, info); err != nil {
+		return nil, nil, nil, "", []error{err}
+	}
+
+	headers, fileReader, redirectURL, errs := c.localBase.Download(ctx, info.ArtifactInfo, info.Version,
+		info.Filename)
+	if len(errs) > 0 {
+		return nil, nil, nil, "", errs
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+// UploadPackage stores the module zip of info.Version of the module info.Image. The zip is spooled to a
+// temporary file to be validated before it's stored.
+func (c *localRegistry) UploadPackage(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+	file io.Reader,
+) (*commons.ResponseHeaders, string, errcode.Error) {
+	zipFile, err := createTempFile(ctx, "go-module-*.zip")
+	if err != nil {
+		return nil, "", errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	defer removeTempFile(ctx, zipFile)
+
+	size, err := io.Copy(zipFile, io.LimitReader(file, MaxZipSize+1))
+	if err != nil {
+		return nil, "", errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to read module zip: %w", err))
+	}
+	if size > MaxZipSize {
+		return nil, "", errcode.ErrCodeInvalidRequest.WithMessage(
+			fmt.Sprintf("module zip is larger than %d bytes", MaxZipSize))
+	}
+
+	goMod, err := ReadZip(zipFile, size, info.Image, info.Version)
+	if err != nil {
+		return nil, "", errcode.ErrCodeInvalidRequest.WithMessage(err.Error())
+	}
+
+	if _, err = zipFile.Seek(0, io.SeekStart); err != nil {
+		return nil, "", errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	return c.upload(ctx, info, goMod, zipFile)
+}
+
+// BuildFromRepository builds the module zip of the module tagged with the tag in the repository and stores
+// it along with the origin of the version. Tags of modules in subdirectories are prefixed with the directory.
+func (c *localRegistry) BuildFromRepository(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+	repo *gitnesstypes.RepositoryCore,
+	tag string,
+) (*gotype.ModuleVersion, errcode.Error) {
+	dir, version, err := ParseTag(tag)
+	if err != nil {
+		return nil, errcode.ErrCodeInvalidRequest.WithMessage(err.Error())
+	}
+
+	ref := "refs/tags/" + strings.TrimPrefix(tag, "refs/tags/")
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   ref,
+	})
+	if gitnesserrors.IsNotFound(err) {
+		return nil, errcode.ErrCodeNameUnknown.WithMessage(
+			fmt.Sprintf("tag %s not found in repository %s", tag, repo.Path))
+	}
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to resolve tag %s: %w", tag, err))
+	}
+	sha := commit.Commit.SHA.String()
+
+	archiveFile, err := createTempFile(ctx, "go-module-*.tar")
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	defer removeTempFile(ctx, archiveFile)
+
+	archiveParams := api.ArchiveParams{
+		Format:  api.ArchiveFormatTar,
+		Treeish: sha,
+	}
+	if dir != "" {
+		archiveParams.Paths = []string{dir}
+	}
+	err = c.git.Archive(ctx, git.ArchiveParams{
+		ReadParams:    git.CreateReadParams(repo),
+		ArchiveParams: archiveParams,
+	}, archiveFile)
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to archive tag %s: %w", tag, err))
+	}
+	if _, err = archiveFile.Seek(0, io.SeekStart); err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	zipFile, err := createTempFile(ctx, "go-module-*.zip")
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	defer removeTempFile(ctx, zipFile)
+
+	modulePath, goMod, err := CreateZipFromTar(archiveFile, dir, version, zipFile)
+	if err != nil {
+		return nil, errcode.ErrCodeInvalidRequest.WithMessage(err.Error())
+	}
+	if err = CheckMajorVersion(modulePath, version); err != nil {
+		return nil, errcode.ErrCodeInvalidRequest.WithMessage(err.Error())
+	}
+	if _, err = zipFile.Seek(0, io.SeekStart); err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	info.Image = modulePath
+	info.Version = version
+	info.Metadata = gopackage.VersionInfo{
+		Version: version,
+		Time:    commit.Commit.Committer.When.UTC(),
+		Origin: &gopackage.Origin{
+			VCS:  "git",
+			URL:  c.urlProvider.GenerateGITCloneURL(ctx, repo.Path),
+			Ref:  ref,
+			Hash: sha,
+		},
+	}
+
+	_, sha256, errc := c.upload(ctx, info, goMod, zipFile)
+	if !commons.IsEmptyError(errc) {
+		return nil, errc
+	}
+	return &gotype.ModuleVersion{
+		Module:  modulePath,
+		Version: version,
+		Sha256:  sha256,
+	}, errcode.Error{}
+}
+
+// upload stores the go.mod and the module zip of a version. Versions of modules are immutable, so an existing
+// version can't be uploaded again.
+func (c *localRegistry) upload(
+	ctx context.Context,
+	info gotype.ArtifactInfo,
+	goMod []byte,
+	zipFile io.Reader,
+) (*commons.ResponseHeaders, string, errcode.Error) {
+	if _, err := c.getVersion(ctx, info); err == nil {
+		return nil, "", errcode.ErrCodeInvalidRequest.WithMessage(
+			fmt.Sprintf("version %s of module %s already exists", info.Version, info.Image))
+	}
+
+	info.Metadata.Version = info.Version
+	if info.Metadata.Time.IsZero() {
+		info.Metadata.Time = time.Now().UTC()
+	}
+
+	modFilename := info.Version + gotype.ModExtension
+	_, _, err := c.localBase.Upload(ctx, info.ArtifactInfo, modFilename, info.Version,
+		pkg.JoinWithSeparator("/", info.Image, info.Version, modFilename), io.NopCloser(bytes.NewReader(goMod)),
+		&gopackage.GoMetadata{VersionInfo: info.Metadata})
+	if !commons.IsEmptyError(err) {
+		return nil, "", err
+	}
+
+	zipFilename := info.Version + gotype.ZipExtension
+	return c.localBase.Upload(ctx, info.ArtifactInfo, zipFilename, info.Version,
+		pkg.JoinWithSeparator("/", info.Image, info.Version, zipFilename), io.NopCloser(zipFile),
+		&gopackage.GoMetadata{VersionInfo: info.Metadata})
+}
+
+// getVersions returns all the stored versions of the module.
+func (c *localRegistry) getVersions(ctx context.Context, info gotype.ArtifactInfo) ([]*version, error) {
+	artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions of module %s: %w", info.Image, err)
+	}
+
+	if len(*artifacts) == 0 {
+		return nil, commons.NotFoundError(fmt.Sprintf("module %s not found", info.Image), nil)
+	}
+
+	versions := make([]*version, len(*artifacts))
+	for i, art := range *artifacts {
+		metadata := &gopackage.GoMetadata{}
+		if err = json.Unmarshal(art.Metadata, metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata of version %s of module %s: %w",
+				art.Version, info.Image, err)
+		}
+		versions[i] = &version{artifact: art, metadata: metadata}
+	}
+	return versions, nil
+}
+
+func (c *localRegistry) getVersion(ctx context.Context, info gotype.ArtifactInfo) (*version, error) {
+	image, err := c.imageDao.GetByName(ctx, info.RegistryID, info.Image)
+	var art *types.Artifact
+	if err == nil {
+		art, err = c.artifactDao.GetByName(ctx, image.ID, info.Version)
+	}
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, commons.NotFoundError(
+			fmt.Sprintf("version %s of module %s not found", info.Version, info.Image), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &gopackage.GoMetadata{}
+	if err = json.Unmarshal(art.Metadata, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of version %s of module %s: %w",
+			info.Version, info.Image, err)
+	}
+	return &version{artifact: *art, metadata: metadata}, nil
+}
+
+// versionRank ranks the versions by the preference of the go command for the latest version.
+func versionRank(v string) int {
+	switch {
+	case IsPseudoVersion(v):
+		return 0
+	case strings.Contains(v, "-"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func compareVersions(a string, b string) int {
+	va, errA := semver.NewVersion(strings.TrimPrefix(a, "v"))
+	vb, errB := semver.NewVersion(strings.TrimPrefix(b, "v"))
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
+
+func createTempFile(ctx context.Context, pattern string) (*os.File, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to create temporary file")
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	return file, nil
+}
+
+func removeTempFile(ctx context.Context, file *os.File) {
+	_ = file.Close()
+	if err := os.Remove(file.Name()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove temporary file %s", file.Name())
+	}
+}
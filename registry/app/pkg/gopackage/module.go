@@ -0,0 +1,317 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Limits of the module zips, same as the ones of the go command.
+// Source: https://go.dev/ref/mod#zip-path-size-constraints
+const (
+	MaxZipSize   = 500 << 20
+	MaxGoModSize = 16 << 20
+)
+
+const goModFile = "go.mod"
+
+var pseudoVersionMatcher = regexp.MustCompile(
+	`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+var pathElementMatcher = regexp.MustCompile(`^[A-Za-z0-9_~+-][A-Za-z0-9._~+-]*$`)
+
+var majorSuffixMatcher = regexp.MustCompile(`^v[0-9]+$`)
+
+// ValidateModulePath checks that the module path is a slash separated path of valid elements.
+func ValidateModulePath(modulePath string) error {
+	if modulePath == "" {
+		return errors.New("empty module path")
+	}
+	for _, elem := range strings.Split(modulePath, "/") {
+		if !pathElementMatcher.MatchString(elem) || strings.HasSuffix(elem, ".") {
+			return fmt.Errorf("invalid module path: %s", modulePath)
+		}
+	}
+	return nil
+}
+
+// ValidateVersion checks that the version is a canonical semantic version prefixed with "v", e.g. v1.2.3.
+// Build metadata, and so +incompatible versions, aren't supported.
+func ValidateVersion(version string) error {
+	if !strings.HasPrefix(version, "v") || strings.Contains(version, "+") {
+		return fmt.Errorf("invalid version: %s", version)
+	}
+	if _, err := semver.StrictNewVersion(version[1:]); err != nil {
+		return fmt.Errorf("invalid version: %s", version)
+	}
+	return nil
+}
+
+// IsPseudoVersion reports whether the version is a pseudo-version, e.g. v0.0.0-20191109021931-daa7c04131f5.
+func IsPseudoVersion(version string) bool {
+	return strings.Count(version, "-") >= 2 && pseudoVersionMatcher.MatchString(version)
+}
+
+// CheckMajorVersion checks that the major version suffix of the module path matches the version:
+// modules from v2 on must end with /vN, e.g. example.com/mod/v2 for v2.0.0.
+func CheckMajorVersion(modulePath string, version string) error {
+	v, err := semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return fmt.Errorf("invalid version: %s", version)
+	}
+
+	suffix := ""
+	if i := strings.LastIndex(modulePath, "/"); i >= 0 && majorSuffixMatcher.MatchString(modulePath[i+1:]) {
+		suffix = modulePath[i+1:]
+	}
+
+	switch {
+	case v.Major() < 2 && suffix != "":
+		return fmt.Errorf("version %s doesn't match the major version suffix of module %s", version, modulePath)
+	case v.Major() >= 2 && suffix != fmt.Sprintf("v%d", v.Major()):
+		return fmt.Errorf("module %s must end with /v%d for version %s", modulePath, v.Major(), version)
+	}
+	return nil
+}
+
+// ParseTag splits a tag of a repository into the directory of the module and the version, e.g. "sub/dir" and
+// "v1.0.0" for "sub/dir/v1.0.0".
+func ParseTag(tag string) (dir string, version string, err error) {
+	tag = strings.TrimPrefix(tag, "refs/tags/")
+	if i := strings.LastIndex(tag, "/"); i >= 0 {
+		dir, version = tag[:i], tag[i+1:]
+	} else {
+		version = tag
+	}
+
+	if err = ValidateVersion(version); err != nil {
+		return "", "", fmt.Errorf("tag %s isn't a module version: %w", tag, err)
+	}
+	if dir != "" && path.Clean(dir) != dir {
+		return "", "", fmt.Errorf("invalid tag: %s", tag)
+	}
+	return dir, version, nil
+}
+
+// ParseModulePath returns the path of the module directive of a go.mod file.
+func ParseModulePath(goMod []byte) string {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		line = strings.TrimSpace(line)
+		rest, ok := strings.CutPrefix(line, "module")
+		if !ok || rest == "" || (rest[0] != ' ' && rest[0] != '\t' && rest[0] != '"') {
+			continue
+		}
+
+		if i := strings.Index(rest, "//"); i >= 0 {
+			rest = rest[:i]
+		}
+		rest = strings.TrimSpace(rest)
+		if unquoted, err := strconv.Unquote(rest); err == nil {
+			return unquoted
+		}
+		return rest
+	}
+	return ""
+}
+
+// ReadZip validates the module zip of the module version and returns its go.mod. A go.mod is synthesized for
+// modules without one, like the go command does.
+func ReadZip(r io.ReaderAt, size int64, modulePath string, version string) ([]byte, error) {
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module zip: %w", err)
+	}
+
+	prefix := modulePath + "@" + version + "/"
+	var total uint64
+	var goMod []byte
+	for _, file := range zipReader.File {
+		name, ok := strings.CutPrefix(file.Name, prefix)
+		if !ok || name == "" || path.Clean(name) != name || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid file %s in module zip, files must be in %s", file.Name, prefix)
+		}
+
+		total += file.UncompressedSize64
+		if total > MaxZipSize {
+			return nil, fmt.Errorf("module zip is larger than %d bytes uncompressed", MaxZipSize)
+		}
+
+		if name != goModFile {
+			continue
+		}
+		if file.UncompressedSize64 > MaxGoModSize {
+			return nil, fmt.Errorf("go.mod is larger than %d bytes", MaxGoModSize)
+		}
+		if goMod, err = readZipFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	if goMod == nil {
+		return []byte(fmt.Sprintf("module %s\n", modulePath)), nil
+	}
+	if declared := ParseModulePath(goMod); declared != modulePath {
+		return nil, fmt.Errorf("go.mod declares module %q, expected %q", declared, modulePath)
+	}
+	return goMod, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// CreateZipFromTar writes the module zip of the version of the module in the directory dir of the tar archive
+// and returns the module path and the go.mod of the module. The vendored packages, the nested modules and
+// everything but regular files are excluded from the zip.
+func CreateZipFromTar(
+	archive io.ReadSeeker,
+	dir string,
+	version string,
+	w io.Writer,
+) (modulePath string, goMod []byte, err error) {
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	// the nested modules must be known before the files are added, so the archive is read twice.
+	nested := make(map[string]bool)
+	err = walkTar(archive, prefix, func(name string, header *tar.Header, r io.Reader) error {
+		if path.Base(name) != goModFile {
+			return nil
+		}
+		if name != goModFile {
+			nested[path.Dir(name)] = true
+			return nil
+		}
+		if header.Size > MaxGoModSize {
+			return fmt.Errorf("go.mod is larger than %d bytes", MaxGoModSize)
+		}
+		content, readErr := io.ReadAll(r)
+		goMod = content
+		return readErr
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if goMod == nil {
+		return "", nil, fmt.Errorf("no go.mod found in %q", dir)
+	}
+	modulePath = ParseModulePath(goMod)
+	if err = ValidateModulePath(modulePath); err != nil {
+		return "", nil, fmt.Errorf("invalid module directive in go.mod: %w", err)
+	}
+
+	if _, err = archive.Seek(0, io.SeekStart); err != nil {
+		return "", nil, fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	zipWriter := zip.NewWriter(w)
+	var total int64
+	err = walkTar(archive, prefix, func(name string, header *tar.Header, r io.Reader) error {
+		if isVendoredPackage(name) || inNestedModule(name, nested) {
+			return nil
+		}
+
+		total += header.Size
+		if total > MaxZipSize {
+			return fmt.Errorf("module is larger than %d bytes", MaxZipSize)
+		}
+
+		fileWriter, createErr := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:   modulePath + "@" + version + "/" + name,
+			Method: zip.Deflate,
+		})
+		if createErr != nil {
+			return fmt.Errorf("failed to add %s to module zip: %w", name, createErr)
+		}
+		_, createErr = io.Copy(fileWriter, r)
+		return createErr
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to write module zip: %w", err)
+	}
+	return modulePath, goMod, nil
+}
+
+// walkTar calls fn for each regular file of the tar archive in the directory prefix, with its name relative
+// to the directory.
+func walkTar(archive io.Reader, prefix string, fn func(name string, header *tar.Header, r io.Reader) error) error {
+	tarReader := tar.NewReader(archive)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name, ok := strings.CutPrefix(header.Name, prefix)
+		if !ok || name == "" {
+			continue
+		}
+
+		if err = fn(name, header, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+// isVendoredPackage reports whether the file is in a vendored package. The files directly in a vendor
+// directory, like vendor/modules.txt, are kept in module zips.
+func isVendoredPackage(name string) bool {
+	var i int
+	if strings.HasPrefix(name, "vendor/") {
+		i = len("vendor/")
+	} else if j := strings.Index(name, "/vendor/"); j >= 0 {
+		i = j + len("/vendor/")
+	} else {
+		return false
+	}
+	return strings.Contains(name[i:], "/")
+}
+
+func inNestedModule(name string, nested map[string]bool) bool {
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if nested[dir] {
+			return true
+		}
+	}
+	return false
+}
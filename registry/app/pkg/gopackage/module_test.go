@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newArchive creates a tar archive with the given files, like the ones of git archive.
+func newArchive(t *testing.T, files map[string]string) *bytes.Reader {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for _, name := range names {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tarWriter.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+		Name:     "sub/link.go",
+		Linkname: "main.go",
+		Typeflag: tar.TypeSymlink,
+	}))
+	require.NoError(t, tarWriter.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func zipFiles(t *testing.T, content []byte) map[string]string {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, file := range zipReader.File {
+		data, err := readZipFile(file)
+		require.NoError(t, err)
+		files[file.Name] = string(data)
+	}
+	return files
+}
+
+func TestCreateZipFromTar(t *testing.T) {
+	archive := newArchive(t, map[string]string{
+		"README.md":                   "readme",
+		"sub/go.mod":                  "module example.com/mod/sub/v2 // comment\n\ngo 1.22\n",
+		"sub/main.go":                 "package main",
+		"sub/nested/go.mod":           "module example.com/nested\n",
+		"sub/nested/nested.go":        "package nested",
+		"sub/vendor/modules.txt":      "# modules",
+		"sub/vendor/example.com/x.go": "package x",
+	})
+
+	var buf bytes.Buffer
+	modulePath, goMod, err := CreateZipFromTar(archive, "sub", "v2.1.0", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/mod/sub/v2", modulePath)
+	assert.Contains(t, string(goMod), "go 1.22")
+
+	assert.Equal(t, map[string]string{
+		"example.com/mod/sub/v2@v2.1.0/go.mod":             string(goMod),
+		"example.com/mod/sub/v2@v2.1.0/main.go":            "package main",
+		"example.com/mod/sub/v2@v2.1.0/vendor/modules.txt": "# modules",
+	}, zipFiles(t, buf.Bytes()))
+
+	readGoMod, err := ReadZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), modulePath, "v2.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, goMod, readGoMod)
+
+	_, err = ReadZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), modulePath, "v2.2.0")
+	assert.Error(t, err)
+
+	_, _, err = CreateZipFromTar(newArchive(t, map[string]string{"main.go": "package main"}), "", "v1.0.0", &buf)
+	assert.Error(t, err)
+}
+
+func TestReadZip_WithoutGoMod(t *testing.T) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	_, err := zipWriter.Create("example.com/mod@v1.0.0/mod.go")
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+
+	goMod, err := ReadZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), "example.com/mod", "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/mod\n", string(goMod))
+}
+
+func TestParseTag(t *testing.T) {
+	dir, version, err := ParseTag("refs/tags/sub/dir/v1.2.3-rc.1")
+	require.NoError(t, err)
+	assert.Equal(t, "sub/dir", dir)
+	assert.Equal(t, "v1.2.3-rc.1", version)
+
+	dir, version, err = ParseTag("v1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, dir)
+	assert.Equal(t, "v1.0.0", version)
+
+	for _, tag := range []string{"1.0.0", "v1.0", "release", "v1.0.0+meta", "sub/../v1.0.0"} {
+		_, _, err = ParseTag(tag)
+		assert.Error(t, err, tag)
+	}
+}
+
+func TestCheckMajorVersion(t *testing.T) {
+	assert.NoError(t, CheckMajorVersion("example.com/mod", "v0.1.0"))
+	assert.NoError(t, CheckMajorVersion("example.com/mod", "v1.0.0"))
+	assert.NoError(t, CheckMajorVersion("example.com/mod/v2", "v2.0.0"))
+	assert.Error(t, CheckMajorVersion("example.com/mod", "v2.0.0"))
+	assert.Error(t, CheckMajorVersion("example.com/mod/v2", "v1.0.0"))
+	assert.Error(t, CheckMajorVersion("example.com/mod/v2", "v3.0.0"))
+}
+
+func TestIsPseudoVersion(t *testing.T) {
+	assert.True(t, IsPseudoVersion("v0.0.0-20191109021931-daa7c04131f5"))
+	assert.True(t, IsPseudoVersion("v1.2.4-0.20191109021931-daa7c04131f5"))
+	assert.True(t, IsPseudoVersion("v1.2.3-pre.0.20191109021931-daa7c04131f5"))
+	assert.False(t, IsPseudoVersion("v1.2.3"))
+	assert.False(t, IsPseudoVersion("v1.2.3-rc.1"))
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/services/refcache"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	cfg "github.com/harness/gitness/registry/config"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/store/database/dbtx"
+	gitnesstypes "github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ pkg.Artifact = (*proxy)(nil)
+var _ Registry = (*proxy)(nil)
+
+type proxy struct {
+	fileManager         filemanager.FileManager
+	proxyStore          store.UpstreamProxyConfigRepository
+	tx                  dbtx.Transactor
+	registryDao         store.RegistryRepository
+	imageDao            store.ImageRepository
+	artifactDao         store.ArtifactRepository
+	urlProvider         urlprovider.Provider
+	spaceFinder         refcache.SpaceFinder
+	service             secret.Service
+	localRegistryHelper LocalRegistryHelper
+}
+
+type Proxy interface {
+	Registry
+}
+
+func NewProxy(
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+	localRegistryHelper LocalRegistryHelper,
+) Proxy {
+	return &proxy{
+		fileManager:         fileManager,
+		proxyStore:          proxyStore,
+		tx:                  tx,
+		registryDao:         registryDao,
+		imageDao:            imageDao,
+		artifactDao:         artifactDao,
+		urlProvider:         urlProvider,
+		spaceFinder:         spaceFinder,
+		service:             service,
+		localRegistryHelper: localRegistryHelper,
+	}
+}
+
+func (r *proxy) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeUPSTREAM
+}
+
+func (r *proxy) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeGO}
+}
+
+// ListVersions Returns the versions of the module from remote, the versions of a module being proxied may
+// be newer than the cached ones.
+func (r *proxy) ListVersions(ctx context.Context, info gotype.ArtifactInfo) ([]string, error) {
+	remote, err := r.newRemote(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetList(ctx, info.Image)
+}
+
+func (r *proxy) GetVersionInfo(ctx context.Context, info gotype.ArtifactInfo) (*gopackage.VersionInfo, error) {
+	remote, err := r.newRemote(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetInfo(ctx, info.Image, info.Version)
+}
+
+func (r *proxy) GetLatestVersion(ctx context.Context, info gotype.ArtifactInfo) (*gopackage.VersionInfo, error) {
+	remote, err := r.newRemote(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return remote.GetLatest(ctx, info.Image)
+}
+
+// DownloadPackageFile serves the file from the cache, or streams it from remote. The version is cached
+// when its module zip is downloaded.
+func (r *proxy) DownloadPackageFile(ctx context.Context, info gotype.ArtifactInfo) (
+	*commons.ResponseHeaders,
+	*storage.FileReader,
+	io.ReadCloser,
+	string,
+	[]error,
+) {
+	exists := r.localRegistryHelper.FileExists(ctx, info)
+	if exists {
+		headers, fileReader, redirectURL, errors := r.localRegistryHelper.DownloadFile(ctx, info)
+		if len(errors) == 0 {
+			return headers, fileReader, nil, redirectURL, errors
+		}
+		// If file exists in local registry, but download failed, we should try to download from remote
+		log.Warn().Ctx(ctx).Msgf("failed to pull from local, attempting streaming from remote, %v", errors)
+	}
+
+	remote, err := r.newRemote(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+
+	if !strings.HasSuffix(info.Filename, gotype.ZipExtension) {
+		file, err := remote.GetMod(ctx, info.Image, info.Version)
+		if err != nil {
+			return nil, nil, nil, "", []error{err}
+		}
+		return nil, nil, file, "", nil
+	}
+
+	file, err := remote.GetZip(ctx, info.Image, info.Version)
+	if err != nil {
+		return nil, nil, nil, "", []error{err}
+	}
+
+	go func(info gotype.ArtifactInfo) {
+		ctx2 := context.WithoutCancel(ctx)
+		ctx2 = context.WithValue(ctx2, cfg.GoRoutineKey, "goRoutine")
+		err = r.putFileToLocal(ctx2, info, remote)
+		if err != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err).Msgf("error while putting file to localRegistry, %v", err)
+			return
+		}
+		log.Ctx(ctx2).Info().Msgf("Successfully updated file: %s, registry: %s", info.Filename, info.RegIdentifier)
+	}(info)
+
+	return nil, nil, file, "", nil
+}
+
+func (r *proxy) putFileToLocal(ctx context.Context, info gotype.ArtifactInfo, remote RemoteRegistryHelper) error {
+	versionInfo, err := remote.GetInfo(ctx, info.Image, info.Version)
+	if err != nil {
+		log.Ctx(ctx).Error().Stack().Err(err).Msgf("fetching info of %s@%s failed, %v", info.Image,
+			info.Version, err)
+		return err
+	}
+
+	file, err := remote.GetZip(ctx, info.Image, info.Version)
+	if err != nil {
+		log.Ctx(ctx).Error().Stack().Err(err).Msgf("fetching file %s failed, %v", info.Filename, err)
+		return err
+	}
+	defer file.Close()
+
+	info.Metadata = *versionInfo
+	_, sha256, err2 := r.localRegistryHelper.UploadPackage(ctx, info, file)
+	if !commons.IsEmptyError(err2) {
+		log.Ctx(ctx).Error().Stack().Err(err2).Msgf("uploading file %s failed, %v", info.Filename, err2)
+		return err2
+	}
+	log.Info().Msgf("Successfully uploaded %s with SHA256: %s", info.Filename, sha256)
+	return nil
+}
+
+func (r *proxy) newRemote(ctx context.Context, info gotype.ArtifactInfo) (RemoteRegistryHelper, error) {
+	upstreamProxy, err := r.proxyStore.GetByRegistryIdentifier(ctx, info.ParentID, info.RegIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteRegistryHelper(ctx, r.spaceFinder, *upstreamProxy, r.service)
+}
+
+func (r *proxy) UploadPackage(
+	ctx context.Context,
+	_ gotype.ArtifactInfo,
+	_ io.Reader,
+) (*commons.ResponseHeaders, string, errcode.Error) {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
+
+func (r *proxy) BuildFromRepository(
+	ctx context.Context,
+	_ gotype.ArtifactInfo,
+	_ *gitnesstypes.RepositoryCore,
+	_ string,
+) (*gotype.ModuleVersion, errcode.Error) {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return nil, errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	"github.com/harness/gitness/registry/app/storage"
+	gitnesstypes "github.com/harness/gitness/types"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	// ListVersions returns the released and pre-release versions of the module, pseudo-versions excluded.
+	ListVersions(ctx context.Context, info gotype.ArtifactInfo) ([]string, error)
+
+	GetVersionInfo(ctx context.Context, info gotype.ArtifactInfo) (*gopackage.VersionInfo, error)
+
+	// GetLatestVersion returns the info of the version the go command resolves to when no version is requested.
+	GetLatestVersion(ctx context.Context, info gotype.ArtifactInfo) (*gopackage.VersionInfo, error)
+
+	// DownloadPackageFile returns the .mod or the .zip file of a module version.
+	DownloadPackageFile(ctx context.Context, info gotype.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		[]error,
+	)
+
+	// UploadPackage stores a new version of the module from its module zip.
+	UploadPackage(
+		ctx context.Context,
+		info gotype.ArtifactInfo,
+		file io.Reader,
+	) (*commons.ResponseHeaders, string, errcode.Error)
+
+	// BuildFromRepository stores a new version of a module from a tag of the repository.
+	BuildFromRepository(
+		ctx context.Context,
+		info gotype.ArtifactInfo,
+		repo *gitnesstypes.RepositoryCore,
+		tag string,
+	) (*gotype.ModuleVersion, errcode.Error)
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	"github.com/harness/gitness/registry/app/remote/adapter"
+	goproxyadapter "github.com/harness/gitness/registry/app/remote/adapter/goproxy"
+	"github.com/harness/gitness/registry/app/remote/registry"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RemoteRegistryHelper interface {
+	// GetList Fetches the versions of the given module
+	GetList(ctx context.Context, module string) ([]string, error)
+
+	// GetInfo Fetches the info of the given module version
+	GetInfo(ctx context.Context, module string, version string) (*gopackage.VersionInfo, error)
+
+	// GetLatest Fetches the info of the latest version of the given module
+	GetLatest(ctx context.Context, module string) (*gopackage.VersionInfo, error)
+
+	// GetMod Downloads the go.mod of the given module version
+	GetMod(ctx context.Context, module string, version string) (io.ReadCloser, error)
+
+	// GetZip Downloads the module zip of the given module version
+	GetZip(ctx context.Context, module string, version string) (io.ReadCloser, error)
+}
+
+type remoteRegistryHelper struct {
+	adapter  registry.GoProxyRegistry
+	registry types.UpstreamProxy
+}
+
+func NewRemoteRegistryHelper(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	registry types.UpstreamProxy,
+	service secret.Service,
+) (RemoteRegistryHelper, error) {
+	r := &remoteRegistryHelper{
+		registry: registry,
+	}
+	if err := r.init(ctx, spaceFinder, service); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to init remote registry for remote: %s", registry.RepoKey)
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *remoteRegistryHelper) init(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+) error {
+	key := string(artifact.UpstreamConfigSourceGoProxy)
+	if r.registry.Source == string(artifact.UpstreamConfigSourceGoProxy) {
+		r.registry.RepoURL = goproxyadapter.GoProxyURL
+	}
+
+	factory, err := adapter.GetFactory(key)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to get factory " + key)
+		return err
+	}
+
+	adpt, err := factory.Create(ctx, spaceFinder, r.registry, service)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to create factory " + key)
+		return err
+	}
+
+	goReg, ok := adpt.(registry.GoProxyRegistry)
+	if !ok {
+		log.Ctx(ctx).Error().Msg("failed to cast factory to go proxy registry")
+		return fmt.Errorf("failed to cast factory to go proxy registry")
+	}
+	r.adapter = goReg
+	return nil
+}
+
+func (r *remoteRegistryHelper) GetList(ctx context.Context, module string) ([]string, error) {
+	versions, err := r.adapter.GetVersionList(ctx, module)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get versions of module: %s", module)
+	}
+	return versions, err
+}
+
+func (r *remoteRegistryHelper) GetInfo(
+	ctx context.Context,
+	module string,
+	version string,
+) (*gopackage.VersionInfo, error) {
+	info, err := r.adapter.GetVersionInfo(ctx, module, version)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get info of module: %s, version: %s", module, version)
+	}
+	return info, err
+}
+
+func (r *remoteRegistryHelper) GetLatest(ctx context.Context, module string) (*gopackage.VersionInfo, error) {
+	info, err := r.adapter.GetLatestVersion(ctx, module)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get latest version of module: %s", module)
+	}
+	return info, err
+}
+
+func (r *remoteRegistryHelper) GetMod(ctx context.Context, module string, version string) (io.ReadCloser, error) {
+	file, err := r.adapter.GetModFile(ctx, module, version)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get go.mod of module: %s, version: %s", module, version)
+	}
+	return file, err
+}
+
+func (r *remoteRegistryHelper) GetZip(ctx context.Context, module string, version string) (io.ReadCloser, error) {
+	file, err := r.adapter.GetModuleZip(ctx, module, version)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get zip of module: %s, version: %s", module, version)
+	}
+	return file, err
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	helmmetadata "github.com/harness/gitness/registry/app/metadata/helm"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	chartFile = "Chart.yaml"
+	// maxChartFileSize limits the size of the Chart.yaml file read from a chart archive.
+	maxChartFileSize = 1 << 20
+)
+
+var nameMatcher = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ParseChart reads the Chart.yaml file of a chart archive and validates the chart name and version.
+func ParseChart(r io.Reader) (*helmmetadata.ChartMetadata, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("chart is not a gzip archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s is missing in the chart", chartFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("chart is not a tar archive: %w", err)
+		}
+
+		// Chart.yaml is at the root of the chart directory, e.g. "mychart/Chart.yaml".
+		parts := strings.Split(path.Clean(header.Name), "/")
+		if len(parts) != 2 || parts[1] != chartFile || header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxChartFileSize {
+			return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", chartFile, maxChartFileSize)
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tarReader, maxChartFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", chartFile, err)
+		}
+		return parseChartMetadata(content)
+	}
+}
+
+// ParseProvenance reads the chart name and version from a provenance file, which is the Chart.yaml of the chart
+// followed by the digests of the archive, signed in a PGP clear text message.
+// Source: https://helm.sh/docs/topics/provenance/#the-provenance-file
+func ParseProvenance(content []byte) (*helmmetadata.ChartMetadata, error) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	// the signed message starts after the armor headers, terminated by an empty line.
+	_, message, found := bytes.Cut(content, []byte("\n\n"))
+	if !found || !bytes.HasPrefix(content, []byte("-----BEGIN PGP SIGNED MESSAGE-----")) {
+		return nil, fmt.Errorf("provenance is not a PGP signed message")
+	}
+	chart, _, found := bytes.Cut(message, []byte("\n...\n"))
+	if !found {
+		return nil, fmt.Errorf("provenance doesn't contain the chart metadata")
+	}
+	return parseChartMetadata(chart)
+}
+
+// ParseFilename returns the name and the version of a chart from the file name of its archive or provenance,
+// e.g. "my-chart" and "1.0.0-rc.1" for "my-chart-1.0.0-rc.1.tgz".
+func ParseFilename(filename string) (string, string, error) {
+	base := strings.TrimSuffix(filename, ".prov")
+	if !strings.HasSuffix(base, helmtype.ChartExtension) {
+		return "", "", fmt.Errorf("invalid chart file name: %s", filename)
+	}
+	base = strings.TrimSuffix(base, helmtype.ChartExtension)
+
+	// chart names may contain dashes, so the version starts at the first dash followed by a version.
+	for i := 1; i < len(base)-1; i++ {
+		if base[i] != '-' {
+			continue
+		}
+		name, version := base[:i], base[i+1:]
+		if _, err := semver.StrictNewVersion(version); err == nil && nameMatcher.MatchString(name) {
+			return name, version, nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid chart file name: %s", filename)
+}
+
+func parseChartMetadata(content []byte) (*helmmetadata.ChartMetadata, error) {
+	metadata := &helmmetadata.ChartMetadata{}
+	if err := yaml.Unmarshal(content, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", chartFile, err)
+	}
+
+	if metadata.APIVersion == "" {
+		return nil, fmt.Errorf("apiVersion is required in %s", chartFile)
+	}
+	if metadata.Name == "" || metadata.Name == "." || metadata.Name == ".." || !nameMatcher.MatchString(metadata.Name) {
+		return nil, fmt.Errorf("invalid chart name: %q", metadata.Name)
+	}
+	// the version must be strict, otherwise it could not be told apart from the name in the file name.
+	if _, err := semver.StrictNewVersion(metadata.Version); err != nil {
+		return nil, fmt.Errorf("invalid chart version: %q, it must be a semantic version", metadata.Version)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChart creates a chart archive with the given files.
+func newChart(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func TestParseChart(t *testing.T) {
+	chart := newChart(t, map[string]string{
+		"my-chart/Chart.yaml": "apiVersion: v2\nname: my-chart\nversion: 1.2.3\nappVersion: \"4.5\"\n" +
+			"maintainers:\n  - name: admin\n",
+		"my-chart/charts/dep/Chart.yaml": "apiVersion: v2\nname: dep\nversion: 0.1.0\n",
+		"my-chart/values.yaml":           "replicas: 1\n",
+	})
+
+	metadata, err := ParseChart(bytes.NewReader(chart))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", metadata.APIVersion)
+	assert.Equal(t, "my-chart", metadata.Name)
+	assert.Equal(t, "1.2.3", metadata.Version)
+	assert.Equal(t, "4.5", metadata.AppVersion)
+	require.Len(t, metadata.Maintainers, 1)
+	assert.Equal(t, "admin", metadata.Maintainers[0].Name)
+}
+
+func TestParseChart_Invalid(t *testing.T) {
+	withChartFile := func(content string) []byte {
+		return newChart(t, map[string]string{"my-chart/Chart.yaml": content})
+	}
+	tests := map[string][]byte{
+		"not gzip":          []byte("chart"),
+		"missing chart":     newChart(t, map[string]string{"my-chart/values.yaml": "replicas: 1\n"}),
+		"missing version":   withChartFile("apiVersion: v2\nname: my-chart\n"),
+		"loose version":     withChartFile("apiVersion: v2\nname: c\nversion: 1.0\n"),
+		"invalid name":      withChartFile("apiVersion: v2\nname: a/b\nversion: 1.0.0\n"),
+		"missing api":       withChartFile("name: my-chart\nversion: 1.0.0\n"),
+		"nested chart only": newChart(t, map[string]string{"a/b/Chart.yaml": "apiVersion: v2\nname: b\nversion: 1.0.0\n"}),
+	}
+	for name, chart := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseChart(bytes.NewReader(chart))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseProvenance(t *testing.T) {
+	provenance := "-----BEGIN PGP SIGNED MESSAGE-----\nHash: SHA512\n\n" +
+		"apiVersion: v2\nname: my-chart\nversion: 1.2.3\n\n...\n" +
+		"files:\n  my-chart-1.2.3.tgz: sha256:abc\n" +
+		"-----BEGIN PGP SIGNATURE-----\n\nsignature\n-----END PGP SIGNATURE-----\n"
+
+	metadata, err := ParseProvenance([]byte(provenance))
+	require.NoError(t, err)
+	assert.Equal(t, "my-chart", metadata.Name)
+	assert.Equal(t, "1.2.3", metadata.Version)
+
+	_, err = ParseProvenance([]byte("apiVersion: v2\nname: my-chart\nversion: 1.2.3\n"))
+	assert.Error(t, err)
+}
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		name     string
+		version  string
+	}{
+		{"my-chart-1.2.3.tgz", "my-chart", "1.2.3"},
+		{"my-chart-1.2.3-rc.1+build.tgz", "my-chart", "1.2.3-rc.1+build"},
+		{"chart-2-1.0.0.tgz", "chart-2", "1.0.0"},
+		{"my-chart-1.2.3.tgz.prov", "my-chart", "1.2.3"},
+	}
+	for _, test := range tests {
+		name, version, err := ParseFilename(test.filename)
+		require.NoError(t, err, test.filename)
+		assert.Equal(t, test.name, name, test.filename)
+		assert.Equal(t, test.version, version, test.filename)
+	}
+
+	for _, filename := range []string{"my-chart.tgz", "my-chart-1.2.3.zip", "-1.2.3.tgz", "my-chart-1.2.tgz"} {
+		_, _, err := ParseFilename(filename)
+		assert.Error(t, err, filename)
+	}
+}
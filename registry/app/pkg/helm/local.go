@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	helmmetadata "github.com/harness/gitness/registry/app/metadata/helm"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	helmtype "github.com/harness/gitness/registry/app/pkg/types/helm"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
+)
+
+const indexAPIVersion = "v1"
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase   base.LocalBase
+	fileManager filemanager.FileManager
+	proxyStore  store.UpstreamProxyConfigRepository
+	tx          dbtx.Transactor
+	registryDao store.RegistryRepository
+	imageDao    store.ImageRepository
+	artifactDao store.ArtifactRepository
+	urlProvider urlprovider.Provider
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:   localBase,
+		fileManager: fileManager,
+		proxyStore:  proxyStore,
+		tx:          tx,
+		registryDao: registryDao,
+		imageDao:    imageDao,
+		artifactDao: artifactDao,
+		urlProvider: urlProvider,
+	}
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeHELM}
+}
+
+// GetIndex builds the index from the chart versions uploaded to the registry. The charts pushed with OCI
+// are not part of the index.
+func (c *localRegistry) GetIndex(ctx context.Context, info helmtype.ArtifactInfo) (*helmtype.IndexFile, error) {
+	index := &helmtype.IndexFile{
+		APIVersion: indexAPIVersion,
+		Entries:    map[string][]*helmtype.ChartVersion{},
+		Generated:  time.Now().UTC(),
+	}
+
+	images, err := c.imageDao.GetByRegistryID(ctx, info.RegistryID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range *images {
+		artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, image.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range *artifacts {
+			metadata, ok := chartMetadata(ctx, a.Metadata)
+			if !ok {
+				continue
+			}
+			index.Entries[image.Name] = append(index.Entries[image.Name], &helmtype.ChartVersion{
+				ChartMetadata: metadata.ChartMetadata,
+				URLs:          []string{ChartURL(ctx, c.urlProvider, info, image.Name, a.Version)},
+				Created:       a.CreatedAt.UTC(),
+				Digest:        metadata.Digest,
+			})
+		}
+		sortChartVersions(index.Entries[image.Name])
+	}
+	return index, nil
+}
+
+func (c *localRegistry) UploadChart(
+	ctx context.Context,
+	info helmtype.ArtifactInfo,
+	file io.ReadCloser,
+) (headers *commons.ResponseHeaders, sha256 string, err errcode.Error) {
+	defer file.Close()
+	path := pkg.JoinWithSeparator("/", info.Image, info.Version, info.Filename)
+	return c.localBase.Upload(ctx, info.ArtifactInfo, info.Filename, info.Version, path, file,
+		&helmmetadata.HelmMetadata{
+			ChartMetadata: info.Metadata.ChartMetadata,
+			Digest:        info.Metadata.Digest,
+		})
+}
+
+func (c *localRegistry) UploadProvenance(
+	ctx context.Context,
+	info helmtype.ArtifactInfo,
+	file io.ReadCloser,
+) (headers *commons.ResponseHeaders, sha256 string, err errcode.Error) {
+	defer file.Close()
+	if err := c.checkVersionExists(ctx, info); err != nil {
+		if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			return nil, "", errcode.ErrCodeNameUnknown.WithMessage(
+				fmt.Sprintf("chart %s version %s must be uploaded before its provenance", info.Image, info.Version))
+		}
+		return nil, "", errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	path := pkg.JoinWithSeparator("/", info.Image, info.Version, info.Filename)
+	// the metadata of the chart version is kept, only the file is added.
+	return c.localBase.Upload(ctx, info.ArtifactInfo, info.Filename, info.Version, path, file,
+		&helmmetadata.HelmMetadata{})
+}
+
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info helmtype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, []error) {
+	if err := c.checkVersionExists(ctx, info); err != nil {
+		if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			return nil, nil, nil, "", []error{commons.NotFoundError(
+				fmt.Sprintf("chart %s version %s not found", info.Image, info.Version), nil)}
+		}
+		return nil, nil, nil, "", []error{err}
+	}
+
+	headers, fileReader, redirectURL, errs := c.localBase.Download(ctx, info.ArtifactInfo, info.Version,
+		info.Filename)
+	if len(errs) > 0 {
+		return nil, nil, nil, "", errs
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+func (c *localRegistry) checkVersionExists(ctx context.Context, info helmtype.ArtifactInfo) error {
+	image, err := c.imageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return err
+	}
+	_, err = c.artifactDao.GetByName(ctx, image.ID, info.Version)
+	return err
+}
+
+// chartMetadata returns the metadata of a chart version uploaded to the chart repository. The versions
+// of the charts pushed with OCI are digests without chart metadata.
+func chartMetadata(ctx context.Context, raw json.RawMessage) (*helmmetadata.HelmMetadata, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+	metadata := &helmmetadata.HelmMetadata{}
+	if err := json.Unmarshal(raw, metadata); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("skipping artifact without chart metadata")
+		return nil, false
+	}
+	if metadata.Name == "" || metadata.Digest == "" {
+		return nil, false
+	}
+	return metadata, true
+}
+
+// sortChartVersions sorts the versions of a chart from the newest to the oldest, like the helm client does.
+func sortChartVersions(versions []*helmtype.ChartVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := semver.NewVersion(versions[i].Version)
+		vj, errJ := semver.NewVersion(versions[j].Version)
+		if errI != nil || errJ != nil {
+			return versions[i].Created.After(versions[j].Created)
+		}
+		return vi.GreaterThan(vj)
+	})
+}
+
+// ChartURL returns the download URL of the archive of the chart version in the registry.
+func ChartURL(
+	ctx context.Context,
+	urlProvider urlprovider.Provider,
+	info helmtype.ArtifactInfo,
+	name string,
+	version string,
+) string {
+	return urlProvider.PackageURL(ctx, info.RootIdentifier, info.RegIdentifier, "helm", "charts",
+		helmtype.ChartFilename(name, version))
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/types/helm"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	// GetIndex returns the index of the chart repository with all the chart versions uploaded to the registry.
+	GetIndex(ctx context.Context, info helm.ArtifactInfo) (*helm.IndexFile, error)
+
+	// UploadChart stores the archive of the chart version described by info.Metadata.
+	UploadChart(
+		ctx context.Context,
+		info helm.ArtifactInfo,
+		file io.ReadCloser,
+	) (*commons.ResponseHeaders, string, errcode.Error)
+
+	// UploadProvenance stores the provenance file of a chart version already uploaded.
+	UploadProvenance(
+		ctx context.Context,
+		info helm.ArtifactInfo,
+		file io.ReadCloser,
+	) (*commons.ResponseHeaders, string, errcode.Error)
+
+	DownloadPackageFile(ctx context.Context, info helm.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		[]error,
+	)
+}
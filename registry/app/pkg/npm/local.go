@@ -0,0 +1,441 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	npmmetadata "github.com/harness/gitness/registry/app/metadata/npm"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+const latestTag = "latest"
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase   base.LocalBase
+	fileManager filemanager.FileManager
+	proxyStore  store.UpstreamProxyConfigRepository
+	tx          dbtx.Transactor
+	registryDao store.RegistryRepository
+	imageDao    store.ImageRepository
+	artifactDao store.ArtifactRepository
+	urlProvider urlprovider.Provider
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:   localBase,
+		fileManager: fileManager,
+		proxyStore:  proxyStore,
+		tx:          tx,
+		registryDao: registryDao,
+		imageDao:    imageDao,
+		artifactDao: artifactDao,
+		urlProvider: urlProvider,
+	}
+}
+
+// version is a stored version of a package along with its decoded metadata.
+type version struct {
+	artifact types.Artifact
+	metadata *npmmetadata.NpmMetadata
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeNPM}
+}
+
+// GetPackageMetadata builds the package document from the stored versions of the package.
+func (c *localRegistry) GetPackageMetadata(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+) (npmtype.PackageMetadata, error) {
+	versions, err := c.getVersions(ctx, info)
+	if err != nil {
+		return npmtype.PackageMetadata{}, err
+	}
+
+	metadata := npmtype.PackageMetadata{
+		ID:       info.Image,
+		Name:     info.Image,
+		DistTags: distTags(versions),
+		Versions: make(map[string]npmmetadata.PackageMetadata, len(versions)),
+		Time:     make(map[string]string, len(versions)+2),
+	}
+
+	var created, modified time.Time
+	for _, v := range versions {
+		v.metadata.PackageMetadata.SetTarball(TarballURL(ctx, c.urlProvider, info, v.artifact.Version))
+		metadata.Versions[v.artifact.Version] = v.metadata.PackageMetadata
+		metadata.Time[v.artifact.Version] = v.artifact.CreatedAt.UTC().Format(time.RFC3339)
+
+		if created.IsZero() || v.artifact.CreatedAt.Before(created) {
+			created = v.artifact.CreatedAt
+		}
+		if v.artifact.UpdatedAt.After(modified) {
+			modified = v.artifact.UpdatedAt
+		}
+	}
+
+	metadata.Time["created"] = created.UTC().Format(time.RFC3339)
+	metadata.Time["modified"] = modified.UTC().Format(time.RFC3339)
+	// the revision is only echoed back by the npm client on unpublish, so it just needs to change on updates.
+	metadata.Rev = fmt.Sprintf("%d-%x", len(versions), modified.UnixMilli())
+
+	if latest, ok := metadata.Versions[metadata.DistTags[latestTag]]; ok {
+		metadata.Description = latest.Description()
+	}
+
+	return metadata, nil
+}
+
+func (c *localRegistry) UploadPackageFile(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+	file io.ReadCloser,
+) (headers *commons.ResponseHeaders, sha256 string, err errcode.Error) {
+	defer file.Close()
+	path := pkg.JoinWithSeparator("/", info.Image, info.Version, info.Filename)
+	headers, sha256, err = c.localBase.Upload(ctx, info.ArtifactInfo, info.Filename, info.Version, path, file,
+		&npmmetadata.NpmMetadata{
+			PackageMetadata: info.Metadata,
+		})
+	if !commons.IsEmptyError(err) {
+		return headers, sha256, err
+	}
+
+	if info.DistTag == "" {
+		return headers, sha256, errcode.Error{}
+	}
+
+	tags, tagErr := c.AddTag(ctx, info)
+	if tagErr == nil && info.DistTag != latestTag && tags[latestTag] == "" {
+		// the npm client installs the latest dist-tag by default, so the first version published is the latest.
+		info.DistTag = latestTag
+		_, tagErr = c.AddTag(ctx, info)
+	}
+	if tagErr != nil {
+		return headers, sha256, errcode.ErrCodeUnknown.WithDetail(tagErr)
+	}
+	return headers, sha256, errcode.Error{}
+}
+
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, []error) {
+	// the tarballs of unpublished versions are kept in the storage, so the version must be checked first.
+	image, err := c.imageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err == nil {
+		_, err = c.artifactDao.GetByName(ctx, image.ID, info.Version)
+	}
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, nil, nil, "", []error{commons.NotFoundError(
+			fmt.Sprintf("version %s of package %s not found", info.Version, info.Image), nil)}
+	}
+	if err != nil {
+		return nil, nil, nil, "", []error{err}
+	}
+
+	headers, fileReader, redirectURL, errs := c.localBase.Download(ctx, info.ArtifactInfo, info.Version,
+		info.Filename)
+	if len(errs) > 0 {
+		return nil, nil, nil, "", errs
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+func (c *localRegistry) UpdatePackage(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+	metadata npmtype.PackageMetadata,
+) error {
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		versions, err := c.getVersions(ctx, info)
+		if err != nil {
+			return err
+		}
+
+		remaining := 0
+		for _, v := range versions {
+			docVersion, ok := metadata.Versions[v.artifact.Version]
+			if !ok {
+				if err = c.artifactDao.DeleteByImageIDAndVersion(ctx, v.artifact.ImageID, v.artifact.Version); err != nil {
+					return fmt.Errorf("failed to delete version %s of package %s: %w", v.artifact.Version, info.Image, err)
+				}
+				continue
+			}
+			remaining++
+
+			changed := false
+			if deprecated := docVersion.Deprecated(); deprecated != v.metadata.PackageMetadata.Deprecated() {
+				v.metadata.PackageMetadata.SetDeprecated(deprecated)
+				changed = true
+			}
+
+			// the dist-tags are synced only if the client sent them.
+			if metadata.DistTags != nil {
+				var tags []string
+				for tag, tagVersion := range metadata.DistTags {
+					if tagVersion == v.artifact.Version {
+						tags = append(tags, tag)
+					}
+				}
+				slices.Sort(tags)
+				if !slices.Equal(tags, v.metadata.DistTags) {
+					v.metadata.DistTags = tags
+					changed = true
+				}
+			}
+
+			if changed {
+				if err = c.saveVersion(ctx, v); err != nil {
+					return err
+				}
+			}
+		}
+
+		if remaining == 0 {
+			return c.imageDao.DeleteByRegistryIDAndName(ctx, info.RegistryID, info.Image)
+		}
+		return nil
+	})
+}
+
+func (c *localRegistry) DeletePackage(ctx context.Context, info npmtype.ArtifactInfo) error {
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		versions, err := c.getVersions(ctx, info)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range versions {
+			if err = c.artifactDao.DeleteByImageIDAndVersion(ctx, v.artifact.ImageID, v.artifact.Version); err != nil {
+				return fmt.Errorf("failed to delete version %s of package %s: %w", v.artifact.Version, info.Image, err)
+			}
+		}
+
+		return c.imageDao.DeleteByRegistryIDAndName(ctx, info.RegistryID, info.Image)
+	})
+}
+
+// DeleteVersion removes the version of the package. The npm client removes the version from the package document
+// before it deletes the tarball, so a missing version isn't an error.
+func (c *localRegistry) DeleteVersion(ctx context.Context, info npmtype.ArtifactInfo) error {
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		image, err := c.imageDao.GetByName(ctx, info.RegistryID, info.Image)
+		if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find package %s: %w", info.Image, err)
+		}
+
+		if err = c.artifactDao.DeleteByImageIDAndVersion(ctx, image.ID, info.Version); err != nil {
+			return fmt.Errorf("failed to delete version %s of package %s: %w", info.Version, info.Image, err)
+		}
+
+		artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+		if err != nil {
+			return fmt.Errorf("failed to list versions of package %s: %w", info.Image, err)
+		}
+		if len(*artifacts) == 0 {
+			return c.imageDao.DeleteByRegistryIDAndName(ctx, info.RegistryID, info.Image)
+		}
+		return nil
+	})
+}
+
+func (c *localRegistry) ListTags(ctx context.Context, info npmtype.ArtifactInfo) (map[string]string, error) {
+	versions, err := c.getVersions(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return distTags(versions), nil
+}
+
+func (c *localRegistry) AddTag(ctx context.Context, info npmtype.ArtifactInfo) (map[string]string, error) {
+	var tags map[string]string
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		versions, err := c.getVersions(ctx, info)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(versions, func(v *version) bool { return v.artifact.Version == info.Version }) {
+			return commons.NotFoundError(
+				fmt.Sprintf("version %s of package %s not found", info.Version, info.Image), nil)
+		}
+
+		// a dist-tag points to a single version, so it's moved from the version it pointed to before.
+		for _, v := range versions {
+			idx := slices.Index(v.metadata.DistTags, info.DistTag)
+			switch {
+			case v.artifact.Version == info.Version && idx < 0:
+				v.metadata.DistTags = append(v.metadata.DistTags, info.DistTag)
+				slices.Sort(v.metadata.DistTags)
+			case v.artifact.Version != info.Version && idx >= 0:
+				v.metadata.DistTags = slices.Delete(v.metadata.DistTags, idx, idx+1)
+			default:
+				continue
+			}
+
+			if err = c.saveVersion(ctx, v); err != nil {
+				return err
+			}
+		}
+
+		tags = distTags(versions)
+		return nil
+	})
+	return tags, err
+}
+
+func (c *localRegistry) DeleteTag(ctx context.Context, info npmtype.ArtifactInfo) (map[string]string, error) {
+	if info.DistTag == latestTag {
+		return nil, commons.New(http.StatusBadRequest, "the latest dist-tag can't be deleted", nil)
+	}
+
+	var tags map[string]string
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		versions, err := c.getVersions(ctx, info)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, v := range versions {
+			idx := slices.Index(v.metadata.DistTags, info.DistTag)
+			if idx < 0 {
+				continue
+			}
+
+			found = true
+			v.metadata.DistTags = slices.Delete(v.metadata.DistTags, idx, idx+1)
+			if err = c.saveVersion(ctx, v); err != nil {
+				return err
+			}
+		}
+
+		if !found {
+			return commons.NotFoundError(fmt.Sprintf("dist-tag %s of package %s not found", info.DistTag, info.Image),
+				nil)
+		}
+
+		tags = distTags(versions)
+		return nil
+	})
+	return tags, err
+}
+
+// getVersions returns all the stored versions of the package, latest first.
+func (c *localRegistry) getVersions(ctx context.Context, info npmtype.ArtifactInfo) ([]*version, error) {
+	artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions of package %s: %w", info.Image, err)
+	}
+
+	if len(*artifacts) == 0 {
+		return nil, commons.NotFoundError(fmt.Sprintf("package %s not found", info.Image), nil)
+	}
+
+	versions := make([]*version, len(*artifacts))
+	for i, art := range *artifacts {
+		metadata := &npmmetadata.NpmMetadata{}
+		if err = json.Unmarshal(art.Metadata, metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata of version %s of package %s: %w",
+				art.Version, info.Image, err)
+		}
+		if metadata.PackageMetadata == nil {
+			metadata.PackageMetadata = npmmetadata.PackageMetadata{}
+		}
+		versions[i] = &version{artifact: art, metadata: metadata}
+	}
+
+	return versions, nil
+}
+
+func (c *localRegistry) saveVersion(ctx context.Context, v *version) error {
+	metadataJSON, err := json.Marshal(v.metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata of version %s: %w", v.artifact.Version, err)
+	}
+
+	v.artifact.Metadata = metadataJSON
+	if err = c.artifactDao.CreateOrUpdate(ctx, &v.artifact); err != nil {
+		return fmt.Errorf("failed to update version %s: %w", v.artifact.Version, err)
+	}
+	return nil
+}
+
+func distTags(versions []*version) map[string]string {
+	tags := make(map[string]string)
+	for _, v := range versions {
+		for _, tag := range v.metadata.DistTags {
+			tags[tag] = v.artifact.Version
+		}
+	}
+	return tags
+}
+
+// TarballURL returns the download URL of the tarball of the package version in the registry.
+func TarballURL(
+	ctx context.Context,
+	urlProvider urlprovider.Provider,
+	info npmtype.ArtifactInfo,
+	version string,
+) string {
+	return urlProvider.PackageURL(ctx, info.RootIdentifier, info.RegIdentifier, "npm", info.Image, "-",
+		npmtype.TarballName(info.Image, version))
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/services/refcache"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	cfg "github.com/harness/gitness/registry/config"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ pkg.Artifact = (*proxy)(nil)
+var _ Registry = (*proxy)(nil)
+
+type proxy struct {
+	fileManager         filemanager.FileManager
+	proxyStore          store.UpstreamProxyConfigRepository
+	tx                  dbtx.Transactor
+	registryDao         store.RegistryRepository
+	imageDao            store.ImageRepository
+	artifactDao         store.ArtifactRepository
+	urlProvider         urlprovider.Provider
+	spaceFinder         refcache.SpaceFinder
+	service             secret.Service
+	localRegistryHelper LocalRegistryHelper
+}
+
+type Proxy interface {
+	Registry
+}
+
+func NewProxy(
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+	localRegistryHelper LocalRegistryHelper,
+) Proxy {
+	return &proxy{
+		fileManager:         fileManager,
+		proxyStore:          proxyStore,
+		tx:                  tx,
+		registryDao:         registryDao,
+		imageDao:            imageDao,
+		artifactDao:         artifactDao,
+		urlProvider:         urlProvider,
+		spaceFinder:         spaceFinder,
+		service:             service,
+		localRegistryHelper: localRegistryHelper,
+	}
+}
+
+func (r *proxy) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeUPSTREAM
+}
+
+func (r *proxy) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeNPM}
+}
+
+// GetPackageMetadata Returns the package document from remote with the tarballs pointing to this registry.
+func (r *proxy) GetPackageMetadata(
+	ctx context.Context,
+	info npmtype.ArtifactInfo,
+) (npmtype.PackageMetadata, error) {
+	remote, err := r.newRemote(ctx, info)
+	if err != nil {
+		return npmtype.PackageMetadata{}, err
+	}
+
+	metadata, err := remote.GetMetadata(ctx, info.Image)
+	if err != nil {
+		return npmtype.PackageMetadata{}, err
+	}
+
+	for version, versionMetadata := range metadata.Versions {
+		if versionMetadata == nil {
+			continue
+		}
+		versionMetadata.SetTarball(TarballURL(ctx, r.urlProvider, info, version))
+	}
+	metadata.Rev = ""
+	return *metadata, nil
+}
+
+func (r *proxy) DownloadPackageFile(ctx context.Context, info npmtype.ArtifactInfo) (
+	*commons.ResponseHeaders,
+	*storage.FileReader,
+	io.ReadCloser,
+	string,
+	[]error,
+) {
+	exists := r.localRegistryHelper.FileExists(ctx, info)
+	if exists {
+		headers, fileReader, redirectURL, errors := r.localRegistryHelper.DownloadFile(ctx, info)
+		if len(errors) == 0 {
+			return headers, fileReader, nil, redirectURL, errors
+		}
+		// If file exists in local registry, but download failed, we should try to download from remote
+		log.Warn().Ctx(ctx).Msgf("failed to pull from local, attempting streaming from remote, %v", errors)
+	}
+
+	remote, err := r.newRemote(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+
+	file, err := remote.GetFile(ctx, info.Image, info.Version)
+	if err != nil {
+		return nil, nil, nil, "", []error{err}
+	}
+
+	go func(info npmtype.ArtifactInfo) {
+		ctx2 := context.WithoutCancel(ctx)
+		ctx2 = context.WithValue(ctx2, cfg.GoRoutineKey, "goRoutine")
+		err = r.putFileToLocal(ctx2, info, remote)
+		if err != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err).Msgf("error while putting file to localRegistry, %v", err)
+			return
+		}
+		log.Ctx(ctx2).Info().Msgf("Successfully updated file: %s, registry: %s", info.Filename, info.RegIdentifier)
+	}(info)
+
+	return nil, nil, file, "", nil
+}
+
+func (r *proxy) putFileToLocal(ctx context.Context, info npmtype.ArtifactInfo, remote RemoteRegistryHelper) error {
+	metadata, err := remote.GetMetadata(ctx, info.Image)
+	if err != nil {
+		log.Ctx(ctx).Error().Stack().Err(err).Msgf("fetching metadata for %s failed, %v", info.Image, err)
+		return err
+	}
+
+	versionMetadata, ok := metadata.Versions[info.Version]
+	if !ok {
+		return fmt.Errorf("version %s of package %s not found in remote", info.Version, info.Image)
+	}
+
+	file, err := remote.GetFile(ctx, info.Image, info.Version)
+	if err != nil {
+		log.Ctx(ctx).Error().Stack().Err(err).Msgf("fetching file %s failed, %v", info.Filename, err)
+		return err
+	}
+
+	info.Metadata = versionMetadata
+	// the dist-tags of a proxied package are always read from remote.
+	info.DistTag = ""
+
+	_, sha256, err2 := r.localRegistryHelper.UploadPackageFile(ctx, info, file)
+	if !commons.IsEmptyError(err2) {
+		log.Ctx(ctx).Error().Stack().Err(err2).Msgf("uploading file %s failed, %v", info.Filename, err2)
+		return err2
+	}
+	log.Info().Msgf("Successfully uploaded %s with SHA256: %s", info.Filename, sha256)
+	return nil
+}
+
+func (r *proxy) newRemote(ctx context.Context, info npmtype.ArtifactInfo) (RemoteRegistryHelper, error) {
+	upstreamProxy, err := r.proxyStore.GetByRegistryIdentifier(ctx, info.ParentID, info.RegIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteRegistryHelper(ctx, r.spaceFinder, *upstreamProxy, r.service)
+}
+
+func (r *proxy) UploadPackageFile(
+	ctx context.Context,
+	_ npmtype.ArtifactInfo,
+	_ io.ReadCloser,
+) (*commons.ResponseHeaders, string, errcode.Error) {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
+
+func (r *proxy) UpdatePackage(ctx context.Context, _ npmtype.ArtifactInfo, _ npmtype.PackageMetadata) error {
+	return notImplemented(ctx)
+}
+
+func (r *proxy) DeletePackage(ctx context.Context, _ npmtype.ArtifactInfo) error {
+	return notImplemented(ctx)
+}
+
+func (r *proxy) DeleteVersion(ctx context.Context, _ npmtype.ArtifactInfo) error {
+	return notImplemented(ctx)
+}
+
+func (r *proxy) ListTags(ctx context.Context, info npmtype.ArtifactInfo) (map[string]string, error) {
+	metadata, err := r.GetPackageMetadata(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.DistTags, nil
+}
+
+func (r *proxy) AddTag(ctx context.Context, _ npmtype.ArtifactInfo) (map[string]string, error) {
+	return nil, notImplemented(ctx)
+}
+
+func (r *proxy) DeleteTag(ctx context.Context, _ npmtype.ArtifactInfo) (map[string]string, error) {
+	return nil, notImplemented(ctx)
+}
+
+func notImplemented(ctx context.Context) error {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	// GetPackageMetadata returns the package document with all the versions and dist-tags of the package.
+	GetPackageMetadata(ctx context.Context, info npm.ArtifactInfo) (npm.PackageMetadata, error)
+
+	// UploadPackageFile publishes a new version of the package with the tarball and the version manifest.
+	UploadPackageFile(
+		ctx context.Context,
+		info npm.ArtifactInfo,
+		file io.ReadCloser,
+	) (*commons.ResponseHeaders, string, errcode.Error)
+
+	DownloadPackageFile(ctx context.Context, info npm.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		[]error,
+	)
+
+	// UpdatePackage applies the package document sent by the npm client on deprecate and unpublish:
+	// the deprecation messages and the dist-tags are updated, the versions missing in the document are removed.
+	UpdatePackage(ctx context.Context, info npm.ArtifactInfo, metadata npm.PackageMetadata) error
+
+	// DeletePackage removes all the versions of the package.
+	DeletePackage(ctx context.Context, info npm.ArtifactInfo) error
+
+	// DeleteVersion removes a single version of the package.
+	DeleteVersion(ctx context.Context, info npm.ArtifactInfo) error
+
+	ListTags(ctx context.Context, info npm.ArtifactInfo) (map[string]string, error)
+
+	// AddTag points the dist-tag info.DistTag to the version info.Version.
+	AddTag(ctx context.Context, info npm.ArtifactInfo) (map[string]string, error)
+
+	// DeleteTag removes the dist-tag info.DistTag.
+	DeleteTag(ctx context.Context, info npm.ArtifactInfo) (map[string]string, error)
+}
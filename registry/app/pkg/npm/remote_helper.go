@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/types/npm"
+	"github.com/harness/gitness/registry/app/remote/adapter"
+	npmadapter "github.com/harness/gitness/registry/app/remote/adapter/npm"
+	"github.com/harness/gitness/registry/app/remote/registry"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RemoteRegistryHelper interface {
+	// GetFile Downloads the tarball of the given package version
+	GetFile(ctx context.Context, pkg string, version string) (io.ReadCloser, error)
+
+	// GetMetadata Fetches the package document for the given package
+	GetMetadata(ctx context.Context, pkg string) (*npm.PackageMetadata, error)
+}
+
+type remoteRegistryHelper struct {
+	adapter  registry.NpmRegistry
+	registry types.UpstreamProxy
+}
+
+func NewRemoteRegistryHelper(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	registry types.UpstreamProxy,
+	service secret.Service,
+) (RemoteRegistryHelper, error) {
+	r := &remoteRegistryHelper{
+		registry: registry,
+	}
+	if err := r.init(ctx, spaceFinder, service); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to init remote registry for remote: %s", registry.RepoKey)
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *remoteRegistryHelper) init(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+) error {
+	key := string(artifact.UpstreamConfigSourceNpmJs)
+	if r.registry.Source == string(artifact.UpstreamConfigSourceNpmJs) {
+		r.registry.RepoURL = npmadapter.NpmJsURL
+	}
+
+	factory, err := adapter.GetFactory(key)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to get factory " + key)
+		return err
+	}
+
+	adpt, err := factory.Create(ctx, spaceFinder, r.registry, service)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to create factory " + key)
+		return err
+	}
+
+	npmReg, ok := adpt.(registry.NpmRegistry)
+	if !ok {
+		log.Ctx(ctx).Error().Msg("failed to cast factory to npm registry")
+		return fmt.Errorf("failed to cast factory to npm registry")
+	}
+	r.adapter = npmReg
+	return nil
+}
+
+func (r *remoteRegistryHelper) GetFile(ctx context.Context, pkg string, version string) (io.ReadCloser, error) {
+	file, err := r.adapter.GetPackage(ctx, pkg, version)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get pkg: %s, version: %s", pkg, version)
+	}
+	return file, err
+}
+
+func (r *remoteRegistryHelper) GetMetadata(ctx context.Context, pkg string) (*npm.PackageMetadata, error) {
+	metadata, err := r.adapter.GetPackageMetadata(ctx, pkg)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to get metadata for pkg: %s", pkg)
+		return nil, err
+	}
+	return metadata, nil
+}
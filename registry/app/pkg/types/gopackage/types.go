@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gopackage
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	"github.com/harness/gitness/registry/app/pkg"
+)
+
+const (
+	// ListFile is the list of the versions of a module, requested as <module>/@v/list.
+	ListFile = "list"
+	// LatestFile is the info of the latest version of a module, requested as <module>/@latest.
+	LatestFile = "@latest"
+
+	InfoExtension = ".info"
+	ModExtension  = ".mod"
+	ZipExtension  = ".zip"
+)
+
+type ArtifactInfo struct {
+	pkg.ArtifactInfo
+	Version  string
+	Filename string
+	Metadata gopackage.VersionInfo
+}
+
+// BaseArtifactInfo implements pkg.PackageArtifactInfo interface.
+func (a ArtifactInfo) BaseArtifactInfo() pkg.ArtifactInfo {
+	return a.ArtifactInfo
+}
+
+// BuildRequest is sent to build a module version from a tag of a repository in the space of the registry.
+// Tags of modules in subdirectories are prefixed with the directory, e.g. "sub/dir/v1.0.0".
+type BuildRequest struct {
+	Repo string `json:"repo"`
+	Tag  string `json:"tag"`
+}
+
+// ModuleVersion is a version of a module stored in the registry, returned on upload and build.
+type ModuleVersion struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Sha256  string `json:"sha256"`
+}
+
+// EscapePath escapes the upper-case letters of a module path or version as the GOPROXY protocol requires,
+// e.g. "github.com/Azure" becomes "github.com/!azure".
+func EscapePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if 'A' <= r && r <= 'Z' {
+			b.WriteByte('!')
+			b.WriteRune(r + 'a' - 'A')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// UnescapePath reverts EscapePath. Escaped paths don't contain upper-case letters.
+func UnescapePath(escaped string) (string, error) {
+	var b strings.Builder
+	bang := false
+	for _, r := range escaped {
+		switch {
+		case r == utf8.RuneError:
+			return "", fmt.Errorf("invalid escaped path %q", escaped)
+		case bang:
+			if r < 'a' || r > 'z' {
+				return "", fmt.Errorf("invalid escaped path %q", escaped)
+			}
+			b.WriteRune(r + 'A' - 'a')
+			bang = false
+		case r == '!':
+			bang = true
+		case 'A' <= r && r <= 'Z':
+			return "", fmt.Errorf("invalid escaped path %q", escaped)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if bang {
+		return "", fmt.Errorf("invalid escaped path %q", escaped)
+	}
+	return b.String(), nil
+}
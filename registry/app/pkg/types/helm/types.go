@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"time"
+
+	"github.com/harness/gitness/registry/app/metadata/helm"
+	"github.com/harness/gitness/registry/app/pkg"
+)
+
+const (
+	ChartExtension      = ".tgz"
+	ProvenanceExtension = ".tgz.prov"
+)
+
+type ArtifactInfo struct {
+	pkg.ArtifactInfo
+	Version  string
+	Filename string
+	Metadata helm.HelmMetadata
+}
+
+// BaseArtifactInfo implements pkg.PackageArtifactInfo interface.
+func (a ArtifactInfo) BaseArtifactInfo() pkg.ArtifactInfo {
+	return a.ArtifactInfo
+}
+
+// ChartFilename returns the file name of the archive of a chart version, e.g. "name-1.0.0.tgz".
+func ChartFilename(name string, version string) string {
+	return name + "-" + version + ChartExtension
+}
+
+// IndexFile is the index.yaml of a chart repository.
+// Source: https://helm.sh/docs/topics/chart_repository/#the-index-file
+type IndexFile struct {
+	APIVersion string                     `yaml:"apiVersion"`
+	Entries    map[string][]*ChartVersion `yaml:"entries"`
+	Generated  time.Time                  `yaml:"generated"`
+}
+
+// ChartVersion is an entry of the index, the metadata of a chart version with the URLs to download it.
+type ChartVersion struct {
+	helm.ChartMetadata `yaml:",inline"`
+	URLs               []string  `yaml:"urls"`
+	Created            time.Time `yaml:"created,omitempty"`
+	Digest             string    `yaml:"digest,omitempty"`
+}
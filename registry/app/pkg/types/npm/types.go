@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"path"
+
+	"github.com/harness/gitness/registry/app/metadata/npm"
+	"github.com/harness/gitness/registry/app/pkg"
+)
+
+type ArtifactInfo struct {
+	pkg.ArtifactInfo
+	Version  string
+	Filename string
+	// DistTag is the distribution tag of the request, e.g. the tag to publish the version with.
+	DistTag  string
+	Metadata npm.PackageMetadata
+}
+
+// BaseArtifactInfo implements pkg.PackageArtifactInfo interface.
+func (a ArtifactInfo) BaseArtifactInfo() pkg.ArtifactInfo {
+	return a.ArtifactInfo
+}
+
+// TarballName returns the file name of the tarball of a package version, e.g. "name-1.0.0.tgz" for "@scope/name".
+func TarballName(name string, version string) string {
+	return path.Base(name) + "-" + version + ".tgz"
+}
+
+// PackageMetadata is the package document served for a package and sent by the npm client on publish.
+// Source: https://github.com/npm/registry/blob/main/docs/REGISTRY-API.md#package
+type PackageMetadata struct {
+	ID          string                         `json:"_id"`
+	Rev         string                         `json:"_rev,omitempty"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description,omitempty"`
+	DistTags    map[string]string              `json:"dist-tags"`
+	Versions    map[string]npm.PackageMetadata `json:"versions"`
+	Time        map[string]string              `json:"time,omitempty"`
+	Attachments map[string]*PackageAttachment  `json:"_attachments,omitempty"`
+}
+
+// PackageAttachment is a base64 encoded tarball of a version sent by the npm client on publish.
+type PackageAttachment struct {
+	ContentType string `json:"content_type"`
+	Data        string `json:"data"`
+	Length      int64  `json:"length"`
+}
+
+// LoginRequest is sent by the npm client on "npm login --auth-type=legacy".
+type LoginRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	OK    bool   `json:"ok"`
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type WhoamiResponse struct {
+	Username string `json:"username"`
+}
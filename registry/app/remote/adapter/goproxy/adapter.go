@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goproxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+	gotype "github.com/harness/gitness/registry/app/pkg/types/gopackage"
+	adp "github.com/harness/gitness/registry/app/remote/adapter"
+	"github.com/harness/gitness/registry/app/remote/adapter/native"
+	"github.com/harness/gitness/registry/app/remote/registry"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ registry.GoProxyRegistry = (*adapter)(nil)
+var _ adp.Adapter = (*adapter)(nil)
+
+const (
+	GoProxyURL = "https://proxy.golang.org"
+)
+
+type adapter struct {
+	*native.Adapter
+	registry types.UpstreamProxy
+	client   *client
+}
+
+func newAdapter(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	registry types.UpstreamProxy,
+	service secret.Service,
+) (adp.Adapter, error) {
+	c, err := newClient(ctx, registry, spaceFinder, service)
+	if err != nil {
+		return nil, err
+	}
+	nativeAdapter := native.NewAdapter(ctx, spaceFinder, service, registry)
+
+	return &adapter{
+		Adapter:  nativeAdapter,
+		registry: registry,
+		client:   c,
+	}, nil
+}
+
+type factory struct {
+}
+
+func (f *factory) Create(
+	ctx context.Context, spaceFinder refcache.SpaceFinder, record types.UpstreamProxy, service secret.Service,
+) (adp.Adapter, error) {
+	return newAdapter(ctx, spaceFinder, record, service)
+}
+
+func init() {
+	adapterType := string(artifact.UpstreamConfigSourceGoProxy)
+	if err := adp.RegisterFactory(adapterType, new(factory)); err != nil {
+		log.Error().Stack().Err(err).Msgf("Failed to register adapter factory for %s", adapterType)
+		return
+	}
+	log.Info().Stack().Msgf("Registered adapter factory for %s", adapterType)
+}
+
+// GetVersionList returns the versions of the module listed by the proxy, pseudo-versions excluded.
+func (a *adapter) GetVersionList(ctx context.Context, module string) ([]string, error) {
+	readCloser, err := a.client.getFile(ctx, a.moduleURL(module)+"/@v/"+gotype.ListFile, "")
+	if err != nil {
+		return nil, err
+	}
+	defer readCloser.Close()
+
+	var versions []string
+	scanner := bufio.NewScanner(readCloser)
+	for scanner.Scan() {
+		// each line may have the version followed by other fields, reserved for future use.
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			versions = append(versions, fields[0])
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read versions of module %s: %w", module, err)
+	}
+	return versions, nil
+}
+
+func (a *adapter) GetVersionInfo(ctx context.Context, module string, version string) (*gopackage.VersionInfo, error) {
+	return a.getInfo(ctx, a.versionURL(module, version, gotype.InfoExtension))
+}
+
+func (a *adapter) GetLatestVersion(ctx context.Context, module string) (*gopackage.VersionInfo, error) {
+	return a.getInfo(ctx, a.moduleURL(module)+"/"+gotype.LatestFile)
+}
+
+func (a *adapter) GetModFile(ctx context.Context, module string, version string) (io.ReadCloser, error) {
+	return a.client.getFile(ctx, a.versionURL(module, version, gotype.ModExtension), "")
+}
+
+func (a *adapter) GetModuleZip(ctx context.Context, module string, version string) (io.ReadCloser, error) {
+	return a.client.getFile(ctx, a.versionURL(module, version, gotype.ZipExtension), "")
+}
+
+func (a *adapter) getInfo(ctx context.Context, infoURL string) (*gopackage.VersionInfo, error) {
+	readCloser, err := a.client.getFile(ctx, infoURL, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer readCloser.Close()
+
+	var info gopackage.VersionInfo
+	if err = json.NewDecoder(readCloser).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", infoURL, err)
+	}
+	if info.Version == "" {
+		return nil, fmt.Errorf("invalid version info: %s has no version", infoURL)
+	}
+	return &info, nil
+}
+
+func (a *adapter) moduleURL(module string) string {
+	return a.client.url + "/" + gotype.EscapePath(module)
+}
+
+func (a *adapter) versionURL(module string, version string, extension string) string {
+	return a.moduleURL(module) + "/@v/" + gotype.EscapePath(version) + extension
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goproxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAdapter creates an adapter for a local stand-in of a module proxy.
+func newTestAdapter(t *testing.T) *adapter {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github.com/!azure/mod/@v/list":
+			_, _ = io.WriteString(w, "v1.0.0\nv1.1.0-rc.1\n\n")
+		case "/github.com/!azure/mod/@v/v1.0.0.info", "/github.com/!azure/mod/@latest":
+			_, _ = io.WriteString(w, `{"Version":"v1.0.0","Time":"2024-01-02T03:04:05Z"}`)
+		case "/github.com/!azure/mod/@v/v1.0.0.mod":
+			_, _ = io.WriteString(w, "module github.com/Azure/mod\n")
+		case "/github.com/!azure/mod/@v/v1.0.0.zip":
+			_, _ = io.WriteString(w, "zip")
+		case "/github.com/!azure/mod/@v/v2.0.0.info":
+			http.Error(w, "gone", http.StatusGone)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	a, err := newAdapter(context.Background(), refcache.SpaceFinder{}, types.UpstreamProxy{
+		RepoKey:      "go-proxy",
+		RepoURL:      server.URL,
+		RepoAuthType: string(artifact.AuthTypeAnonymous),
+	}, nil)
+	require.NoError(t, err)
+
+	goAdapter, ok := a.(*adapter)
+	require.True(t, ok)
+	return goAdapter
+}
+
+func TestAdapter_GetVersionList(t *testing.T) {
+	a := newTestAdapter(t)
+
+	versions, err := a.GetVersionList(context.Background(), "github.com/Azure/mod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0-rc.1"}, versions)
+
+	_, err = a.GetVersionList(context.Background(), "github.com/azure/mod")
+	var commonsErr *commons.Error
+	require.True(t, errors.As(err, &commonsErr))
+	assert.Equal(t, http.StatusNotFound, commonsErr.Status)
+}
+
+func TestAdapter_GetVersionInfo(t *testing.T) {
+	a := newTestAdapter(t)
+
+	info, err := a.GetVersionInfo(context.Background(), "github.com/Azure/mod", "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", info.Version)
+	assert.Equal(t, 2024, info.Time.Year())
+
+	latest, err := a.GetLatestVersion(context.Background(), "github.com/Azure/mod")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", latest.Version)
+
+	_, err = a.GetVersionInfo(context.Background(), "github.com/Azure/mod", "v2.0.0")
+	var commonsErr *commons.Error
+	require.True(t, errors.As(err, &commonsErr))
+	assert.Equal(t, http.StatusNotFound, commonsErr.Status)
+}
+
+func TestAdapter_GetModuleFiles(t *testing.T) {
+	a := newTestAdapter(t)
+
+	readCloser, err := a.GetModFile(context.Background(), "github.com/Azure/mod", "v1.0.0")
+	require.NoError(t, err)
+	defer readCloser.Close()
+	content, err := io.ReadAll(readCloser)
+	require.NoError(t, err)
+	assert.Equal(t, "module github.com/Azure/mod\n", string(content))
+
+	zipReadCloser, err := a.GetModuleZip(context.Background(), "github.com/Azure/mod", "v1.0.0")
+	require.NoError(t, err)
+	defer zipReadCloser.Close()
+	content, err = io.ReadAll(zipReadCloser)
+	require.NoError(t, err)
+	assert.Equal(t, "zip", string(content))
+
+	_, err = a.GetModuleZip(context.Background(), "github.com/Azure/mod", "v3.0.0")
+	assert.Error(t, err)
+}
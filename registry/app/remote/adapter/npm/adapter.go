@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	npmtype "github.com/harness/gitness/registry/app/pkg/types/npm"
+	adp "github.com/harness/gitness/registry/app/remote/adapter"
+	"github.com/harness/gitness/registry/app/remote/adapter/native"
+	"github.com/harness/gitness/registry/app/remote/registry"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ registry.NpmRegistry = (*adapter)(nil)
+var _ adp.Adapter = (*adapter)(nil)
+
+const (
+	NpmJsURL = "https://registry.npmjs.org"
+)
+
+type adapter struct {
+	*native.Adapter
+	registry types.UpstreamProxy
+	client   *client
+}
+
+func newAdapter(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	registry types.UpstreamProxy,
+	service secret.Service,
+) (adp.Adapter, error) {
+	c, err := newClient(ctx, registry, spaceFinder, service)
+	if err != nil {
+		return nil, err
+	}
+	nativeAdapter := native.NewAdapter(ctx, spaceFinder, service, registry)
+
+	return &adapter{
+		Adapter:  nativeAdapter,
+		registry: registry,
+		client:   c,
+	}, nil
+}
+
+type factory struct {
+}
+
+func (f *factory) Create(
+	ctx context.Context, spaceFinder refcache.SpaceFinder, record types.UpstreamProxy, service secret.Service,
+) (adp.Adapter, error) {
+	return newAdapter(ctx, spaceFinder, record, service)
+}
+
+func init() {
+	adapterType := string(artifact.UpstreamConfigSourceNpmJs)
+	if err := adp.RegisterFactory(adapterType, new(factory)); err != nil {
+		log.Error().Stack().Err(err).Msgf("Failed to register adapter factory for %s", adapterType)
+		return
+	}
+	log.Info().Stack().Msgf("Registered adapter factory for %s", adapterType)
+}
+
+// GetPackageMetadata fetches the package document with all the versions of the package.
+func (a *adapter) GetPackageMetadata(ctx context.Context, pkg string) (*npmtype.PackageMetadata, error) {
+	readCloser, err := a.client.getFile(ctx, a.client.url+"/"+EscapePackageName(pkg), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer readCloser.Close()
+
+	var metadata npmtype.PackageMetadata
+	if err = json.NewDecoder(readCloser).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata of package %s: %w", pkg, err)
+	}
+
+	if metadata.Name != pkg {
+		return nil, fmt.Errorf("invalid metadata: expected package %s, got %s", pkg, metadata.Name)
+	}
+
+	return &metadata, nil
+}
+
+// GetPackage downloads the tarball of the given package version.
+func (a *adapter) GetPackage(ctx context.Context, pkg string, version string) (io.ReadCloser, error) {
+	metadata, err := a.GetPackageMetadata(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	versionMetadata, ok := metadata.Versions[version]
+	if !ok || versionMetadata.Tarball() == "" {
+		return nil, fmt.Errorf("pkg: %s, version: %s not found", pkg, version)
+	}
+
+	downloadURL := versionMetadata.Tarball()
+	log.Ctx(ctx).Info().Msgf("Download URL: %s", downloadURL)
+	readCloser, err := a.client.getFile(ctx, downloadURL, "")
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("Failed to get file from URL: %s", downloadURL)
+		return nil, err
+	}
+	return readCloser, nil
+}
+
+// EscapePackageName escapes the slash of a scoped package name as expected by the npm registries,
+// e.g. "@scope/name" is escaped to "@scope%2fname".
+func EscapePackageName(pkg string) string {
+	return strings.Replace(pkg, "/", "%2f", 1)
+}
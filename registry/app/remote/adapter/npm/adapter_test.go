@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAdapter creates an adapter for a local stand-in of the npm registry.
+func newTestAdapter(t *testing.T) *adapter {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/@scope%2fpkg":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{
+				"_id": "@scope/pkg",
+				"name": "@scope/pkg",
+				"dist-tags": {"latest": "1.0.0"},
+				"versions": {
+					"1.0.0": {
+						"name": "@scope/pkg",
+						"version": "1.0.0",
+						"dist": {"tarball": "`+server.URL+`/@scope/pkg/-/pkg-1.0.0.tgz"}
+					}
+				}
+			}`)
+		case "/@scope/pkg/-/pkg-1.0.0.tgz":
+			_, _ = io.WriteString(w, "tarball")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	a, err := newAdapter(context.Background(), refcache.SpaceFinder{}, types.UpstreamProxy{
+		RepoKey:      "npm-proxy",
+		RepoURL:      server.URL,
+		RepoAuthType: string(artifact.AuthTypeAnonymous),
+	}, nil)
+	require.NoError(t, err)
+
+	npmAdapter, ok := a.(*adapter)
+	require.True(t, ok)
+	return npmAdapter
+}
+
+func TestAdapter_GetPackageMetadata(t *testing.T) {
+	a := newTestAdapter(t)
+
+	metadata, err := a.GetPackageMetadata(context.Background(), "@scope/pkg")
+	require.NoError(t, err)
+	assert.Equal(t, "@scope/pkg", metadata.Name)
+	assert.Equal(t, map[string]string{"latest": "1.0.0"}, metadata.DistTags)
+	assert.Equal(t, "1.0.0", metadata.Versions["1.0.0"].Version())
+
+	_, err = a.GetPackageMetadata(context.Background(), "unknown")
+	var commonsErr *commons.Error
+	require.True(t, errors.As(err, &commonsErr))
+	assert.Equal(t, http.StatusNotFound, commonsErr.Status)
+}
+
+func TestAdapter_GetPackage(t *testing.T) {
+	a := newTestAdapter(t)
+
+	readCloser, err := a.GetPackage(context.Background(), "@scope/pkg", "1.0.0")
+	require.NoError(t, err)
+	defer readCloser.Close()
+
+	content, err := io.ReadAll(readCloser)
+	require.NoError(t, err)
+	assert.Equal(t, "tarball", string(content))
+
+	_, err = a.GetPackage(context.Background(), "@scope/pkg", "2.0.0")
+	assert.Error(t, err)
+}
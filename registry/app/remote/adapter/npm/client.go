@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/app/services/refcache"
+	commonhttp "github.com/harness/gitness/registry/app/common/http"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	adaptercommons "github.com/harness/gitness/registry/app/remote/adapter/commons"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+type client struct {
+	client   *http.Client
+	url      string
+	username string
+	password string
+}
+
+// newClient creates a new npm client.
+func newClient(
+	ctx context.Context,
+	registry types.UpstreamProxy,
+	finder refcache.SpaceFinder,
+	service secret.Service,
+) (*client, error) {
+	accessKey, secretKey, _, err := adaptercommons.GetCredentials(ctx, finder, service, registry)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("error getting credentials for registry: %s %v", registry.RepoKey, err)
+		return nil, err
+	}
+
+	c := &client{
+		url: strings.TrimRight(registry.RepoURL, "/"),
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(true)),
+		},
+		username: accessKey,
+		password: secretKey,
+	}
+
+	return c, nil
+}
+
+// getFile downloads the file from the given URL. The credentials are sent only to the host of the registry,
+// the tarballs might be served from a different host (e.g. CDN).
+func (c *client) getFile(ctx context.Context, fileURL string, accept string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if (c.username != "" || c.password != "") && c.isRegistryHost(req.URL) {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	log.Ctx(ctx).Info().Msgf("[Remote Call]: Request: %s %s", req.Method, req.URL.String())
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		message := fmt.Sprintf("failed to get %s, http status code: %d", fileURL, resp.StatusCode)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, commons.NotFoundError(message, nil)
+		}
+		return nil, commons.New(http.StatusBadGateway, message, nil)
+	}
+
+	return resp.Body, nil
+}
+
+func (c *client) isRegistryHost(u *url.URL) bool {
+	registryURL, err := url.Parse(c.url)
+	if err != nil {
+		return false
+	}
+	return registryURL.Host == u.Host
+}
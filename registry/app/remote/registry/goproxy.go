@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/metadata/gopackage"
+)
+
+type GoProxyRegistry interface {
+	GetVersionList(ctx context.Context, module string) ([]string, error)
+	GetVersionInfo(ctx context.Context, module string, version string) (*gopackage.VersionInfo, error)
+	GetLatestVersion(ctx context.Context, module string) (*gopackage.VersionInfo, error)
+	GetModFile(ctx context.Context, module string, version string) (io.ReadCloser, error)
+	GetModuleZip(ctx context.Context, module string, version string) (io.ReadCloser, error)
+}
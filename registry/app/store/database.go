@@ -53,6 +53,8 @@ type BlobRepository interface {
 type CleanupPolicyRepository interface {
 	// GetIdsByRegistryId the CleanupPolicy Ids specified by Registry Key
 	GetIDsByRegistryID(ctx context.Context, id int64) (ids []int64, err error)
+	// GetRegistryIDs returns the ids of all registries that have cleanup policies
+	GetRegistryIDs(ctx context.Context) (ids []int64, err error)
 	// GetByRegistryId the CleanupPolicy specified by Registry Key
 	GetByRegistryID(
 		ctx context.Context,
@@ -248,6 +250,10 @@ type TagRepository interface {
 		search string,
 	) (*[]types.TagMetadata, error)
 
+	// GetAllTagsByRegistryID returns all tags of the registry ordered by the image name
+	// and the update time, most recent first.
+	GetAllTagsByRegistryID(ctx context.Context, registryID int64) ([]*types.Tag, error)
+
 	DeleteTag(ctx context.Context, registryID int64, imageName string, name string) (err error)
 
 	CountAllTagsByRepoAndImage(
@@ -412,6 +418,8 @@ type ImageRepository interface {
 		ctx context.Context, registryID int64,
 		name string,
 	) (*types.Image, error)
+	// GetByRegistryID returns all the images of a registry ordered by name.
+	GetByRegistryID(ctx context.Context, registryID int64) (*[]types.Image, error)
 	// Get the Labels specified by Parent ID and Repo
 	GetLabelsByParentIDAndRepo(
 		ctx context.Context, parentID int64,
@@ -438,6 +446,9 @@ type ImageRepository interface {
 	DeleteByRegistryID(ctx context.Context, registryID int64) (err error)
 	DeleteBandwidthStatByRegistryID(ctx context.Context, registryID int64) (err error)
 	DeleteDownloadStatByRegistryID(ctx context.Context, registryID int64) (err error)
+	// DeleteByRegistryIDAndName deletes an image along with its bandwidth stats.
+	// All versions of the image must be deleted before.
+	DeleteByRegistryIDAndName(ctx context.Context, registryID int64, name string) (err error)
 }
 
 type ArtifactRepository interface {
@@ -486,6 +497,8 @@ type ArtifactRepository interface {
 		*[]types.Artifact,
 		error,
 	)
+	// DeleteByImageIDAndVersion deletes a version of an image along with its download stats.
+	DeleteByImageIDAndVersion(ctx context.Context, imageID int64, version string) (err error)
 }
 
 type DownloadStatRepository interface {
@@ -530,6 +543,11 @@ type GCManifestTaskRepository interface {
 	DeleteManifest(ctx context.Context, registryID, id int64) (*digest.Digest, error)
 }
 
+type GCReviewAfterDefaultRepository interface {
+	// UpdateAll sets the review delay of all garbage collection events.
+	UpdateAll(ctx context.Context, reviewAfter time.Duration) error
+}
+
 type NodesRepository interface {
 	// Get a node specified by ID
 	Get(ctx context.Context, id int64) (*types.Node, error)
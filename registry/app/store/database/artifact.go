@@ -145,6 +145,52 @@ func (a ArtifactDao) CreateOrUpdate(ctx context.Context, artifact *types.Artifac
 	return nil
 }
 
+func (a ArtifactDao) DeleteByImageIDAndVersion(ctx context.Context, imageID int64, version string) (err error) {
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	var ids []int64
+	stmt := databaseg.Builder.Select("artifact_id").
+		From("artifacts").
+		Where("artifact_image_id = ? AND artifact_version = ?", imageID, version)
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if err = db.SelectContext(ctx, &ids, query, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to find artifact")
+	}
+
+	delStmt := databaseg.Builder.Delete("download_stats").
+		Where(sq.Eq{"download_stat_artifact_id": ids})
+
+	delQuery, delArgs, err := delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, delQuery, delArgs...)
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	delStmt = databaseg.Builder.Delete("artifacts").
+		Where("artifact_image_id = ? AND artifact_version = ?", imageID, version)
+
+	delQuery, delArgs, err = delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, delQuery, delArgs...)
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	return nil
+}
+
 func (a ArtifactDao) Count(ctx context.Context) (int64, error) {
 	stmt := databaseg.Builder.Select("COUNT(*)").
 		From("artifacts")
@@ -38,14 +38,15 @@ type CleanupPolicyDao struct {
 }
 
 type CleanupPolicyDB struct {
-	ID             int64  `db:"cp_id"`
-	RegistryID     int64  `db:"cp_registry_id"`
-	Name           string `db:"cp_name"`
-	ExpiryTimeInMs int64  `db:"cp_expiry_time_ms"`
-	CreatedAt      int64  `db:"cp_created_at"`
-	UpdatedAt      int64  `db:"cp_updated_at"`
-	CreatedBy      int64  `db:"cp_created_by"`
-	UpdatedBy      int64  `db:"cp_updated_by"`
+	ID               int64  `db:"cp_id"`
+	RegistryID       int64  `db:"cp_registry_id"`
+	Name             string `db:"cp_name"`
+	ExpiryTimeInMs   int64  `db:"cp_expiry_time_ms"`
+	KeepLastVersions int    `db:"cp_keep_last_versions"`
+	CreatedAt        int64  `db:"cp_created_at"`
+	UpdatedAt        int64  `db:"cp_updated_at"`
+	CreatedBy        int64  `db:"cp_created_by"`
+	UpdatedBy        int64  `db:"cp_updated_by"`
 }
 
 type CleanupPolicyPrefixMappingDB struct {
@@ -88,6 +89,23 @@ func (c CleanupPolicyDao) GetIDsByRegistryID(ctx context.Context, id int64) (ids
 	return res, nil
 }
 
+// GetRegistryIDs returns the ids of all registries that have at least one cleanup policy.
+func (c CleanupPolicyDao) GetRegistryIDs(ctx context.Context) (ids []int64, err error) {
+	stmt := databaseg.Builder.Select("DISTINCT cp_registry_id").From("cleanup_policies").
+		OrderBy("cp_registry_id")
+	db := dbtx.GetAccessor(ctx, c.db)
+	var res []int64
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to get registry ids of cleanup policies")
+	}
+
+	return res, nil
+}
+
 func (c CleanupPolicyDao) GetByRegistryID(
 	ctx context.Context,
 	id int64,
@@ -97,17 +115,19 @@ func (c CleanupPolicyDao) GetByRegistryID(
 		"cp_registry_id",
 		"cp_name",
 		"cp_expiry_time_ms",
+		"cp_keep_last_versions",
 		"cp_created_at",
 		"cp_updated_at",
 		"cp_created_by",
 		"cp_updated_by",
-		"cpp_id",
-		"cpp_cleanup_policy_id",
-		"cpp_prefix",
-		"cpp_prefix_type",
+		"COALESCE(cpp_id, 0) AS cpp_id",
+		"COALESCE(cpp_cleanup_policy_id, 0) AS cpp_cleanup_policy_id",
+		"COALESCE(cpp_prefix, '') AS cpp_prefix",
+		"COALESCE(cpp_prefix_type, '') AS cpp_prefix_type",
 	).
 		From("cleanup_policies").
-		Join("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
+		// policies without any prefix apply to all packages and versions.
+		LeftJoin("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
 		Where("cp_registry_id = ?", id)
 
 	db := dbtx.GetAccessor(ctx, c.db)
@@ -142,6 +162,7 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			cp_registry_id
 			,cp_name
 			,cp_expiry_time_ms
+			,cp_keep_last_versions
 			,cp_created_at
 			,cp_updated_at
 			,cp_created_by
@@ -150,6 +171,7 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			:cp_registry_id
 			,:cp_name
 			,:cp_expiry_time_ms
+			,:cp_keep_last_versions
 			,:cp_created_at
 			,:cp_updated_at
 			,:cp_created_by
@@ -340,14 +362,15 @@ func (c CleanupPolicyDao) mapToInternalCleanupPolicy(
 	cp.UpdatedBy = session.Principal.ID
 
 	return &CleanupPolicyDB{
-		ID:             cp.ID,
-		RegistryID:     cp.RegistryID,
-		Name:           cp.Name,
-		ExpiryTimeInMs: cp.ExpiryTime,
-		CreatedAt:      cp.CreatedAt.UnixMilli(),
-		UpdatedAt:      cp.UpdatedAt.UnixMilli(),
-		CreatedBy:      cp.CreatedBy,
-		UpdatedBy:      cp.UpdatedBy,
+		ID:               cp.ID,
+		RegistryID:       cp.RegistryID,
+		Name:             cp.Name,
+		ExpiryTimeInMs:   cp.ExpiryTime,
+		KeepLastVersions: cp.KeepLastVersions,
+		CreatedAt:        cp.CreatedAt.UnixMilli(),
+		UpdatedAt:        cp.UpdatedAt.UnixMilli(),
+		CreatedBy:        cp.CreatedBy,
+		UpdatedBy:        cp.UpdatedBy,
 	}
 }
 
@@ -366,14 +389,15 @@ func (c CleanupPolicyDao) mapToCleanupPolicies(
 
 		if _, exists := cleanupPolicies[cp.ID]; !exists {
 			cleanupPolicies[cp.ID] = &types.CleanupPolicy{
-				ID:            cp.ID,
-				RegistryID:    cp.RegistryID,
-				Name:          cp.Name,
-				ExpiryTime:    cp.ExpiryTimeInMs,
-				CreatedAt:     time.UnixMilli(cp.CreatedAt),
-				UpdatedAt:     time.UnixMilli(cp.UpdatedAt),
-				PackagePrefix: make([]string, 0),
-				VersionPrefix: make([]string, 0),
+				ID:               cp.ID,
+				RegistryID:       cp.RegistryID,
+				Name:             cp.Name,
+				ExpiryTime:       cp.ExpiryTimeInMs,
+				KeepLastVersions: cp.KeepLastVersions,
+				CreatedAt:        time.UnixMilli(cp.CreatedAt),
+				UpdatedAt:        time.UnixMilli(cp.UpdatedAt),
+				PackagePrefix:    make([]string, 0),
+				VersionPrefix:    make([]string, 0),
 			}
 		}
 
@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	gcBlobReviewQueueTable = "gc_blob_review_queue"
+
+	// gcLockSuffix locks the selected review tasks. SQLite doesn't support row locks,
+	// but its write transactions are serialized, so the suffix is omitted there.
+	gcLockSuffix     = "FOR UPDATE"
+	gcSkipLockSuffix = "FOR UPDATE SKIP LOCKED"
+)
+
+type gcBlobTaskDao struct {
+	db *sqlx.DB
+}
+
+func NewGCBlobTaskDao(db *sqlx.DB) store.GCBlobTaskRepository {
+	return &gcBlobTaskDao{
+		db: db,
+	}
+}
+
+type gcBlobTaskDB struct {
+	BlobID      int64  `db:"blob_id"`
+	ReviewAfter int64  `db:"review_after"`
+	ReviewCount int    `db:"review_count"`
+	CreatedAt   int64  `db:"created_at"`
+	Event       string `db:"event"`
+}
+
+var gcBlobTaskColumns = []string{
+	"blob_id",
+	"review_after",
+	"review_count",
+	"created_at",
+	"event",
+}
+
+func (dao gcBlobTaskDao) FindAll(ctx context.Context) ([]*types.GCBlobTask, error) {
+	stmt := databaseg.Builder.
+		Select(gcBlobTaskColumns...).
+		From(gcBlobReviewQueueTable).
+		OrderBy("review_after ASC")
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find gc blob tasks query to sql: %w", err)
+	}
+
+	dst := []*gcBlobTaskDB{}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to find gc blob tasks")
+	}
+
+	tasks := make([]*types.GCBlobTask, len(dst))
+	for i := range dst {
+		tasks[i] = mapToGCBlobTask(dst[i])
+	}
+
+	return tasks, nil
+}
+
+// FindAndLockBefore finds and locks the review task of the blob if it's due for review before the date.
+// It returns nil if there is no such task.
+func (dao gcBlobTaskDao) FindAndLockBefore(
+	ctx context.Context,
+	blobID int64,
+	date time.Time,
+) (*types.GCBlobTask, error) {
+	stmt := databaseg.Builder.
+		Select(gcBlobTaskColumns...).
+		From(gcBlobReviewQueueTable).
+		Where("blob_id = ? AND review_after < ?", blobID, date.Unix())
+
+	return dao.findOne(ctx, dao.lock(stmt, gcLockSuffix))
+}
+
+func (dao gcBlobTaskDao) Count(ctx context.Context) (int, error) {
+	stmt := databaseg.Builder.
+		Select("COUNT(*)").
+		From(gcBlobReviewQueueTable)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count gc blob tasks query to sql: %w", err)
+	}
+
+	var count int
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		return 0, databaseg.ProcessSQLErrorf(ctx, err, "failed to count gc blob tasks")
+	}
+
+	return count, nil
+}
+
+// Next finds and locks the oldest review task that is due for review.
+// Tasks locked by other transactions are skipped. It returns nil if there is no such task.
+func (dao gcBlobTaskDao) Next(ctx context.Context) (*types.GCBlobTask, error) {
+	stmt := databaseg.Builder.
+		Select(gcBlobTaskColumns...).
+		From(gcBlobReviewQueueTable).
+		Where("review_after < ?", time.Now().Unix()).
+		OrderBy("review_after ASC").
+		Limit(1)
+
+	return dao.findOne(ctx, dao.lock(stmt, gcSkipLockSuffix))
+}
+
+// Reschedule moves the review of the task to the provided duration from now.
+func (dao gcBlobTaskDao) Reschedule(ctx context.Context, b *types.GCBlobTask, d time.Duration) error {
+	reviewAfter := time.Now().Add(d).Unix()
+
+	stmt := databaseg.Builder.
+		Update(gcBlobReviewQueueTable).
+		Set("review_after", reviewAfter).
+		Where("blob_id = ?", b.BlobID)
+
+	if err := dao.exec(ctx, stmt); err != nil {
+		return err
+	}
+
+	b.ReviewAfter = reviewAfter
+
+	return nil
+}
+
+// Postpone moves the review of the task to the provided duration from now and increases its review count.
+// It's used when the review of the task failed.
+func (dao gcBlobTaskDao) Postpone(ctx context.Context, b *types.GCBlobTask, d time.Duration) error {
+	reviewAfter := time.Now().Add(d).Unix()
+
+	stmt := databaseg.Builder.
+		Update(gcBlobReviewQueueTable).
+		Set("review_after", reviewAfter).
+		Set("review_count", sq.Expr("review_count + 1")).
+		Where("blob_id = ?", b.BlobID)
+
+	if err := dao.exec(ctx, stmt); err != nil {
+		return err
+	}
+
+	b.ReviewAfter = reviewAfter
+	b.ReviewCount++
+
+	return nil
+}
+
+// IsDangling returns true if the blob of the task is referenced neither as a layer
+// nor as a configuration of any manifest.
+func (dao gcBlobTaskDao) IsDangling(ctx context.Context, b *types.GCBlobTask) (bool, error) {
+	const sqlQuery = `
+		SELECT NOT EXISTS (
+			SELECT 1 FROM layers WHERE layer_blob_id = $1
+		) AND NOT EXISTS (
+			SELECT 1 FROM manifests WHERE manifest_configuration_blob_id = $1
+		)`
+
+	var dangling bool
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err := db.QueryRowContext(ctx, sqlQuery, b.BlobID).Scan(&dangling); err != nil {
+		return false, databaseg.ProcessSQLErrorf(ctx, err, "failed to check if blob %d is dangling", b.BlobID)
+	}
+
+	return dangling, nil
+}
+
+func (dao gcBlobTaskDao) Delete(ctx context.Context, b *types.GCBlobTask) error {
+	stmt := databaseg.Builder.
+		Delete(gcBlobReviewQueueTable).
+		Where("blob_id = ?", b.BlobID)
+
+	return dao.exec(ctx, stmt)
+}
+
+func (dao gcBlobTaskDao) lock(stmt sq.SelectBuilder, suffix string) sq.SelectBuilder {
+	if dao.db.DriverName() == SQLITE3 {
+		return stmt
+	}
+
+	return stmt.Suffix(suffix)
+}
+
+func (dao gcBlobTaskDao) findOne(ctx context.Context, stmt sq.SelectBuilder) (*types.GCBlobTask, error) {
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find gc blob task query to sql: %w", err)
+	}
+
+	dst := &gcBlobTaskDB{}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil // nil means that there is no task.
+		}
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to find gc blob task")
+	}
+
+	return mapToGCBlobTask(dst), nil
+}
+
+func (dao gcBlobTaskDao) exec(ctx context.Context, stmt sq.Sqlizer) error {
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert gc blob task query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "gc blob task query failed")
+	}
+
+	return nil
+}
+
+func mapToGCBlobTask(dst *gcBlobTaskDB) *types.GCBlobTask {
+	return &types.GCBlobTask{
+		BlobID:      dst.BlobID,
+		ReviewAfter: dst.ReviewAfter,
+		ReviewCount: dst.ReviewCount,
+		CreatedAt:   dst.CreatedAt,
+		Event:       dst.Event,
+	}
+}
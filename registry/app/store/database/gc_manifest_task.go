@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/store/database/util"
+	"github.com/harness/gitness/registry/types"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opencontainers/go-digest"
+)
+
+const gcManifestReviewQueueTable = "gc_manifest_review_queue"
+
+type gcManifestTaskDao struct {
+	db *sqlx.DB
+}
+
+func NewGCManifestTaskDao(db *sqlx.DB) store.GCManifestTaskRepository {
+	return &gcManifestTaskDao{
+		db: db,
+	}
+}
+
+type gcManifestTaskDB struct {
+	RegistryID  int64  `db:"registry_id"`
+	ManifestID  int64  `db:"manifest_id"`
+	ReviewAfter int64  `db:"review_after"`
+	ReviewCount int    `db:"review_count"`
+	CreatedAt   int64  `db:"created_at"`
+	Event       string `db:"event"`
+}
+
+var gcManifestTaskColumns = []string{
+	"registry_id",
+	"manifest_id",
+	"review_after",
+	"review_count",
+	"created_at",
+	"event",
+}
+
+// FindAndLock finds and locks the review task of the manifest. It returns nil if there is no such task.
+func (dao gcManifestTaskDao) FindAndLock(
+	ctx context.Context,
+	registryID, manifestID int64,
+) (*types.GCManifestTask, error) {
+	stmt := databaseg.Builder.
+		Select(gcManifestTaskColumns...).
+		From(gcManifestReviewQueueTable).
+		Where("registry_id = ? AND manifest_id = ?", registryID, manifestID)
+
+	return dao.findOne(ctx, dao.lock(stmt, gcLockSuffix))
+}
+
+// FindAndLockBefore finds and locks the review task of the manifest if it's due for review before the date.
+// It returns nil if there is no such task.
+func (dao gcManifestTaskDao) FindAndLockBefore(
+	ctx context.Context,
+	registryID, manifestID int64,
+	date time.Time,
+) (*types.GCManifestTask, error) {
+	stmt := databaseg.Builder.
+		Select(gcManifestTaskColumns...).
+		From(gcManifestReviewQueueTable).
+		Where("registry_id = ? AND manifest_id = ? AND review_after < ?", registryID, manifestID, date.Unix())
+
+	return dao.findOne(ctx, dao.lock(stmt, gcLockSuffix))
+}
+
+// FindAndLockNBefore finds and locks the review tasks of the manifests that are due for review before the date.
+func (dao gcManifestTaskDao) FindAndLockNBefore(
+	ctx context.Context,
+	registryID int64,
+	manifestIDs []int64,
+	date time.Time,
+) ([]*types.GCManifestTask, error) {
+	if len(manifestIDs) == 0 {
+		return nil, nil
+	}
+
+	stmt := databaseg.Builder.
+		Select(gcManifestTaskColumns...).
+		From(gcManifestReviewQueueTable).
+		Where("registry_id = ? AND review_after < ?", registryID, date.Unix()).
+		Where(sq.Eq{"manifest_id": manifestIDs}).
+		// lock the rows in a consistent order to avoid deadlocks.
+		OrderBy("registry_id", "manifest_id")
+
+	sqlQuery, args, err := dao.lock(stmt, gcLockSuffix).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find gc manifest tasks query to sql: %w", err)
+	}
+
+	dst := []*gcManifestTaskDB{}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to find gc manifest tasks")
+	}
+
+	tasks := make([]*types.GCManifestTask, len(dst))
+	for i := range dst {
+		tasks[i] = mapToGCManifestTask(dst[i])
+	}
+
+	return tasks, nil
+}
+
+// Next finds and locks the oldest review task that is due for review.
+// Tasks locked by other transactions are skipped. It returns nil if there is no such task.
+func (dao gcManifestTaskDao) Next(ctx context.Context) (*types.GCManifestTask, error) {
+	stmt := databaseg.Builder.
+		Select(gcManifestTaskColumns...).
+		From(gcManifestReviewQueueTable).
+		Where("review_after < ?", time.Now().Unix()).
+		OrderBy("review_after ASC").
+		Limit(1)
+
+	return dao.findOne(ctx, dao.lock(stmt, gcSkipLockSuffix))
+}
+
+// Postpone moves the review of the task to the provided duration from now and increases its review count.
+// It's used when the review of the task failed.
+func (dao gcManifestTaskDao) Postpone(ctx context.Context, m *types.GCManifestTask, d time.Duration) error {
+	reviewAfter := time.Now().Add(d).Unix()
+
+	stmt := databaseg.Builder.
+		Update(gcManifestReviewQueueTable).
+		Set("review_after", reviewAfter).
+		Set("review_count", sq.Expr("review_count + 1")).
+		Where("registry_id = ? AND manifest_id = ?", m.RegistryID, m.ManifestID)
+
+	if err := dao.exec(ctx, stmt); err != nil {
+		return err
+	}
+
+	m.ReviewAfter = reviewAfter
+	m.ReviewCount++
+
+	return nil
+}
+
+// IsDangling returns true if the manifest of the task isn't tagged and isn't referenced by any manifest list.
+// Manifests with a subject (referrers, e.g. signatures) are never dangling,
+// they are deleted together with their subject.
+func (dao gcManifestTaskDao) IsDangling(ctx context.Context, m *types.GCManifestTask) (bool, error) {
+	const sqlQuery = `
+		SELECT NOT EXISTS (
+			SELECT 1 FROM tags WHERE tag_registry_id = $1 AND tag_manifest_id = $2
+		) AND NOT EXISTS (
+			SELECT 1 FROM manifest_references
+			WHERE manifest_ref_registry_id = $1 AND manifest_ref_child_id = $2
+		) AND NOT EXISTS (
+			SELECT 1 FROM manifests
+			WHERE manifest_registry_id = $1 AND manifest_id = $2 AND manifest_subject_id IS NOT NULL
+		)`
+
+	var dangling bool
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err := db.QueryRowContext(ctx, sqlQuery, m.RegistryID, m.ManifestID).Scan(&dangling); err != nil {
+		return false, databaseg.ProcessSQLErrorf(ctx, err,
+			"failed to check if manifest %d is dangling", m.ManifestID)
+	}
+
+	return dangling, nil
+}
+
+func (dao gcManifestTaskDao) Delete(ctx context.Context, m *types.GCManifestTask) error {
+	stmt := databaseg.Builder.
+		Delete(gcManifestReviewQueueTable).
+		Where("registry_id = ? AND manifest_id = ?", m.RegistryID, m.ManifestID)
+
+	return dao.exec(ctx, stmt)
+}
+
+// DeleteManifest deletes the manifest and returns its digest. It returns nil if the manifest doesn't exist.
+// The layers, references, tags and the review task of the manifest are deleted by the foreign key cascades,
+// and the database triggers queue the blobs and child manifests for review.
+func (dao gcManifestTaskDao) DeleteManifest(ctx context.Context, registryID, id int64) (*digest.Digest, error) {
+	stmt := databaseg.Builder.
+		Delete("manifests").
+		Where("manifest_registry_id = ? AND manifest_id = ?", registryID, id).
+		Suffix("RETURNING manifest_digest")
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert delete manifest query to sql: %w", err)
+	}
+
+	var digestBytes []byte
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&digestBytes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil // nil means that the manifest doesn't exist.
+		}
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to delete manifest %d", id)
+	}
+
+	dgst, err := types.Digest(util.GetHexEncodedString(digestBytes)).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse digest of manifest %d: %w", id, err)
+	}
+
+	return &dgst, nil
+}
+
+func (dao gcManifestTaskDao) lock(stmt sq.SelectBuilder, suffix string) sq.SelectBuilder {
+	if dao.db.DriverName() == SQLITE3 {
+		return stmt
+	}
+
+	return stmt.Suffix(suffix)
+}
+
+func (dao gcManifestTaskDao) findOne(ctx context.Context, stmt sq.SelectBuilder) (*types.GCManifestTask, error) {
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find gc manifest task query to sql: %w", err)
+	}
+
+	dst := &gcManifestTaskDB{}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil // nil means that there is no task.
+		}
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to find gc manifest task")
+	}
+
+	return mapToGCManifestTask(dst), nil
+}
+
+func (dao gcManifestTaskDao) exec(ctx context.Context, stmt sq.Sqlizer) error {
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert gc manifest task query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "gc manifest task query failed")
+	}
+
+	return nil
+}
+
+func mapToGCManifestTask(dst *gcManifestTaskDB) *types.GCManifestTask {
+	return &types.GCManifestTask{
+		RegistryID:  dst.RegistryID,
+		ManifestID:  dst.ManifestID,
+		ReviewAfter: dst.ReviewAfter,
+		ReviewCount: dst.ReviewCount,
+		CreatedAt:   dst.CreatedAt,
+		Event:       dst.Event,
+	}
+}
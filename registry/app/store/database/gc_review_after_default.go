@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const gcReviewAfterDefaultsTable = "gc_review_after_defaults"
+
+type gcReviewAfterDefaultDao struct {
+	db *sqlx.DB
+}
+
+func NewGCReviewAfterDefaultDao(db *sqlx.DB) store.GCReviewAfterDefaultRepository {
+	return &gcReviewAfterDefaultDao{
+		db: db,
+	}
+}
+
+// UpdateAll sets the review delay of all garbage collection events.
+// The delay is stored as an interval in postgres and as a number of seconds in sqlite.
+func (dao gcReviewAfterDefaultDao) UpdateAll(ctx context.Context, reviewAfter time.Duration) error {
+	seconds := int64(reviewAfter.Seconds())
+
+	value := sq.Expr("make_interval(secs => ?)", seconds)
+	if dao.db.DriverName() == SQLITE3 {
+		value = sq.Expr("?", seconds)
+	}
+
+	stmt := databaseg.Builder.
+		Update(gcReviewAfterDefaultsTable).
+		Set("value", value)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert update gc review after defaults query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, dao.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "failed to update gc review after defaults")
+	}
+
+	return nil
+}
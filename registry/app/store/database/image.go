@@ -198,6 +198,52 @@ func (i ImageDao) DeleteDownloadStatByRegistryID(ctx context.Context, registryID
 	return nil
 }
 
+func (i ImageDao) DeleteByRegistryIDAndName(ctx context.Context, registryID int64, name string) (err error) {
+	db := dbtx.GetAccessor(ctx, i.db)
+
+	var ids []int64
+	stmt := databaseg.Builder.Select("image_id").
+		From("images").
+		Where("image_registry_id = ? AND image_name = ?", registryID, name)
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if err = db.SelectContext(ctx, &ids, query, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to find image")
+	}
+
+	delStmt := databaseg.Builder.Delete("bandwidth_stats").
+		Where(sq.Eq{"bandwidth_stat_image_id": ids})
+
+	delQuery, delArgs, err := delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, delQuery, delArgs...)
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	delStmt = databaseg.Builder.Delete("images").
+		Where("image_registry_id = ? AND image_name = ?", registryID, name)
+
+	delQuery, delArgs, err = delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, delQuery, delArgs...)
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	return nil
+}
+
 func (i ImageDao) GetByName(ctx context.Context, registryID int64, name string) (*types.Image, error) {
 	q := databaseg.Builder.Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(imageDB{}), ",")).
 		From("images").
@@ -217,6 +263,35 @@ func (i ImageDao) GetByName(ctx context.Context, registryID int64, name string)
 	return i.mapToImage(ctx, dst)
 }
 
+func (i ImageDao) GetByRegistryID(ctx context.Context, registryID int64) (*[]types.Image, error) {
+	q := databaseg.Builder.Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(imageDB{}), ",")).
+		From("images").
+		Where("image_registry_id = ?", registryID).
+		OrderBy("image_name ASC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, i.db)
+
+	dst := []imageDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to get images")
+	}
+
+	images := make([]types.Image, len(dst))
+	for j := range dst {
+		image, err := i.mapToImage(ctx, &dst[j])
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to map image")
+		}
+		images[j] = *image
+	}
+	return &images, nil
+}
+
 func (i ImageDao) CreateOrUpdate(ctx context.Context, image *types.Image) error {
 	const sqlQuery = `
 		INSERT INTO images ( 
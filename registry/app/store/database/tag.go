@@ -1086,6 +1086,28 @@ func (t tagDao) FindTag(
 	return t.mapToTag(ctx, dst)
 }
 
+func (t tagDao) GetAllTagsByRegistryID(ctx context.Context, registryID int64) ([]*types.Tag, error) {
+	stmt := databaseg.Builder.
+		Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(tagDB{}), ",")).
+		From("tags").
+		Where("tag_registry_id = ?", registryID).
+		OrderBy("tag_image_name ASC", "tag_updated_at DESC", "tag_id DESC")
+
+	db := dbtx.GetAccessor(ctx, t.db)
+
+	dst := []*tagDB{}
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to find tags of registry")
+	}
+
+	return t.mapToTagList(ctx, dst)
+}
+
 func (t tagDao) DeleteTagsByImageName(
 	ctx context.Context, registryID int64,
 	imageName string,
@@ -103,6 +103,18 @@ func ProvideGenericBlobDao(db *sqlx.DB) store.GenericBlobRepository {
 	return NewGenericBlobDao(db)
 }
 
+func ProvideGCBlobTaskDao(db *sqlx.DB) store.GCBlobTaskRepository {
+	return NewGCBlobTaskDao(db)
+}
+
+func ProvideGCManifestTaskDao(db *sqlx.DB) store.GCManifestTaskRepository {
+	return NewGCManifestTaskDao(db)
+}
+
+func ProvideGCReviewAfterDefaultDao(db *sqlx.DB) store.GCReviewAfterDefaultRepository {
+	return NewGCReviewAfterDefaultDao(db)
+}
+
 var WireSet = wire.NewSet(
 	ProvideUpstreamDao,
 	ProvideRepoDao,
@@ -123,4 +135,7 @@ var WireSet = wire.NewSet(
 	ProvideGenericBlobDao,
 	ProvideWebhookDao,
 	ProvideWebhookExecutionDao,
+	ProvideGCBlobTaskDao,
+	ProvideGCManifestTaskDao,
+	ProvideGCReviewAfterDefaultDao,
 )
@@ -1,4 +1,4 @@
-//  Copyright 2023 Harness, Inc.
+// Copyright 2023 Harness, Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,51 +16,346 @@ package gc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 
 	corestore "github.com/harness/gitness/app/store"
 	storagedriver "github.com/harness/gitness/registry/app/driver"
+	"github.com/harness/gitness/registry/app/storage"
 	"github.com/harness/gitness/registry/app/store"
 	registrytypes "github.com/harness/gitness/registry/types"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
 )
 
-type Noop struct{}
+const (
+	// postponeBaseDuration is the delay of the first retry of a review task that failed to be processed.
+	// Every subsequent failure doubles the delay up to postponeMaxDuration.
+	postponeBaseDuration = 5 * time.Minute
+	postponeMaxDuration  = 24 * time.Hour
+
+	defaultInitialInterval = 5 * time.Second
+)
+
+type garbageCollector struct {
+	tx                 dbtx.Transactor
+	blobTaskStore      store.GCBlobTaskRepository
+	manifestTaskStore  store.GCManifestTaskRepository
+	reviewAfterDefault store.GCReviewAfterDefaultRepository
+
+	spaceStore    corestore.SpaceStore
+	blobRepo      store.BlobRepository
+	storageClient *storage.GcStorageClient
+	config        *types.Config
+
+	stats stats
+}
+
+// stats holds the totals of the garbage collected artifacts since the service was started.
+// They are reported in the logs.
+type stats struct {
+	BlobsDeleted     atomic.Int64
+	BytesReclaimed   atomic.Int64
+	ManifestsDeleted atomic.Int64
+}
 
-func New() Service {
-	return &Noop{}
+func New(
+	tx dbtx.Transactor,
+	blobTaskStore store.GCBlobTaskRepository,
+	manifestTaskStore store.GCManifestTaskRepository,
+	reviewAfterDefault store.GCReviewAfterDefaultRepository,
+) Service {
+	return &garbageCollector{
+		tx:                 tx,
+		blobTaskStore:      blobTaskStore,
+		manifestTaskStore:  manifestTaskStore,
+		reviewAfterDefault: reviewAfterDefault,
+	}
 }
 
-func (s *Noop) Start(
-	context.Context, corestore.SpaceStore,
-	store.BlobRepository, storagedriver.StorageDeleter,
-	*types.Config,
+// Start starts the background workers which review the queued blobs and manifests
+// and delete the ones that are no longer referenced.
+func (c *garbageCollector) Start(
+	ctx context.Context,
+	spaceStore corestore.SpaceStore,
+	blobRepo store.BlobRepository,
+	storageDeleter storagedriver.StorageDeleter,
+	config *types.Config,
 ) {
-	// NOOP
+	if !config.Registry.GarbageCollection.Enabled {
+		log.Ctx(ctx).Info().Msg("registry garbage collection is disabled")
+		return
+	}
+
+	c.spaceStore = spaceStore
+	c.blobRepo = blobRepo
+	c.storageClient = storage.NewGcStorageClient(storageDeleter)
+	c.config = config
+
+	if reviewAfter := config.Registry.GarbageCollection.ReviewAfterDuration; reviewAfter > 0 {
+		if err := c.reviewAfterDefault.UpdateAll(ctx, reviewAfter); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to update registry garbage collection review delay")
+		}
+	}
+
+	go c.run(ctx, "blob", c.processBlobTask)
+	go c.run(ctx, "manifest", c.processManifestTask)
+
+	log.Ctx(ctx).Info().Msg("registry garbage collection started")
 }
 
-func (s *Noop) BlobFindAndLockBefore(context.Context, int64, time.Time) (*registrytypes.GCBlobTask, error) {
-	// NOOP
-	//nolint:nilnil
-	return nil, nil
+func (c *garbageCollector) BlobFindAndLockBefore(
+	ctx context.Context,
+	blobID int64,
+	date time.Time,
+) (*registrytypes.GCBlobTask, error) {
+	return c.blobTaskStore.FindAndLockBefore(ctx, blobID, date)
 }
 
-func (s *Noop) BlobReschedule(context.Context, *registrytypes.GCBlobTask, time.Duration) error {
-	// NOOP
-	return nil
+func (c *garbageCollector) BlobReschedule(ctx context.Context, b *registrytypes.GCBlobTask, d time.Duration) error {
+	return c.blobTaskStore.Reschedule(ctx, b, d)
 }
 
-func (s *Noop) ManifestFindAndLockBefore(context.Context, int64, int64, time.Time) (
-	*registrytypes.GCManifestTask, error,
-) {
-	// NOOP
-	//nolint:nilnil
-	return nil, nil
+func (c *garbageCollector) ManifestFindAndLockBefore(
+	ctx context.Context,
+	registryID, manifestID int64,
+	date time.Time,
+) (*registrytypes.GCManifestTask, error) {
+	return c.manifestTaskStore.FindAndLockBefore(ctx, registryID, manifestID, date)
 }
 
-func (s *Noop) ManifestFindAndLockNBefore(context.Context, int64, []int64, time.Time) (
-	[]*registrytypes.GCManifestTask, error,
-) {
-	// NOOP
-	return nil, nil
+func (c *garbageCollector) ManifestFindAndLockNBefore(
+	ctx context.Context,
+	registryID int64,
+	manifestIDs []int64,
+	date time.Time,
+) ([]*registrytypes.GCManifestTask, error) {
+	return c.manifestTaskStore.FindAndLockNBefore(ctx, registryID, manifestIDs, date)
+}
+
+// run processes review tasks one at a time until the context is canceled.
+// The worker backs off exponentially if there are no tasks due for review or if the processing fails.
+func (c *garbageCollector) run(ctx context.Context, name string, process func(ctx context.Context) (bool, error)) {
+	cfg := c.config.Registry.GarbageCollection
+
+	initialInterval := cfg.InitialIntervalDuration
+	if initialInterval <= 0 {
+		initialInterval = defaultInitialInterval
+	}
+	maxBackoff := max(cfg.MaxBackoffDuration, initialInterval)
+
+	logger := log.Ctx(ctx).With().Str("gc.worker", name).Logger()
+
+	interval := initialInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		found, err := process(ctx)
+
+		switch {
+		case err != nil:
+			logger.Error().Err(err).Msg("failed to process garbage collection review task")
+			interval = min(2*interval, maxBackoff)
+		case !found && !cfg.NoIdleBackoff:
+			interval = min(2*interval, maxBackoff)
+		default:
+			interval = initialInterval
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// processBlobTask reviews the next blob that is due for review. If the blob is no longer referenced by any manifest,
+// it's deleted from the database and from the storage. It returns false if there was no task to process.
+func (c *garbageCollector) processBlobTask(ctx context.Context) (bool, error) {
+	cfg := c.config.Registry.GarbageCollection
+
+	var (
+		task *registrytypes.GCBlobTask
+		blob *registrytypes.Blob
+	)
+
+	txCtx, cancel := context.WithTimeout(ctx, cfg.TransactionTimeoutDuration)
+	defer cancel()
+
+	err := c.tx.WithTx(txCtx, func(ctx context.Context) error {
+		var err error
+
+		task, err = c.blobTaskStore.Next(ctx)
+		if err != nil || task == nil {
+			return err
+		}
+
+		dangling, err := c.blobTaskStore.IsDangling(ctx, task)
+		if err != nil {
+			return fmt.Errorf("failed to check if blob is dangling: %w", err)
+		}
+
+		if !dangling {
+			return c.blobTaskStore.Delete(ctx, task)
+		}
+
+		blob, err = c.blobRepo.FindByID(ctx, task.BlobID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return c.blobTaskStore.Delete(ctx, task)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find blob: %w", err)
+		}
+
+		rootSpace, err := c.spaceStore.Find(ctx, blob.RootParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find root space of blob: %w", err)
+		}
+
+		// the registry blob links are removed together with the blob.
+		if err = c.blobRepo.DeleteByID(ctx, blob.ID); err != nil {
+			return fmt.Errorf("failed to delete blob: %w", err)
+		}
+
+		if err = c.blobTaskStore.Delete(ctx, task); err != nil {
+			return fmt.Errorf("failed to delete blob review task: %w", err)
+		}
+
+		// the blob is removed from the storage inside the transaction, so the database record
+		// is kept if the removal fails and the blob is reviewed again later.
+		storageCtx, cancel := context.WithTimeout(ctx, cfg.BlobsStorageTimeoutDuration)
+		defer cancel()
+
+		err = c.storageClient.RemoveBlob(storageCtx, blob.Digest, rootSpace.Identifier)
+		if err != nil && !errors.As(err, &storagedriver.PathNotFoundError{}) {
+			return fmt.Errorf("failed to remove blob from storage: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if task != nil {
+			c.postponeBlobTask(ctx, task)
+		}
+		return false, err
+	}
+
+	if task == nil {
+		return false, nil
+	}
+
+	if blob != nil {
+		blobsDeleted := c.stats.BlobsDeleted.Add(1)
+		bytesReclaimed := c.stats.BytesReclaimed.Add(blob.Size)
+
+		log.Ctx(ctx).Info().
+			Str("gc.blob_digest", blob.Digest.String()).
+			Int64("gc.reclaimed_bytes", blob.Size).
+			Int64("gc.total_blobs_deleted", blobsDeleted).
+			Int64("gc.total_reclaimed_bytes", bytesReclaimed).
+			Msg("garbage collected blob")
+	}
+
+	return true, nil
+}
+
+// processManifestTask reviews the next manifest that is due for review. If the manifest is neither tagged
+// nor referenced by another manifest, it's deleted. Its layers and configuration are queued for review by
+// the database triggers. It returns false if there was no task to process.
+func (c *garbageCollector) processManifestTask(ctx context.Context) (bool, error) {
+	cfg := c.config.Registry.GarbageCollection
+
+	var task *registrytypes.GCManifestTask
+
+	txCtx, cancel := context.WithTimeout(ctx, cfg.TransactionTimeoutDuration)
+	defer cancel()
+
+	var deleted bool
+
+	err := c.tx.WithTx(txCtx, func(ctx context.Context) error {
+		var err error
+
+		task, err = c.manifestTaskStore.Next(ctx)
+		if err != nil || task == nil {
+			return err
+		}
+
+		dangling, err := c.manifestTaskStore.IsDangling(ctx, task)
+		if err != nil {
+			return fmt.Errorf("failed to check if manifest is dangling: %w", err)
+		}
+
+		if !dangling {
+			return c.manifestTaskStore.Delete(ctx, task)
+		}
+
+		// the review task is removed together with the manifest.
+		dgst, err := c.manifestTaskStore.DeleteManifest(ctx, task.RegistryID, task.ManifestID)
+		if err != nil {
+			return fmt.Errorf("failed to delete manifest: %w", err)
+		}
+
+		deleted = dgst != nil
+
+		return nil
+	})
+	if err != nil {
+		if task != nil {
+			c.postponeManifestTask(ctx, task)
+		}
+		return false, err
+	}
+
+	if task == nil {
+		return false, nil
+	}
+
+	if deleted {
+		manifestsDeleted := c.stats.ManifestsDeleted.Add(1)
+
+		log.Ctx(ctx).Info().
+			Int64("gc.registry_id", task.RegistryID).
+			Int64("gc.manifest_id", task.ManifestID).
+			Int64("gc.total_manifests_deleted", manifestsDeleted).
+			Msg("garbage collected manifest")
+	}
+
+	return true, nil
+}
+
+func (c *garbageCollector) postponeBlobTask(ctx context.Context, task *registrytypes.GCBlobTask) {
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.blobTaskStore.Postpone(ctx, task, postponeDuration(task.ReviewCount))
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to postpone review of blob %d", task.BlobID)
+	}
+}
+
+func (c *garbageCollector) postponeManifestTask(ctx context.Context, task *registrytypes.GCManifestTask) {
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.manifestTaskStore.Postpone(ctx, task, postponeDuration(task.ReviewCount))
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to postpone review of manifest %d", task.ManifestID)
+	}
+}
+
+// postponeDuration returns the delay of the next review of a task that failed reviewCount times before.
+func postponeDuration(reviewCount int) time.Duration {
+	d := postponeBaseDuration
+	for i := 0; i < reviewCount && d < postponeMaxDuration; i++ {
+		d *= 2
+	}
+
+	return min(d, postponeMaxDuration)
 }
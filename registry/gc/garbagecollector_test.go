@@ -0,0 +1,419 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/registry/app/storage"
+	registrydatabase "github.com/harness/gitness/registry/app/store/database"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+const testRootSpaceID = 1
+
+func TestBlobIsDangling(t *testing.T) {
+	ctx := context.Background()
+	f := newGCFixture(t)
+
+	registryID := f.registry()
+	layerBlob := f.blob("layer")
+	configBlob := f.blob("config")
+	unreferencedBlob := f.blob("unreferenced")
+
+	manifestID := f.manifest(registryID, "manifest", &configBlob)
+	f.layer(registryID, manifestID, layerBlob)
+
+	for _, test := range []struct {
+		name     string
+		blobID   int64
+		dangling bool
+	}{
+		{name: "layer", blobID: layerBlob},
+		{name: "config", blobID: configBlob},
+		{name: "unreferenced", blobID: unreferencedBlob, dangling: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dangling, err := f.gc.blobTaskStore.IsDangling(ctx, &registrytypes.GCBlobTask{BlobID: test.blobID})
+			require.NoError(t, err)
+			require.Equal(t, test.dangling, dangling)
+		})
+	}
+}
+
+func TestManifestIsDangling(t *testing.T) {
+	ctx := context.Background()
+	f := newGCFixture(t)
+
+	registryID := f.registry()
+	tagged := f.manifest(registryID, "tagged", nil)
+	f.tag(registryID, tagged, "latest")
+	list := f.manifest(registryID, "list", nil)
+	child := f.manifest(registryID, "child", nil)
+	f.manifestReference(registryID, list, child)
+	untagged := f.manifest(registryID, "untagged", nil)
+
+	for _, test := range []struct {
+		name       string
+		manifestID int64
+		dangling   bool
+	}{
+		{name: "tagged", manifestID: tagged},
+		{name: "referenced", manifestID: child},
+		{name: "untagged-list", manifestID: list, dangling: true},
+		{name: "untagged", manifestID: untagged, dangling: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			task := &registrytypes.GCManifestTask{RegistryID: registryID, ManifestID: test.manifestID}
+			dangling, err := f.gc.manifestTaskStore.IsDangling(ctx, task)
+			require.NoError(t, err)
+			require.Equal(t, test.dangling, dangling)
+		})
+	}
+}
+
+func TestReviewQueueTriggers(t *testing.T) {
+	f := newGCFixture(t)
+
+	registryID := f.registry()
+	layerBlob := f.blob("layer")
+	configBlob := f.blob("config")
+
+	// uploaded blobs and manifests are queued for review
+	require.Equal(t, "blob_upload", f.blobTaskEvent(layerBlob))
+
+	manifestID := f.manifest(registryID, "manifest", &configBlob)
+	f.layer(registryID, manifestID, layerBlob)
+	f.tag(registryID, manifestID, "latest")
+	require.Equal(t, "manifest_upload", f.manifestTaskEvent(registryID, manifestID))
+
+	// a deleted tag queues its manifest for review
+	f.exec(`DELETE FROM gc_manifest_review_queue`)
+	f.exec(`DELETE FROM tags WHERE tag_manifest_id = $1`, manifestID)
+	require.Equal(t, "tag_delete", f.manifestTaskEvent(registryID, manifestID))
+
+	// a deleted manifest queues its layers and configuration for review
+	f.exec(`DELETE FROM gc_blob_review_queue`)
+	f.exec(`DELETE FROM manifests WHERE manifest_id = $1`, manifestID)
+	require.Equal(t, "layer_delete", f.blobTaskEvent(layerBlob))
+	require.Equal(t, "manifest_delete", f.blobTaskEvent(configBlob))
+}
+
+func TestProcessBlobTask(t *testing.T) {
+	ctx := context.Background()
+	f := newGCFixture(t)
+
+	registryID := f.registry()
+	layerBlob := f.blob("layer")
+	unreferencedBlob := f.blob("unreferenced")
+
+	manifestID := f.manifest(registryID, "manifest", nil)
+	f.layer(registryID, manifestID, layerBlob)
+
+	f.makeTasksDue()
+
+	for range 2 {
+		found, err := f.gc.processBlobTask(ctx)
+		require.NoError(t, err)
+		require.True(t, found)
+	}
+
+	found, err := f.gc.processBlobTask(ctx)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the referenced blob is kept, the unreferenced one is removed from the database and the storage
+	require.True(t, f.blobExists(layerBlob))
+	require.False(t, f.blobExists(unreferencedBlob))
+	require.Len(t, f.storage.deleted, 1)
+	require.Contains(t, f.storage.deleted[0], digest.FromString("unreferenced").Encoded())
+	require.Zero(t, f.count(`SELECT COUNT(*) FROM gc_blob_review_queue`))
+
+	require.EqualValues(t, 1, f.gc.stats.BlobsDeleted.Load())
+	require.EqualValues(t, 42, f.gc.stats.BytesReclaimed.Load())
+}
+
+func TestProcessBlobTaskStorageFailure(t *testing.T) {
+	ctx := context.Background()
+	f := newGCFixture(t)
+
+	blobID := f.blob("unreferenced")
+	f.makeTasksDue()
+
+	f.storage.err = errors.New("storage unavailable")
+
+	found, err := f.gc.processBlobTask(ctx)
+	require.Error(t, err)
+	require.False(t, found)
+
+	// the transaction is rolled back and the review is postponed
+	require.True(t, f.blobExists(blobID))
+
+	task, err := f.gc.blobTaskStore.FindAndLockBefore(ctx, blobID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, task)
+	require.Equal(t, 1, task.ReviewCount)
+	require.Greater(t, task.ReviewAfter, time.Now().Unix())
+
+	require.Zero(t, f.gc.stats.BlobsDeleted.Load())
+}
+
+func TestProcessManifestTask(t *testing.T) {
+	ctx := context.Background()
+	f := newGCFixture(t)
+
+	registryID := f.registry()
+	layerBlob := f.blob("layer")
+	tagged := f.manifest(registryID, "tagged", nil)
+	f.tag(registryID, tagged, "latest")
+	untagged := f.manifest(registryID, "untagged", nil)
+	f.layer(registryID, untagged, layerBlob)
+
+	f.exec(`DELETE FROM gc_blob_review_queue`)
+	f.makeTasksDue()
+
+	for range 2 {
+		found, err := f.gc.processManifestTask(ctx)
+		require.NoError(t, err)
+		require.True(t, found)
+	}
+
+	found, err := f.gc.processManifestTask(ctx)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.Equal(t, 1, f.count(`SELECT COUNT(*) FROM manifests WHERE manifest_id = $1`, tagged))
+	require.Zero(t, f.count(`SELECT COUNT(*) FROM manifests WHERE manifest_id = $1`, untagged))
+	require.Zero(t, f.count(`SELECT COUNT(*) FROM gc_manifest_review_queue`))
+
+	// the layers of the deleted manifest are queued for review
+	require.Equal(t, "layer_delete", f.blobTaskEvent(layerBlob))
+
+	require.EqualValues(t, 1, f.gc.stats.ManifestsDeleted.Load())
+}
+
+func TestPostponeDuration(t *testing.T) {
+	require.Equal(t, postponeBaseDuration, postponeDuration(0))
+	require.Equal(t, 2*postponeBaseDuration, postponeDuration(1))
+	require.Equal(t, 8*postponeBaseDuration, postponeDuration(3))
+	require.Equal(t, postponeMaxDuration, postponeDuration(100))
+}
+
+type gcFixture struct {
+	t           *testing.T
+	db          *sqlx.DB
+	gc          *garbageCollector
+	storage     *fakeStorageDeleter
+	mediaTypeID int64
+	seq         int
+}
+
+func newGCFixture(t *testing.T) *gcFixture {
+	t.Helper()
+
+	ctx := context.Background()
+
+	db, err := database.ConnectAndMigrate(ctx, "sqlite3", filepath.Join(t.TempDir(), "gc.db"), migrate.Migrate)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	config := &types.Config{}
+	config.Registry.GarbageCollection.Enabled = true
+	config.Registry.GarbageCollection.TransactionTimeoutDuration = 10 * time.Second
+	config.Registry.GarbageCollection.BlobsStorageTimeoutDuration = 5 * time.Second
+
+	f := &gcFixture{
+		t:       t,
+		db:      db,
+		storage: &fakeStorageDeleter{},
+	}
+
+	gc := New(
+		dbtx.New(db),
+		registrydatabase.NewGCBlobTaskDao(db),
+		registrydatabase.NewGCManifestTaskDao(db),
+		registrydatabase.NewGCReviewAfterDefaultDao(db),
+	).(*garbageCollector) //nolint:errcheck
+
+	gc.spaceStore = fakeSpaceStore{}
+	gc.blobRepo = registrydatabase.NewBlobDao(db, registrydatabase.NewMediaTypesDao(db))
+	gc.storageClient = storage.NewGcStorageClient(f.storage)
+	gc.config = config
+	f.gc = gc
+
+	require.NoError(t, db.Get(&f.mediaTypeID, `SELECT mt_id FROM media_types ORDER BY mt_id LIMIT 1`))
+
+	return f
+}
+
+func (f *gcFixture) next() int {
+	f.seq++
+	return f.seq
+}
+
+func (f *gcFixture) insert(query string, args ...any) int64 {
+	f.t.Helper()
+
+	var id int64
+	require.NoError(f.t, f.db.Get(&id, query, args...))
+
+	return id
+}
+
+func (f *gcFixture) exec(query string, args ...any) {
+	f.t.Helper()
+
+	_, err := f.db.Exec(query, args...)
+	require.NoError(f.t, err)
+}
+
+func (f *gcFixture) count(query string, args ...any) int {
+	f.t.Helper()
+
+	var n int
+	require.NoError(f.t, f.db.Get(&n, query, args...))
+
+	return n
+}
+
+func (f *gcFixture) registry() int64 {
+	return f.insert(`
+		INSERT INTO registries (registry_name, registry_root_parent_id, registry_parent_id, registry_type,
+			registry_package_type, registry_created_at, registry_updated_at, registry_created_by,
+			registry_updated_by)
+		VALUES ($1, $2, $2, 'VIRTUAL', 'DOCKER', 0, 0, 1, 1)
+		RETURNING registry_id`,
+		"registry-"+strconv.Itoa(f.next()), testRootSpaceID)
+}
+
+func (f *gcFixture) digestBytes(content string) []byte {
+	f.t.Helper()
+
+	b, err := registrytypes.GetDigestBytes(digest.FromString(content))
+	require.NoError(f.t, err)
+
+	return b
+}
+
+func (f *gcFixture) blob(content string) int64 {
+	return f.insert(`
+		INSERT INTO blobs (blob_root_parent_id, blob_digest, blob_media_type_id, blob_size,
+			blob_created_at, blob_created_by)
+		VALUES ($1, $2, $3, 42, 0, 1)
+		RETURNING blob_id`,
+		testRootSpaceID, f.digestBytes(content), f.mediaTypeID)
+}
+
+func (f *gcFixture) manifest(registryID int64, content string, configBlobID *int64) int64 {
+	return f.insert(`
+		INSERT INTO manifests (manifest_registry_id, manifest_schema_version, manifest_media_type_id,
+			manifest_total_size, manifest_configuration_blob_id, manifest_digest, manifest_payload,
+			manifest_image_name, manifest_created_at, manifest_created_by, manifest_updated_at,
+			manifest_updated_by)
+		VALUES ($1, 2, $2, 42, $3, $4, '{}', 'image', 0, 1, 0, 1)
+		RETURNING manifest_id`,
+		registryID, f.mediaTypeID, configBlobID, f.digestBytes(content))
+}
+
+func (f *gcFixture) layer(registryID, manifestID, blobID int64) {
+	f.exec(`
+		INSERT INTO layers (layer_registry_id, layer_manifest_id, layer_media_type_id, layer_blob_id,
+			layer_size, layer_created_at, layer_updated_at, layer_created_by, layer_updated_by)
+		VALUES ($1, $2, $3, $4, 42, 0, 0, 1, 1)`,
+		registryID, manifestID, f.mediaTypeID, blobID)
+}
+
+func (f *gcFixture) tag(registryID, manifestID int64, name string) {
+	f.exec(`
+		INSERT INTO tags (tag_name, tag_image_name, tag_registry_id, tag_manifest_id)
+		VALUES ($1, 'image', $2, $3)`,
+		name, registryID, manifestID)
+}
+
+func (f *gcFixture) manifestReference(registryID, parentID, childID int64) {
+	f.exec(`
+		INSERT INTO manifest_references (manifest_ref_registry_id, manifest_ref_parent_id, manifest_ref_child_id,
+			manifest_ref_created_at, manifest_ref_updated_at, manifest_ref_created_by, manifest_ref_updated_by)
+		VALUES ($1, $2, $3, 0, 0, 1, 1)`,
+		registryID, parentID, childID)
+}
+
+// makeTasksDue makes all queued review tasks due for review.
+func (f *gcFixture) makeTasksDue() {
+	f.exec(`UPDATE gc_blob_review_queue SET review_after = 0`)
+	f.exec(`UPDATE gc_manifest_review_queue SET review_after = 0`)
+}
+
+func (f *gcFixture) blobExists(blobID int64) bool {
+	return f.count(`SELECT COUNT(*) FROM blobs WHERE blob_id = $1`, blobID) == 1
+}
+
+func (f *gcFixture) blobTaskEvent(blobID int64) string {
+	f.t.Helper()
+
+	var event string
+	require.NoError(f.t, f.db.Get(&event, `SELECT event FROM gc_blob_review_queue WHERE blob_id = $1`, blobID))
+
+	return event
+}
+
+func (f *gcFixture) manifestTaskEvent(registryID, manifestID int64) string {
+	f.t.Helper()
+
+	var event string
+	require.NoError(f.t, f.db.Get(&event,
+		`SELECT event FROM gc_manifest_review_queue WHERE registry_id = $1 AND manifest_id = $2`,
+		registryID, manifestID))
+
+	return event
+}
+
+// fakeSpaceStore returns the root space of the blobs, other methods of store.SpaceStore are not used.
+type fakeSpaceStore struct {
+	corestore.SpaceStore
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	return &types.Space{ID: id, Identifier: "root"}, nil
+}
+
+type fakeStorageDeleter struct {
+	deleted []string
+	err     error
+}
+
+func (s *fakeStorageDeleter) Delete(_ context.Context, path string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.deleted = append(s.deleted, path)
+
+	return nil
+}
@@ -16,6 +16,8 @@ package gc
 
 import (
 	storagedriver "github.com/harness/gitness/registry/app/driver"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
 )
@@ -24,8 +26,13 @@ func StorageDeleterProvider(driver storagedriver.StorageDriver) storagedriver.St
 	return driver
 }
 
-func ServiceProvider() Service {
-	return New()
+func ServiceProvider(
+	tx dbtx.Transactor,
+	blobTaskStore store.GCBlobTaskRepository,
+	manifestTaskStore store.GCManifestTaskRepository,
+	reviewAfterDefault store.GCReviewAfterDefaultRepository,
+) Service {
+	return New(tx, blobTaskStore, manifestTaskStore, reviewAfterDefault)
 }
 
 var WireSet = wire.NewSet(StorageDeleterProvider, ServiceProvider)
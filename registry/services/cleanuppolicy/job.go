@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanuppolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCleanupPolicies        = "gitness:registry:cleanup-policies"
+	jobCronCleanupPolicies        = "41 * * * *" // At minute 41 past every hour.
+	jobMaxDurationCleanupPolicies = 30 * time.Minute
+)
+
+type cleanupPoliciesJob struct {
+	cleanupPolicyStore store.CleanupPolicyRepository
+	service            *Service
+}
+
+func newCleanupPoliciesJob(
+	cleanupPolicyStore store.CleanupPolicyRepository,
+	service *Service,
+) *cleanupPoliciesJob {
+	return &cleanupPoliciesJob{
+		cleanupPolicyStore: cleanupPolicyStore,
+		service:            service,
+	}
+}
+
+// Handle enforces the cleanup policies of all registries that have any.
+// A failure of one registry doesn't prevent the cleanup of the others.
+// The registries whose cleanup policies can't be enforced are skipped and reported.
+func (j *cleanupPoliciesJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	registryIDs, err := j.cleanupPolicyStore.GetRegistryIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registries with cleanup policies: %w", err)
+	}
+
+	var deleted, skipped, failed int
+	for _, registryID := range registryIDs {
+		n, err := j.service.Execute(ctx, registryID)
+		deleted += n
+		switch {
+		case errors.Is(err, ErrPackageTypeNotSupported):
+			skipped++
+			log.Ctx(ctx).Warn().Err(err).Msgf("skipped cleanup policies of registry %d", registryID)
+		case err != nil:
+			failed++
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to enforce cleanup policies of registry %d", registryID)
+		}
+	}
+
+	result := "no artifact versions matched by cleanup policies found"
+	if deleted > 0 {
+		result = fmt.Sprintf("deleted %d artifact versions", deleted)
+	}
+	if skipped > 0 {
+		result += fmt.Sprintf(", skipped cleanup policies of %d registries of unsupported package types", skipped)
+	}
+	if failed > 0 {
+		result += fmt.Sprintf(", failed to enforce cleanup policies of %d registries", failed)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanuppolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/refcache"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	registryevents "github.com/harness/gitness/registry/app/events"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/services/tag"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrPackageTypeNotSupported is returned for the cleanup policies of registries whose artifact versions
+// aren't stored as OCI tags, because only the OCI tags can be deleted like with the delete artifact version API.
+var ErrPackageTypeNotSupported = errors.New("cleanup policies are only supported for docker and helm registries")
+
+// IsPackageTypeSupported tells whether the cleanup policies can be enforced for the registries of the package type.
+// Only the OCI tags are evaluated, so the helm charts are covered only if they're pushed to an OCI registry.
+func IsPackageTypeSupported(packageType artifact.PackageType) bool {
+	return packageType == artifact.PackageTypeDOCKER || packageType == artifact.PackageTypeHELM
+}
+
+// Candidate is an artifact version that is matched by a cleanup policy of its registry.
+type Candidate struct {
+	PolicyName string
+	Tag        *registrytypes.Tag
+	Digest     string
+	Reason     string
+}
+
+// Service enforces the cleanup policies of the registries.
+type Service struct {
+	scheduler          *job.Scheduler
+	executor           *job.Executor
+	registryStore      store.RegistryRepository
+	cleanupPolicyStore store.CleanupPolicyRepository
+	tagStore           store.TagRepository
+	manifestStore      store.ManifestRepository
+	spaceFinder        refcache.SpaceFinder
+	tagDeleter         *tag.Deleter
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	registryStore store.RegistryRepository,
+	cleanupPolicyStore store.CleanupPolicyRepository,
+	tagStore store.TagRepository,
+	manifestStore store.ManifestRepository,
+	spaceFinder refcache.SpaceFinder,
+	auditService audit.Service,
+	artifactEventReporter registryevents.Reporter,
+	urlProvider urlprovider.Provider,
+) *Service {
+	return &Service{
+		scheduler:          scheduler,
+		executor:           executor,
+		registryStore:      registryStore,
+		cleanupPolicyStore: cleanupPolicyStore,
+		tagStore:           tagStore,
+		manifestStore:      manifestStore,
+		spaceFinder:        spaceFinder,
+		tagDeleter:         tag.NewDeleter(tagStore, manifestStore, auditService, artifactEventReporter, urlProvider),
+	}
+}
+
+// Register registers the job handler and schedules the recurring job which enforces the cleanup policies.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeCleanupPolicies, newCleanupPoliciesJob(s.cleanupPolicyStore, s))
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for registry cleanup policies: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeCleanupPolicies,
+		jobTypeCleanupPolicies,
+		jobCronCleanupPolicies,
+		jobMaxDurationCleanupPolicies,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule registry cleanup policies job: %w", err)
+	}
+
+	return nil
+}
+
+// Evaluate returns the artifact versions of the registry that are matched by its cleanup policies.
+// Nothing is deleted, so the result can be used as a dry run report.
+// ErrPackageTypeNotSupported is returned if the registry has cleanup policies, but they can't be enforced.
+func (s *Service) Evaluate(ctx context.Context, registryID int64) ([]Candidate, error) {
+	registry, err := s.registryStore.Get(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry: %w", err)
+	}
+
+	return s.evaluateRegistry(ctx, registry)
+}
+
+func (s *Service) evaluateRegistry(ctx context.Context, registry *registrytypes.Registry) ([]Candidate, error) {
+	policies, err := s.cleanupPolicyStore.GetByRegistryID(ctx, registry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup policies: %w", err)
+	}
+
+	if policies == nil || len(*policies) == 0 {
+		return nil, nil
+	}
+
+	if !IsPackageTypeSupported(registry.PackageType) {
+		return nil, fmt.Errorf("registry %q of package type %s: %w",
+			registry.Name, registry.PackageType, ErrPackageTypeNotSupported)
+	}
+
+	tags, err := s.tagStore.GetAllTagsByRegistryID(ctx, registry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	candidates := evaluate(*policies, tags, time.Now())
+
+	for i := range candidates {
+		manifest, err := s.manifestStore.Get(ctx, candidates[i].Tag.ManifestID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to find manifest %d", candidates[i].Tag.ManifestID)
+			continue
+		}
+
+		candidates[i].Digest = manifest.Digest.String()
+	}
+
+	return candidates, nil
+}
+
+// Execute deletes the artifact versions of the registry that are matched by its cleanup policies.
+// It returns the number of deleted versions.
+func (s *Service) Execute(ctx context.Context, registryID int64) (int, error) {
+	registry, err := s.registryStore.Get(ctx, registryID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find registry: %w", err)
+	}
+
+	candidates, err := s.evaluateRegistry(ctx, registry)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	parentSpace, err := s.spaceFinder.FindByID(ctx, registry.ParentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find parent space of registry: %w", err)
+	}
+
+	rootSpace, err := s.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find root space of registry: %w", err)
+	}
+
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	var deleted int
+	for i := range candidates {
+		err = s.deleteTag(ctx, registry, parentSpace.Path, rootSpace.Identifier, principal, &candidates[i])
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete version %s of artifact %s: %w",
+				candidates[i].Tag.Name, candidates[i].Tag.ImageName, err)
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// deleteTag deletes the artifact version the same way as the delete artifact version API,
+// including the webhook event and the audit log entry.
+func (s *Service) deleteTag(
+	ctx context.Context,
+	registry *registrytypes.Registry,
+	parentRef string,
+	rootIdentifier string,
+	principal types.Principal,
+	candidate *Candidate,
+) error {
+	return s.tagDeleter.DeleteWithAudit(ctx, principal, tag.DeleteInput{
+		RegistryID:     registry.ID,
+		RegistryName:   registry.Name,
+		PackageType:    registry.PackageType,
+		ParentRef:      parentRef,
+		RootIdentifier: rootIdentifier,
+		ArtifactName:   candidate.Tag.ImageName,
+		VersionName:    candidate.Tag.Name,
+	}, audit.WithData("cleanup policy", candidate.PolicyName))
+}
+
+// evaluate returns the tags that are matched by the policies. The tags must be ordered by the image name
+// and by the update time, most recent first. A tag matched by several policies is returned only once.
+func evaluate(policies []registrytypes.CleanupPolicy, tags []*registrytypes.Tag, now time.Time) []Candidate {
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	var candidates []Candidate
+	matched := make(map[int64]struct{})
+
+	for _, policy := range policies {
+		// a policy without any limit would delete all matching versions, so it's ignored.
+		if policy.ExpiryTime <= 0 && policy.KeepLastVersions <= 0 {
+			continue
+		}
+
+		expiryTime := time.Duration(policy.ExpiryTime) * time.Millisecond
+		versionCount := make(map[string]int)
+
+		for _, tag := range tags {
+			if !hasAnyPrefix(tag.ImageName, policy.PackagePrefix) || !hasAnyPrefix(tag.Name, policy.VersionPrefix) {
+				continue
+			}
+
+			versionCount[tag.ImageName]++
+			if versionCount[tag.ImageName] <= policy.KeepLastVersions {
+				continue
+			}
+
+			var reason string
+			if expiryTime > 0 {
+				if now.Sub(tag.UpdatedAt) <= expiryTime {
+					continue
+				}
+				reason = fmt.Sprintf("not updated for more than %d days", int(expiryTime.Hours()/24))
+			} else {
+				reason = fmt.Sprintf("not among the last %d versions", policy.KeepLastVersions)
+			}
+
+			if _, ok := matched[tag.ID]; ok {
+				continue
+			}
+			matched[tag.ID] = struct{}{}
+
+			candidates = append(candidates, Candidate{
+				PolicyName: policy.Name,
+				Tag:        tag,
+				Reason:     reason,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// hasAnyPrefix returns true if the value starts with any of the prefixes or if there are no prefixes.
+func hasAnyPrefix(value string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanuppolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/store"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	// tags are ordered by the image name and by the update time, most recent first.
+	tags := []*registrytypes.Tag{
+		{ID: 1, ImageName: "app", Name: "v3", UpdatedAt: now.Add(-1 * day)},
+		{ID: 2, ImageName: "app", Name: "v2", UpdatedAt: now.Add(-10 * day)},
+		{ID: 3, ImageName: "app", Name: "dev-2", UpdatedAt: now.Add(-20 * day)},
+		{ID: 4, ImageName: "app", Name: "v1", UpdatedAt: now.Add(-40 * day)},
+		{ID: 5, ImageName: "lib", Name: "v1", UpdatedAt: now.Add(-40 * day)},
+	}
+
+	tests := []struct {
+		name     string
+		policies []registrytypes.CleanupPolicy
+		expIDs   []int64
+	}{
+		{
+			name:     "no-limits",
+			policies: []registrytypes.CleanupPolicy{{Name: "p"}},
+		},
+		{
+			name:     "expiry",
+			policies: []registrytypes.CleanupPolicy{{Name: "p", ExpiryTime: (15 * day).Milliseconds()}},
+			expIDs:   []int64{3, 4, 5},
+		},
+		{
+			name:     "keep-last",
+			policies: []registrytypes.CleanupPolicy{{Name: "p", KeepLastVersions: 2}},
+			expIDs:   []int64{3, 4},
+		},
+		{
+			name: "keep-last-and-expiry",
+			policies: []registrytypes.CleanupPolicy{
+				{Name: "p", KeepLastVersions: 3, ExpiryTime: (5 * day).Milliseconds()},
+			},
+			expIDs: []int64{4},
+		},
+		{
+			name: "prefixes",
+			policies: []registrytypes.CleanupPolicy{
+				{
+					Name:          "p",
+					PackagePrefix: []string{"ap"},
+					VersionPrefix: []string{"dev-"},
+					ExpiryTime:    (5 * day).Milliseconds(),
+				},
+			},
+			expIDs: []int64{3},
+		},
+		{
+			name: "overlapping-policies",
+			policies: []registrytypes.CleanupPolicy{
+				{Name: "b", KeepLastVersions: 1},
+				{Name: "a", ExpiryTime: (30 * day).Milliseconds()},
+			},
+			expIDs: []int64{4, 5, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			candidates := evaluate(test.policies, tags, now)
+
+			if len(candidates) != len(test.expIDs) {
+				t.Fatalf("expected %d candidates, got %d", len(test.expIDs), len(candidates))
+			}
+
+			for i, candidate := range candidates {
+				if candidate.Tag.ID != test.expIDs[i] {
+					t.Errorf("candidate %d: expected tag %d, got %d", i, test.expIDs[i], candidate.Tag.ID)
+				}
+				if candidate.Reason == "" {
+					t.Errorf("candidate %d: expected a reason", i)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateRegistryPackageType(t *testing.T) {
+	s := &Service{
+		cleanupPolicyStore: testCleanupPolicyStore{
+			policies: []registrytypes.CleanupPolicy{{Name: "p", KeepLastVersions: 1}},
+		},
+	}
+
+	for _, packageType := range []artifact.PackageType{
+		artifact.PackageTypeMAVEN,
+		artifact.PackageTypeGENERIC,
+		artifact.PackageTypePYTHON,
+		artifact.PackageTypeNPM,
+	} {
+		t.Run(string(packageType), func(t *testing.T) {
+			_, err := s.evaluateRegistry(context.Background(), &registrytypes.Registry{
+				ID:          1,
+				Name:        "registry",
+				PackageType: packageType,
+			})
+			if !errors.Is(err, ErrPackageTypeNotSupported) {
+				t.Errorf("expected ErrPackageTypeNotSupported, got %v", err)
+			}
+		})
+	}
+}
+
+type testCleanupPolicyStore struct {
+	store.CleanupPolicyRepository
+	policies []registrytypes.CleanupPolicy
+}
+
+func (s testCleanupPolicyStore) GetByRegistryID(context.Context, int64) (*[]registrytypes.CleanupPolicy, error) {
+	return &s.policies, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanuppolicy
+
+import (
+	"github.com/harness/gitness/app/services/refcache"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/job"
+	registryevents "github.com/harness/gitness/registry/app/events"
+	"github.com/harness/gitness/registry/app/store"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	registryStore store.RegistryRepository,
+	cleanupPolicyStore store.CleanupPolicyRepository,
+	tagStore store.TagRepository,
+	manifestStore store.ManifestRepository,
+	spaceFinder refcache.SpaceFinder,
+	auditService audit.Service,
+	artifactEventReporter *registryevents.Reporter,
+	urlProvider urlprovider.Provider,
+) *Service {
+	return NewService(
+		scheduler,
+		executor,
+		registryStore,
+		cleanupPolicyStore,
+		tagStore,
+		manifestStore,
+		spaceFinder,
+		auditService,
+		*artifactEventReporter,
+		urlProvider,
+	)
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	registryevents "github.com/harness/gitness/registry/app/events"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/types"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/rs/zerolog/log"
+)
+
+// Deleter deletes the versions of OCI artifacts.
+// It's shared by the delete artifact version API and the registry cleanup policies.
+type Deleter struct {
+	tagStore              store.TagRepository
+	manifestStore         store.ManifestRepository
+	auditService          audit.Service
+	artifactEventReporter registryevents.Reporter
+	urlProvider           urlprovider.Provider
+}
+
+func NewDeleter(
+	tagStore store.TagRepository,
+	manifestStore store.ManifestRepository,
+	auditService audit.Service,
+	artifactEventReporter registryevents.Reporter,
+	urlProvider urlprovider.Provider,
+) *Deleter {
+	return &Deleter{
+		tagStore:              tagStore,
+		manifestStore:         manifestStore,
+		auditService:          auditService,
+		artifactEventReporter: artifactEventReporter,
+		urlProvider:           urlProvider,
+	}
+}
+
+// DeleteInput identifies the artifact version to delete.
+type DeleteInput struct {
+	RegistryID     int64
+	RegistryName   string
+	PackageType    artifact.PackageType
+	ParentRef      string
+	RootIdentifier string
+	ArtifactName   string
+	VersionName    string
+}
+
+// DeleteWithAudit deletes the artifact version, reports the artifact deleted webhook event
+// and records the audit log entry. The audit options are added to the audit log entry.
+func (d *Deleter) DeleteWithAudit(
+	ctx context.Context,
+	principal types.Principal,
+	in DeleteInput,
+	auditOptions ...audit.Option,
+) error {
+	existingDigest := d.getTagDigest(ctx, in.RegistryID, in.ArtifactName, in.VersionName)
+
+	err := d.tagStore.DeleteTag(ctx, in.RegistryID, in.ArtifactName, in.VersionName)
+	if err != nil {
+		return err
+	}
+
+	if existingDigest != "" {
+		payload := webhook.GetArtifactDeletedPayload(ctx, principal.ID, in.RegistryID,
+			in.RegistryName, in.VersionName, existingDigest.String(), in.RootIdentifier,
+			in.PackageType, in.ArtifactName, d.urlProvider)
+		d.artifactEventReporter.ArtifactDeleted(ctx, &payload)
+	}
+
+	auditOptions = append([]audit.Option{
+		audit.WithData("registry name", in.RegistryName),
+		audit.WithData("artifact name", in.ArtifactName),
+		audit.WithData("version name", in.VersionName),
+	}, auditOptions...)
+
+	auditErr := d.auditService.Log(
+		ctx,
+		principal,
+		audit.NewResource(audit.ResourceTypeRegistry, in.ArtifactName),
+		audit.ActionDeleted,
+		in.ParentRef,
+		auditOptions...,
+	)
+	if auditErr != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete tag operation: %s", auditErr)
+	}
+
+	return nil
+}
+
+func (d *Deleter) getTagDigest(
+	ctx context.Context,
+	registryID int64,
+	imageName string,
+	tag string,
+) digest.Digest {
+	existingTag, findTagErr := d.tagStore.FindTag(ctx, registryID, imageName, tag)
+	if findTagErr == nil && existingTag != nil {
+		existingTaggedManifest, getManifestErr := d.manifestStore.Get(ctx, existingTag.ManifestID)
+		if getManifestErr == nil {
+			return existingTaggedManifest.Digest
+		}
+	}
+	return ""
+}
@@ -27,11 +27,14 @@ type CleanupPolicy struct {
 	Name          string
 	VersionPrefix []string
 	PackagePrefix []string
-	ExpiryTime    int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	CreatedBy     int64
-	UpdatedBy     int64
+	// ExpiryTime is the age in milliseconds after which the matching versions are deleted.
+	ExpiryTime int64
+	// KeepLastVersions is the number of the most recent matching versions of each package that are never deleted.
+	KeepLastVersions int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	CreatedBy        int64
+	UpdatedBy        int64
 }
 
 // CleanupPolicyPrefix DTO object.
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "encoding/json"
+
+// AuditEvent represents a persisted audit log entry.
+type AuditEvent struct {
+	ID         int64  `json:"id"`
+	Identifier string `json:"identifier"`
+	Created    int64  `json:"created"`
+
+	Action string `json:"action"`
+
+	ActorID   int64         `json:"-"`
+	ActorInfo PrincipalInfo `json:"actor"`
+
+	SpaceID int64 `json:"space_id,omitempty"`
+	// SpacePath is the path of the space at the time of the event.
+	SpacePath string `json:"space_path"`
+	RepoID    int64  `json:"repo_id,omitempty"`
+	// RepoPath is the path of the repository at the time of the event.
+	RepoPath string `json:"repo_path,omitempty"`
+
+	ResourceType       string            `json:"resource_type"`
+	ResourceIdentifier string            `json:"resource_identifier"`
+	ResourceData       map[string]string `json:"resource_data,omitempty"`
+
+	OldObject json.RawMessage `json:"old_object,omitempty"`
+	NewObject json.RawMessage `json:"new_object,omitempty"`
+
+	ClientIP      string            `json:"client_ip,omitempty"`
+	RequestMethod string            `json:"request_method,omitempty"`
+	Data          map[string]string `json:"data,omitempty"`
+}
+
+// AuditEventFilter stores audit event query parameters.
+type AuditEventFilter struct {
+	Pagination
+	CreatedFilter
+
+	ActorIDs      []int64  `json:"actor_ids"`
+	ResourceTypes []string `json:"resource_types"`
+	Actions       []string `json:"actions"`
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// ChatWebhook is a chat webhook of a space that receives pull request notifications
+// of all repositories in the space and its subspaces.
+type ChatWebhook struct {
+	Identifier string            `json:"identifier"`
+	Provider   enum.ChatProvider `json:"provider"`
+	// URL is the webhook URL. It contains a secret, so it's never returned by the API.
+	URL     string `json:"url,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+// ChatHandles are the handles of a user on chat platforms.
+// They are used to mention the user in chat notifications.
+type ChatHandles struct {
+	// Slack is the Slack member ID of the user, e.g. U012AB3CD.
+	Slack string `json:"slack,omitempty"`
+	// Teams is the Microsoft Teams user principal name of the user, usually the email.
+	Teams string `json:"teams,omitempty"`
+	// Generic is the handle sent to generic webhooks.
+	Generic string `json:"generic,omitempty"`
+}
+
+// Get returns the handle of the user for the chat provider.
+func (h ChatHandles) Get(provider enum.ChatProvider) string {
+	switch provider {
+	case enum.ChatProviderSlack:
+		return h.Slack
+	case enum.ChatProviderTeams:
+		return h.Teams
+	case enum.ChatProviderGeneric:
+		return h.Generic
+	default:
+		return ""
+	}
+}
@@ -369,6 +369,27 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	Mirror struct {
+		// SchedulerCron defines how often the mirrors are checked and synchronized if their schedule is due.
+		SchedulerCron string `envconfig:"GITNESS_MIRROR_SCHEDULER_CRON" default:"* * * * *"`
+		// DefaultCron is the synchronization schedule of mirrors that don't define their own.
+		DefaultCron string `envconfig:"GITNESS_MIRROR_DEFAULT_CRON" default:"0 * * * *"`
+		// MaxDuration is the maximum duration of a single mirror synchronization.
+		MaxDuration time.Duration `envconfig:"GITNESS_MIRROR_MAX_DURATION" default:"30m"`
+		// MaxSyncsPerRun is the maximum number of mirror synchronizations started in a single scheduler run.
+		MaxSyncsPerRun int `envconfig:"GITNESS_MIRROR_MAX_SYNCS_PER_RUN" default:"50"`
+		// PushSchedulerCron defines how often all push mirrors are pushed, in addition to the pushes after updates.
+		PushSchedulerCron string `envconfig:"GITNESS_MIRROR_PUSH_SCHEDULER_CRON" default:"17 */6 * * *"`
+		// PushMaxDuration is the maximum duration of a single push to a push mirror.
+		PushMaxDuration time.Duration `envconfig:"GITNESS_MIRROR_PUSH_MAX_DURATION" default:"30m"`
+		// PushExecutionsRetentionTime is the duration after which push mirror executions are deleted.
+		PushExecutionsRetentionTime time.Duration `envconfig:"GITNESS_MIRROR_PUSH_EXECUTIONS_RETENTION_TIME" default:"168h"`
+		// AllowPrivateNetwork allows remote repositories of mirrors in the private network.
+		AllowPrivateNetwork bool `envconfig:"GITNESS_MIRROR_ALLOW_PRIVATE_NETWORK" default:"false"`
+		// AllowLoopback allows remote repositories of mirrors on the loopback interface.
+		AllowLoopback bool `envconfig:"GITNESS_MIRROR_ALLOW_LOOPBACK" default:"false"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
@@ -385,6 +406,24 @@ type Config struct {
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		// DigestHourlyCron and DigestDailyCron define when the notification digests are sent.
+		DigestHourlyCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_HOURLY_CRON" default:"0 * * * *"`
+		DigestDailyCron  string `envconfig:"GITNESS_NOTIFICATION_DIGEST_DAILY_CRON" default:"0 8 * * *"`
+
+		// Reply configures posting pull request comments by replying to notification emails.
+		// It's enabled if both the address and the maildir are provided.
+		Reply struct {
+			// Address is the address replies are sent to, e.g. reply@example.com. The mail server must
+			// support subaddressing because every notification gets a reply address like reply+<token>@example.com.
+			Address string `envconfig:"GITNESS_NOTIFICATION_REPLY_ADDRESS"`
+			// Maildir is the path of the maildir to which the mail server delivers the replies.
+			Maildir  string        `envconfig:"GITNESS_NOTIFICATION_REPLY_MAILDIR"`
+			PollCron string        `envconfig:"GITNESS_NOTIFICATION_REPLY_POLL_CRON" default:"* * * * *"`
+			TokenTTL time.Duration `envconfig:"GITNESS_NOTIFICATION_REPLY_TOKEN_TTL" default:"720h"` // 30 days
+			// Secret is used to sign the reply tokens. It's required if replying is enabled.
+			Secret string `envconfig:"GITNESS_NOTIFICATION_REPLY_SECRET"`
+		}
 	}
 
 	KeywordSearch struct {
@@ -397,6 +436,11 @@ type Config struct {
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
 	}
 
+	Audit struct {
+		// RetentionTime is the duration after which audit events will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"8760h"` // 365 days
+	}
+
 	Docker struct {
 		// Host sets the url to the docker server.
 		Host string `envconfig:"GITNESS_DOCKER_HOST"`
@@ -541,6 +585,8 @@ type Config struct {
 			InitialIntervalDuration     time.Duration `envconfig:"GITNESS_REGISTRY_GARBAGE_COLLECTION_INITIAL_INTERVAL_DURATION" default:"5s"`     //nolint:lll
 			TransactionTimeoutDuration  time.Duration `envconfig:"GITNESS_REGISTRY_GARBAGE_COLLECTION_TRANSACTION_TIMEOUT_DURATION" default:"10s"` //nolint:lll
 			BlobsStorageTimeoutDuration time.Duration `envconfig:"GITNESS_REGISTRY_GARBAGE_COLLECTION_BLOB_STORAGE_TIMEOUT_DURATION" default:"5s"` //nolint:lll
+			// ReviewAfterDuration is the delay after which deleted or untagged manifests and unreferenced blobs are reviewed.
+			ReviewAfterDuration time.Duration `envconfig:"GITNESS_REGISTRY_GARBAGE_COLLECTION_REVIEW_AFTER_DURATION" default:"24h"` //nolint:lll
 		}
 	}
 
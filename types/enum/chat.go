@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ChatProvider defines the type of chat webhook that receives notifications.
+type ChatProvider string
+
+const (
+	// ChatProviderSlack is a Slack incoming webhook.
+	ChatProviderSlack ChatProvider = "slack"
+	// ChatProviderTeams is a Microsoft Teams connector (incoming webhook).
+	ChatProviderTeams ChatProvider = "teams"
+	// ChatProviderGeneric is a generic webhook that receives notifications as JSON.
+	ChatProviderGeneric ChatProvider = "generic"
+)
+
+var chatProviders = sortEnum([]ChatProvider{
+	ChatProviderSlack,
+	ChatProviderTeams,
+	ChatProviderGeneric,
+})
+
+func (ChatProvider) Enum() []interface{} { return toInterfaceSlice(chatProviders) }
+func (p ChatProvider) Sanitize() (ChatProvider, bool) {
+	return Sanitize(p, GetAllChatProviders)
+}
+func GetAllChatProviders() ([]ChatProvider, ChatProvider) {
+	return chatProviders, ""
+}
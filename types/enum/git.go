@@ -114,3 +114,26 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return "", fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// GitSignatureResult is the result of verification of a signature of a git object (commit or tag).
+type GitSignatureResult string
+
+const (
+	// GitSignatureVerified means the signature is valid and the signing key belongs to the signer identity.
+	GitSignatureVerified GitSignatureResult = "verified"
+	// GitSignatureUnverified means the signature couldn't be attributed to the signer identity.
+	GitSignatureUnverified GitSignatureResult = "unverified"
+	// GitSignatureUnknownKey means the signing key isn't registered as a signing key of any user.
+	GitSignatureUnknownKey GitSignatureResult = "unknown_key"
+	// GitSignatureBad means the signature doesn't match the signed content.
+	GitSignatureBad GitSignatureResult = "bad_signature"
+)
+
+var gitSignatureResults = sortEnum([]GitSignatureResult{
+	GitSignatureVerified,
+	GitSignatureUnverified,
+	GitSignatureUnknownKey,
+	GitSignatureBad,
+})
+
+func (GitSignatureResult) Enum() []interface{} { return toInterfaceSlice(gitSignatureResults) }
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueEntryState defines the state of a pull request in the merge queue.
+type MergeQueueEntryState string
+
+func (MergeQueueEntryState) Enum() []interface{} { return toInterfaceSlice(mergeQueueEntryStates) }
+func (s MergeQueueEntryState) Sanitize() (MergeQueueEntryState, bool) {
+	return Sanitize(s, GetAllMergeQueueEntryStates)
+}
+func GetAllMergeQueueEntryStates() ([]MergeQueueEntryState, MergeQueueEntryState) {
+	return mergeQueueEntryStates, ""
+}
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStateWaiting means that the speculative merge commit hasn't been created yet.
+	MergeQueueEntryStateWaiting MergeQueueEntryState = "waiting"
+	// MergeQueueEntryStateChecking means that the status checks are running for the speculative merge commit.
+	MergeQueueEntryStateChecking MergeQueueEntryState = "checking"
+)
+
+var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
+	MergeQueueEntryStateWaiting,
+	MergeQueueEntryStateChecking,
+})
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MirrorSyncStatus defines the status of the last synchronization of a mirror.
+type MirrorSyncStatus string
+
+func (MirrorSyncStatus) Enum() []interface{} { return toInterfaceSlice(mirrorSyncStatuses) }
+func (s MirrorSyncStatus) Sanitize() (MirrorSyncStatus, bool) {
+	return Sanitize(s, GetAllMirrorSyncStatuses)
+}
+func GetAllMirrorSyncStatuses() ([]MirrorSyncStatus, MirrorSyncStatus) {
+	return mirrorSyncStatuses, MirrorSyncStatusNone
+}
+
+// MirrorSyncStatus enumeration.
+const (
+	// MirrorSyncStatusNone means that the mirror hasn't been synchronized yet.
+	MirrorSyncStatusNone MirrorSyncStatus = "none"
+	// MirrorSyncStatusQueued means that the synchronization job has been scheduled, but hasn't started yet.
+	MirrorSyncStatusQueued MirrorSyncStatus = "queued"
+	// MirrorSyncStatusRunning means that the synchronization is in progress.
+	MirrorSyncStatusRunning MirrorSyncStatus = "running"
+	// MirrorSyncStatusSuccess means that the last synchronization finished successfully.
+	MirrorSyncStatusSuccess MirrorSyncStatus = "success"
+	// MirrorSyncStatusFailed means that the last synchronization failed.
+	MirrorSyncStatusFailed MirrorSyncStatus = "failed"
+)
+
+var mirrorSyncStatuses = sortEnum([]MirrorSyncStatus{
+	MirrorSyncStatusNone,
+	MirrorSyncStatusQueued,
+	MirrorSyncStatusRunning,
+	MirrorSyncStatusSuccess,
+	MirrorSyncStatusFailed,
+})
+
+// IsInProgress returns true if the synchronization is either queued or running.
+func (s MirrorSyncStatus) IsInProgress() bool {
+	return s == MirrorSyncStatusQueued || s == MirrorSyncStatusRunning
+}
+
+// PushMirrorTrigger defines what triggered a push to a push mirror.
+type PushMirrorTrigger string
+
+func (PushMirrorTrigger) Enum() []interface{} { return toInterfaceSlice(pushMirrorTriggers) }
+func (s PushMirrorTrigger) Sanitize() (PushMirrorTrigger, bool) {
+	return Sanitize(s, GetAllPushMirrorTriggers)
+}
+func GetAllPushMirrorTriggers() ([]PushMirrorTrigger, PushMirrorTrigger) {
+	return pushMirrorTriggers, ""
+}
+
+// PushMirrorTrigger enumeration.
+const (
+	// PushMirrorTriggerPush means that the push was triggered by an update of a branch or a tag.
+	PushMirrorTriggerPush PushMirrorTrigger = "push"
+	// PushMirrorTriggerSchedule means that the push was triggered by the periodic schedule.
+	PushMirrorTriggerSchedule PushMirrorTrigger = "schedule"
+	// PushMirrorTriggerManual means that the push was triggered by a user.
+	PushMirrorTriggerManual PushMirrorTrigger = "manual"
+)
+
+var pushMirrorTriggers = sortEnum([]PushMirrorTrigger{
+	PushMirrorTriggerPush,
+	PushMirrorTriggerSchedule,
+	PushMirrorTriggerManual,
+})
+
+// PushMirrorExecutionResult defines the result of a push to a push mirror.
+type PushMirrorExecutionResult string
+
+func (PushMirrorExecutionResult) Enum() []interface{} {
+	return toInterfaceSlice(pushMirrorExecutionResults)
+}
+func (s PushMirrorExecutionResult) Sanitize() (PushMirrorExecutionResult, bool) {
+	return Sanitize(s, GetAllPushMirrorExecutionResults)
+}
+func GetAllPushMirrorExecutionResults() ([]PushMirrorExecutionResult, PushMirrorExecutionResult) {
+	return pushMirrorExecutionResults, ""
+}
+
+// PushMirrorExecutionResult enumeration.
+const (
+	// PushMirrorExecutionResultSuccess means that all references were pushed to the remote.
+	PushMirrorExecutionResultSuccess PushMirrorExecutionResult = "success"
+	// PushMirrorExecutionResultFailed means that the push to the remote failed.
+	PushMirrorExecutionResultFailed PushMirrorExecutionResult = "failed"
+)
+
+var pushMirrorExecutionResults = sortEnum([]PushMirrorExecutionResult{
+	PushMirrorExecutionResultSuccess,
+	PushMirrorExecutionResultFailed,
+})
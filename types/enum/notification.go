@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationEvent defines the type of event a user can be notified about.
+type NotificationEvent string
+
+const (
+	// NotificationEventMention is sent when the user is mentioned in a pull request comment.
+	NotificationEventMention NotificationEvent = "mention"
+	// NotificationEventComment is sent when a comment is added to the user's pull request
+	// or to a comment thread the user participates in.
+	NotificationEventComment NotificationEvent = "comment"
+	// NotificationEventReviewRequested is sent when a reviewer is added to a pull request.
+	NotificationEventReviewRequested NotificationEvent = "review_requested"
+	// NotificationEventReviewSubmitted is sent when a review is submitted for the user's pull request.
+	NotificationEventReviewSubmitted NotificationEvent = "review_submitted"
+	// NotificationEventBranchUpdated is sent when the source branch of a pull request is updated.
+	NotificationEventBranchUpdated NotificationEvent = "branch_updated"
+	// NotificationEventPullReqMerged is sent when a pull request is merged.
+	NotificationEventPullReqMerged NotificationEvent = "pullreq_merged"
+	// NotificationEventPullReqStateChanged is sent when a pull request is closed or reopened.
+	NotificationEventPullReqStateChanged NotificationEvent = "pullreq_state_changed"
+)
+
+var notificationEvents = sortEnum([]NotificationEvent{
+	NotificationEventMention,
+	NotificationEventComment,
+	NotificationEventReviewRequested,
+	NotificationEventReviewSubmitted,
+	NotificationEventBranchUpdated,
+	NotificationEventPullReqMerged,
+	NotificationEventPullReqStateChanged,
+})
+
+func (NotificationEvent) Enum() []interface{} { return toInterfaceSlice(notificationEvents) }
+func (e NotificationEvent) Sanitize() (NotificationEvent, bool) {
+	return Sanitize(e, GetAllNotificationEvents)
+}
+func GetAllNotificationEvents() ([]NotificationEvent, NotificationEvent) {
+	return notificationEvents, ""
+}
+
+// NotificationDelivery defines how notifications are delivered to a user.
+type NotificationDelivery string
+
+const (
+	// NotificationDeliveryImmediate sends a notification for every event as it happens.
+	NotificationDeliveryImmediate NotificationDelivery = "immediate"
+	// NotificationDeliveryHourly batches the notifications into an hourly digest.
+	NotificationDeliveryHourly NotificationDelivery = "hourly"
+	// NotificationDeliveryDaily batches the notifications into a daily digest.
+	NotificationDeliveryDaily NotificationDelivery = "daily"
+)
+
+var notificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryImmediate,
+	NotificationDeliveryHourly,
+	NotificationDeliveryDaily,
+})
+
+func (NotificationDelivery) Enum() []interface{} { return toInterfaceSlice(notificationDeliveries) }
+func (d NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(d, GetAllNotificationDeliveries)
+}
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return notificationDeliveries, NotificationDeliveryImmediate
+}
@@ -25,6 +25,7 @@ const (
 
 var publicKeyTypes = sortEnum([]PublicKeyUsage{
 	PublicKeyUsageAuth,
+	PublicKeyUsageSign,
 })
 
 func (PublicKeyUsage) Enum() []interface{} { return toInterfaceSlice(publicKeyTypes) }
@@ -36,6 +37,26 @@ func GetAllPublicKeyUsages() ([]PublicKeyUsage, PublicKeyUsage) {
 }
 
 // PublicKeySort is used to specify sorting of public keys.
+type PublicKeyScheme string
+
+const (
+	PublicKeySchemeSSH PublicKeyScheme = "ssh"
+	PublicKeySchemePGP PublicKeyScheme = "pgp"
+)
+
+var publicKeySchemes = sortEnum([]PublicKeyScheme{
+	PublicKeySchemeSSH,
+	PublicKeySchemePGP,
+})
+
+func (PublicKeyScheme) Enum() []interface{} { return toInterfaceSlice(publicKeySchemes) }
+func (s PublicKeyScheme) Sanitize() (PublicKeyScheme, bool) {
+	return Sanitize(s, GetAllPublicKeySchemes)
+}
+func GetAllPublicKeySchemes() ([]PublicKeyScheme, PublicKeyScheme) {
+	return publicKeySchemes, PublicKeySchemeSSH
+}
+
 type PublicKeySort string
 
 // PublicKeySort enumeration.
@@ -90,6 +90,8 @@ const (
 	PullReqActivityTypeBranchRestore  PullReqActivityType = "branch-restore"
 	PullReqActivityTypeMerge          PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify    PullReqActivityType = "label-modify"
+	PullReqActivityTypeAutoMerge      PullReqActivityType = "auto-merge"
+	PullReqActivityTypeMergeQueue     PullReqActivityType = "merge-queue"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -105,6 +107,8 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchRestore,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeAutoMerge,
+	PullReqActivityTypeMergeQueue,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
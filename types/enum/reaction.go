@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// Reaction defines an emoji reaction to a pull request description or a comment.
+type Reaction string
+
+func (Reaction) Enum() []interface{} { return toInterfaceSlice(reactions) }
+func (r Reaction) Sanitize() (Reaction, bool) {
+	return Sanitize(r, GetAllReactions)
+}
+func GetAllReactions() ([]Reaction, Reaction) {
+	return reactions, ""
+}
+
+// Reaction enumeration.
+const (
+	ReactionThumbsUp   Reaction = "+1"
+	ReactionThumbsDown Reaction = "-1"
+	ReactionLaugh      Reaction = "laugh"
+	ReactionHooray     Reaction = "hooray"
+	ReactionConfused   Reaction = "confused"
+	ReactionHeart      Reaction = "heart"
+	ReactionRocket     Reaction = "rocket"
+	ReactionEyes       Reaction = "eyes"
+)
+
+var reactions = sortEnum([]Reaction{
+	ReactionThumbsUp,
+	ReactionThumbsDown,
+	ReactionLaugh,
+	ReactionHooray,
+	ReactionConfused,
+	ReactionHeart,
+	ReactionRocket,
+	ReactionEyes,
+})
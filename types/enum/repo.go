@@ -88,6 +88,7 @@ const (
 	RepoStateMigrateGitPush
 	RepoStateMigrateDataImport
 	RepoStateArchived
+	RepoStatePullMirror
 )
 
 // String returns the string representation of the RepoState.
@@ -103,6 +104,8 @@ func (state RepoState) String() string {
 		return "migrate-data-import"
 	case RepoStateArchived:
 		return "archived"
+	case RepoStatePullMirror:
+		return "pull-mirror"
 	default:
 		return undefined
 	}
@@ -51,6 +51,14 @@ const (
 	SSETypePullReqMarkedAsDraft  SSEType = "pullreq_marked_as_draft"
 	SSETypePullReqReadyForReview SSEType = "pullreq_ready_for_review"
 
+	SSETypePullReqAutoMergeEnabled  SSEType = "pullreq_auto_merge_enabled"
+	SSETypePullReqAutoMergeDisabled SSEType = "pullreq_auto_merge_disabled"
+
+	SSETypePullReqMergeQueueAdded   SSEType = "pullreq_merge_queue_added"
+	SSETypePullReqMergeQueueRemoved SSEType = "pullreq_merge_queue_removed"
+
+	SSETypePullReqReactionsUpdated SSEType = "pullreq_reactions_updated"
+
 	// Branches.
 
 	SSETypeBranchMergableUpdated SSEType = "branch_mergable_updated"
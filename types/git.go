@@ -96,6 +96,22 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	// Verification is the result of the commit signature verification - nil if the commit isn't signed.
+	Verification *SignatureVerification `json:"verification,omitempty"`
+}
+
+// SignatureVerification contains the result of verification of a signature of a git object.
+type SignatureVerification struct {
+	Result         enum.GitSignatureResult `json:"result"`
+	KeyScheme      enum.PublicKeyScheme    `json:"key_scheme,omitempty"`
+	KeyFingerprint string                  `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo          `json:"signer,omitempty"`
+}
+
+// IsVerified returns true if the provided verification is not nil and the signature has been verified.
+func (v *SignatureVerification) IsVerified() bool {
+	return v != nil && v.Result == enum.GitSignatureVerified
 }
 
 type Signature struct {
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry is a pull request waiting in the merge queue of its target branch.
+// The pull requests are merged in the order they have been added to the queue.
+type MergeQueueEntry struct {
+	ID            int64                     `json:"-"`
+	RepoID        int64                     `json:"-"`
+	Branch        string                    `json:"branch"`
+	PullReqID     int64                     `json:"-"`
+	PullReqNumber int64                     `json:"pullreq_number"`
+	CreatedBy     int64                     `json:"-"`
+	Created       int64                     `json:"created"`
+	Updated       int64                     `json:"updated"`
+	Method        enum.MergeMethod          `json:"method"`
+	Title         string                    `json:"title,omitempty"`
+	Message       string                    `json:"message,omitempty"`
+	State         enum.MergeQueueEntryState `json:"state"`
+
+	// HeadSHA is the source branch commit of the pull request that is merged by the speculative merge commit.
+	HeadSHA string `json:"head_sha,omitempty"`
+	// BaseSHA is the commit the speculative merge commit is based on: either the target branch commit
+	// or the speculative merge commit of the previous pull request in the queue.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeBaseSHA is the merge base of the HeadSHA and the BaseSHA.
+	MergeBaseSHA string `json:"merge_base_sha,omitempty"`
+	// MergeSHA is the speculative merge commit. The status checks must pass for it.
+	MergeSHA string `json:"merge_sha,omitempty"`
+
+	AddedBy *PrincipalInfo `json:"added_by,omitempty"`
+}
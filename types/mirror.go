@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// PullMirror is the configuration of a read-only repository that is periodically synchronized from a remote.
+type PullMirror struct {
+	ID        int64 `json:"-"`
+	Version   int64 `json:"-"`
+	RepoID    int64 `json:"repo_id"`
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	RemoteURL string `json:"remote_url"`
+	Username  string `json:"username,omitempty"`
+	// Password is the encrypted password (or access token) used to authenticate to the remote.
+	Password string `json:"-"`
+	// SecretSpaceID and SecretIdentifier reference a secret that contains the password.
+	// It's used instead of the Password if set.
+	SecretSpaceID    int64  `json:"-"`
+	SecretIdentifier string `json:"secret_identifier,omitempty"`
+
+	// Cron defines how often the mirror is synchronized.
+	Cron     string `json:"cron"`
+	Enabled  bool   `json:"enabled"`
+	NextSync int64  `json:"next_sync"`
+
+	LastSyncStatus   enum.MirrorSyncStatus `json:"last_sync_status"`
+	LastSyncStarted  int64                 `json:"last_sync_started"`
+	LastSyncFinished int64                 `json:"last_sync_finished"`
+	LastSyncError    string                `json:"last_sync_error,omitempty"`
+}
+
+// MarshalJSON overrides the default json marshaling for `PullMirror` allowing us to inject the `HasPassword` field.
+func (m *PullMirror) MarshalJSON() ([]byte, error) {
+	type alias PullMirror
+	return json.Marshal(&struct {
+		*alias
+		HasPassword bool `json:"has_password"`
+	}{
+		alias:       (*alias)(m),
+		HasPassword: m != nil && m.Password != "",
+	})
+}
+
+// PushMirror is a remote repository to which the branches and tags of a repository are pushed
+// after every update and periodically.
+type PushMirror struct {
+	ID         int64  `json:"id"`
+	Version    int64  `json:"-"`
+	RepoID     int64  `json:"repo_id"`
+	Identifier string `json:"identifier"`
+	CreatedBy  int64  `json:"created_by"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+
+	RemoteURL string `json:"remote_url"`
+	Username  string `json:"username,omitempty"`
+	// SecretSpaceID and SecretIdentifier reference a secret that contains the password used for the push.
+	SecretSpaceID    int64  `json:"-"`
+	SecretIdentifier string `json:"secret_identifier,omitempty"`
+
+	// BranchPatterns and TagPatterns restrict the pushed branches and tags. All are pushed if empty.
+	BranchPatterns []string `json:"branch_patterns"`
+	TagPatterns    []string `json:"tag_patterns"`
+	// Force defines whether the references are force pushed, overwriting any diverged remote history.
+	// Remote references that don't exist locally are deleted only if Force is set or all references are pushed.
+	Force   bool `json:"force"`
+	Enabled bool `json:"enabled"`
+
+	LatestExecutionResult *enum.PushMirrorExecutionResult `json:"latest_execution_result,omitempty"`
+}
+
+// PushMirrorExecution represents a single push to a push mirror.
+type PushMirrorExecution struct {
+	ID           int64                          `json:"id"`
+	PushMirrorID int64                          `json:"push_mirror_id"`
+	Created      int64                          `json:"created"`
+	Trigger      enum.PushMirrorTrigger         `json:"trigger"`
+	Result       enum.PushMirrorExecutionResult `json:"result"`
+	Duration     int64                          `json:"duration"`
+	Error        string                         `json:"error,omitempty"`
+}
+
+// PushMirrorFilter stores PushMirror query parameters for listing.
+type PushMirrorFilter struct {
+	Query string `json:"query"`
+	Page  int    `json:"page"`
+	Size  int    `json:"size"`
+}
+
+// PushMirrorExecutionFilter stores PushMirrorExecution query parameters for listing.
+type PushMirrorExecutionFilter struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
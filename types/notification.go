@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"slices"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationPreference contains the notification settings of a user.
+// A preference applies either globally (no space and no repository),
+// to all repositories of a space and its subspaces, or to a single repository.
+// The most specific preference applies.
+type NotificationPreference struct {
+	ID          int64  `json:"-"`
+	PrincipalID int64  `json:"-"`
+	SpaceID     *int64 `json:"space_id,omitempty"`
+	RepoID      *int64 `json:"repo_id,omitempty"`
+
+	// SpacePath and RepoPath are the paths of the scope of the preference. They are not stored.
+	SpacePath string `json:"space_path,omitempty"`
+	RepoPath  string `json:"repo_path,omitempty"`
+
+	DisabledEvents []enum.NotificationEvent  `json:"disabled_events"`
+	Delivery       enum.NotificationDelivery `json:"delivery"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+// IsEnabled returns true if the user wants to be notified about the event.
+func (p *NotificationPreference) IsEnabled(event enum.NotificationEvent) bool {
+	return !slices.Contains(p.DisabledEvents, event)
+}
+
+// NotificationDigestItem is a notification waiting to be sent to a user as a part of a digest.
+type NotificationDigestItem struct {
+	ID          int64
+	PrincipalID int64
+	Delivery    enum.NotificationDelivery
+	EventID     string
+	Event       enum.NotificationEvent
+	RepoID      int64
+	Subject     string
+	Text        string
+	URL         string
+	Created     int64
+}
@@ -17,20 +17,31 @@ package types
 import "github.com/harness/gitness/types/enum"
 
 type PublicKey struct {
-	ID          int64               `json:"-"` // frontend doesn't need it
-	PrincipalID int64               `json:"-"` // API always returns keys for the same user
-	Created     int64               `json:"created"`
-	Verified    *int64              `json:"verified"`
-	Identifier  string              `json:"identifier"`
-	Usage       enum.PublicKeyUsage `json:"usage"`
-	Fingerprint string              `json:"fingerprint"`
-	Content     string              `json:"-"`
-	Comment     string              `json:"comment"`
-	Type        string              `json:"type"`
+	ID          int64                `json:"-"` // frontend doesn't need it
+	PrincipalID int64                `json:"-"` // API always returns keys for the same user
+	Created     int64                `json:"created"`
+	Verified    *int64               `json:"verified"`
+	Identifier  string               `json:"identifier"`
+	Usage       enum.PublicKeyUsage  `json:"usage"`
+	Scheme      enum.PublicKeyScheme `json:"scheme"`
+	Fingerprint string               `json:"fingerprint"`
+	Content     string               `json:"-"`
+	Comment     string               `json:"comment"`
+	Type        string               `json:"type"`
 }
 
 type PublicKeyFilter struct {
 	ListQueryFilter
-	Sort  enum.PublicKeySort
-	Order enum.Order
+	Sort    enum.PublicKeySort
+	Order   enum.Order
+	Usages  []enum.PublicKeyUsage
+	Schemes []enum.PublicKeyScheme
+}
+
+// ServerSigningKey is the public part of the key that is used by the server to sign the commits it creates.
+type ServerSigningKey struct {
+	Scheme      enum.PublicKeyScheme `json:"scheme"`
+	Type        string               `json:"type"`
+	Fingerprint string               `json:"fingerprint"`
+	PublicKey   string               `json:"public_key"`
 }
@@ -69,6 +69,9 @@ type PullReq struct {
 	Labels       []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
 	CheckSummary *CheckCountSummary            `json:"check_summary,omitempty"`
 	Rules        []RuleInfo                    `json:"rules,omitempty"`
+	AutoMerge    *PullReqAutoMerge             `json:"auto_merge,omitempty"`
+	MergeQueue   *MergeQueueEntry              `json:"merge_queue,omitempty"`
+	Reactions    []ReactionSummary             `json:"reactions,omitempty"`
 }
 
 func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []string) {
@@ -118,6 +121,21 @@ func (pr *PullReq) MarkAsMerged() {
 	pr.RebaseConflicts = nil
 }
 
+// PullReqAutoMerge holds the merge settings of a pull request that is merged automatically
+// by the user who enabled auto-merge once all protection requirements are satisfied.
+type PullReqAutoMerge struct {
+	PullReqID int64 `json:"-"`
+	RepoID    int64 `json:"-"`
+	CreatedBy int64 `json:"-"` // not returned, because the user info is in the EnabledBy field
+	Created   int64 `json:"created"`
+
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title,omitempty"`
+	Message string           `json:"message,omitempty"`
+
+	EnabledBy *PrincipalInfo `json:"enabled_by,omitempty"`
+}
+
 // DiffStats holds summary of changes in git:
 // total number of commits, number modified files and number of line changes.
 type DiffStats struct {
@@ -280,6 +298,7 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest bool `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution        bool `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests         bool `json:"requires_no_change_requests,omitempty"`
+	RequiresMergeQueue               bool `json:"requires_merge_queue,omitempty"`
 }
 
 type MergeViolations struct {
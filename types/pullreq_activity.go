@@ -51,12 +51,16 @@ type PullReqActivity struct {
 	ResolvedBy *int64 `json:"-"` // not returned, because the resolver info is in the Resolver field
 	Resolved   *int64 `json:"resolved,omitempty"`
 
+	// Pending is true for review comments that are visible only to their author until the review is submitted.
+	Pending bool `json:"pending,omitempty"`
+
 	Author   PrincipalInfo  `json:"author"`
 	Resolver *PrincipalInfo `json:"resolver,omitempty"`
 
 	CodeComment *CodeCommentFields `json:"code_comment,omitempty"`
 
-	Mentions map[int64]*PrincipalInfo `json:"mentions,omitempty"` // used only in response
+	Mentions  map[int64]*PrincipalInfo `json:"mentions,omitempty"`  // used only in response
+	Reactions []ReactionSummary        `json:"reactions,omitempty"` // used only in response
 }
 
 func (a *PullReqActivity) IsValidCodeComment() bool {
@@ -165,4 +169,7 @@ type PullReqActivityFilter struct {
 
 	Types []enum.PullReqActivityType `json:"type"`
 	Kinds []enum.PullReqActivityKind `json:"kind"`
+
+	// PendingAuthorID includes the pending comments of the principal. Pending comments of others are never listed.
+	PendingAuthorID int64 `json:"-"`
 }
@@ -61,6 +61,8 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -179,6 +181,29 @@ func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqAct
 	return enum.PullReqActivityTypeBranchRestore
 }
 
+type PullRequestActivityPayloadAutoMerge struct {
+	Enabled     bool             `json:"enabled"`
+	MergeMethod enum.MergeMethod `json:"merge_method,omitempty"`
+	// Reason is set if auto-merge has been disabled by the system, e.g. because of a push by another user.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+type PullRequestActivityPayloadMergeQueue struct {
+	Added       bool             `json:"added"`
+	MergeMethod enum.MergeMethod `json:"merge_method,omitempty"`
+	// Reason is set if the pull request has been removed from the merge queue by the system,
+	// e.g. because a required status check failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
 type PullRequestActivityLabel struct {
 	Label         string                        `json:"label"`
 	LabelColor    enum.LabelColor               `json:"label_color"`
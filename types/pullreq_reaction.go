@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullReqReaction is an emoji reaction of a user to a pull request description or a pull request comment.
+type PullReqReaction struct {
+	PullReqID int64 `json:"-"`
+	// ActivityID is the ID of the comment. It's nil for reactions to the pull request description.
+	ActivityID *int64        `json:"-"`
+	CreatedBy  int64         `json:"-"`
+	Created    int64         `json:"created"`
+	Reaction   enum.Reaction `json:"reaction"`
+}
+
+// ReactionSummary contains the aggregated information about one reaction type.
+type ReactionSummary struct {
+	Reaction enum.Reaction    `json:"reaction"`
+	Count    int              `json:"count"`
+	Reactors []*PrincipalInfo `json:"reactors"`
+}
+
+// PullReqReactionsUpdate is sent as the payload of the reactions SSE event.
+type PullReqReactionsUpdate struct {
+	PullReqID     int64             `json:"pullreq_id"`
+	PullReqNumber int64             `json:"pullreq_number"`
+	ActivityID    *int64            `json:"activity_id,omitempty"`
+	Reactions     []ReactionSummary `json:"reactions"`
+}
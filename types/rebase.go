@@ -34,3 +34,14 @@ type SquashResponse struct {
 	DryRun        bool     `json:"dry_run,omitempty"`
 	ConflictFiles []string `json:"conflict_files,omitempty"`
 }
+
+type SyncForkResponse struct {
+	AlreadyAncestor bool             `json:"already_ancestor,omitempty"`
+	FastForward     bool             `json:"fast_forward,omitempty"`
+	NewBranchSHA    sha.SHA          `json:"new_branch_sha"`
+	RuleViolations  []RuleViolations `json:"rule_violations,omitempty"`
+
+	DryRunRules   bool     `json:"dry_run_rules,omitempty"`
+	DryRun        bool     `json:"dry_run,omitempty"`
+	ConflictFiles []string `json:"conflict_files,omitempty"`
+}
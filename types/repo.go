@@ -66,6 +66,9 @@ type Repository struct {
 	State   enum.RepoState `json:"state" yaml:"-"`
 	IsEmpty bool           `json:"is_empty,omitempty" yaml:"is_empty"`
 
+	// IsTemplate is true if new repositories can be created from the repository.
+	IsTemplate bool `json:"is_template" yaml:"is_template"`
+
 	// git urls
 	GitURL    string `json:"git_url" yaml:"-"`
 	GitSSHURL string `json:"git_ssh_url,omitempty" yaml:"-"`
@@ -118,6 +121,8 @@ type RepoFilter struct {
 	DeletedAt         *int64        `json:"deleted_at,omitempty"`
 	DeletedBeforeOrAt *int64        `json:"deleted_before_or_at,omitempty"`
 	Recursive         bool
+	// Archived filters repos by the archived state. If nil, both archived and not archived repos are returned.
+	Archived *bool `json:"archived,omitempty"`
 }
 
 type RepoCacheKey struct {